@@ -1,12 +1,56 @@
+// The laki tool renders scenes using Vulkan.
+//
+// Usage:
+//
+//	laki [OPTION]...
+//
+// Flags:
+//
+//	-duration duration
+//	      duration to record for, if -frames is 0 (default 2s)
+//	-fps float
+//	      frame rate of headless recording (default 30)
+//	-frames int
+//	      number of frames to record
+//	-headless
+//	      record scene offscreen, without a window (requires -record)
+//	-height int
+//	      image height of headless recording (default 240)
+//	-msaa int
+//	      samples per pixel of multisample anti-aliasing of window (1, 2, 4 or 8) (default 1)
+//	-present-mode string
+//	      comma-separated present modes of window in order of preference (immediate, mailbox, fifo or fifo-relaxed) (default "mailbox,fifo")
+//	-record string
+//	      record frames to animated GIF ("*.gif") or PNG image sequence (e.g. "frame_%04d.png")
+//	-sample-shading
+//	      shade each sample of multisample anti-aliasing, rather than each pixel
+//	-scene string
+//	      scene of headless recording (default "quad")
+//	-screenshot string
+//	      save screenshot of first frame of window to PNG file
+//	-seed int
+//	      seed of pseudo-random numbers of headless recording (default 1)
+//	-width int
+//	      image width of headless recording (default 320)
+//
+// Press F12 to save a screenshot of the window.
+// Press F5 to reload the shaders, and Tab to switch to the next scene.
+// Press V to toggle vertical synchronization (VSync).
 package main
 
 import (
+	"flag"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/mewkiz/pkg/term"
+	"github.com/mewmew/laki/recording"
 	"github.com/mewmew/laki/vk"
+	"github.com/pkg/errors"
 )
 
 var (
@@ -28,7 +72,122 @@ func init() {
 const debug = true
 
 func main() {
-	if err := vk.Init(); err != nil {
+	// Parse command line arguments.
+	var (
+		// Output path of recording.
+		recordPath string
+		// Length of recording.
+		nframes  int
+		duration time.Duration
+		// Record scene offscreen.
+		headless bool
+		// Multisample anti-aliasing of window.
+		samples       int
+		sampleShading bool
+		// Present modes of window in order of preference.
+		presentModeNames string
+		// Output path of screenshot of the first frame of the window.
+		screenshotPath string
+		// Scene, resolution, seed of pseudo-random numbers and frame rate of
+		// headless recording.
+		sceneName     string
+		width, height int
+		seed          int64
+		fps           float64
+	)
+	flag.StringVar(&recordPath, "record", "", `record frames to animated GIF ("*.gif") or PNG image sequence (e.g. "frame_%04d.png")`)
+	flag.IntVar(&nframes, "frames", 0, "number of frames to record")
+	flag.DurationVar(&duration, "duration", 2*time.Second, "duration to record for, if -frames is 0")
+	flag.BoolVar(&headless, "headless", false, "record scene offscreen, without a window (requires -record)")
+	flag.IntVar(&samples, "msaa", 1, "samples per pixel of multisample anti-aliasing of window (1, 2, 4 or 8)")
+	flag.BoolVar(&sampleShading, "sample-shading", false, "shade each sample of multisample anti-aliasing, rather than each pixel")
+	flag.StringVar(&presentModeNames, "present-mode", "mailbox,fifo", "comma-separated present modes of window in order of preference (immediate, mailbox, fifo or fifo-relaxed)")
+	flag.StringVar(&screenshotPath, "screenshot", "", "save screenshot of first frame of window to PNG file")
+	flag.StringVar(&sceneName, "scene", vk.SceneNames()[0], "scene of headless recording")
+	flag.IntVar(&width, "width", 320, "image width of headless recording")
+	flag.IntVar(&height, "height", 240, "image height of headless recording")
+	flag.Int64Var(&seed, "seed", 1, "seed of pseudo-random numbers of headless recording")
+	flag.Float64Var(&fps, "fps", 30, "frame rate of headless recording")
+	flag.Parse()
+	if headless && len(recordPath) == 0 {
+		warn.Fatalln("missing output path of headless recording; use -record")
+	}
+
+	presentModes, err := parsePresentModes(presentModeNames)
+	if err != nil {
+		warn.Fatalf("%+v", err)
+	}
+
+	opts := vk.Options{
+		Samples:       samples,
+		SampleShading: sampleShading,
+		PresentModes:  presentModes,
+	}
+	if len(recordPath) > 0 {
+		rec, err := newRecorder(recordPath)
+		if err != nil {
+			warn.Fatalf("%+v", err)
+		}
+		opts.Recording = &vk.Recording{
+			Recorder: rec,
+			Frames:   nframes,
+			Duration: duration,
+		}
+	}
+	if len(screenshotPath) > 0 {
+		requested := false
+		opts.OnFrame = func(app *vk.App) {
+			if !requested {
+				vk.RequestScreenshot(app, screenshotPath)
+				requested = true
+			}
+		}
+	}
+	if headless {
+		if err := vk.RecordScene(sceneName, width, height, seed, fps, opts.Recording); err != nil {
+			warn.Fatalf("%+v", err)
+		}
+		dbg.Printf("recording written to %q", recordPath)
+		return
+	}
+	if err := vk.Init(opts); err != nil {
 		warn.Fatalf("%+v", err)
 	}
 }
+
+// newRecorder returns a recorder of frames writing to the given path; an
+// animated GIF image if the path has a ".gif" extension, and a numbered PNG
+// image sequence otherwise.
+func newRecorder(path string) (vk.Recorder, error) {
+	if strings.ToLower(filepath.Ext(path)) == ".gif" {
+		return recording.NewGIF(path), nil
+	}
+	seq, err := recording.NewPNGSequence(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return seq, nil
+}
+
+// presentModesByName maps from present mode name to present mode.
+var presentModesByName = map[string]vk.PresentMode{
+	"immediate":    vk.PresentModeImmediate,
+	"mailbox":      vk.PresentModeMailbox,
+	"fifo":         vk.PresentModeFifo,
+	"fifo-relaxed": vk.PresentModeFifoRelaxed,
+}
+
+// parsePresentModes parses the given comma-separated list of present mode
+// names (e.g. "immediate,fifo").
+func parsePresentModes(s string) ([]vk.PresentMode, error) {
+	var modes []vk.PresentMode
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		mode, ok := presentModesByName[name]
+		if !ok {
+			return nil, errors.Errorf("invalid present mode %q; expected immediate, mailbox, fifo or fifo-relaxed", name)
+		}
+		modes = append(modes, mode)
+	}
+	return modes, nil
+}
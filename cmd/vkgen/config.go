@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Config specifies the declarations of the registry to generate Go code for.
+type Config struct {
+	// Types with arena helpers allocating a single value (e.g.
+	// "VkInstanceCreateInfo").
+	News []string
+	// Types with arena helpers allocating slices (e.g. "VkSemaphore").
+	Slices []string
+	// Enum and bitmask types.
+	Enums []*EnumConfig
+	// Commands loaded through vkGetInstanceProcAddr or vkGetDeviceProcAddr
+	// (e.g. "vkCreateDebugUtilsMessengerEXT").
+	Commands []string
+}
+
+// EnumConfig specifies an enum or bitmask type to generate Go code for.
+type EnumConfig struct {
+	// C type name (e.g. "VkPresentModeKHR").
+	CName string
+	// Go type name (e.g. "PresentMode").
+	GoName string
+}
+
+// parseConfig parses the given vkgen configuration file.
+//
+// Each non-empty line of the configuration file contains a directive, and
+// lines starting with '#' are comments.
+//
+//	new TYPE          arena helper allocating a TYPE value
+//	slice TYPE        arena helpers allocating []TYPE slices
+//	enum TYPE [NAME]  Go enum or bitmask type NAME of TYPE, with String method
+//	command NAME      loader and trampoline of the command NAME
+func parseConfig(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer f.Close()
+	config := &Config{}
+	s := bufio.NewScanner(f)
+	for lineNr := 1; s.Scan(); lineNr++ {
+		line := strings.TrimSpace(s.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		directive, args := fields[0], fields[1:]
+		switch {
+		case directive == "new" && len(args) == 1:
+			config.News = append(config.News, args[0])
+		case directive == "slice" && len(args) == 1:
+			config.Slices = append(config.Slices, args[0])
+		case directive == "enum" && (len(args) == 1 || len(args) == 2):
+			enum := &EnumConfig{CName: args[0], GoName: strings.TrimPrefix(args[0], "Vk")}
+			if len(args) == 2 {
+				enum.GoName = args[1]
+			}
+			config.Enums = append(config.Enums, enum)
+		case directive == "command" && len(args) == 1:
+			config.Commands = append(config.Commands, args[0])
+		default:
+			return nil, errors.Errorf("%s:%d: invalid directive %q", path, lineNr, line)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return config, nil
+}
@@ -0,0 +1,669 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// header is the first line of generated files.
+const header = "// Code generated by vkgen. DO NOT EDIT."
+
+// generator generates Go code for the declarations of a registry.
+type generator struct {
+	reg    *Registry
+	config *Config
+	// Output directory.
+	outDir string
+	// Vendor tags of the registry (e.g. "KHR").
+	tags map[string]bool
+}
+
+// newGenerator returns a new generator for the given registry and
+// configuration, writing output files to outDir.
+func newGenerator(reg *Registry, config *Config, outDir string) *generator {
+	tags := make(map[string]bool)
+	for _, tag := range reg.Tags {
+		tags[tag.Name] = true
+	}
+	return &generator{reg: reg, config: config, outDir: outDir, tags: tags}
+}
+
+// gen generates the output files.
+func (g *generator) gen() error {
+	if err := g.genMalloc(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := g.genSlice(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := g.genEnums(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := g.genCommands(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// ### [ Arena helpers ] #######################################################
+
+// cType is a C type with arena helpers.
+type cType struct {
+	// C type name (e.g. "VkSemaphore", "uint32_t").
+	CName string
+	// Go name of type used in helper names (e.g. "VkSemaphore", "CUint32").
+	GoName string
+}
+
+// cTypes returns the C types of the given type names.
+func (g *generator) cTypes(names []string) ([]cType, error) {
+	var ts []cType
+	for _, name := range names {
+		if _, ok := g.reg.findType(name); !ok {
+			return nil, errors.Errorf("unable to locate type %q", name)
+		}
+		goName := name
+		if !strings.HasPrefix(name, "Vk") {
+			// uint32_t -> CUint32
+			goName = "C" + camelCase(strings.TrimSuffix(name, "_t"))
+		}
+		ts = append(ts, cType{CName: name, GoName: goName})
+	}
+	return ts, nil
+}
+
+// genMalloc generates arena helpers allocating single values.
+func (g *generator) genMalloc() error {
+	ts, err := g.cTypes(g.config.News)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return g.writeGo("malloc.go", mallocTmpl, ts)
+}
+
+// genSlice generates arena helpers allocating slices.
+func (g *generator) genSlice() error {
+	ts, err := g.cTypes(g.config.Slices)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return g.writeGo("slice.go", sliceTmpl, ts)
+}
+
+var mallocTmpl = header + `
+
+// C values allocated in arenas.
+
+package vk
+
+// #include <vulkan/vulkan.h>
+import "C"
+{{ range . }}
+func (a *arena) new{{ .GoName }}(v C.{{ .CName }}) *C.{{ .CName }} {
+	p := (*C.{{ .CName }})(a.alloc(C.sizeof_{{ .CName }}))
+	*p = v
+	return p
+}
+{{ end }}`
+
+var sliceTmpl = header + `
+
+// Go slices backed by C memory allocated in arenas.
+
+package vk
+
+// #include <stdint.h>
+// #include <vulkan/vulkan.h>
+import "C"
+
+import (
+	"unsafe"
+)
+{{ range . }}
+func (a *arena) new{{ .GoName }}Slice(elems ...C.{{ .CName }}) []C.{{ .CName }} {
+	dst := a.make{{ .GoName }}Slice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) make{{ .GoName }}Slice(n int) []C.{{ .CName }} {
+	return unsafe.Slice((*C.{{ .CName }})(a.alloc(uintptr(n)*C.sizeof_{{ .CName }})), n)
+}
+{{ end }}`
+
+// ### [ Enums ] ###############################################################
+
+// goEnum is a Go enum or bitmask type.
+type goEnum struct {
+	// C type name (e.g. "VkPresentModeKHR").
+	CName string
+	// Go type name (e.g. "PresentMode").
+	GoName string
+	// Underlying Go type (e.g. "int32").
+	Underlying string
+	// Bitmask type.
+	Bitmask bool
+	// Enumerants.
+	Values []goEnumValue
+}
+
+// goEnumValue is a Go constant of an enumerant.
+type goEnumValue struct {
+	// C enumerant name (e.g. "VK_PRESENT_MODE_FIFO_KHR").
+	CName string
+	// Go constant name (e.g. "PresentModeFifo").
+	GoName string
+	// Integer value in Go syntax.
+	Value string
+	// Duplicate value of a previous enumerant, or multi-bit value of bitmask;
+	// omitted from String.
+	Skip bool
+}
+
+// genEnums generates Go enum and bitmask types.
+func (g *generator) genEnums() error {
+	var enums []*goEnum
+	for _, config := range g.config.Enums {
+		e, values, err := g.reg.findEnums(config.CName)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		enum := &goEnum{
+			CName:      config.CName,
+			GoName:     config.GoName,
+			Underlying: "int32",
+			Bitmask:    e.Kind == "bitmask",
+		}
+		if enum.Bitmask {
+			enum.Underlying = "uint32"
+			if e.BitWidth == "64" {
+				enum.Underlying = "uint64"
+			}
+		}
+		prefix, vendor := g.enumPrefix(config.CName)
+		seen := make(map[int64]bool)
+		for _, v := range values {
+			name := strings.TrimPrefix(v.name, "VK_")
+			name = strings.TrimPrefix(name, prefix)
+			if len(vendor) > 0 {
+				name = strings.TrimSuffix(name, "_"+vendor)
+			}
+			if enum.Bitmask {
+				name = strings.TrimSuffix(name, "_BIT")
+			}
+			value := fmt.Sprintf("%d", v.value)
+			skip := seen[v.value]
+			if enum.Bitmask {
+				value = fmt.Sprintf("0x%08X", v.value)
+				skip = skip || bits.OnesCount64(uint64(v.value)) != 1
+			}
+			seen[v.value] = true
+			enum.Values = append(enum.Values, goEnumValue{
+				CName:  v.name,
+				GoName: config.GoName + g.camelCase(name),
+				Value:  value,
+				Skip:   skip,
+			})
+		}
+		enums = append(enums, enum)
+	}
+	return g.writeGo("enums.go", enumsTmpl, enums)
+}
+
+// enumPrefix returns the common prefix of enumerants of the given enum type,
+// and the vendor tag of the enum type. For instance, the prefix of
+// VkPresentModeKHR is "PRESENT_MODE_" and the vendor tag is "KHR".
+//
+// ref: https://registry.khronos.org/vulkan/specs/latest/styleguide.html#naming-enumerants
+func (g *generator) enumPrefix(cname string) (prefix, vendor string) {
+	name := strings.TrimPrefix(cname, "Vk")
+	for tag := range g.tags {
+		if strings.HasSuffix(name, tag) && len(tag) > len(vendor) {
+			vendor = tag
+		}
+	}
+	name = strings.TrimSuffix(name, vendor)
+	// VkSampleCountFlagBits -> SampleCount
+	// VkAccessFlagBits2 -> Access2 -> ACCESS_2_
+	name = strings.Replace(name, "FlagBits", "", 1)
+	var words []string
+	start := 0
+	prev := rune(0)
+	for i, r := range name {
+		isDigitStart := unicode.IsDigit(r) && !unicode.IsDigit(prev)
+		prev = r
+		if i > 0 && (unicode.IsUpper(r) || isDigitStart) {
+			words = append(words, strings.ToUpper(name[start:i]))
+			start = i
+		}
+	}
+	words = append(words, strings.ToUpper(name[start:]))
+	return strings.Join(words, "_") + "_", vendor
+}
+
+// initialisms are words of enumerants kept in upper case.
+var initialisms = map[string]bool{
+	"CPU":  true,
+	"GPU":  true,
+	"ID":   true,
+	"HDR":  true,
+	"SRGB": true,
+}
+
+// camelCase returns the CamelCase Go name of the given SNAKE_CASE enumerant
+// name, keeping vendor tags, initialisms and words starting with a digit (e.g.
+// "2D") in upper case.
+func (g *generator) camelCase(s string) string {
+	words := strings.Split(s, "_")
+	for i, word := range words {
+		if g.tags[word] || initialisms[word] || (len(word) > 0 && unicode.IsDigit(rune(word[0]))) {
+			continue
+		}
+		words[i] = camelCase(word)
+	}
+	return strings.Join(words, "")
+}
+
+// camelCase returns the given lower or upper case word with an initial upper
+// case letter (e.g. "FIFO" -> "Fifo").
+func camelCase(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+}
+
+var enumsTmpl = header + `
+
+// Go enum and bitmask types of Vulkan.
+
+package vk
+
+import (
+	"fmt"
+{{- if hasBitmask . }}
+	"strings"
+{{- end }}
+)
+{{ range . }}
+{{- if .Bitmask }}
+// {{ .GoName }} is a Vulkan bitmask ({{ .CName }}).
+{{- else }}
+// {{ .GoName }} is a Vulkan enum ({{ .CName }}).
+{{- end }}
+type {{ .GoName }} {{ .Underlying }}
+{{ $enum := . }}
+// Values of {{ .GoName }}.
+const (
+{{- range .Values }}
+	{{ .GoName }} {{ $enum.GoName }} = {{ .Value }} // {{ .CName }}
+{{- end }}
+)
+{{ if .Bitmask }}
+// {{ lower .GoName }}Bits specifies the names of {{ .GoName }} bits.
+var {{ lower .GoName }}Bits = []struct {
+	bit  {{ .GoName }}
+	name string
+}{
+{{- range .Values }}{{ if not .Skip }}
+	{ {{- .GoName }}, "{{ .CName }}"},
+{{- end }}{{ end }}
+}
+
+// String returns the names of the {{ .CName }} bits set in v, separated
+// by '|'.
+func (v {{ .GoName }}) String() string {
+	if v == 0 {
+		return "0"
+	}
+	var names []string
+	for _, b := range {{ lower .GoName }}Bits {
+		if v&b.bit != 0 {
+			names = append(names, b.name)
+			v &^= b.bit
+		}
+	}
+	if v != 0 {
+		names = append(names, fmt.Sprintf("0x%X", {{ .Underlying }}(v)))
+	}
+	return strings.Join(names, "|")
+}
+{{- else }}
+// String returns the name of the {{ .CName }} value.
+func (v {{ .GoName }}) String() string {
+	switch v {
+{{- range .Values }}{{ if not .Skip }}
+	case {{ .GoName }}:
+		return "{{ .CName }}"
+{{- end }}{{ end }}
+	}
+	return fmt.Sprintf("{{ .CName }}(%d)", {{ .Underlying }}(v))
+}
+{{- end }}
+{{ end }}`
+
+// ### [ Commands ] ############################################################
+
+// goCommand is a Vulkan command loaded through vkGetInstanceProcAddr or
+// vkGetDeviceProcAddr.
+type goCommand struct {
+	// C command name (e.g. "vkCreateDebugUtilsMessengerEXT").
+	CName string
+	// Go method name (e.g. "CreateDebugUtilsMessengerEXT").
+	GoName string
+	// Name of extension of command (e.g. "VK_EXT_debug_utils"); empty if core.
+	Extension string
+	// C return type (e.g. "VkResult", "void").
+	CResult string
+	// Parameters.
+	Params []goParam
+}
+
+// goParam is a parameter of a Vulkan command.
+type goParam struct {
+	// C parameter declaration (e.g. "const VkDebugUtilsLabelEXT *pLabelInfo").
+	CDecl string
+	// C parameter name (e.g. "pLabelInfo").
+	CName string
+	// Go parameter name (e.g. "pLabelInfo").
+	GoName string
+	// cgo parameter type (e.g. "*C.VkDebugUtilsLabelEXT").
+	GoType string
+}
+
+// commands specifies the commands of the global, instance and device dispatch
+// tables.
+type commands struct {
+	Global   []*goCommand
+	Instance []*goCommand
+	Device   []*goCommand
+	// All commands, in configuration order.
+	All []*goCommand
+}
+
+// Dispatchable handle types of instance-level and device-level commands.
+var (
+	instanceDispatchTypes = map[string]bool{
+		"VkInstance":       true,
+		"VkPhysicalDevice": true,
+	}
+	deviceDispatchTypes = map[string]bool{
+		"VkDevice":        true,
+		"VkQueue":         true,
+		"VkCommandBuffer": true,
+	}
+)
+
+// genCommands generates dispatch tables and trampolines of Vulkan commands.
+//
+// Commands not dispatched on a Vulkan object (e.g. vkCreateInstance) are
+// global commands, loaded through vkGetInstanceProcAddr with a NULL instance.
+// Commands of instance extensions, and commands dispatched on instances or
+// physical devices, are loaded through vkGetInstanceProcAddr. Other commands
+// are loaded through vkGetDeviceProcAddr, to skip the dispatch of the Vulkan
+// loader.
+//
+// vkGetInstanceProcAddr is only given a trampoline, as it is the entry point
+// used to load all other commands.
+func (g *generator) genCommands() error {
+	cmds := &commands{}
+	for _, name := range g.config.Commands {
+		cmd, ext, ok := g.reg.findCommand(name)
+		if !ok {
+			return errors.Errorf("unable to locate command %q", name)
+		}
+		c := &goCommand{
+			CName:   name,
+			GoName:  strings.TrimPrefix(name, "vk"),
+			CResult: cmd.Proto.Type,
+		}
+		if ext != nil {
+			c.Extension = ext.Name
+		}
+		params := cmd.params()
+		for _, param := range params {
+			goName := param.Name
+			if token.IsKeyword(goName) {
+				goName += "_"
+			}
+			c.Params = append(c.Params, goParam{
+				CDecl:  param.cDecl(),
+				CName:  param.Name,
+				GoName: goName,
+				GoType: param.goType(),
+			})
+		}
+		cmds.All = append(cmds.All, c)
+		var dispatchType string
+		if len(params) > 0 {
+			dispatchType = params[0].Type
+		}
+		switch {
+		case name == "vkGetInstanceProcAddr":
+			// trampoline only.
+		case name == "vkGetDeviceProcAddr":
+			cmds.Instance = append(cmds.Instance, c)
+		case deviceDispatchTypes[dispatchType] && (ext == nil || ext.Kind == "device"):
+			cmds.Device = append(cmds.Device, c)
+		case deviceDispatchTypes[dispatchType] || instanceDispatchTypes[dispatchType]:
+			cmds.Instance = append(cmds.Instance, c)
+		default:
+			cmds.Global = append(cmds.Global, c)
+		}
+	}
+	if err := g.write("invoke.h", invokeHTmpl, cmds); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := g.writeGo("invoke.go", invokeTmpl, cmds); err != nil {
+		return errors.WithStack(err)
+	}
+	return g.writeGo("procs.go", procsTmpl, cmds)
+}
+
+var invokeHTmpl = header + `
+
+#ifndef __INVOKE_H__
+#define __INVOKE_H__
+
+#include <vulkan/vulkan.h>
+{{ range .All }}
+extern {{ .CResult }} invoke_{{ .GoName }}(
+	PFN_{{ .CName }} fn
+{{- range .Params }},
+	{{ .CDecl }}
+{{- end }});
+{{ end }}
+#endif // #ifndef __INVOKE_H__
+`
+
+var invokeTmpl = header + `
+
+// Trampolines calling Vulkan function pointers.
+
+package vk
+
+// #include "invoke.h"
+{{- range .All }}
+//
+// {{ .CResult }} invoke_{{ .GoName }}(
+// 	PFN_{{ .CName }} fn
+{{- range .Params }},
+// 	{{ .CDecl }}
+{{- end }}) {
+// 	{{ if ne .CResult "void" }}return {{ end }}fn({{ range $i, $p := .Params }}{{ if $i }}, {{ end }}{{ $p.CName }}{{ end }});
+// }
+{{- end }}
+import "C"
+`
+
+var procsTmpl = header + `
+
+// Dispatch tables of Vulkan commands loaded through vkGetInstanceProcAddr and
+// vkGetDeviceProcAddr.
+
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"unsafe"
+)
+
+// procAddrFunc returns the Vulkan command with the given name, or nil if not
+// present.
+type procAddrFunc func(name string) C.PFN_vkVoidFunction
+
+// globalProcs holds the global Vulkan commands, loaded through
+// vkGetInstanceProcAddr with a NULL instance. Commands not present are nil.
+type globalProcs struct {
+{{- range .Global }}
+	{{ .CName }} C.PFN_{{ .CName }}
+{{- end }}
+}
+
+// loadGlobalProcs loads the global Vulkan commands.
+func loadGlobalProcs(getProcAddr procAddrFunc) *globalProcs {
+	return &globalProcs{
+{{- range .Global }}
+		{{ .CName }}: (C.PFN_{{ .CName }})(unsafe.Pointer(getProcAddr("{{ .CName }}"))),
+{{- end }}
+	}
+}
+{{ range .Global }}{{ template "method" (method "globalProcs" .) }}{{ end }}
+// instanceProcs holds the Vulkan commands of an instance, loaded through
+// vkGetInstanceProcAddr. Commands not present are nil.
+type instanceProcs struct {
+{{- range .Instance }}
+	{{ .CName }} C.PFN_{{ .CName }}
+{{- end }}
+}
+
+// loadInstanceProcs loads the Vulkan commands of an instance.
+func loadInstanceProcs(getProcAddr procAddrFunc) *instanceProcs {
+	return &instanceProcs{
+{{- range .Instance }}
+		{{ .CName }}: (C.PFN_{{ .CName }})(unsafe.Pointer(getProcAddr("{{ .CName }}"))),
+{{- end }}
+	}
+}
+{{ range .Instance }}{{ template "method" (method "instanceProcs" .) }}{{ end }}
+// deviceProcs holds the Vulkan commands of a device, loaded through
+// vkGetDeviceProcAddr. Commands not present are nil.
+type deviceProcs struct {
+{{- range .Device }}
+	{{ .CName }} C.PFN_{{ .CName }}
+{{- end }}
+}
+
+// loadDeviceProcs loads the Vulkan commands of a device.
+func loadDeviceProcs(getProcAddr procAddrFunc) *deviceProcs {
+	return &deviceProcs{
+{{- range .Device }}
+		{{ .CName }}: (C.PFN_{{ .CName }})(unsafe.Pointer(getProcAddr("{{ .CName }}"))),
+{{- end }}
+	}
+}
+{{ range .Device }}{{ template "method" (method "deviceProcs" .) }}{{ end }}{{ define "method" }}{{ $cmd := .Cmd }}
+// {{ $cmd.GoName }} calls {{ $cmd.CName }}
+{{- if $cmd.Extension }} of {{ $cmd.Extension }}{{ end }}.
+//
+{{- if eq $cmd.CResult "VkResult" }}
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+{{- else }}
+// The call is a no-op if the command is not present.
+{{- end }}
+func (p *{{ .Recv }}) {{ $cmd.GoName }}({{ range $i, $p := $cmd.Params }}{{ if $i }}, {{ end }}{{ $p.GoName }} {{ $p.GoType }}{{ end }}){{ if ne $cmd.CResult "void" }} C.{{ $cmd.CResult }}{{ end }} {
+	if p.{{ $cmd.CName }} == nil {
+{{- if eq $cmd.CResult "VkResult" }}
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+{{- else if eq $cmd.CResult "void" }}
+		return
+{{- else }}
+		var zero C.{{ $cmd.CResult }}
+		return zero
+{{- end }}
+	}
+	{{ if ne $cmd.CResult "void" }}return {{ end }}C.invoke_{{ $cmd.GoName }}(p.{{ $cmd.CName }}{{ range $cmd.Params }}, {{ .GoName }}{{ end }})
+}
+{{ end }}`
+
+// ### [ Output ] ##############################################################
+
+// funcs are the template functions of output files.
+var funcs = template.FuncMap{
+	"hasBitmask": func(enums []*goEnum) bool {
+		for _, enum := range enums {
+			if enum.Bitmask {
+				return true
+			}
+		}
+		return false
+	},
+	"lower": func(s string) string {
+		return strings.ToLower(s[:1]) + s[1:]
+	},
+	"method": func(recv string, cmd *goCommand) interface{} {
+		return struct {
+			Recv string
+			Cmd  *goCommand
+		}{Recv: recv, Cmd: cmd}
+	},
+}
+
+// write executes the given template, and writes the output to the given file
+// of the output directory.
+func (g *generator) write(name, tmplContent string, data interface{}) error {
+	buf, err := execute(name, tmplContent, data)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return g.writeFile(name, buf)
+}
+
+// writeGo executes the given template, and writes the gofmt formatted output
+// to the given file of the output directory.
+func (g *generator) writeGo(name, tmplContent string, data interface{}) error {
+	buf, err := execute(name, tmplContent, data)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	src, err := format.Source(buf)
+	if err != nil {
+		return errors.Wrapf(err, "unable to format %q:\n%s", name, buf)
+	}
+	return g.writeFile(name, src)
+}
+
+// execute executes the given template.
+func execute(name, tmplContent string, data interface{}) ([]byte, error) {
+	t, err := template.New(name).Funcs(funcs).Parse(tmplContent)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, data); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeFile writes the given file of the output directory.
+func (g *generator) writeFile(name string, buf []byte) error {
+	path := filepath.Join(g.outDir, name)
+	dbg.Printf("creating %q", path)
+	if err := os.WriteFile(path, buf, 0o644); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// The vkgen tool generates Go bindings for Vulkan from the Vulkan API registry
+// (vk.xml).
+//
+// The declarations to generate Go code for are specified by a configuration
+// file (see parseConfig). vkgen outputs the following files:
+//
+//	malloc.go  arena helpers allocating C values
+//	slice.go   arena helpers allocating Go slices backed by C memory
+//	enums.go   Go enum and bitmask types, with String methods
+//	procs.go   dispatch tables of global, instance-level and device-level
+//	           Vulkan commands
+//	invoke.go  trampolines calling Vulkan function pointers
+//	invoke.h   declarations of trampolines
+//
+// Usage:
+//
+//	vkgen [OPTION]... CONFIG
+//
+// Flags:
+//
+//	-o string
+//	      output directory (default ".")
+//	-registry string
+//	      path to vk.xml (default "$VULKAN_SDK/share/vulkan/registry/vk.xml" or
+//	      "/usr/share/vulkan/registry/vk.xml")
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/mewkiz/pkg/term"
+	"github.com/pkg/errors"
+)
+
+var (
+	// dbg is a logger with the "vkgen:" prefix which logs debug messages to
+	// standard error.
+	dbg = log.New(os.Stderr, term.MagentaBold("vkgen:")+" ", 0)
+	// warn is a logger with the "vkgen:" prefix which logs warning messages to
+	// standard error.
+	warn = log.New(os.Stderr, term.RedBold("vkgen:")+" ", log.Lshortfile)
+)
+
+func usage() {
+	const use = `
+Generate Go bindings for Vulkan from the Vulkan API registry (vk.xml).
+
+Usage:
+
+	vkgen [OPTION]... CONFIG
+
+Flags:
+`
+	fmt.Fprintln(os.Stderr, use[1:])
+	flag.PrintDefaults()
+}
+
+func main() {
+	// Parse command line arguments.
+	var (
+		// Output directory.
+		outDir string
+		// Path to vk.xml.
+		registryPath string
+		// Suppress non-error messages.
+		quiet bool
+	)
+	flag.StringVar(&outDir, "o", ".", "output directory")
+	flag.StringVar(&registryPath, "registry", defaultRegistryPath(), "path to vk.xml")
+	flag.BoolVar(&quiet, "q", false, "suppress non-error messages")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	if quiet {
+		dbg.SetOutput(ioutil.Discard)
+	}
+	configPath := flag.Arg(0)
+	if err := vkgen(registryPath, configPath, outDir); err != nil {
+		warn.Fatalf("%+v", err)
+	}
+}
+
+// vkgen generates Go bindings for the declarations of the given vk.xml
+// registry, as specified by the given configuration file.
+func vkgen(registryPath, configPath, outDir string) error {
+	dbg.Printf("parsing %q", registryPath)
+	reg, err := parseRegistry(registryPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	config, err := parseConfig(configPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	g := newGenerator(reg, config, outDir)
+	return g.gen()
+}
+
+// defaultRegistryPath returns the path to vk.xml of the installed Vulkan
+// headers; either of the Vulkan SDK (if VULKAN_SDK is set) or of the system.
+func defaultRegistryPath() string {
+	if sdk := os.Getenv("VULKAN_SDK"); len(sdk) > 0 {
+		return filepath.Join(sdk, "share", "vulkan", "registry", "vk.xml")
+	}
+	return "/usr/share/vulkan/registry/vk.xml"
+}
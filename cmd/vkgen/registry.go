@@ -0,0 +1,377 @@
+package main
+
+import (
+	"encoding/xml"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Registry is the Vulkan API registry, as specified by vk.xml.
+//
+// ref: https://registry.khronos.org/vulkan/specs/latest/registry.html
+type Registry struct {
+	Tags       []*Tag       `xml:"tags>tag"`
+	Types      []*Type      `xml:"types>type"`
+	Enums      []*Enums     `xml:"enums"`
+	Commands   []*Command   `xml:"commands>command"`
+	Features   []*Feature   `xml:"feature"`
+	Extensions []*Extension `xml:"extensions>extension"`
+}
+
+// Tag is a vendor tag of the registry (e.g. "KHR", "EXT").
+type Tag struct {
+	Name string `xml:"name,attr"`
+}
+
+// Type is a type declaration of the registry.
+type Type struct {
+	// Type name; specified either as attribute (e.g. structs, enums) or as
+	// element (e.g. handles, bitmasks).
+	NameAttr string `xml:"name,attr"`
+	NameElem string `xml:"name"`
+	// Type category (e.g. "struct", "handle", "bitmask").
+	Category string `xml:"category,attr"`
+	// Name of aliased type.
+	Alias string `xml:"alias,attr"`
+	// API of type declaration (e.g. "vulkan", "vulkansc"); empty if shared.
+	API string `xml:"api,attr"`
+}
+
+// Name returns the name of the type.
+func (t *Type) Name() string {
+	if len(t.NameAttr) > 0 {
+		return t.NameAttr
+	}
+	return t.NameElem
+}
+
+// Enums is an enum or bitmask type of the registry.
+type Enums struct {
+	// Enum type name (e.g. "VkPresentModeKHR").
+	Name string `xml:"name,attr"`
+	// Enum kind ("enum" or "bitmask"); empty for API constants.
+	Kind string `xml:"type,attr"`
+	// Bit width of bitmask type ("64" for 64-bit bitmasks); empty if 32-bit.
+	BitWidth string `xml:"bitwidth,attr"`
+	// Enumerants of core Vulkan.
+	Enums []*Enum `xml:"enum"`
+}
+
+// Enum is an enumerant of the registry.
+type Enum struct {
+	// Enumerant name (e.g. "VK_PRESENT_MODE_FIFO_KHR").
+	Name string `xml:"name,attr"`
+	// Integer value.
+	Value string `xml:"value,attr"`
+	// Bit position of bitmask value.
+	BitPos string `xml:"bitpos,attr"`
+	// Name of aliased enumerant.
+	Alias string `xml:"alias,attr"`
+	// Name of extended enum type; used by features and extensions.
+	Extends string `xml:"extends,attr"`
+	// Extension number and offset of value; used by features and extensions.
+	ExtNumber string `xml:"extnumber,attr"`
+	Offset    string `xml:"offset,attr"`
+	// Sign of value computed from offset ("-" if negative).
+	Dir string `xml:"dir,attr"`
+	// API of enumerant (e.g. "vulkan", "vulkansc"); empty if shared.
+	API string `xml:"api,attr"`
+}
+
+// Command is a command declaration of the registry.
+type Command struct {
+	// Name of aliased command; and command name of alias.
+	Alias    string `xml:"alias,attr"`
+	NameAttr string `xml:"name,attr"`
+	// API of command declaration (e.g. "vulkan", "vulkansc"); empty if shared.
+	API string `xml:"api,attr"`
+	// Command prototype.
+	Proto struct {
+		Type string `xml:"type"`
+		Name string `xml:"name"`
+	} `xml:"proto"`
+	// Command parameters.
+	Params []*Param `xml:"param"`
+}
+
+// Name returns the name of the command.
+func (c *Command) Name() string {
+	if len(c.NameAttr) > 0 {
+		return c.NameAttr
+	}
+	return c.Proto.Name
+}
+
+// Param is a command parameter.
+type Param struct {
+	// C declaration of parameter, with type and name elements.
+	InnerXML string `xml:",innerxml"`
+	// Base type of parameter (e.g. "VkInstanceCreateInfo").
+	Type string `xml:"type"`
+	// Parameter name.
+	Name string `xml:"name"`
+	// API of parameter (e.g. "vulkan", "vulkansc"); empty if shared.
+	API string `xml:"api,attr"`
+}
+
+// Feature is a core Vulkan version of the registry.
+type Feature struct {
+	// Feature name (e.g. "VK_VERSION_1_1").
+	Name string `xml:"name,attr"`
+	// Comma-separated list of APIs of feature (e.g. "vulkan,vulkansc").
+	API      string     `xml:"api,attr"`
+	Requires []*Require `xml:"require"`
+}
+
+// Extension is a Vulkan extension of the registry.
+type Extension struct {
+	// Extension name (e.g. "VK_EXT_debug_utils").
+	Name string `xml:"name,attr"`
+	// Extension number.
+	Number string `xml:"number,attr"`
+	// Extension kind ("instance" or "device").
+	Kind string `xml:"type,attr"`
+	// Comma-separated list of APIs supporting the extension (e.g. "vulkan"),
+	// or "disabled".
+	Supported string     `xml:"supported,attr"`
+	Requires  []*Require `xml:"require"`
+}
+
+// Require is a set of declarations required by a feature or extension.
+type Require struct {
+	// API of requirements (e.g. "vulkan", "vulkansc"); empty if shared.
+	API      string  `xml:"api,attr"`
+	Enums    []*Enum `xml:"enum"`
+	Commands []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"command"`
+}
+
+// parseRegistry parses the given vk.xml file.
+func parseRegistry(path string) (*Registry, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	reg := &Registry{}
+	if err := xml.Unmarshal(buf, reg); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse %q", path)
+	}
+	return reg, nil
+}
+
+// isVulkan reports whether the given comma-separated list of APIs includes
+// Vulkan; an empty list is shared by all APIs.
+func isVulkan(apis string) bool {
+	if len(apis) == 0 {
+		return true
+	}
+	for _, api := range strings.Split(apis, ",") {
+		if api == "vulkan" {
+			return true
+		}
+	}
+	return false
+}
+
+// findType returns the Vulkan type declaration with the given name.
+func (reg *Registry) findType(name string) (*Type, bool) {
+	for _, t := range reg.Types {
+		if t.Name() == name && isVulkan(t.API) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// findCommand returns the Vulkan command declaration with the given name, and
+// the extension requiring the command; or nil if core.
+func (reg *Registry) findCommand(name string) (*Command, *Extension, bool) {
+	for _, cmd := range reg.Commands {
+		if cmd.Name() != name || !isVulkan(cmd.API) {
+			continue
+		}
+		if len(cmd.Alias) > 0 {
+			return nil, nil, false
+		}
+		for _, ext := range reg.Extensions {
+			if !isVulkan(ext.Supported) {
+				continue
+			}
+			for _, req := range ext.Requires {
+				for _, c := range req.Commands {
+					if c.Name == name {
+						return cmd, ext, true
+					}
+				}
+			}
+		}
+		return cmd, nil, true
+	}
+	return nil, nil, false
+}
+
+// enumValue is a resolved enumerant of an enum or bitmask type.
+type enumValue struct {
+	// Enumerant name (e.g. "VK_PRESENT_MODE_FIFO_KHR").
+	name string
+	// Integer value.
+	value int64
+}
+
+// findEnums returns the enum or bitmask type with the given name, and its
+// enumerants defined by core Vulkan and by supported extensions. Aliases of
+// enumerants are omitted.
+func (reg *Registry) findEnums(name string) (*Enums, []enumValue, error) {
+	var enums *Enums
+	for _, e := range reg.Enums {
+		if e.Name == name {
+			enums = e
+			break
+		}
+	}
+	if enums == nil || (enums.Kind != "enum" && enums.Kind != "bitmask") {
+		return nil, nil, errors.Errorf("unable to locate enum or bitmask type %q", name)
+	}
+	var values []enumValue
+	seen := make(map[string]bool)
+	add := func(e *Enum, extNumber string) error {
+		if len(e.Alias) > 0 || seen[e.Name] || !isVulkan(e.API) {
+			return nil
+		}
+		v, err := e.resolve(extNumber)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		seen[e.Name] = true
+		values = append(values, enumValue{name: e.Name, value: v})
+		return nil
+	}
+	for _, e := range enums.Enums {
+		if err := add(e, ""); err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+	}
+	for _, feature := range reg.Features {
+		if !isVulkan(feature.API) {
+			continue
+		}
+		for _, req := range feature.Requires {
+			if !isVulkan(req.API) {
+				continue
+			}
+			for _, e := range req.Enums {
+				if e.Extends != name {
+					continue
+				}
+				if err := add(e, ""); err != nil {
+					return nil, nil, errors.WithStack(err)
+				}
+			}
+		}
+	}
+	for _, ext := range reg.Extensions {
+		if !isVulkan(ext.Supported) {
+			continue
+		}
+		for _, req := range ext.Requires {
+			if !isVulkan(req.API) {
+				continue
+			}
+			for _, e := range req.Enums {
+				if e.Extends != name {
+					continue
+				}
+				if err := add(e, ext.Number); err != nil {
+					return nil, nil, errors.WithStack(err)
+				}
+			}
+		}
+	}
+	return enums, values, nil
+}
+
+// resolve returns the integer value of the enumerant. The extension number is
+// used for offset values, unless specified by the enumerant.
+//
+// ref: https://registry.khronos.org/vulkan/specs/latest/styleguide.html#_assigning_extension_token_values
+func (e *Enum) resolve(extNumber string) (int64, error) {
+	switch {
+	case len(e.Value) > 0:
+		v, err := strconv.ParseInt(e.Value, 0, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable to parse value of enumerant %q", e.Name)
+		}
+		return v, nil
+	case len(e.BitPos) > 0:
+		pos, err := strconv.ParseUint(e.BitPos, 10, 6)
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable to parse bit position of enumerant %q", e.Name)
+		}
+		return 1 << pos, nil
+	case len(e.Offset) > 0:
+		if len(e.ExtNumber) > 0 {
+			extNumber = e.ExtNumber
+		}
+		n, err := strconv.ParseInt(extNumber, 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable to parse extension number of enumerant %q", e.Name)
+		}
+		offset, err := strconv.ParseInt(e.Offset, 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable to parse offset of enumerant %q", e.Name)
+		}
+		v := 1000000000 + (n-1)*1000 + offset
+		if e.Dir == "-" {
+			v = -v
+		}
+		return v, nil
+	}
+	return 0, errors.Errorf("missing value of enumerant %q", e.Name)
+}
+
+// params returns the Vulkan parameters of the command.
+func (c *Command) params() []*Param {
+	var params []*Param
+	for _, param := range c.Params {
+		if isVulkan(param.API) {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+var (
+	// reTag matches XML tags.
+	reTag = regexp.MustCompile(`<[^>]*>`)
+	// reSpace matches runs of whitespace.
+	reSpace = regexp.MustCompile(`\s+`)
+	// rePtr matches pointer declarators.
+	rePtr = regexp.MustCompile(`\s*\*\s*`)
+)
+
+// cDecl returns the C declaration of the parameter (e.g.
+// "const VkInstanceCreateInfo *pCreateInfo").
+func (p *Param) cDecl() string {
+	s := reTag.ReplaceAllString(p.InnerXML, "")
+	s = strings.TrimSpace(reSpace.ReplaceAllString(s, " "))
+	return rePtr.ReplaceAllString(s, " *")
+}
+
+// goType returns the cgo type of the parameter (e.g.
+// "*C.VkInstanceCreateInfo").
+func (p *Param) goType() string {
+	decl := p.cDecl()
+	// Array parameters are passed as pointers.
+	nptrs := strings.Count(decl, "*") + strings.Count(decl, "[")
+	typ := "C." + p.Type
+	if p.Type == "void" && nptrs > 0 {
+		typ = "unsafe.Pointer"
+		nptrs--
+	}
+	return strings.Repeat("*", nptrs) + typ
+}
@@ -5,7 +5,7 @@ go 1.17
 require (
 	github.com/kr/pretty v0.2.1
 	github.com/mewkiz/pkg v0.0.0-20210604082325-6217eed0deab
-	github.com/pkg/errors v0.8.1
+	github.com/pkg/errors v0.9.1
 )
 
 require github.com/kr/text v0.1.0 // indirect
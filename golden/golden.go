@@ -0,0 +1,187 @@
+// Package golden compares rendered images against reference images (golden
+// images) stored as PNG files.
+package golden
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Options specifies the tolerance of image comparisons.
+type Options struct {
+	// Maximum difference of color channels (in range [0, 255]) between pixels
+	// considered equal.
+	Tolerance uint8
+	// Maximum fraction of pixels which may differ (in range [0, 1]).
+	Budget float64
+}
+
+// DefaultOptions specifies the default tolerance of image comparisons; small
+// enough to catch rendering changes, and large enough to allow for rounding
+// differences between drivers.
+var DefaultOptions = Options{
+	Tolerance: 2,
+	Budget:    0.001,
+}
+
+// Result is the result of an image comparison.
+type Result struct {
+	// Number of pixels differing by more than the tolerance.
+	NDiffs int
+	// Total number of pixels.
+	NPixels int
+	// Maximum difference of color channels between pixels.
+	MaxDelta uint8
+	// Diff image; differing pixels are red, and equal pixels are faded
+	// grayscale pixels of the reference image.
+	Diff *image.RGBA
+}
+
+// Compare compares the image against the reference image, using the given
+// tolerance. An error is returned if the images differ in size.
+func Compare(got, want image.Image, opts Options) (*Result, error) {
+	bounds := want.Bounds()
+	if got.Bounds().Size() != bounds.Size() {
+		return nil, errors.Errorf("image size mismatch; expected %v, got %v", bounds.Size(), got.Bounds().Size())
+	}
+	res := &Result{
+		NPixels: bounds.Dx() * bounds.Dy(),
+		Diff:    image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy())),
+	}
+	offset := got.Bounds().Min.Sub(bounds.Min)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			w := color.NRGBAModel.Convert(want.At(x, y)).(color.NRGBA)
+			g := color.NRGBAModel.Convert(got.At(x+offset.X, y+offset.Y)).(color.NRGBA)
+			delta := maxDelta(w, g)
+			if delta > res.MaxDelta {
+				res.MaxDelta = delta
+			}
+			p := image.Pt(x, y).Sub(bounds.Min)
+			if delta > opts.Tolerance {
+				res.NDiffs++
+				res.Diff.Set(p.X, p.Y, color.RGBA{R: 0xFF, A: 0xFF})
+				continue
+			}
+			gray := color.GrayModel.Convert(w).(color.Gray)
+			faded := 0xC0 + gray.Y/4
+			res.Diff.Set(p.X, p.Y, color.RGBA{R: faded, G: faded, B: faded, A: 0xFF})
+		}
+	}
+	return res, nil
+}
+
+// OK reports whether the number of differing pixels is within the budget of
+// the given options.
+func (res *Result) OK(opts Options) bool {
+	return float64(res.NDiffs) <= opts.Budget*float64(res.NPixels)
+}
+
+// String returns a summary of the image comparison.
+func (res *Result) String() string {
+	return fmt.Sprintf("%d of %d pixels differ (%.3f%%); max delta %d", res.NDiffs, res.NPixels, 100*float64(res.NDiffs)/float64(res.NPixels), res.MaxDelta)
+}
+
+// Check compares the image against the reference image "NAME.png" of the given
+// directory. If update is set, the reference image is written instead.
+//
+// On mismatch, the image and a diff image are written to "failed/NAME.png" and
+// "failed/NAME_diff.png" of the directory, and an error is returned.
+func Check(dir, name string, got image.Image, update bool, opts Options) error {
+	refPath := filepath.Join(dir, name+".png")
+	if update {
+		if err := WritePNG(refPath, got); err != nil {
+			return errors.WithStack(err)
+		}
+		return nil
+	}
+	want, err := ReadPNG(refPath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read reference image of %q; run with -update to create it", name)
+	}
+	failedDir := filepath.Join(dir, "failed")
+	gotPath := filepath.Join(failedDir, name+".png")
+	res, err := Compare(got, want, opts)
+	if err != nil {
+		if err := WritePNG(gotPath, got); err != nil {
+			return errors.WithStack(err)
+		}
+		return errors.Wrapf(err, "mismatch of %q; image written to %q", name, gotPath)
+	}
+	if res.OK(opts) {
+		return nil
+	}
+	diffPath := filepath.Join(failedDir, name+"_diff.png")
+	if err := WritePNG(gotPath, got); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := WritePNG(diffPath, res.Diff); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.Errorf("mismatch of %q; %v; image written to %q and diff to %q", name, res, gotPath, diffPath)
+}
+
+// ReadPNG reads the given PNG image.
+func ReadPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to decode %q", path)
+	}
+	return img, nil
+}
+
+// WritePNG writes the image to the given PNG file, creating its parent
+// directory if not present.
+func WritePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.WithStack(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "unable to encode %q", path)
+	}
+	if err := f.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// maxDelta returns the maximum difference of color channels between the given
+// colors.
+func maxDelta(a, b color.NRGBA) uint8 {
+	var max uint8
+	for _, d := range []uint8{
+		absDiff(a.R, b.R),
+		absDiff(a.G, b.G),
+		absDiff(a.B, b.B),
+		absDiff(a.A, b.A),
+	} {
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+// absDiff returns the absolute difference of a and b.
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
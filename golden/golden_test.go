@@ -0,0 +1,194 @@
+package golden
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	golden := []struct {
+		name string
+		// Color of pixels of the image, except for the given number of pixels
+		// which differ by delta.
+		delta  uint8
+		ndiffs int
+		opts   Options
+		// Expected number of differing pixels, maximum delta, and whether the
+		// comparison is within budget.
+		wantNDiffs   int
+		wantMaxDelta uint8
+		wantOK       bool
+	}{
+		{
+			name:   "identical",
+			opts:   DefaultOptions,
+			wantOK: true,
+		},
+		{
+			name:         "within tolerance",
+			delta:        2,
+			ndiffs:       10,
+			opts:         Options{Tolerance: 2, Budget: 0},
+			wantNDiffs:   0,
+			wantMaxDelta: 2,
+			wantOK:       true,
+		},
+		{
+			name:         "above tolerance",
+			delta:        3,
+			ndiffs:       10,
+			opts:         Options{Tolerance: 2, Budget: 0},
+			wantNDiffs:   10,
+			wantMaxDelta: 3,
+			wantOK:       false,
+		},
+		{
+			name:         "within budget",
+			delta:        100,
+			ndiffs:       10,
+			opts:         Options{Tolerance: 2, Budget: 10.0 / (16 * 16)},
+			wantNDiffs:   10,
+			wantMaxDelta: 100,
+			wantOK:       true,
+		},
+		{
+			name:         "above budget",
+			delta:        100,
+			ndiffs:       11,
+			opts:         Options{Tolerance: 2, Budget: 10.0 / (16 * 16)},
+			wantNDiffs:   11,
+			wantMaxDelta: 100,
+			wantOK:       false,
+		},
+	}
+	for _, g := range golden {
+		want := newImage(16, 16, color.NRGBA{R: 0x40, G: 0x80, B: 0xC0, A: 0xFF})
+		got := newImage(16, 16, color.NRGBA{R: 0x40, G: 0x80, B: 0xC0, A: 0xFF})
+		for i := 0; i < g.ndiffs; i++ {
+			got.Set(i%16, i/16, color.NRGBA{R: 0x40, G: 0x80 + g.delta, B: 0xC0, A: 0xFF})
+		}
+		res, err := Compare(got, want, g.opts)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", g.name, err)
+			continue
+		}
+		if res.NDiffs != g.wantNDiffs {
+			t.Errorf("%s: number of differing pixels mismatch; expected %d, got %d", g.name, g.wantNDiffs, res.NDiffs)
+		}
+		if res.MaxDelta != g.wantMaxDelta {
+			t.Errorf("%s: max delta mismatch; expected %d, got %d", g.name, g.wantMaxDelta, res.MaxDelta)
+		}
+		if res.NPixels != 16*16 {
+			t.Errorf("%s: number of pixels mismatch; expected %d, got %d", g.name, 16*16, res.NPixels)
+		}
+		if ok := res.OK(g.opts); ok != g.wantOK {
+			t.Errorf("%s: OK mismatch; expected %v, got %v (%v)", g.name, g.wantOK, ok, res)
+		}
+		// Differing pixels are red in the diff image.
+		red := color.RGBA{R: 0xFF, A: 0xFF}
+		ndiffs := 0
+		for y := 0; y < 16; y++ {
+			for x := 0; x < 16; x++ {
+				if res.Diff.RGBAAt(x, y) == red {
+					ndiffs++
+				}
+			}
+		}
+		if ndiffs != g.wantNDiffs {
+			t.Errorf("%s: number of red pixels of diff image mismatch; expected %d, got %d", g.name, g.wantNDiffs, ndiffs)
+		}
+	}
+}
+
+func TestCompareSizeMismatch(t *testing.T) {
+	want := newImage(16, 16, color.NRGBA{A: 0xFF})
+	got := newImage(16, 8, color.NRGBA{A: 0xFF})
+	if _, err := Compare(got, want, DefaultOptions); err == nil {
+		t.Errorf("expected error on image size mismatch")
+	}
+}
+
+func TestCompareOffset(t *testing.T) {
+	// Images of equal size are compared pixel by pixel, regardless of the
+	// origin of their bounds.
+	c := color.NRGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xFF}
+	want := newImage(4, 4, c)
+	got := image.NewNRGBA(image.Rect(10, 20, 14, 24))
+	for y := 20; y < 24; y++ {
+		for x := 10; x < 14; x++ {
+			got.Set(x, y, c)
+		}
+	}
+	got.Set(10, 20, color.NRGBA{A: 0xFF})
+	res, err := Compare(got, want, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.NDiffs != 1 {
+		t.Errorf("number of differing pixels mismatch; expected 1, got %d", res.NDiffs)
+	}
+	if res.Diff.RGBAAt(0, 0) != (color.RGBA{R: 0xFF, A: 0xFF}) {
+		t.Errorf("expected red diff pixel at (0, 0), got %v", res.Diff.RGBAAt(0, 0))
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	c := color.NRGBA{R: 0x40, G: 0x80, B: 0xC0, A: 0xFF}
+	ref := newImage(8, 8, c)
+	opts := Options{Tolerance: 2, Budget: 0}
+	// Missing reference image.
+	if err := Check(dir, "scene", ref, false, opts); err == nil {
+		t.Errorf("expected error on missing reference image")
+	}
+	// Create reference image.
+	if err := Check(dir, "scene", ref, true, opts); err != nil {
+		t.Fatalf("unable to update reference image: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "scene.png")); err != nil {
+		t.Fatalf("reference image not written: %v", err)
+	}
+	// Match within tolerance.
+	within := newImage(8, 8, color.NRGBA{R: 0x42, G: 0x7E, B: 0xC0, A: 0xFF})
+	if err := Check(dir, "scene", within, false, opts); err != nil {
+		t.Errorf("unexpected mismatch within tolerance: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "failed")); !os.IsNotExist(err) {
+		t.Errorf("unexpected failed directory on match")
+	}
+	// Mismatch above tolerance.
+	above := newImage(8, 8, c)
+	above.Set(3, 3, color.NRGBA{R: 0x43, G: 0x80, B: 0xC0, A: 0xFF})
+	if err := Check(dir, "scene", above, false, opts); err == nil {
+		t.Errorf("expected mismatch above tolerance")
+	}
+	for _, name := range []string{"scene.png", "scene_diff.png"} {
+		if _, err := os.Stat(filepath.Join(dir, "failed", name)); err != nil {
+			t.Errorf("failed image %q not written: %v", name, err)
+		}
+	}
+	// Mismatch within budget.
+	opts.Budget = 1.0 / 64
+	if err := Check(dir, "scene", above, false, opts); err != nil {
+		t.Errorf("unexpected mismatch within budget: %v", err)
+	}
+	// Size mismatch.
+	small := newImage(4, 4, c)
+	if err := Check(dir, "scene", small, false, opts); err == nil {
+		t.Errorf("expected error on image size mismatch")
+	}
+}
+
+// newImage returns an image of the given size filled with the given color.
+func newImage(width, height int, c color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
@@ -0,0 +1,85 @@
+// Package recording writes recorded frames as numbered PNG image sequences or
+// animated GIF images.
+package recording
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// GIF is a recorder of frames writing an animated GIF image. Frames are
+// quantized to palettes of at most 256 colors as they are added, and the GIF
+// image is written once the recording is closed.
+type GIF struct {
+	// Path of the GIF image.
+	path string
+	// Quantized frames.
+	frames []*image.Paletted
+	// Time of each frame, relative to the first frame.
+	times []time.Duration
+}
+
+// NewGIF returns a new recorder of frames writing an animated GIF image to the
+// given path.
+func NewGIF(path string) *GIF {
+	return &GIF{path: path}
+}
+
+// AddFrame adds the next frame of the recording, rendered at the given time
+// relative to the first frame.
+func (g *GIF) AddFrame(img *image.RGBA, t time.Duration) error {
+	bounds := img.Bounds()
+	palette := MedianCut{}.Quantize(make(color.Palette, 0, 256), img)
+	frame := image.NewPaletted(bounds, palette)
+	draw.FloydSteinberg.Draw(frame, bounds, img, bounds.Min)
+	g.frames = append(g.frames, frame)
+	g.times = append(g.times, t)
+	return nil
+}
+
+// Close writes the animated GIF image of the recorded frames.
+func (g *GIF) Close() error {
+	if len(g.frames) == 0 {
+		return errors.Errorf("unable to write %q; no frames recorded", g.path)
+	}
+	anim := &gif.GIF{
+		Image: g.frames,
+		Delay: delays(g.times),
+	}
+	f, err := os.Create(g.path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := gif.EncodeAll(f, anim); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "unable to encode %q", g.path)
+	}
+	if err := f.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// delays returns the delay of each frame in 100ths of a second, based on the
+// time of the frames. Delays are rounded based on the time of each frame, so
+// that rounding errors do not accumulate. The last frame is given the delay of
+// the previous frame.
+func delays(times []time.Duration) []int {
+	centis := func(t time.Duration) int {
+		return int(t.Round(10*time.Millisecond) / (10 * time.Millisecond))
+	}
+	ds := make([]int, len(times))
+	for i := 0; i+1 < len(times); i++ {
+		ds[i] = centis(times[i+1]) - centis(times[i])
+	}
+	if len(ds) > 1 {
+		ds[len(ds)-1] = ds[len(ds)-2]
+	}
+	return ds
+}
@@ -0,0 +1,94 @@
+package recording
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// PNGSequence is a recorder of frames writing a numbered sequence of PNG
+// images. Frames are written in the background as they are added.
+type PNGSequence struct {
+	// Path pattern of the PNG images, with a verb formatting the frame number
+	// (e.g. "frame_%04d.png").
+	pattern string
+	// Number of frames added.
+	nframes int
+	// Frames to write.
+	frames chan pngFrame
+	// Result of the background writer, once all frames have been written.
+	done chan error
+}
+
+// pngFrame is a frame of a PNG image sequence.
+type pngFrame struct {
+	path string
+	img  *image.RGBA
+}
+
+// NewPNGSequence returns a new recorder of frames writing a numbered sequence of
+// PNG images, the paths of which are given by the frame number (starting at 0)
+// formatted according to the given pattern (e.g. "frame_%04d.png").
+func NewPNGSequence(pattern string) (*PNGSequence, error) {
+	if !strings.Contains(pattern, "%") {
+		return nil, errors.Errorf("invalid path pattern %q of PNG image sequence; expected verb of frame number (e.g. %q)", pattern, "frame_%04d.png")
+	}
+	seq := &PNGSequence{
+		pattern: pattern,
+		frames:  make(chan pngFrame, 16),
+		done:    make(chan error, 1),
+	}
+	go seq.write()
+	return seq, nil
+}
+
+// AddFrame adds the next frame of the recording; the time of the frame is not
+// recorded.
+func (seq *PNGSequence) AddFrame(img *image.RGBA, t time.Duration) error {
+	path := fmt.Sprintf(seq.pattern, seq.nframes)
+	seq.nframes++
+	seq.frames <- pngFrame{path: path, img: img}
+	return nil
+}
+
+// Close waits for the frames of the recording to be written.
+func (seq *PNGSequence) Close() error {
+	close(seq.frames)
+	return <-seq.done
+}
+
+// write writes the frames of the PNG image sequence, reporting the first error
+// once all frames have been received.
+func (seq *PNGSequence) write() {
+	var firstErr error
+	for frame := range seq.frames {
+		if firstErr != nil {
+			continue // drain remaining frames.
+		}
+		if err := writePNG(frame.path, frame.img); err != nil {
+			firstErr = err
+		}
+	}
+	seq.done <- firstErr
+}
+
+// writePNG writes the image to the given PNG file.
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "unable to encode %q", path)
+	}
+	if err := f.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package recording
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// MedianCut is a color quantizer using the median cut algorithm; the color
+// space of the image is recursively split at the median of its widest color
+// channel, and each resulting box of colors is replaced by its mean color.
+//
+// The quantization is deterministic, and alpha is ignored (i.e. colors are
+// opaque).
+type MedianCut struct{}
+
+// Quantize appends up to cap(p)-len(p) colors to p, quantizing the colors of
+// the given image.
+func (MedianCut) Quantize(p color.Palette, m image.Image) color.Palette {
+	n := cap(p) - len(p)
+	if n <= 0 {
+		return p
+	}
+	boxes := []*colorBox{newColorBox(histogram(m))}
+	for len(boxes) < n {
+		// Split the box with the widest color channel.
+		widest := -1
+		for i, box := range boxes {
+			if len(box.colors) < 2 {
+				continue
+			}
+			if widest == -1 || box.width() > boxes[widest].width() {
+				widest = i
+			}
+		}
+		if widest == -1 {
+			break // all boxes hold a single color.
+		}
+		a, b := boxes[widest].split()
+		boxes[widest] = a
+		boxes = append(boxes, b)
+	}
+	for _, box := range boxes {
+		if len(box.colors) > 0 {
+			p = append(p, box.mean())
+		}
+	}
+	return p
+}
+
+// colorCount is a color and its number of occurrences in an image.
+type colorCount struct {
+	rgb   [3]uint8
+	count int
+}
+
+// histogram returns the colors of the given image and their number of
+// occurrences, sorted by color.
+func histogram(m image.Image) []colorCount {
+	counts := make(map[[3]uint8]int)
+	bounds := m.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		if rgba, ok := m.(*image.RGBA); ok {
+			// Fast path for opaque RGBA images (e.g. recorded frames).
+			row := rgba.Pix[rgba.PixOffset(bounds.Min.X, y):rgba.PixOffset(bounds.Max.X, y)]
+			for i := 0; i < len(row); i += 4 {
+				counts[[3]uint8{row[i+0], row[i+1], row[i+2]}]++
+			}
+			continue
+		}
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(m.At(x, y)).(color.NRGBA)
+			counts[[3]uint8{c.R, c.G, c.B}]++
+		}
+	}
+	colors := make([]colorCount, 0, len(counts))
+	for rgb, count := range counts {
+		colors = append(colors, colorCount{rgb: rgb, count: count})
+	}
+	// Sort colors, as the iteration order of maps is random.
+	sort.Slice(colors, func(i, j int) bool {
+		a, b := colors[i].rgb, colors[j].rgb
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return false
+	})
+	return colors
+}
+
+// colorBox is a box of the color space.
+type colorBox struct {
+	// Colors of the box.
+	colors []colorCount
+	// Minimum and maximum value of each color channel.
+	min, max [3]uint8
+}
+
+// newColorBox returns the bounding box of the given colors.
+func newColorBox(colors []colorCount) *colorBox {
+	box := &colorBox{colors: colors}
+	box.min = [3]uint8{0xFF, 0xFF, 0xFF}
+	for _, c := range colors {
+		for k, v := range c.rgb {
+			if v < box.min[k] {
+				box.min[k] = v
+			}
+			if v > box.max[k] {
+				box.max[k] = v
+			}
+		}
+	}
+	return box
+}
+
+// widestChannel returns the index of the widest color channel of the box.
+func (box *colorBox) widestChannel() int {
+	widest := 0
+	for k := range box.min {
+		if box.max[k]-box.min[k] > box.max[widest]-box.min[widest] {
+			widest = k
+		}
+	}
+	return widest
+}
+
+// width returns the width of the widest color channel of the box.
+func (box *colorBox) width() uint8 {
+	k := box.widestChannel()
+	return box.max[k] - box.min[k]
+}
+
+// split splits the box in two at the median of its widest color channel,
+// weighted by the number of occurrences of each color. The box must hold at
+// least two colors.
+func (box *colorBox) split() (*colorBox, *colorBox) {
+	k := box.widestChannel()
+	sort.SliceStable(box.colors, func(i, j int) bool {
+		return box.colors[i].rgb[k] < box.colors[j].rgb[k]
+	})
+	total := 0
+	for _, c := range box.colors {
+		total += c.count
+	}
+	// Locate median, keeping at least one color in each box.
+	mid, sum := 1, box.colors[0].count
+	for mid < len(box.colors)-1 && sum < total/2 {
+		sum += box.colors[mid].count
+		mid++
+	}
+	return newColorBox(box.colors[:mid]), newColorBox(box.colors[mid:])
+}
+
+// mean returns the mean color of the box, weighted by the number of
+// occurrences of each color.
+func (box *colorBox) mean() color.Color {
+	var sum [3]int
+	total := 0
+	for _, c := range box.colors {
+		for k, v := range c.rgb {
+			sum[k] += int(v) * c.count
+		}
+		total += c.count
+	}
+	return color.RGBA{
+		R: uint8((sum[0] + total/2) / total),
+		G: uint8((sum[1] + total/2) / total),
+		B: uint8((sum[2] + total/2) / total),
+		A: 0xFF,
+	}
+}
@@ -0,0 +1,122 @@
+package recording
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestMedianCutPaletteSize(t *testing.T) {
+	golden := []struct {
+		// Number of distinct colors of the image.
+		ncolors int
+		// Capacity of the palette.
+		cap  int
+		want int
+	}{
+		{ncolors: 1000, cap: 256, want: 256},
+		{ncolors: 1000, cap: 16, want: 16},
+		{ncolors: 10, cap: 256, want: 10},
+		{ncolors: 2, cap: 2, want: 2},
+		{ncolors: 1000, cap: 0, want: 0},
+	}
+	for _, g := range golden {
+		m := newGradient(g.ncolors)
+		p := MedianCut{}.Quantize(make(color.Palette, 0, g.cap), m)
+		if len(p) != g.want {
+			t.Errorf("palette size mismatch of %d colors quantized to at most %d; expected %d, got %d", g.ncolors, g.cap, g.want, len(p))
+		}
+	}
+}
+
+func TestMedianCutAppend(t *testing.T) {
+	// Colors are appended to the colors already present in the palette.
+	transparent := color.RGBA{}
+	p := make(color.Palette, 1, 16)
+	p[0] = transparent
+	p = MedianCut{}.Quantize(p, newGradient(1000))
+	if len(p) != 16 {
+		t.Fatalf("palette size mismatch; expected 16, got %d", len(p))
+	}
+	if p[0] != transparent {
+		t.Errorf("first palette color mismatch; expected %v, got %v", transparent, p[0])
+	}
+}
+
+func TestMedianCutSingleColor(t *testing.T) {
+	c := color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xFF}
+	m := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			m.SetRGBA(x, y, c)
+		}
+	}
+	p := MedianCut{}.Quantize(make(color.Palette, 0, 256), m)
+	want := color.Palette{c}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("palette mismatch; expected %v, got %v", want, p)
+	}
+}
+
+func TestMedianCutExactColors(t *testing.T) {
+	// Images with no more colors than the palette capacity are quantized
+	// without loss.
+	m := newGradient(8)
+	p := MedianCut{}.Quantize(make(color.Palette, 0, 256), m)
+	bounds := m.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := m.At(x, y)
+			if got := p.Convert(c); got != c {
+				t.Fatalf("color mismatch at (%d, %d); expected %v, got %v", x, y, c, got)
+			}
+		}
+	}
+}
+
+func TestMedianCutDeterministic(t *testing.T) {
+	m := newGradient(5000)
+	want := MedianCut{}.Quantize(make(color.Palette, 0, 64), m)
+	for i := 0; i < 10; i++ {
+		// Quantize a copy of the image, using the generic (non-RGBA) path on
+		// every other run.
+		var img image.Image = newGradient(5000)
+		if i%2 == 1 {
+			img = toNRGBA(img)
+		}
+		got := MedianCut{}.Quantize(make(color.Palette, 0, 64), img)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("palette mismatch of run %d; expected %v, got %v", i, want, got)
+		}
+	}
+}
+
+// newGradient returns an opaque RGBA image with the given number of distinct
+// colors, one per pixel.
+func newGradient(ncolors int) *image.RGBA {
+	const width = 64
+	height := (ncolors + width - 1) / width
+	m := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := 0; i < width*height; i++ {
+		j := i
+		if j >= ncolors {
+			j = ncolors - 1
+		}
+		c := color.RGBA{R: uint8(j), G: uint8(j>>8) * 16, B: uint8(j * 5), A: 0xFF}
+		m.SetRGBA(i%width, i/width, c)
+	}
+	return m
+}
+
+// toNRGBA returns a copy of the given image as an NRGBA image.
+func toNRGBA(m image.Image) *image.NRGBA {
+	bounds := m.Bounds()
+	dst := image.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			dst.Set(x, y, m.At(x, y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,33 @@
+#version 450
+
+// per-vertex input variables.
+layout(location = 0) in vec2 inPosition;
+layout(location = 1) in vec3 inColor;
+
+// per-instance input variables.
+layout(location = 2) in vec2 inOffset; // translation of the instance.
+layout(location = 3) in float inScale; // uniform scale of the instance.
+layout(location = 4) in float inAngle; // rotation of the instance in radians.
+layout(location = 5) in vec3 inTint;   // color multiplied with vertex colors.
+
+// push constants updated every draw.
+layout(push_constant) uniform PushConstants {
+	mat4 transform; // transform from model space to clip space.
+} pc;
+
+// output to framebuffer index 0.
+layout(location = 0) out vec3 fragColor;
+
+// rotate returns the rotation matrix of the given angle in radians.
+mat2 rotate(float angle) {
+	float c = cos(angle);
+	float s = sin(angle);
+	return mat2(c, s, -s, c);
+}
+
+// main called for every vertex of every instance.
+void main() {
+	vec2 pos = inOffset + inScale * (rotate(inAngle) * inPosition);
+	gl_Position = pc.transform * vec4(pos, 0.0, 1.0); // xy, z, w
+	fragColor = inColor * inTint;
+}
@@ -0,0 +1,16 @@
+#version 450
+
+// input from vertex shader.
+layout(location = 0) in vec3 fragColor;
+layout(location = 1) in vec2 fragTexCoord;
+
+// texture of the scene, filtered within and between mip levels.
+layout(set = 0, binding = 0) uniform sampler2D texSampler;
+
+// output to framebuffer index 0.
+layout(location = 0) out vec4 outColor;
+
+// main called for every fragment.
+void main() {
+	outColor = texture(texSampler, fragTexCoord) * vec4(fragColor, 1.0); // tinted by vertex color.
+}
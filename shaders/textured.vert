@@ -0,0 +1,24 @@
+#version 450
+
+// input variables.
+layout(location = 0) in vec2 inPosition;
+layout(location = 1) in vec3 inColor;
+
+// push constants updated every draw.
+layout(push_constant) uniform PushConstants {
+	mat4 transform; // transform from model space to clip space.
+} pc;
+
+// output to fragment shader.
+layout(location = 0) out vec3 fragColor;
+layout(location = 1) out vec2 fragTexCoord;
+
+// number of repetitions of the texture per unit of model space.
+const float textureRepeat = 4.0;
+
+// main called for every vertex.
+void main() {
+	gl_Position = pc.transform * vec4(inPosition, 0.0, 1.0); // xy, z, w
+	fragColor = inColor;
+	fragTexCoord = inPosition * textureRepeat; // uv from model space position.
+}
@@ -0,0 +1,247 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// Number of invocations of a workgroup of the compute shaders animating
+// vertices (local_size_x).
+const animateWorkgroupSize = 64
+
+// animatePushConstants specifies the push constants of the compute shaders
+// animating vertices.
+type animatePushConstants struct {
+	// Time of the frame in seconds.
+	time float32
+	// Number of vertices.
+	nvertices uint32
+}
+
+// animation is the animation of the vertices of a scene by a compute shader.
+type animation struct {
+	// Compute pipeline animating the vertices of the scene.
+	pipeline *computePipeline
+	// Vertex buffers written by the compute queue, one per frame in flight.
+	vertexBuffers    [MaxFramesInFlight]*C.VkBuffer
+	vertexBufferMems [MaxFramesInFlight]*C.VkDeviceMemory
+	// Command buffers of the compute queue, one per frame in flight.
+	commandBuffers     []C.VkCommandBuffer
+	finishedSemaphores [MaxFramesInFlight]*C.VkSemaphore // animated vertices written, ready for rendering; or nil if timeline semaphores
+}
+
+// initAnimation initializes the animation of the vertices of the scene on the
+// compute queue, if the scene has a compute shader; stored in app.animation.
+//
+// Each frame in flight, the compute shader reads the vertex buffer and writes
+// the animated vertex buffer of the frame, which is then used as vertex buffer
+// by the graphics queue. If the compute and graphics queue families differ,
+// ownership of the animated vertex buffer is transferred from the compute to
+// the graphics queue family.
+func initAnimation(app *App) error {
+	if len(app.scene.computeShader) == 0 {
+		return nil
+	}
+	descriptorTypes := []C.VkDescriptorType{
+		C.VK_DESCRIPTOR_TYPE_STORAGE_BUFFER, // input vertices.
+		C.VK_DESCRIPTOR_TYPE_STORAGE_BUFFER, // output vertices.
+	}
+	shaderCode, err := readShader(app.scene.computeShader)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	anim := &animation{}
+	app.animation = anim
+	cp, err := createComputePipeline(app, "animate", shaderCode, descriptorTypes, int(unsafe.Sizeof(animatePushConstants{})), MaxFramesInFlight)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	anim.pipeline = cp
+	// Create animated vertex buffers in GPU memory, owned by the compute queue
+	// family.
+	vertexBufferSize := C.VkDeviceSize(app.sceneMesh.vertexCount * int(unsafe.Sizeof(Vertex{})))
+	usage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_STORAGE_BUFFER_BIT | C.VK_BUFFER_USAGE_VERTEX_BUFFER_BIT)
+	properties := C.VkMemoryPropertyFlags(C.VK_MEMORY_PROPERTY_DEVICE_LOCAL_BIT)
+	for i := range anim.vertexBuffers {
+		buffer, bufferMem, err := createBuffer(app, "animatedVertexBuffer", vertexBufferSize, usage, properties)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		anim.vertexBuffers[i] = buffer
+		anim.vertexBufferMems[i] = bufferMem
+		resources := []descriptorResource{
+			{buffer: *app.sceneMesh.vertexBuffer},
+			{buffer: *buffer},
+		}
+		if err := cp.updateDescriptorSet(app, i, resources); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	// Create command buffers and semaphores of the compute queue.
+	computeCommandBuffers := app.arena.makeVkCommandBufferSlice(MaxFramesInFlight)
+	commandBufferAllocateInfo := C.VkCommandBufferAllocateInfo{
+		sType:              C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_ALLOCATE_INFO,
+		commandPool:        *app.computeCommandPool,
+		level:              C.VK_COMMAND_BUFFER_LEVEL_PRIMARY,
+		commandBufferCount: C.uint(len(computeCommandBuffers)),
+	}
+	if result := app.deviceProcs.AllocateCommandBuffers(*app.device, &commandBufferAllocateInfo, &computeCommandBuffers[0]); result != C.VK_SUCCESS {
+		return errors.Wrap(Result(result), "unable to create compute command buffers")
+	}
+	anim.commandBuffers = computeCommandBuffers
+	for i := range computeCommandBuffers {
+		trackObjectf(app, C.VK_OBJECT_TYPE_COMMAND_BUFFER, unsafe.Pointer(computeCommandBuffers[i]), "computeCommandBuffer[%d]", i)
+	}
+	if app.timelineSemaphores {
+		// Rendering waits for the value of the compute timeline instead.
+		return nil
+	}
+	semaphoreCreateInfo := C.VkSemaphoreCreateInfo{
+		sType: C.VK_STRUCTURE_TYPE_SEMAPHORE_CREATE_INFO,
+	}
+	for i := range anim.finishedSemaphores {
+		computeFinishedSemaphore := app.arena.newVkSemaphore(nil)
+		if result := app.deviceProcs.CreateSemaphore(*app.device, &semaphoreCreateInfo, nil, computeFinishedSemaphore); result != C.VK_SUCCESS {
+			return errors.Wrap(Result(result), "unable to create semaphore")
+		}
+		anim.finishedSemaphores[i] = computeFinishedSemaphore
+		trackObjectf(app, C.VK_OBJECT_TYPE_SEMAPHORE, unsafe.Pointer(*computeFinishedSemaphore), "computeFinishedSemaphore[%d]", i)
+	}
+	return nil
+}
+
+// destroyAnimation releases the resources of the given animation of the
+// vertices of a scene.
+func destroyAnimation(app *App, anim *animation) {
+	for i := range anim.finishedSemaphores {
+		destroySemaphore(app, anim.finishedSemaphores[i])
+		anim.finishedSemaphores[i] = nil
+	}
+	if len(anim.commandBuffers) > 0 {
+		for i := range anim.commandBuffers {
+			untrackObject(C.VK_OBJECT_TYPE_COMMAND_BUFFER, unsafe.Pointer(anim.commandBuffers[i]))
+		}
+		app.deviceProcs.FreeCommandBuffers(*app.device, *app.computeCommandPool, C.uint(len(anim.commandBuffers)), &anim.commandBuffers[0])
+		anim.commandBuffers = nil
+	}
+	for i := range anim.vertexBuffers {
+		if anim.vertexBuffers[i] != nil {
+			destroyBuffer(app, anim.vertexBuffers[i], anim.vertexBufferMems[i])
+			anim.vertexBuffers[i] = nil
+			anim.vertexBufferMems[i] = nil
+		}
+	}
+	if anim.pipeline != nil {
+		destroyComputePipeline(app, anim.pipeline)
+		anim.pipeline = nil
+	}
+}
+
+// submitAnimation submits the compute commands animating the vertices of the
+// scene for the current frame of the clock to the compute queue. The returned
+// semaphore, to be waited for by the graphics queue, is signalled once the
+// animated vertex buffer of the frame has been written; or nil if the scene is
+// not animated by a compute shader.
+//
+// The command buffer of the frame in flight is reused once the previous use of
+// the frame has completed, as the graphics commands of the frame wait for the
+// semaphore. The compute commands wait for the upload of the vertices of the
+// scene.
+func submitAnimation(app *App, scratch *arena) (*submitSemaphore, error) {
+	anim := app.animation
+	if anim == nil {
+		return nil, nil
+	}
+	var waits []submitSemaphore
+	uploaded, err := app.sceneUpload.waitOp(app, C.VK_PIPELINE_STAGE_COMPUTE_SHADER_BIT)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if uploaded != nil {
+		waits = append(waits, *uploaded)
+	}
+	commandBuffer := anim.commandBuffers[app.curFrame]
+	commandBufferBeginInfo := C.VkCommandBufferBeginInfo{
+		sType: C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_BEGIN_INFO,
+		flags: C.VK_COMMAND_BUFFER_USAGE_ONE_TIME_SUBMIT_BIT,
+	}
+	if result := app.deviceProcs.BeginCommandBuffer(commandBuffer, &commandBufferBeginInfo); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to begin recording command buffer")
+	}
+	nvertices := app.sceneMesh.vertexCount
+	pushConstants := animatePushConstants{
+		time:      float32(app.clock.now.Seconds()),
+		nvertices: uint32(nvertices),
+	}
+	pushConstantBytes := unsafe.Slice((*byte)(unsafe.Pointer(&pushConstants)), unsafe.Sizeof(pushConstants))
+	groupCountX := (nvertices + animateWorkgroupSize - 1) / animateWorkgroupSize
+	anim.pipeline.cmdDispatch(app, scratch, commandBuffer, app.curFrame, pushConstantBytes, groupCountX, 1, 1)
+	if app.computeQueueFamilyIndex != app.graphicsQueueFamilyIndex {
+		// Release ownership of animated vertex buffer to the graphics queue
+		// family; acquired by cmdAcquireAnimatedVertices.
+		releaseBarriers := scratch.newVkBufferMemoryBarrierSlice(animatedVerticesOwnershipBarrier(app, C.VK_ACCESS_SHADER_WRITE_BIT, 0))
+		app.deviceProcs.CmdPipelineBarrier(commandBuffer, C.VK_PIPELINE_STAGE_COMPUTE_SHADER_BIT, C.VK_PIPELINE_STAGE_BOTTOM_OF_PIPE_BIT, 0, 0, nil, C.uint(len(releaseBarriers)), &releaseBarriers[0], 0, nil)
+	}
+	if result := app.deviceProcs.EndCommandBuffer(commandBuffer); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to record command buffer")
+	}
+	// Signal the value of the compute timeline, or the finished semaphore of
+	// the frame if binary semaphores.
+	value := app.computeTimeline.next()
+	finished := submitSemaphore{stage: C.VK_PIPELINE_STAGE_VERTEX_INPUT_BIT}
+	if app.timelineSemaphores {
+		finished.semaphore = *app.computeTimeline.semaphore
+		finished.value = value
+	} else {
+		finished.semaphore = *anim.finishedSemaphores[app.curFrame]
+	}
+	submitInfo := newSubmitInfo(app, scratch, []C.VkCommandBuffer{commandBuffer}, waits, []submitSemaphore{finished})
+	submits := scratch.newVkSubmitInfoSlice(submitInfo)
+	if result := app.deviceProcs.QueueSubmit(*app.computeQueue, C.uint(len(submits)), &submits[0], nil); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to submit command buffers to compute queue")
+	}
+	return &finished, nil
+}
+
+// cmdAcquireAnimatedVertices records commands to acquire ownership of the
+// animated vertex buffer of the current frame in flight for the graphics queue
+// family, if released by the compute queue family. Must be recorded outside of
+// render passes.
+func cmdAcquireAnimatedVertices(app *App, scratch *arena, commandBuffer C.VkCommandBuffer) {
+	if app.animation == nil || app.computeQueueFamilyIndex == app.graphicsQueueFamilyIndex {
+		return
+	}
+	acquireBarriers := scratch.newVkBufferMemoryBarrierSlice(animatedVerticesOwnershipBarrier(app, 0, C.VK_ACCESS_VERTEX_ATTRIBUTE_READ_BIT))
+	app.deviceProcs.CmdPipelineBarrier(commandBuffer, C.VK_PIPELINE_STAGE_TOP_OF_PIPE_BIT, C.VK_PIPELINE_STAGE_VERTEX_INPUT_BIT, 0, 0, nil, C.uint(len(acquireBarriers)), &acquireBarriers[0], 0, nil)
+}
+
+// animatedVerticesOwnershipBarrier returns the buffer memory barrier
+// transferring ownership of the animated vertex buffer of the current frame in
+// flight from the compute to the graphics queue family, with the given access
+// masks. The release and acquire barriers of the transfer must match.
+func animatedVerticesOwnershipBarrier(app *App, srcAccessMask, dstAccessMask C.VkAccessFlags) C.VkBufferMemoryBarrier {
+	return C.VkBufferMemoryBarrier{
+		sType:               C.VK_STRUCTURE_TYPE_BUFFER_MEMORY_BARRIER,
+		srcAccessMask:       srcAccessMask,
+		dstAccessMask:       dstAccessMask,
+		srcQueueFamilyIndex: C.uint32_t(app.computeQueueFamilyIndex),
+		dstQueueFamilyIndex: C.uint32_t(app.graphicsQueueFamilyIndex),
+		buffer:              *app.animation.vertexBuffers[app.curFrame],
+		offset:              0,
+		size:                C.VK_WHOLE_SIZE,
+	}
+}
+
+// frameVertexBuffer returns the vertex buffer of the current frame in flight;
+// the animated vertex buffer of the frame if the scene is animated by a compute
+// shader.
+func frameVertexBuffer(app *App) C.VkBuffer {
+	if app.animation != nil {
+		return *app.animation.vertexBuffers[app.curFrame]
+	}
+	return *app.sceneMesh.vertexBuffer
+}
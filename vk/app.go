@@ -4,70 +4,196 @@ package vk
 // #include <GLFW/glfw3.h>
 import "C"
 
+import (
+	"sync"
+)
+
 type App struct {
+	// Render to an offscreen image, rather than to the window surface; no
+	// window is created when headless.
+	headless bool
+	// Scene to render, and seed of pseudo-random numbers used by the scene.
+	scene *scene
+	seed  int64
 	// GLFW.
 	win *C.GLFWwindow
 	// Vulkan.
-	instance       *C.VkInstance
-	debugMessanger *C.VkDebugUtilsMessengerEXT
+	instance *C.VkInstance
+	// Vulkan API version of the instance; the latest version supported by the
+	// Vulkan loader, up to Vulkan 1.3.
+	apiVersion uint32
+	// Enabled instance extensions.
+	instanceExtensions []string
+	debugMessanger     *C.VkDebugUtilsMessengerEXT
+	// Vulkan commands loaded through vkGetInstanceProcAddr and
+	// vkGetDeviceProcAddr.
+	instanceProcs  *instanceProcs
+	deviceProcs    *deviceProcs
 	physicalDevice *C.VkPhysicalDevice
 	device         *C.VkDevice
 	graphicsQueue  *C.VkQueue
 	presentQueue   *C.VkQueue
+	computeQueue   *C.VkQueue
+	transferQueue  *C.VkQueue
 	surface        *C.VkSurfaceKHR
 	*QueueFamilyIndices
-	swapchainSupportInfo    *SwapchainSupportInfo
 	swapchain               *C.VkSwapchainKHR
 	swapchainImageFormat    C.VkFormat
 	swapchainExtent         C.VkExtent2D
+	swapchainImageUsage     ImageUsage
+	swapchainPresentMode    PresentMode
 	swapchainImgs           []C.VkImage
 	swapchainImgViews       []C.VkImageView
-	swapchainFramebuffers   []C.VkFramebuffer
+	swapchainFramebuffers   []C.VkFramebuffer // nil if dynamic rendering
 	swapchainCommandBuffers []C.VkCommandBuffer
-	// Render pass.
+	// Offscreen image rendered to when headless, in place of swapchain images.
+	offscreenImg    *C.VkImage
+	offscreenImgMem *C.VkDeviceMemory
+	// Requested number of samples per pixel of multisample anti-aliasing
+	// (MSAA), and sample shading.
+	requestedSamples       int
+	requestedSampleShading bool
+	// Number of samples per pixel of the color target, limited by the
+	// physical device, and whether sample shading is enabled.
+	msaaSamples   C.VkSampleCountFlagBits
+	sampleShading bool
+	// Requested present modes of the swapchain in order of preference; or nil
+	// to use DefaultPresentModes. The swapchain is recreated at the end of the
+	// frame if changed.
+	presentModes       []PresentMode
+	presentModeChanged bool
+	// Multisampled color target, resolved into the swapchain image at the end
+	// of the render pass; or nil if msaaSamples is 1.
+	msaaColorImg     *C.VkImage
+	msaaColorImgMem  *C.VkDeviceMemory
+	msaaColorImgView *C.VkImageView
+	// Requested maximum anisotropy of texture samplers, and the maximum
+	// anisotropy used, limited by the physical device; 1 if anisotropic
+	// filtering is disabled.
+	requestedAnisotropy  float32
+	maxSamplerAnisotropy float32
+	// Render with dynamic rendering (vkCmdBeginRendering), rather than with a
+	// render pass and framebuffers; and whether dynamic rendering is provided
+	// by the VK_KHR_dynamic_rendering extension, rather than by Vulkan 1.3.
+	dynamicRendering    bool
+	dynamicRenderingKHR bool
+	// Render pass; or nil if dynamic rendering.
 	renderPass *C.VkRenderPass
-	// Uniform values.
-	pipelineLayout *C.VkPipelineLayout
-	// Graphics pipelines.
-	graphicsPipelines []C.VkPipeline
+	// Descriptor set layout of textures, and pipeline layout shared by
+	// graphics pipelines.
+	textureSetLayout *C.VkDescriptorSetLayout
+	pipelineLayout   *C.VkPipelineLayout
+	// Graphics pipelines by description, created on first use; recreated with
+	// the swapchain.
+	pipelines map[PipelineDesc]C.VkPipeline
 
 	commandPool *C.VkCommandPool
+	// Uploads of buffers and images through a staging ring buffer.
+	uploader *uploader
+	// Command pool of the compute queue family.
+	computeCommandPool *C.VkCommandPool
+
+	// Animation of the vertices of the scene on the compute queue; or nil if
+	// the scene has no compute shader.
+	animation *animation
+	// Resources released once no longer in use by frames in flight.
+	deletionQueue deletionQueue
+
+	// Synchronize with timeline semaphores, rather than with binary semaphores
+	// and fences; and whether timeline semaphores are provided by the
+	// VK_KHR_timeline_semaphore extension, rather than by Vulkan 1.2.
+	timelineSemaphores    bool
+	timelineSemaphoresKHR bool
+	// Timelines of submissions to the graphics, compute and transfer queues.
+	graphicsTimeline *timeline
+	computeTimeline  *timeline
+	transferTimeline *timeline
 
 	imageAvailableSemaphores [MaxFramesInFlight]*C.VkSemaphore // image aquired, ready for rendering
 	renderFinishedSemaphores [MaxFramesInFlight]*C.VkSemaphore // rendering finished, ready for presentation
-	framesInFlightFences     [MaxFramesInFlight]*C.VkFence     // fence for frame in flight
-	imagesInFlightFences     []*C.VkFence                      // fence for each image in swap chain
+	framesInFlightFences     [MaxFramesInFlight]*C.VkFence     // fence for frame in flight; or nil if timeline semaphores
+	frameValues              [MaxFramesInFlight]uint64         // graphics timeline value of frame in flight; or 0
+	imagesInFlight           []uint64                          // graphics timeline value of last frame using each image in swap chain; or 0
 	curFrame                 int                               // in range [0, MaxFramesInFlight)
 
 	framebufferResized bool
 
-	vertexBuffer    *C.VkBuffer
-	vertexBufferMem *C.VkDeviceMemory
+	// Statistics of the frames rendered during the last second.
+	frameStats FrameStats
+	// Invoked by the event loop before each frame; or nil.
+	onFrame func(app *App)
+
+	// Clock measuring the time of rendered frames.
+	clock *clock
+
+	// Requests to capture the next presented frame.
+	captureRequests []captureRequest
+	// Captures being copied by frames in flight.
+	captures []*capture
+	// Screenshots being written to PNG files.
+	screenshotWrites sync.WaitGroup
+	// Recording of presented frames in progress; or nil.
+	recording *recording
 
-	indexBuffer    *C.VkBuffer
-	indexBufferMem *C.VkDeviceMemory
+	// Upload of the vertex and index buffers of the scene.
+	sceneUpload *upload
+	// Reload of shaders and switch to next scene requested by the user.
+	reloadShadersRequested bool
+	nextSceneRequested     bool
 
-	//vertices []Vertex
-	// only used for debugging.
-	indices        []uint32
-	uniqueVertices []Vertex
+	// Mesh of the scene.
+	sceneMesh *Mesh
+	// Texture of the scene; or nil if the scene is untextured.
+	sceneTexture *texture
+	// Root node of the scene graph drawn each frame.
+	sceneRoot *Node
+	// Number of meshes created, used to assign mesh IDs.
+	nmeshes int
+	// Draw list of the current frame.
+	drawList drawList
+	// IDs of pipeline descriptions, used to sort draws by pipeline.
+	pipelineIDs map[PipelineDesc]uint32
+
+	// Instance buffers of instanced rendering, one per frame in flight; grown
+	// as needed.
+	instanceBuffers [MaxFramesInFlight]instanceBuffer
+
+	// C memory of handles with the same lifetime as the app.
+	arena *arena
+	// C memory of handles recreated with the swapchain.
+	swapchainArena *arena
+	// C memory of structs used to submit and present the current frame.
+	frameArena *arena
 }
 
 func newApp() *App {
 	return &App{
-		QueueFamilyIndices: newQueueFamilyIndices(),
+		scene:                scenes[0],
+		seed:                 1,
+		requestedSamples:     1,
+		msaaSamples:          C.VK_SAMPLE_COUNT_1_BIT,
+		maxSamplerAnisotropy: 1,
+		clock:                newWallClock(),
+		QueueFamilyIndices:   newQueueFamilyIndices(),
+		arena:                newArena(),
+		swapchainArena:       newArena(),
+		frameArena:           newArena(),
 	}
 }
 
 type QueueFamilyIndices struct {
 	graphicsQueueFamilyIndex int
 	presentQueueFamilyIndex  int
+	computeQueueFamilyIndex  int
+	transferQueueFamilyIndex int
 }
 
 func newQueueFamilyIndices() *QueueFamilyIndices {
 	return &QueueFamilyIndices{
 		graphicsQueueFamilyIndex: -1,
 		presentQueueFamilyIndex:  -1,
+		computeQueueFamilyIndex:  -1,
+		transferQueueFamilyIndex: -1,
 	}
 }
 
@@ -75,11 +201,7 @@ func (queueFamilyIndices *QueueFamilyIndices) Indices() []int {
 	return []int{
 		queueFamilyIndices.graphicsQueueFamilyIndex,
 		queueFamilyIndices.presentQueueFamilyIndex,
+		queueFamilyIndices.computeQueueFamilyIndex,
+		queueFamilyIndices.transferQueueFamilyIndex,
 	}
 }
-
-type SwapchainSupportInfo struct {
-	surfaceCapabilities *C.VkSurfaceCapabilitiesKHR
-	surfaceFormats      []C.VkSurfaceFormatKHR
-	presentModes        []C.VkPresentModeKHR
-}
@@ -0,0 +1,151 @@
+package vk
+
+// #include <stdlib.h>
+// #include <stdint.h>
+// #include <string.h>
+import "C"
+
+import (
+	"unsafe"
+)
+
+// An arena is a bump allocator of zero-initialized C memory. All allocations
+// of an arena are released together, either by reset (to reuse the memory of
+// the arena) or by free.
+//
+// Vulkan structs, arrays and strings passed to Vulkan are allocated in arenas,
+// so that no Go pointers are stored in C memory (as checked by
+// GODEBUG=cgocheck=2), and so that handles of Vulkan objects are never stored
+// in Go memory, where the garbage collector may mistake them for pointers.
+//
+// Arenas used by laki:
+//
+//   - app.arena: handles of objects with the same lifetime as the app.
+//   - app.swapchainArena: handles of objects recreated with the swapchain.
+//   - app.frameArena: structs used to submit and present a frame; reset at
+//     the start of each frame.
+//   - scratch arenas: create info structs used by an init function; freed
+//     on return.
+type arena struct {
+	// C memory blocks of arena.
+	blocks []arenaBlock
+	// Index of current block.
+	cur int
+	// Offset of next allocation in current block.
+	off uintptr
+}
+
+// arenaBlock is a C memory block of an arena.
+type arenaBlock struct {
+	data unsafe.Pointer
+	size uintptr
+}
+
+const (
+	// Size of arena blocks; larger allocations get a dedicated block.
+	arenaBlockSize = 16 * 1024
+	// Alignment of arena allocations.
+	arenaAlign = 16
+)
+
+// newArena returns a new arena. The C memory of the arena is allocated on
+// first use.
+func newArena() *arena {
+	return &arena{}
+}
+
+// alloc allocates size bytes of zero-initialized C memory from the arena.
+func (a *arena) alloc(size uintptr) unsafe.Pointer {
+	if size == 0 {
+		// Return a valid pointer for empty allocations.
+		size = 1
+	}
+	for a.cur < len(a.blocks) {
+		block := a.blocks[a.cur]
+		if a.off+size <= block.size {
+			p := unsafe.Pointer(uintptr(block.data) + a.off)
+			a.off = alignUp(a.off+size, arenaAlign)
+			C.memset(p, 0, C.size_t(size))
+			return p
+		}
+		a.cur++
+		a.off = 0
+	}
+	blockSize := uintptr(arenaBlockSize)
+	if size > blockSize {
+		blockSize = alignUp(size, arenaAlign)
+	}
+	data := C.calloc(1, C.size_t(blockSize))
+	if data == nil {
+		panic("unable to allocate C memory of arena")
+	}
+	trackAlloc(data, "arena block")
+	a.blocks = append(a.blocks, arenaBlock{data: data, size: blockSize})
+	a.cur = len(a.blocks) - 1
+	a.off = alignUp(size, arenaAlign)
+	return data
+}
+
+// reset releases all allocations of the arena, retaining its C memory for
+// reuse.
+func (a *arena) reset() {
+	a.cur = 0
+	a.off = 0
+}
+
+// free releases all allocations and the C memory of the arena.
+func (a *arena) free() {
+	for _, block := range a.blocks {
+		freeAlloc(block.data)
+	}
+	a.blocks = nil
+	a.reset()
+}
+
+// cString returns a NULL-terminated copy of the given string, allocated in the
+// arena.
+func (a *arena) cString(s string) *C.char {
+	p := a.alloc(uintptr(len(s) + 1))
+	copy(unsafe.Slice((*byte)(p), len(s)), s)
+	return (*C.char)(p)
+}
+
+// cStrings returns an array of NULL-terminated copies of the given strings,
+// allocated in the arena; or nil if empty.
+func (a *arena) cStrings(ss []string) **C.char {
+	if len(ss) == 0 {
+		return nil
+	}
+	dst := unsafe.Slice((**C.char)(a.alloc(uintptr(len(ss))*unsafe.Sizeof((*C.char)(nil)))), len(ss))
+	for i := range ss {
+		dst[i] = a.cString(ss[i])
+	}
+	return &dst[0]
+}
+
+// cUint32s returns an array of uint32_t copies of the given integers,
+// allocated in the arena; or nil if empty.
+func (a *arena) cUint32s(xs []int) *C.uint32_t {
+	if len(xs) == 0 {
+		return nil
+	}
+	dst := unsafe.Slice((*C.uint32_t)(a.alloc(uintptr(len(xs))*C.sizeof_uint32_t)), len(xs))
+	for i := range xs {
+		dst[i] = C.uint32_t(xs[i])
+	}
+	return &dst[0]
+}
+
+// code returns a 4-byte aligned copy of the given SPIR-V code, allocated in
+// the arena.
+func (a *arena) code(buf []byte) *C.uint32_t {
+	p := a.alloc(uintptr(len(buf)))
+	copy(unsafe.Slice((*byte)(p), len(buf)), buf)
+	return (*C.uint32_t)(p)
+}
+
+// alignUp rounds x up to the nearest multiple of align, which must be a power
+// of two.
+func alignUp(x, align uintptr) uintptr {
+	return (x + align - 1) &^ (align - 1)
+}
@@ -5,37 +5,18 @@ package vk
 import "C"
 
 import (
-	"log"
-	"os"
 	"unsafe"
-
-	"github.com/mewkiz/pkg/term"
-)
-
-var (
-	// dbg is a logger with the "vk:" prefix which logs debug messages to
-	// standard error.
-	dbgValidationLayer = log.New(os.Stderr, term.CyanBold("vk (validation layer):")+" ", 0)
-	// warn is a logger with the "vk:" prefix which logs warning messages to
-	// standard error.
-	warnValidationLayer = log.New(os.Stderr, term.RedBold("vk (validation layer):")+" ", log.Lshortfile)
 )
 
+// debugCallback is invoked by the Vulkan validation layers to report debug
+// messages, which are dispatched to the registered debug sinks.
+//
+// NOTE: panicking across the C stack frames of Vulkan is not safe; validation
+// errors are instead recorded and reported by checkValidationErrors.
+//
 //export debugCallback
 func debugCallback(messageSeverity C.VkDebugUtilsMessageSeverityFlagBitsEXT, messageTypes C.VkDebugUtilsMessageTypeFlagsEXT, pCallbackData *C.VkDebugUtilsMessengerCallbackDataEXT, pUserData unsafe.Pointer) C.VkBool32 {
-	message := C.GoString(pCallbackData.pMessage)
-	if (messageSeverity & C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_ERROR_BIT_EXT) != 0 {
-		warnValidationLayer.Println("(error):", message)
-	}
-	if (messageSeverity & C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_WARNING_BIT_EXT) != 0 {
-		warnValidationLayer.Println("(warning):", message)
-	}
-	if (messageSeverity & C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_INFO_BIT_EXT) != 0 {
-		dbgValidationLayer.Println("(info):", message)
-	}
-	if (messageSeverity & C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_VERBOSE_BIT_EXT) != 0 {
-		dbgValidationLayer.Println("(verbose):", message)
-	}
+	handleDebugMessage(newDebugMessage(messageSeverity, messageTypes, pCallbackData))
 	return C.VK_FALSE
 }
 
@@ -47,3 +28,12 @@ func framebufferResizeCallback(win *C.GLFWwindow, width, height C.int) {
 }
 
 var _framebufferResizeCallback func(win *C.GLFWwindow, width, height int)
+
+//export keyCallback
+func keyCallback(win *C.GLFWwindow, key, scancode, action, mods C.int) {
+	if _keyCallback != nil {
+		_keyCallback(win, int(key), int(scancode), int(action), int(mods))
+	}
+}
+
+var _keyCallback func(win *C.GLFWwindow, key, scancode, action, mods int)
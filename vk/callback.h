@@ -11,4 +11,6 @@ extern VkBool32 debugCallback(
 
 extern void framebufferResizeCallback(GLFWwindow *win, int width, int height);
 
+extern void keyCallback(GLFWwindow *win, int key, int scancode, int action, int mods);
+
 #endif // #ifndef __CALLBACK_H__
@@ -0,0 +1,164 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"image"
+
+	"github.com/pkg/errors"
+)
+
+// captureRequest is a request to capture the next presented frame.
+type captureRequest struct {
+	// Name of the capture (e.g. path of screenshot), used in error messages.
+	name string
+	// handle is invoked with the pixels of the captured frame, in RGBA order;
+	// or with nil if the capture failed. The image must not be modified, as it
+	// is shared by the requests of the frame.
+	handle func(img *image.RGBA)
+}
+
+// capture is a capture of a presented swapchain image in progress.
+type capture struct {
+	// Requests to capture the frame.
+	requests []captureRequest
+	// Frame in flight which copies the swapchain image to the readback buffer;
+	// the copy is complete once the fence of the frame is signalled.
+	frame int
+	// Format and extent of the swapchain image.
+	bgra   bool
+	extent C.VkExtent2D
+	// Command buffer copying the swapchain image.
+	commandBuffer C.VkCommandBuffer
+	// Host-visible buffer holding the pixels of the swapchain image.
+	buffer    *C.VkBuffer
+	bufferMem *C.VkDeviceMemory
+}
+
+// requestCapture requests a capture of the next presented frame, invoking
+// handle with the pixels of the frame once rendered.
+func requestCapture(app *App, name string, handle func(img *image.RGBA)) {
+	app.captureRequests = append(app.captureRequests, captureRequest{name: name, handle: handle})
+}
+
+// checkCaptureSupport reports an error if presented frames cannot be captured.
+func checkCaptureSupport(app *App) error {
+	if app.swapchainImageUsage&ImageUsageTransferSrc == 0 {
+		return errors.New("swapchain images do not support VK_IMAGE_USAGE_TRANSFER_SRC_BIT")
+	}
+	if _, err := isBGRA(app.swapchainImageFormat); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// initCapture begins the capture of the current frame, if requested, copying
+// the given swapchain image as part of the frame. The returned command buffer
+// is to be submitted after the render commands of the frame; or nil if no
+// capture was requested.
+func initCapture(app *App, imageIndex int) (C.VkCommandBuffer, error) {
+	if len(app.captureRequests) == 0 {
+		return nil, nil
+	}
+	requests := app.captureRequests
+	app.captureRequests = nil
+	commandBuffer, err := initCaptureCommands(app, imageIndex, requests)
+	if err != nil {
+		failCapture(requests)
+		return nil, errors.Wrapf(err, "unable to capture %q", requests[0].name)
+	}
+	return commandBuffer, nil
+}
+
+// initCaptureCommands records the commands copying the given swapchain image to
+// a readback buffer, for the given capture requests.
+func initCaptureCommands(app *App, imageIndex int, requests []captureRequest) (C.VkCommandBuffer, error) {
+	if err := checkCaptureSupport(app); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	bgra, err := isBGRA(app.swapchainImageFormat)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	buffer, bufferMem, err := createReadbackBuffer(app, "captureBuffer", app.swapchainExtent)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	commandBuffer, err := beginSingleTimeCommands(app, app.frameArena, "captureCommandBuffer")
+	if err != nil {
+		destroyBuffer(app, buffer, bufferMem)
+		return nil, errors.WithStack(err)
+	}
+	cmdReadImage(app, app.frameArena, commandBuffer, app.swapchainImgs[imageIndex], C.VK_IMAGE_LAYOUT_PRESENT_SRC_KHR, app.swapchainExtent, *buffer)
+	if result := app.deviceProcs.EndCommandBuffer(commandBuffer); result != C.VK_SUCCESS {
+		freeCommandBuffer(app, app.frameArena, commandBuffer)
+		destroyBuffer(app, buffer, bufferMem)
+		return nil, errors.Wrap(Result(result), "unable to record command buffer")
+	}
+	c := &capture{
+		requests:      requests,
+		frame:         app.curFrame,
+		bgra:          bgra,
+		extent:        app.swapchainExtent,
+		commandBuffer: commandBuffer,
+		buffer:        buffer,
+		bufferMem:     bufferMem,
+	}
+	app.captures = append(app.captures, c)
+	return commandBuffer, nil
+}
+
+// finishCaptures finishes the captures copied by the given frame in flight, the
+// fence of which must be signalled. The pixels are copied from the readback
+// buffers, and passed on to the capture requests in order.
+func finishCaptures(app *App, scratch *arena, frame int) {
+	var pending []*capture
+	for _, c := range app.captures {
+		if c.frame != frame {
+			pending = append(pending, c)
+			continue
+		}
+		img, err := readbackPixels(app, c.bufferMem, c.extent)
+		freeCommandBuffer(app, scratch, c.commandBuffer)
+		destroyBuffer(app, c.buffer, c.bufferMem)
+		if err != nil {
+			warn.Printf("unable to capture %q: %+v", c.requests[0].name, err)
+			failCapture(c.requests)
+			continue
+		}
+		if c.bgra {
+			bgraToRGBA(img)
+		}
+		// The alpha channel is ignored by the presentation engine
+		// (VK_COMPOSITE_ALPHA_OPAQUE_BIT_KHR).
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xFF
+		}
+		for _, req := range c.requests {
+			req.handle(img)
+		}
+	}
+	app.captures = pending
+}
+
+// cancelCapture releases the resources of the capture recorded by the given
+// command buffer, which failed to be submitted.
+func cancelCapture(app *App, scratch *arena, commandBuffer C.VkCommandBuffer) {
+	for i, c := range app.captures {
+		if c.commandBuffer == commandBuffer {
+			freeCommandBuffer(app, scratch, c.commandBuffer)
+			destroyBuffer(app, c.buffer, c.bufferMem)
+			failCapture(c.requests)
+			app.captures = append(app.captures[:i], app.captures[i+1:]...)
+			return
+		}
+	}
+}
+
+// failCapture notifies the given capture requests of failure.
+func failCapture(requests []captureRequest) {
+	for _, req := range requests {
+		req.handle(nil)
+	}
+}
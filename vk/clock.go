@@ -0,0 +1,45 @@
+package vk
+
+import (
+	"time"
+)
+
+// clock measures the time of rendered frames; either wall time, or simulated
+// time advanced by a fixed step per frame for reproducible output.
+type clock struct {
+	// Time step per frame; or 0 to use wall time.
+	step time.Duration
+	// Wall time of the first frame.
+	start time.Time
+	// Number of frames.
+	nframes int
+	// Time of the current frame, relative to the first frame.
+	now time.Duration
+}
+
+// newWallClock returns a clock measuring wall time.
+func newWallClock() *clock {
+	return &clock{}
+}
+
+// newFixedClock returns a clock simulating time, advanced by the given step per
+// frame.
+func newFixedClock(step time.Duration) *clock {
+	return &clock{step: step}
+}
+
+// tick advances the clock to the next frame, and returns its time relative to
+// the first frame.
+func (c *clock) tick() time.Duration {
+	switch {
+	case c.step != 0:
+		c.now = time.Duration(c.nframes) * c.step
+	case c.nframes == 0:
+		c.start = time.Now()
+		c.now = 0
+	default:
+		c.now = time.Since(c.start)
+	}
+	c.nframes++
+	return c.now
+}
@@ -0,0 +1,44 @@
+package vk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFixedClock(t *testing.T) {
+	golden := []time.Duration{
+		time.Second / 30,
+		time.Second / 60,
+		time.Millisecond,
+	}
+	for _, step := range golden {
+		c := newFixedClock(step)
+		for n := 0; n < 100; n++ {
+			want := time.Duration(n) * step
+			if got := c.tick(); got != want {
+				t.Fatalf("time of frame %d mismatch with step %v; expected %v, got %v", n, step, want, got)
+			}
+			if c.now != want {
+				t.Fatalf("current time of frame %d mismatch with step %v; expected %v, got %v", n, step, want, c.now)
+			}
+		}
+		if c.nframes != 100 {
+			t.Errorf("number of frames mismatch; expected 100, got %d", c.nframes)
+		}
+	}
+}
+
+func TestWallClock(t *testing.T) {
+	c := newWallClock()
+	if got := c.tick(); got != 0 {
+		t.Errorf("time of first frame mismatch; expected 0, got %v", got)
+	}
+	prev := time.Duration(0)
+	for n := 1; n < 10; n++ {
+		got := c.tick()
+		if got < prev {
+			t.Fatalf("time of frame %d before previous frame; %v < %v", n, got, prev)
+		}
+		prev = got
+	}
+}
@@ -0,0 +1,288 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// computePipeline is a compute pipeline, the shader of which accesses storage
+// buffers and storage images through a single descriptor set.
+type computePipeline struct {
+	// Name of the compute pipeline, used as debug name of its objects.
+	name string
+	// Descriptor types of the bindings of the descriptor set, in binding order.
+	descriptorTypes []C.VkDescriptorType
+	// Size in bytes of the push constants of the compute shader.
+	pushConstantSize int
+	// Vulkan objects of the compute pipeline.
+	descriptorSetLayout *C.VkDescriptorSetLayout
+	pipelineLayout      *C.VkPipelineLayout
+	pipeline            C.VkPipeline
+	// Descriptor sets allocated from the descriptor pool of the pipeline.
+	descriptorPool *C.VkDescriptorPool
+	descriptorSets []C.VkDescriptorSet
+	// C memory of the handles of the compute pipeline.
+	arena *arena
+}
+
+// descriptorResource specifies the resource bound to a descriptor of a compute
+// pipeline; either a storage buffer or a storage image view.
+type descriptorResource struct {
+	// Storage buffer (VK_DESCRIPTOR_TYPE_STORAGE_BUFFER); bound in whole.
+	buffer C.VkBuffer
+	// Storage image view (VK_DESCRIPTOR_TYPE_STORAGE_IMAGE); accessed in
+	// VK_IMAGE_LAYOUT_GENERAL.
+	imageView C.VkImageView
+}
+
+// createComputePipeline creates a compute pipeline with the given name from
+// the SPIR-V code of the given compute shader, with descriptor bindings of the
+// given types and push constants of the given size in bytes. Descriptor sets
+// are allocated for each of the nsets independent uses of the pipeline (e.g.
+// one per frame in flight).
+func createComputePipeline(app *App, name string, shaderCode []byte, descriptorTypes []C.VkDescriptorType, pushConstantSize, nsets int) (_ *computePipeline, err error) {
+	scratch := newArena()
+	defer scratch.free()
+	cp := &computePipeline{
+		name:             name,
+		descriptorTypes:  descriptorTypes,
+		pushConstantSize: pushConstantSize,
+		arena:            newArena(),
+	}
+	defer func() {
+		if err != nil {
+			destroyComputePipeline(app, cp)
+		}
+	}()
+
+	// Create descriptor set layout.
+	bindings := scratch.makeVkDescriptorSetLayoutBindingSlice(len(descriptorTypes))
+	for i, descriptorType := range descriptorTypes {
+		bindings[i] = C.VkDescriptorSetLayoutBinding{
+			binding:         C.uint32_t(i),
+			descriptorType:  descriptorType,
+			descriptorCount: 1,
+			stageFlags:      C.VK_SHADER_STAGE_COMPUTE_BIT,
+		}
+	}
+	descriptorSetLayoutCreateInfo := C.VkDescriptorSetLayoutCreateInfo{
+		sType:        C.VK_STRUCTURE_TYPE_DESCRIPTOR_SET_LAYOUT_CREATE_INFO,
+		bindingCount: C.uint32_t(len(bindings)),
+	}
+	if len(bindings) > 0 {
+		descriptorSetLayoutCreateInfo.pBindings = &bindings[0]
+	}
+	descriptorSetLayout := cp.arena.newVkDescriptorSetLayout(nil)
+	if result := app.deviceProcs.CreateDescriptorSetLayout(*app.device, &descriptorSetLayoutCreateInfo, nil, descriptorSetLayout); result != C.VK_SUCCESS {
+		return nil, errors.Wrapf(Result(result), "unable to create descriptor set layout of compute pipeline %q", name)
+	}
+	cp.descriptorSetLayout = descriptorSetLayout
+	trackObject(app, C.VK_OBJECT_TYPE_DESCRIPTOR_SET_LAYOUT, unsafe.Pointer(*descriptorSetLayout), name+"DescriptorSetLayout")
+
+	// Create pipeline layout.
+	setLayouts := scratch.newVkDescriptorSetLayoutSlice(*descriptorSetLayout)
+	pipelineLayoutCreateInfo := C.VkPipelineLayoutCreateInfo{
+		sType:          C.VK_STRUCTURE_TYPE_PIPELINE_LAYOUT_CREATE_INFO,
+		setLayoutCount: C.uint(len(setLayouts)),
+		pSetLayouts:    &setLayouts[0],
+	}
+	if pushConstantSize > 0 {
+		pushConstantRanges := scratch.newVkPushConstantRangeSlice(
+			C.VkPushConstantRange{
+				stageFlags: C.VK_SHADER_STAGE_COMPUTE_BIT,
+				offset:     0,
+				size:       C.uint32_t(pushConstantSize),
+			},
+		)
+		pipelineLayoutCreateInfo.pushConstantRangeCount = C.uint(len(pushConstantRanges))
+		pipelineLayoutCreateInfo.pPushConstantRanges = &pushConstantRanges[0]
+	}
+	pipelineLayout := cp.arena.newVkPipelineLayout(nil)
+	if result := app.deviceProcs.CreatePipelineLayout(*app.device, &pipelineLayoutCreateInfo, nil, pipelineLayout); result != C.VK_SUCCESS {
+		return nil, errors.Wrapf(Result(result), "unable to create pipeline layout of compute pipeline %q", name)
+	}
+	cp.pipelineLayout = pipelineLayout
+	trackObject(app, C.VK_OBJECT_TYPE_PIPELINE_LAYOUT, unsafe.Pointer(*pipelineLayout), name+"PipelineLayout")
+
+	// Create compute pipeline.
+	shaderModule, err := createShaderModuleFromCode(app, scratch, name+"ShaderModule", shaderCode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer destroyShaderModule(app, shaderModule)
+	computePipelineCreateInfos := scratch.newVkComputePipelineCreateInfoSlice(
+		C.VkComputePipelineCreateInfo{
+			sType: C.VK_STRUCTURE_TYPE_COMPUTE_PIPELINE_CREATE_INFO,
+			stage: C.VkPipelineShaderStageCreateInfo{
+				sType:  C.VK_STRUCTURE_TYPE_PIPELINE_SHADER_STAGE_CREATE_INFO,
+				stage:  C.VK_SHADER_STAGE_COMPUTE_BIT,
+				module: *shaderModule,
+				pName:  scratch.cString("main"),
+			},
+			layout:             *pipelineLayout,
+			basePipelineHandle: nil, // optional
+			basePipelineIndex:  -1,  // optional
+		},
+	)
+	pipelines := scratch.makeVkPipelineSlice(len(computePipelineCreateInfos))
+	if result := app.deviceProcs.CreateComputePipelines(*app.device, nil, C.uint(len(computePipelineCreateInfos)), &computePipelineCreateInfos[0], nil, &pipelines[0]); result != C.VK_SUCCESS {
+		return nil, errors.Wrapf(Result(result), "unable to create compute pipeline %q", name)
+	}
+	cp.pipeline = pipelines[0]
+	trackObject(app, C.VK_OBJECT_TYPE_PIPELINE, unsafe.Pointer(cp.pipeline), name+"Pipeline")
+
+	// Create descriptor pool.
+	var poolSizes []C.VkDescriptorPoolSize
+	for _, descriptorType := range descriptorTypes {
+		poolSizes = append(poolSizes, C.VkDescriptorPoolSize{
+			_type:           descriptorType,
+			descriptorCount: C.uint32_t(nsets),
+		})
+	}
+	descriptorPoolCreateInfo := C.VkDescriptorPoolCreateInfo{
+		sType:   C.VK_STRUCTURE_TYPE_DESCRIPTOR_POOL_CREATE_INFO,
+		maxSets: C.uint32_t(nsets),
+	}
+	if len(poolSizes) > 0 {
+		cPoolSizes := scratch.newVkDescriptorPoolSizeSlice(poolSizes...)
+		descriptorPoolCreateInfo.poolSizeCount = C.uint32_t(len(cPoolSizes))
+		descriptorPoolCreateInfo.pPoolSizes = &cPoolSizes[0]
+	}
+	descriptorPool := cp.arena.newVkDescriptorPool(nil)
+	if result := app.deviceProcs.CreateDescriptorPool(*app.device, &descriptorPoolCreateInfo, nil, descriptorPool); result != C.VK_SUCCESS {
+		return nil, errors.Wrapf(Result(result), "unable to create descriptor pool of compute pipeline %q", name)
+	}
+	cp.descriptorPool = descriptorPool
+	trackObject(app, C.VK_OBJECT_TYPE_DESCRIPTOR_POOL, unsafe.Pointer(*descriptorPool), name+"DescriptorPool")
+
+	// Allocate descriptor sets; freed with the descriptor pool.
+	setLayouts = scratch.makeVkDescriptorSetLayoutSlice(nsets)
+	for i := range setLayouts {
+		setLayouts[i] = *descriptorSetLayout
+	}
+	descriptorSetAllocateInfo := C.VkDescriptorSetAllocateInfo{
+		sType:              C.VK_STRUCTURE_TYPE_DESCRIPTOR_SET_ALLOCATE_INFO,
+		descriptorPool:     *descriptorPool,
+		descriptorSetCount: C.uint32_t(len(setLayouts)),
+		pSetLayouts:        &setLayouts[0],
+	}
+	descriptorSets := cp.arena.makeVkDescriptorSetSlice(nsets)
+	if result := app.deviceProcs.AllocateDescriptorSets(*app.device, &descriptorSetAllocateInfo, &descriptorSets[0]); result != C.VK_SUCCESS {
+		return nil, errors.Wrapf(Result(result), "unable to allocate descriptor sets of compute pipeline %q", name)
+	}
+	cp.descriptorSets = descriptorSets
+	for i := range descriptorSets {
+		setObjectNamef(app, C.VK_OBJECT_TYPE_DESCRIPTOR_SET, unsafe.Pointer(descriptorSets[i]), "%sDescriptorSet[%d]", name, i)
+	}
+	return cp, nil
+}
+
+// updateDescriptorSet binds the given resources to the descriptors of the given
+// descriptor set of the compute pipeline, in binding order.
+func (cp *computePipeline) updateDescriptorSet(app *App, set int, resources []descriptorResource) error {
+	if len(resources) != len(cp.descriptorTypes) {
+		return errors.Errorf("descriptor count mismatch of compute pipeline %q; expected %d, got %d", cp.name, len(cp.descriptorTypes), len(resources))
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	scratch := newArena()
+	defer scratch.free()
+	writes := scratch.makeVkWriteDescriptorSetSlice(len(resources))
+	for i, resource := range resources {
+		writes[i] = C.VkWriteDescriptorSet{
+			sType:           C.VK_STRUCTURE_TYPE_WRITE_DESCRIPTOR_SET,
+			dstSet:          cp.descriptorSets[set],
+			dstBinding:      C.uint32_t(i),
+			dstArrayElement: 0,
+			descriptorCount: 1,
+			descriptorType:  cp.descriptorTypes[i],
+		}
+		switch cp.descriptorTypes[i] {
+		case C.VK_DESCRIPTOR_TYPE_STORAGE_BUFFER:
+			bufferInfos := scratch.newVkDescriptorBufferInfoSlice(
+				C.VkDescriptorBufferInfo{
+					buffer: resource.buffer,
+					offset: 0,
+					_range: C.VK_WHOLE_SIZE,
+				},
+			)
+			writes[i].pBufferInfo = &bufferInfos[0]
+		case C.VK_DESCRIPTOR_TYPE_STORAGE_IMAGE:
+			imageInfos := scratch.newVkDescriptorImageInfoSlice(
+				C.VkDescriptorImageInfo{
+					imageView:   resource.imageView,
+					imageLayout: C.VK_IMAGE_LAYOUT_GENERAL,
+				},
+			)
+			writes[i].pImageInfo = &imageInfos[0]
+		default:
+			return errors.Errorf("support for descriptor type %d of compute pipeline %q not yet implemented", cp.descriptorTypes[i], cp.name)
+		}
+	}
+	app.deviceProcs.UpdateDescriptorSets(*app.device, C.uint32_t(len(writes)), &writes[0], 0, nil)
+	return nil
+}
+
+// cmdDispatch records commands to dispatch the given number of workgroups of
+// the compute pipeline, using the given descriptor set and push constants.
+func (cp *computePipeline) cmdDispatch(app *App, scratch *arena, commandBuffer C.VkCommandBuffer, set int, pushConstants []byte, groupCountX, groupCountY, groupCountZ int) {
+	beginLabel(app, scratch, commandBuffer, cp.name, labelColorCompute)
+	app.deviceProcs.CmdBindPipeline(commandBuffer, C.VK_PIPELINE_BIND_POINT_COMPUTE, cp.pipeline)
+	descriptorSets := scratch.newVkDescriptorSetSlice(cp.descriptorSets[set])
+	const firstSet = 0
+	app.deviceProcs.CmdBindDescriptorSets(commandBuffer, C.VK_PIPELINE_BIND_POINT_COMPUTE, *cp.pipelineLayout, firstSet, C.uint32_t(len(descriptorSets)), &descriptorSets[0], 0, nil)
+	if len(pushConstants) > 0 {
+		values := scratch.alloc(uintptr(len(pushConstants)))
+		copy(unsafe.Slice((*byte)(values), len(pushConstants)), pushConstants)
+		app.deviceProcs.CmdPushConstants(commandBuffer, *cp.pipelineLayout, C.VK_SHADER_STAGE_COMPUTE_BIT, 0, C.uint32_t(len(pushConstants)), values)
+	}
+	app.deviceProcs.CmdDispatch(commandBuffer, C.uint32_t(groupCountX), C.uint32_t(groupCountY), C.uint32_t(groupCountZ))
+	endLabel(app, commandBuffer)
+}
+
+// destroyComputePipeline destroys the given compute pipeline, and frees its
+// descriptor sets.
+func destroyComputePipeline(app *App, cp *computePipeline) {
+	if cp.descriptorPool != nil {
+		untrackObject(C.VK_OBJECT_TYPE_DESCRIPTOR_POOL, unsafe.Pointer(*cp.descriptorPool))
+		app.deviceProcs.DestroyDescriptorPool(*app.device, *cp.descriptorPool, nil)
+		cp.descriptorPool = nil
+		cp.descriptorSets = nil
+	}
+	if cp.pipeline != nil {
+		untrackObject(C.VK_OBJECT_TYPE_PIPELINE, unsafe.Pointer(cp.pipeline))
+		app.deviceProcs.DestroyPipeline(*app.device, cp.pipeline, nil)
+		cp.pipeline = nil
+	}
+	if cp.pipelineLayout != nil {
+		untrackObject(C.VK_OBJECT_TYPE_PIPELINE_LAYOUT, unsafe.Pointer(*cp.pipelineLayout))
+		app.deviceProcs.DestroyPipelineLayout(*app.device, *cp.pipelineLayout, nil)
+		cp.pipelineLayout = nil
+	}
+	if cp.descriptorSetLayout != nil {
+		untrackObject(C.VK_OBJECT_TYPE_DESCRIPTOR_SET_LAYOUT, unsafe.Pointer(*cp.descriptorSetLayout))
+		app.deviceProcs.DestroyDescriptorSetLayout(*app.device, *cp.descriptorSetLayout, nil)
+		cp.descriptorSetLayout = nil
+	}
+	cp.arena.free()
+}
+
+// initComputeCommandPool creates the command pool of the compute queue family.
+func initComputeCommandPool(app *App) (*C.VkCommandPool, error) {
+	commandPoolCreateInfo := C.VkCommandPoolCreateInfo{
+		sType:            C.VK_STRUCTURE_TYPE_COMMAND_POOL_CREATE_INFO,
+		flags:            C.VK_COMMAND_POOL_CREATE_RESET_COMMAND_BUFFER_BIT, // command buffers are re-recorded every frame.
+		queueFamilyIndex: C.uint(app.computeQueueFamilyIndex),
+	}
+	commandPool := app.arena.newVkCommandPool(nil)
+	if result := app.deviceProcs.CreateCommandPool(*app.device, &commandPoolCreateInfo, nil, commandPool); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to create compute command pool")
+	}
+	trackObject(app, C.VK_OBJECT_TYPE_COMMAND_POOL, unsafe.Pointer(*commandPool), "computeCommandPool")
+	return commandPool, nil
+}
@@ -0,0 +1,66 @@
+package vk
+
+// #include <stdlib.h>
+// #include "invoke.h"
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Colors of command buffer labels (r, g, b, a).
+var (
+	labelColorPass    = [4]float32{0.2, 0.4, 1.0, 1.0}
+	labelColorDraw    = [4]float32{0.2, 1.0, 0.4, 1.0}
+	labelColorCopy    = [4]float32{1.0, 0.6, 0.2, 1.0}
+	labelColorCompute = [4]float32{0.8, 0.2, 1.0, 1.0}
+)
+
+// setObjectName sets the debug name of the given Vulkan object. The handle is
+// the Vulkan handle of the object (e.g. unsafe.Pointer(*mesh.vertexBuffer)).
+func setObjectName(app *App, objectType C.VkObjectType, handle unsafe.Pointer, name string) {
+	if app.instanceProcs == nil || app.instanceProcs.vkSetDebugUtilsObjectNameEXT == nil || app.device == nil || handle == nil {
+		return
+	}
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	nameInfo := C.VkDebugUtilsObjectNameInfoEXT{
+		sType:        C.VK_STRUCTURE_TYPE_DEBUG_UTILS_OBJECT_NAME_INFO_EXT,
+		objectType:   objectType,
+		objectHandle: C.uint64_t(uintptr(handle)),
+		pObjectName:  cname,
+	}
+	if result := app.instanceProcs.SetDebugUtilsObjectNameEXT(*app.device, &nameInfo); result != C.VK_SUCCESS {
+		warn.Printf("unable to set debug name %q of Vulkan object: %v", name, Result(result))
+	}
+}
+
+// setObjectNamef sets the debug name of the given Vulkan object, based on the
+// given format specifier.
+func setObjectNamef(app *App, objectType C.VkObjectType, handle unsafe.Pointer, format string, args ...interface{}) {
+	setObjectName(app, objectType, handle, fmt.Sprintf(format, args...))
+}
+
+// beginLabel opens a labelled region of the given command buffer, which is
+// closed by endLabel. Labelled regions may be nested. The label is allocated
+// in the given arena.
+func beginLabel(app *App, scratch *arena, commandBuffer C.VkCommandBuffer, name string, color [4]float32) {
+	if app.instanceProcs == nil || app.instanceProcs.vkCmdBeginDebugUtilsLabelEXT == nil {
+		return
+	}
+	labelInfo := C.VkDebugUtilsLabelEXT{
+		sType:      C.VK_STRUCTURE_TYPE_DEBUG_UTILS_LABEL_EXT,
+		pLabelName: scratch.cString(name),
+		color:      [4]C.float{C.float(color[0]), C.float(color[1]), C.float(color[2]), C.float(color[3])},
+	}
+	app.instanceProcs.CmdBeginDebugUtilsLabelEXT(commandBuffer, &labelInfo)
+}
+
+// endLabel closes the innermost labelled region of the given command buffer.
+func endLabel(app *App, commandBuffer C.VkCommandBuffer) {
+	if app.instanceProcs == nil {
+		return
+	}
+	app.instanceProcs.CmdEndDebugUtilsLabelEXT(commandBuffer)
+}
@@ -0,0 +1,94 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+// deletionQueue defers the release of resources until the GPU no longer uses
+// them, without waiting for the device to become idle.
+//
+// Resources released while frames are in flight may still be used by the
+// command buffers of those frames. The releases are therefore held back until
+// the next frame has been submitted, and run once the fence of that frame has
+// signalled; as fences signal after all prior submissions to the queue have
+// completed, this covers the last use of the resources by any earlier frame.
+type deletionQueue struct {
+	// Releases queued since the last submitted frame.
+	pending []func()
+	// Releases of each frame in flight, run once the fence of the frame has
+	// signalled.
+	frames [MaxFramesInFlight][]func()
+}
+
+// deferRelease queues the given function releasing resources (e.g. buffers,
+// images, pipelines or descriptor sets), to be run once the GPU has finished
+// all frames submitted so far.
+func deferRelease(app *App, release func()) {
+	app.deletionQueue.pending = append(app.deletionQueue.pending, release)
+}
+
+// submitDeletions ties the pending releases to the fence of the given frame in
+// flight; called after the frame has been submitted.
+func submitDeletions(app *App, frame int) {
+	q := &app.deletionQueue
+	q.frames[frame] = append(q.frames[frame], q.pending...)
+	q.pending = nil
+}
+
+// runDeletions runs the releases of the given frame in flight; called once the
+// fence of the frame has signalled.
+func runDeletions(app *App, frame int) {
+	q := &app.deletionQueue
+	releases := q.frames[frame]
+	q.frames[frame] = nil
+	for _, release := range releases {
+		release()
+	}
+}
+
+// flushDeletions runs all queued releases; called once the device is idle.
+func flushDeletions(app *App) {
+	for frame := range app.deletionQueue.frames {
+		runDeletions(app, frame)
+	}
+	releases := app.deletionQueue.pending
+	app.deletionQueue.pending = nil
+	for _, release := range releases {
+		release()
+	}
+}
+
+// deferDestroyBuffer destroys the given buffer and frees its memory once no
+// longer in use by the GPU.
+func deferDestroyBuffer(app *App, buffer *C.VkBuffer, bufferMem *C.VkDeviceMemory) {
+	deferRelease(app, func() {
+		destroyBuffer(app, buffer, bufferMem)
+	})
+}
+
+// deferDestroyMesh destroys the vertex and index buffers of the given mesh
+// once no longer in use by the GPU.
+func deferDestroyMesh(app *App, mesh *Mesh) {
+	deferRelease(app, func() {
+		destroyMesh(app, mesh)
+	})
+}
+
+// deferDestroyTexture destroys the image, sampler and descriptor set of the
+// given texture once no longer in use by the GPU.
+func deferDestroyTexture(app *App, tex *texture) {
+	deferRelease(app, func() {
+		destroyTexture(app, tex)
+	})
+}
+
+// deferDestroyPipelines destroys the given graphics pipelines once no longer in
+// use by the GPU. The handles are copied, as they may be stored in arenas reset
+// before the release.
+func deferDestroyPipelines(app *App, pipelines []C.VkPipeline) {
+	pipelines = append([]C.VkPipeline(nil), pipelines...)
+	deferRelease(app, func() {
+		for _, pipeline := range pipelines {
+			destroyPipeline(app, pipeline)
+		}
+	})
+}
@@ -0,0 +1,214 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"sort"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// draw is a draw of a mesh in the draw list of a frame.
+type draw struct {
+	// Mesh to draw.
+	mesh *Mesh
+	// Vertex buffer read in place of the vertex buffer of the mesh (e.g. the
+	// animated vertices of the frame); or nil.
+	vertexBuffer C.VkBuffer
+	// Graphics pipeline of the draw.
+	pipeline PipelineDesc
+	// Descriptor set of the texture sampled by the draw, bound to set 0; or nil
+	// if untextured.
+	descriptorSet C.VkDescriptorSet
+	// Push constants of the draw.
+	constants pushConstants
+	// Instance buffer read from vertex buffer binding 1, and range of
+	// instances within the buffer; or nil if the mesh is drawn once without
+	// instancing.
+	instanceBuffer C.VkBuffer
+	firstInstance  int
+	instanceCount  int
+	// Sort key of the draw; the pipeline ID in the upper 32 bits and the mesh ID
+	// in the lower 32 bits.
+	sortKey uint64
+}
+
+// drawList is the list of draws of a frame, retained between frames to reuse
+// its memory.
+type drawList struct {
+	draws []draw
+	// Instances of instanced draws, written to the instance buffer of the
+	// frame.
+	instances []Instance
+}
+
+// reset clears the draws of the draw list.
+func (l *drawList) reset() {
+	l.draws = l.draws[:0]
+	l.instances = l.instances[:0]
+}
+
+// add appends the given draw to the draw list, and computes its sort key from
+// the pipeline and the mesh of the draw.
+func (l *drawList) add(app *App, d draw) {
+	d.sortKey = uint64(pipelineID(app, d.pipeline))<<32 | uint64(d.mesh.id)
+	l.draws = append(l.draws, d)
+}
+
+// sort sorts the draws of the draw list by sort key, so that draws of the same
+// pipeline, and within them draws of the same mesh, are recorded consecutively
+// without rebinding. Draws of equal sort keys are kept in order of addition.
+func (l *drawList) sort() {
+	sort.SliceStable(l.draws, func(i, j int) bool {
+		return l.draws[i].sortKey < l.draws[j].sortKey
+	})
+}
+
+// pipelineID returns the ID of the given pipeline description, assigned in
+// order of first use; used to sort draws by pipeline.
+func pipelineID(app *App, desc PipelineDesc) uint32 {
+	if id, ok := app.pipelineIDs[desc]; ok {
+		return id
+	}
+	if app.pipelineIDs == nil {
+		app.pipelineIDs = make(map[PipelineDesc]uint32)
+	}
+	id := uint32(len(app.pipelineIDs) + 1)
+	app.pipelineIDs[desc] = id
+	return id
+}
+
+// buildDrawList builds the draw list of the current frame of the clock, by
+// walking the scene graph of the application; stored in app.drawList.
+//
+// Each node with a mesh adds a draw, transformed by the world transform of the
+// node and the view-projection transform of the camera of the scene graph. The
+// instances of instanced draws are written to the instance buffer of the frame.
+func buildDrawList(app *App) error {
+	updateSceneGraph(app)
+	l := &app.drawList
+	l.reset()
+	aspect := float32(app.swapchainExtent.width) / float32(app.swapchainExtent.height)
+	viewProjection := sceneViewProjection(app.sceneRoot, aspect)
+	app.sceneRoot.Walk(func(n *Node) {
+		if n.Mesh == nil {
+			return
+		}
+		d := draw{
+			mesh:      n.Mesh,
+			pipeline:  defaultPipelineDesc(app),
+			constants: pushConstants{transform: viewProjection.Mul(n.WorldTransform())},
+		}
+		if n.Mesh == app.sceneMesh {
+			// Read the animated vertices of the frame, if any.
+			d.vertexBuffer = frameVertexBuffer(app)
+			if app.sceneTexture != nil {
+				// Sample the texture of the scene.
+				d.pipeline.VertexShader = texturedVertexShader
+				d.pipeline.FragmentShader = texturedFragmentShader
+				d.descriptorSet = app.sceneTexture.descriptorSet
+			}
+		}
+		if len(n.Instances) > 0 {
+			d.pipeline.VertexShader = instancedVertexShader
+			d.pipeline.VertexLayout = instancedVertexLayout
+			d.firstInstance = len(l.instances)
+			d.instanceCount = len(n.Instances)
+			l.instances = append(l.instances, n.Instances...)
+		}
+		if n.Pipeline != nil {
+			d.pipeline = *n.Pipeline
+		}
+		l.add(app, d)
+	})
+	if len(l.instances) > 0 {
+		instanceBuffer, err := updateInstances(app, l.instances)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for i := range l.draws {
+			if l.draws[i].instanceCount > 0 {
+				l.draws[i].instanceBuffer = instanceBuffer
+			}
+		}
+	}
+	return nil
+}
+
+// cmdDrawList records the draws of the given draw list, in order. Pipelines,
+// descriptor sets, vertex and index buffers and push constants are only bound
+// when changed from the previous draw. Must be recorded within the render pass.
+func cmdDrawList(app *App, scratch *arena, commandBuffer C.VkCommandBuffer, l *drawList) error {
+	var (
+		boundPipeline      C.VkPipeline
+		boundDescriptorSet C.VkDescriptorSet
+		boundVertexBuffers [2]C.VkBuffer
+		boundIndexBuffer   C.VkBuffer
+		boundConstants     *pushConstants
+	)
+	for i := range l.draws {
+		d := &l.draws[i]
+		beginLabel(app, scratch, commandBuffer, "draw "+d.mesh.name, labelColorDraw)
+		// Bind pipeline.
+		pipeline, err := getPipeline(app, d.pipeline)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if pipeline != boundPipeline {
+			app.deviceProcs.CmdBindPipeline(commandBuffer, C.VK_PIPELINE_BIND_POINT_GRAPHICS, pipeline)
+			boundPipeline = pipeline
+		}
+		// Bind descriptor set of texture.
+		if d.descriptorSet != nil && d.descriptorSet != boundDescriptorSet {
+			descriptorSets := scratch.newVkDescriptorSetSlice(d.descriptorSet)
+			const firstSet = 0
+			app.deviceProcs.CmdBindDescriptorSets(commandBuffer, C.VK_PIPELINE_BIND_POINT_GRAPHICS, *app.pipelineLayout, firstSet, C.uint32_t(len(descriptorSets)), &descriptorSets[0], 0, nil)
+			boundDescriptorSet = d.descriptorSet
+		}
+		// Bind vertex buffers; the vertex buffer at binding 0 and the instance
+		// buffer at binding 1, if instanced.
+		vertexBuffers := [2]C.VkBuffer{d.vertexBuffer, d.instanceBuffer}
+		if vertexBuffers[0] == nil {
+			vertexBuffers[0] = *d.mesh.vertexBuffer
+		}
+		nbindings := 1
+		if d.instanceBuffer != nil {
+			nbindings = 2
+		}
+		if vertexBuffers[0] != boundVertexBuffers[0] || (d.instanceBuffer != nil && vertexBuffers[1] != boundVertexBuffers[1]) {
+			buffers := scratch.makeVkBufferSlice(nbindings)
+			offsets := scratch.makeVkDeviceSizeSlice(nbindings)
+			copy(buffers, vertexBuffers[:nbindings])
+			const firstVertexBufferBinding = 0
+			app.deviceProcs.CmdBindVertexBuffers(commandBuffer, firstVertexBufferBinding, C.uint(len(buffers)), &buffers[0], &offsets[0])
+			copy(boundVertexBuffers[:], vertexBuffers[:nbindings])
+		}
+		// Bind index buffer.
+		if *d.mesh.indexBuffer != boundIndexBuffer {
+			const indexBufferOffset = 0
+			app.deviceProcs.CmdBindIndexBuffer(commandBuffer, *d.mesh.indexBuffer, indexBufferOffset, d.mesh.indexType)
+			boundIndexBuffer = *d.mesh.indexBuffer
+		}
+		// Push constants.
+		if boundConstants == nil || *boundConstants != d.constants {
+			pushConstantValues := (*pushConstants)(scratch.alloc(unsafe.Sizeof(pushConstants{})))
+			*pushConstantValues = d.constants
+			app.deviceProcs.CmdPushConstants(commandBuffer, *app.pipelineLayout, C.VK_SHADER_STAGE_VERTEX_BIT, 0, C.uint32_t(unsafe.Sizeof(*pushConstantValues)), unsafe.Pointer(pushConstantValues))
+			boundConstants = &d.constants
+		}
+		// Draw mesh.
+		instanceCount, firstInstance := 1, 0
+		if d.instanceBuffer != nil {
+			instanceCount, firstInstance = d.instanceCount, d.firstInstance
+		}
+		const (
+			firstIndex   = 0
+			vertexOffset = 0
+		)
+		app.deviceProcs.CmdDrawIndexed(commandBuffer, C.uint(d.mesh.indexCount), C.uint(instanceCount), firstIndex, vertexOffset, C.uint(firstInstance))
+		endLabel(app, commandBuffer)
+	}
+	return nil
+}
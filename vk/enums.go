@@ -0,0 +1,904 @@
+// Code generated by vkgen. DO NOT EDIT.
+
+// Go enum and bitmask types of Vulkan.
+
+package vk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PhysicalDeviceType is a Vulkan enum (VkPhysicalDeviceType).
+type PhysicalDeviceType int32
+
+// Values of PhysicalDeviceType.
+const (
+	PhysicalDeviceTypeOther         PhysicalDeviceType = 0 // VK_PHYSICAL_DEVICE_TYPE_OTHER
+	PhysicalDeviceTypeIntegratedGPU PhysicalDeviceType = 1 // VK_PHYSICAL_DEVICE_TYPE_INTEGRATED_GPU
+	PhysicalDeviceTypeDiscreteGPU   PhysicalDeviceType = 2 // VK_PHYSICAL_DEVICE_TYPE_DISCRETE_GPU
+	PhysicalDeviceTypeVirtualGPU    PhysicalDeviceType = 3 // VK_PHYSICAL_DEVICE_TYPE_VIRTUAL_GPU
+	PhysicalDeviceTypeCPU           PhysicalDeviceType = 4 // VK_PHYSICAL_DEVICE_TYPE_CPU
+)
+
+// String returns the name of the VkPhysicalDeviceType value.
+func (v PhysicalDeviceType) String() string {
+	switch v {
+	case PhysicalDeviceTypeOther:
+		return "VK_PHYSICAL_DEVICE_TYPE_OTHER"
+	case PhysicalDeviceTypeIntegratedGPU:
+		return "VK_PHYSICAL_DEVICE_TYPE_INTEGRATED_GPU"
+	case PhysicalDeviceTypeDiscreteGPU:
+		return "VK_PHYSICAL_DEVICE_TYPE_DISCRETE_GPU"
+	case PhysicalDeviceTypeVirtualGPU:
+		return "VK_PHYSICAL_DEVICE_TYPE_VIRTUAL_GPU"
+	case PhysicalDeviceTypeCPU:
+		return "VK_PHYSICAL_DEVICE_TYPE_CPU"
+	}
+	return fmt.Sprintf("VkPhysicalDeviceType(%d)", int32(v))
+}
+
+// PresentMode is a Vulkan enum (VkPresentModeKHR).
+type PresentMode int32
+
+// Values of PresentMode.
+const (
+	PresentModeImmediate               PresentMode = 0          // VK_PRESENT_MODE_IMMEDIATE_KHR
+	PresentModeMailbox                 PresentMode = 1          // VK_PRESENT_MODE_MAILBOX_KHR
+	PresentModeFifo                    PresentMode = 2          // VK_PRESENT_MODE_FIFO_KHR
+	PresentModeFifoRelaxed             PresentMode = 3          // VK_PRESENT_MODE_FIFO_RELAXED_KHR
+	PresentModeSharedDemandRefresh     PresentMode = 1000111000 // VK_PRESENT_MODE_SHARED_DEMAND_REFRESH_KHR
+	PresentModeSharedContinuousRefresh PresentMode = 1000111001 // VK_PRESENT_MODE_SHARED_CONTINUOUS_REFRESH_KHR
+)
+
+// String returns the name of the VkPresentModeKHR value.
+func (v PresentMode) String() string {
+	switch v {
+	case PresentModeImmediate:
+		return "VK_PRESENT_MODE_IMMEDIATE_KHR"
+	case PresentModeMailbox:
+		return "VK_PRESENT_MODE_MAILBOX_KHR"
+	case PresentModeFifo:
+		return "VK_PRESENT_MODE_FIFO_KHR"
+	case PresentModeFifoRelaxed:
+		return "VK_PRESENT_MODE_FIFO_RELAXED_KHR"
+	case PresentModeSharedDemandRefresh:
+		return "VK_PRESENT_MODE_SHARED_DEMAND_REFRESH_KHR"
+	case PresentModeSharedContinuousRefresh:
+		return "VK_PRESENT_MODE_SHARED_CONTINUOUS_REFRESH_KHR"
+	}
+	return fmt.Sprintf("VkPresentModeKHR(%d)", int32(v))
+}
+
+// SampleCount is a Vulkan bitmask (VkSampleCountFlagBits).
+type SampleCount uint32
+
+// Values of SampleCount.
+const (
+	SampleCount1  SampleCount = 0x00000001 // VK_SAMPLE_COUNT_1_BIT
+	SampleCount2  SampleCount = 0x00000002 // VK_SAMPLE_COUNT_2_BIT
+	SampleCount4  SampleCount = 0x00000004 // VK_SAMPLE_COUNT_4_BIT
+	SampleCount8  SampleCount = 0x00000008 // VK_SAMPLE_COUNT_8_BIT
+	SampleCount16 SampleCount = 0x00000010 // VK_SAMPLE_COUNT_16_BIT
+	SampleCount32 SampleCount = 0x00000020 // VK_SAMPLE_COUNT_32_BIT
+	SampleCount64 SampleCount = 0x00000040 // VK_SAMPLE_COUNT_64_BIT
+)
+
+// sampleCountBits specifies the names of SampleCount bits.
+var sampleCountBits = []struct {
+	bit  SampleCount
+	name string
+}{
+	{SampleCount1, "VK_SAMPLE_COUNT_1_BIT"},
+	{SampleCount2, "VK_SAMPLE_COUNT_2_BIT"},
+	{SampleCount4, "VK_SAMPLE_COUNT_4_BIT"},
+	{SampleCount8, "VK_SAMPLE_COUNT_8_BIT"},
+	{SampleCount16, "VK_SAMPLE_COUNT_16_BIT"},
+	{SampleCount32, "VK_SAMPLE_COUNT_32_BIT"},
+	{SampleCount64, "VK_SAMPLE_COUNT_64_BIT"},
+}
+
+// String returns the names of the VkSampleCountFlagBits bits set in v, separated
+// by '|'.
+func (v SampleCount) String() string {
+	if v == 0 {
+		return "0"
+	}
+	var names []string
+	for _, b := range sampleCountBits {
+		if v&b.bit != 0 {
+			names = append(names, b.name)
+			v &^= b.bit
+		}
+	}
+	if v != 0 {
+		names = append(names, fmt.Sprintf("0x%X", uint32(v)))
+	}
+	return strings.Join(names, "|")
+}
+
+// QueueFlag is a Vulkan bitmask (VkQueueFlagBits).
+type QueueFlag uint32
+
+// Values of QueueFlag.
+const (
+	QueueFlagGraphics      QueueFlag = 0x00000001 // VK_QUEUE_GRAPHICS_BIT
+	QueueFlagCompute       QueueFlag = 0x00000002 // VK_QUEUE_COMPUTE_BIT
+	QueueFlagTransfer      QueueFlag = 0x00000004 // VK_QUEUE_TRANSFER_BIT
+	QueueFlagSparseBinding QueueFlag = 0x00000008 // VK_QUEUE_SPARSE_BINDING_BIT
+	QueueFlagProtected     QueueFlag = 0x00000010 // VK_QUEUE_PROTECTED_BIT
+)
+
+// queueFlagBits specifies the names of QueueFlag bits.
+var queueFlagBits = []struct {
+	bit  QueueFlag
+	name string
+}{
+	{QueueFlagGraphics, "VK_QUEUE_GRAPHICS_BIT"},
+	{QueueFlagCompute, "VK_QUEUE_COMPUTE_BIT"},
+	{QueueFlagTransfer, "VK_QUEUE_TRANSFER_BIT"},
+	{QueueFlagSparseBinding, "VK_QUEUE_SPARSE_BINDING_BIT"},
+	{QueueFlagProtected, "VK_QUEUE_PROTECTED_BIT"},
+}
+
+// String returns the names of the VkQueueFlagBits bits set in v, separated
+// by '|'.
+func (v QueueFlag) String() string {
+	if v == 0 {
+		return "0"
+	}
+	var names []string
+	for _, b := range queueFlagBits {
+		if v&b.bit != 0 {
+			names = append(names, b.name)
+			v &^= b.bit
+		}
+	}
+	if v != 0 {
+		names = append(names, fmt.Sprintf("0x%X", uint32(v)))
+	}
+	return strings.Join(names, "|")
+}
+
+// ImageUsage is a Vulkan bitmask (VkImageUsageFlagBits).
+type ImageUsage uint32
+
+// Values of ImageUsage.
+const (
+	ImageUsageTransferSrc            ImageUsage = 0x00000001 // VK_IMAGE_USAGE_TRANSFER_SRC_BIT
+	ImageUsageTransferDst            ImageUsage = 0x00000002 // VK_IMAGE_USAGE_TRANSFER_DST_BIT
+	ImageUsageSampled                ImageUsage = 0x00000004 // VK_IMAGE_USAGE_SAMPLED_BIT
+	ImageUsageStorage                ImageUsage = 0x00000008 // VK_IMAGE_USAGE_STORAGE_BIT
+	ImageUsageColorAttachment        ImageUsage = 0x00000010 // VK_IMAGE_USAGE_COLOR_ATTACHMENT_BIT
+	ImageUsageDepthStencilAttachment ImageUsage = 0x00000020 // VK_IMAGE_USAGE_DEPTH_STENCIL_ATTACHMENT_BIT
+	ImageUsageTransientAttachment    ImageUsage = 0x00000040 // VK_IMAGE_USAGE_TRANSIENT_ATTACHMENT_BIT
+	ImageUsageInputAttachment        ImageUsage = 0x00000080 // VK_IMAGE_USAGE_INPUT_ATTACHMENT_BIT
+)
+
+// imageUsageBits specifies the names of ImageUsage bits.
+var imageUsageBits = []struct {
+	bit  ImageUsage
+	name string
+}{
+	{ImageUsageTransferSrc, "VK_IMAGE_USAGE_TRANSFER_SRC_BIT"},
+	{ImageUsageTransferDst, "VK_IMAGE_USAGE_TRANSFER_DST_BIT"},
+	{ImageUsageSampled, "VK_IMAGE_USAGE_SAMPLED_BIT"},
+	{ImageUsageStorage, "VK_IMAGE_USAGE_STORAGE_BIT"},
+	{ImageUsageColorAttachment, "VK_IMAGE_USAGE_COLOR_ATTACHMENT_BIT"},
+	{ImageUsageDepthStencilAttachment, "VK_IMAGE_USAGE_DEPTH_STENCIL_ATTACHMENT_BIT"},
+	{ImageUsageTransientAttachment, "VK_IMAGE_USAGE_TRANSIENT_ATTACHMENT_BIT"},
+	{ImageUsageInputAttachment, "VK_IMAGE_USAGE_INPUT_ATTACHMENT_BIT"},
+}
+
+// String returns the names of the VkImageUsageFlagBits bits set in v, separated
+// by '|'.
+func (v ImageUsage) String() string {
+	if v == 0 {
+		return "0"
+	}
+	var names []string
+	for _, b := range imageUsageBits {
+		if v&b.bit != 0 {
+			names = append(names, b.name)
+			v &^= b.bit
+		}
+	}
+	if v != 0 {
+		names = append(names, fmt.Sprintf("0x%X", uint32(v)))
+	}
+	return strings.Join(names, "|")
+}
+
+// PrimitiveTopology is a Vulkan enum (VkPrimitiveTopology).
+type PrimitiveTopology int32
+
+// Values of PrimitiveTopology.
+const (
+	PrimitiveTopologyPointList                  PrimitiveTopology = 0  // VK_PRIMITIVE_TOPOLOGY_POINT_LIST
+	PrimitiveTopologyLineList                   PrimitiveTopology = 1  // VK_PRIMITIVE_TOPOLOGY_LINE_LIST
+	PrimitiveTopologyLineStrip                  PrimitiveTopology = 2  // VK_PRIMITIVE_TOPOLOGY_LINE_STRIP
+	PrimitiveTopologyTriangleList               PrimitiveTopology = 3  // VK_PRIMITIVE_TOPOLOGY_TRIANGLE_LIST
+	PrimitiveTopologyTriangleStrip              PrimitiveTopology = 4  // VK_PRIMITIVE_TOPOLOGY_TRIANGLE_STRIP
+	PrimitiveTopologyTriangleFan                PrimitiveTopology = 5  // VK_PRIMITIVE_TOPOLOGY_TRIANGLE_FAN
+	PrimitiveTopologyLineListWithAdjacency      PrimitiveTopology = 6  // VK_PRIMITIVE_TOPOLOGY_LINE_LIST_WITH_ADJACENCY
+	PrimitiveTopologyLineStripWithAdjacency     PrimitiveTopology = 7  // VK_PRIMITIVE_TOPOLOGY_LINE_STRIP_WITH_ADJACENCY
+	PrimitiveTopologyTriangleListWithAdjacency  PrimitiveTopology = 8  // VK_PRIMITIVE_TOPOLOGY_TRIANGLE_LIST_WITH_ADJACENCY
+	PrimitiveTopologyTriangleStripWithAdjacency PrimitiveTopology = 9  // VK_PRIMITIVE_TOPOLOGY_TRIANGLE_STRIP_WITH_ADJACENCY
+	PrimitiveTopologyPatchList                  PrimitiveTopology = 10 // VK_PRIMITIVE_TOPOLOGY_PATCH_LIST
+)
+
+// String returns the name of the VkPrimitiveTopology value.
+func (v PrimitiveTopology) String() string {
+	switch v {
+	case PrimitiveTopologyPointList:
+		return "VK_PRIMITIVE_TOPOLOGY_POINT_LIST"
+	case PrimitiveTopologyLineList:
+		return "VK_PRIMITIVE_TOPOLOGY_LINE_LIST"
+	case PrimitiveTopologyLineStrip:
+		return "VK_PRIMITIVE_TOPOLOGY_LINE_STRIP"
+	case PrimitiveTopologyTriangleList:
+		return "VK_PRIMITIVE_TOPOLOGY_TRIANGLE_LIST"
+	case PrimitiveTopologyTriangleStrip:
+		return "VK_PRIMITIVE_TOPOLOGY_TRIANGLE_STRIP"
+	case PrimitiveTopologyTriangleFan:
+		return "VK_PRIMITIVE_TOPOLOGY_TRIANGLE_FAN"
+	case PrimitiveTopologyLineListWithAdjacency:
+		return "VK_PRIMITIVE_TOPOLOGY_LINE_LIST_WITH_ADJACENCY"
+	case PrimitiveTopologyLineStripWithAdjacency:
+		return "VK_PRIMITIVE_TOPOLOGY_LINE_STRIP_WITH_ADJACENCY"
+	case PrimitiveTopologyTriangleListWithAdjacency:
+		return "VK_PRIMITIVE_TOPOLOGY_TRIANGLE_LIST_WITH_ADJACENCY"
+	case PrimitiveTopologyTriangleStripWithAdjacency:
+		return "VK_PRIMITIVE_TOPOLOGY_TRIANGLE_STRIP_WITH_ADJACENCY"
+	case PrimitiveTopologyPatchList:
+		return "VK_PRIMITIVE_TOPOLOGY_PATCH_LIST"
+	}
+	return fmt.Sprintf("VkPrimitiveTopology(%d)", int32(v))
+}
+
+// PolygonMode is a Vulkan enum (VkPolygonMode).
+type PolygonMode int32
+
+// Values of PolygonMode.
+const (
+	PolygonModeFill  PolygonMode = 0 // VK_POLYGON_MODE_FILL
+	PolygonModeLine  PolygonMode = 1 // VK_POLYGON_MODE_LINE
+	PolygonModePoint PolygonMode = 2 // VK_POLYGON_MODE_POINT
+)
+
+// String returns the name of the VkPolygonMode value.
+func (v PolygonMode) String() string {
+	switch v {
+	case PolygonModeFill:
+		return "VK_POLYGON_MODE_FILL"
+	case PolygonModeLine:
+		return "VK_POLYGON_MODE_LINE"
+	case PolygonModePoint:
+		return "VK_POLYGON_MODE_POINT"
+	}
+	return fmt.Sprintf("VkPolygonMode(%d)", int32(v))
+}
+
+// CullMode is a Vulkan bitmask (VkCullModeFlagBits).
+type CullMode uint32
+
+// Values of CullMode.
+const (
+	CullModeNone         CullMode = 0x00000000 // VK_CULL_MODE_NONE
+	CullModeFront        CullMode = 0x00000001 // VK_CULL_MODE_FRONT_BIT
+	CullModeBack         CullMode = 0x00000002 // VK_CULL_MODE_BACK_BIT
+	CullModeFrontAndBack CullMode = 0x00000003 // VK_CULL_MODE_FRONT_AND_BACK
+)
+
+// cullModeBits specifies the names of CullMode bits.
+var cullModeBits = []struct {
+	bit  CullMode
+	name string
+}{
+	{CullModeFront, "VK_CULL_MODE_FRONT_BIT"},
+	{CullModeBack, "VK_CULL_MODE_BACK_BIT"},
+}
+
+// String returns the names of the VkCullModeFlagBits bits set in v, separated
+// by '|'.
+func (v CullMode) String() string {
+	if v == 0 {
+		return "0"
+	}
+	var names []string
+	for _, b := range cullModeBits {
+		if v&b.bit != 0 {
+			names = append(names, b.name)
+			v &^= b.bit
+		}
+	}
+	if v != 0 {
+		names = append(names, fmt.Sprintf("0x%X", uint32(v)))
+	}
+	return strings.Join(names, "|")
+}
+
+// FrontFace is a Vulkan enum (VkFrontFace).
+type FrontFace int32
+
+// Values of FrontFace.
+const (
+	FrontFaceCounterClockwise FrontFace = 0 // VK_FRONT_FACE_COUNTER_CLOCKWISE
+	FrontFaceClockwise        FrontFace = 1 // VK_FRONT_FACE_CLOCKWISE
+)
+
+// String returns the name of the VkFrontFace value.
+func (v FrontFace) String() string {
+	switch v {
+	case FrontFaceCounterClockwise:
+		return "VK_FRONT_FACE_COUNTER_CLOCKWISE"
+	case FrontFaceClockwise:
+		return "VK_FRONT_FACE_CLOCKWISE"
+	}
+	return fmt.Sprintf("VkFrontFace(%d)", int32(v))
+}
+
+// CompareOp is a Vulkan enum (VkCompareOp).
+type CompareOp int32
+
+// Values of CompareOp.
+const (
+	CompareOpNever          CompareOp = 0 // VK_COMPARE_OP_NEVER
+	CompareOpLess           CompareOp = 1 // VK_COMPARE_OP_LESS
+	CompareOpEqual          CompareOp = 2 // VK_COMPARE_OP_EQUAL
+	CompareOpLessOrEqual    CompareOp = 3 // VK_COMPARE_OP_LESS_OR_EQUAL
+	CompareOpGreater        CompareOp = 4 // VK_COMPARE_OP_GREATER
+	CompareOpNotEqual       CompareOp = 5 // VK_COMPARE_OP_NOT_EQUAL
+	CompareOpGreaterOrEqual CompareOp = 6 // VK_COMPARE_OP_GREATER_OR_EQUAL
+	CompareOpAlways         CompareOp = 7 // VK_COMPARE_OP_ALWAYS
+)
+
+// String returns the name of the VkCompareOp value.
+func (v CompareOp) String() string {
+	switch v {
+	case CompareOpNever:
+		return "VK_COMPARE_OP_NEVER"
+	case CompareOpLess:
+		return "VK_COMPARE_OP_LESS"
+	case CompareOpEqual:
+		return "VK_COMPARE_OP_EQUAL"
+	case CompareOpLessOrEqual:
+		return "VK_COMPARE_OP_LESS_OR_EQUAL"
+	case CompareOpGreater:
+		return "VK_COMPARE_OP_GREATER"
+	case CompareOpNotEqual:
+		return "VK_COMPARE_OP_NOT_EQUAL"
+	case CompareOpGreaterOrEqual:
+		return "VK_COMPARE_OP_GREATER_OR_EQUAL"
+	case CompareOpAlways:
+		return "VK_COMPARE_OP_ALWAYS"
+	}
+	return fmt.Sprintf("VkCompareOp(%d)", int32(v))
+}
+
+// BlendFactor is a Vulkan enum (VkBlendFactor).
+type BlendFactor int32
+
+// Values of BlendFactor.
+const (
+	BlendFactorZero                  BlendFactor = 0  // VK_BLEND_FACTOR_ZERO
+	BlendFactorOne                   BlendFactor = 1  // VK_BLEND_FACTOR_ONE
+	BlendFactorSrcColor              BlendFactor = 2  // VK_BLEND_FACTOR_SRC_COLOR
+	BlendFactorOneMinusSrcColor      BlendFactor = 3  // VK_BLEND_FACTOR_ONE_MINUS_SRC_COLOR
+	BlendFactorDstColor              BlendFactor = 4  // VK_BLEND_FACTOR_DST_COLOR
+	BlendFactorOneMinusDstColor      BlendFactor = 5  // VK_BLEND_FACTOR_ONE_MINUS_DST_COLOR
+	BlendFactorSrcAlpha              BlendFactor = 6  // VK_BLEND_FACTOR_SRC_ALPHA
+	BlendFactorOneMinusSrcAlpha      BlendFactor = 7  // VK_BLEND_FACTOR_ONE_MINUS_SRC_ALPHA
+	BlendFactorDstAlpha              BlendFactor = 8  // VK_BLEND_FACTOR_DST_ALPHA
+	BlendFactorOneMinusDstAlpha      BlendFactor = 9  // VK_BLEND_FACTOR_ONE_MINUS_DST_ALPHA
+	BlendFactorConstantColor         BlendFactor = 10 // VK_BLEND_FACTOR_CONSTANT_COLOR
+	BlendFactorOneMinusConstantColor BlendFactor = 11 // VK_BLEND_FACTOR_ONE_MINUS_CONSTANT_COLOR
+	BlendFactorConstantAlpha         BlendFactor = 12 // VK_BLEND_FACTOR_CONSTANT_ALPHA
+	BlendFactorOneMinusConstantAlpha BlendFactor = 13 // VK_BLEND_FACTOR_ONE_MINUS_CONSTANT_ALPHA
+	BlendFactorSrcAlphaSaturate      BlendFactor = 14 // VK_BLEND_FACTOR_SRC_ALPHA_SATURATE
+	BlendFactorSrc1Color             BlendFactor = 15 // VK_BLEND_FACTOR_SRC1_COLOR
+	BlendFactorOneMinusSrc1Color     BlendFactor = 16 // VK_BLEND_FACTOR_ONE_MINUS_SRC1_COLOR
+	BlendFactorSrc1Alpha             BlendFactor = 17 // VK_BLEND_FACTOR_SRC1_ALPHA
+	BlendFactorOneMinusSrc1Alpha     BlendFactor = 18 // VK_BLEND_FACTOR_ONE_MINUS_SRC1_ALPHA
+)
+
+// String returns the name of the VkBlendFactor value.
+func (v BlendFactor) String() string {
+	switch v {
+	case BlendFactorZero:
+		return "VK_BLEND_FACTOR_ZERO"
+	case BlendFactorOne:
+		return "VK_BLEND_FACTOR_ONE"
+	case BlendFactorSrcColor:
+		return "VK_BLEND_FACTOR_SRC_COLOR"
+	case BlendFactorOneMinusSrcColor:
+		return "VK_BLEND_FACTOR_ONE_MINUS_SRC_COLOR"
+	case BlendFactorDstColor:
+		return "VK_BLEND_FACTOR_DST_COLOR"
+	case BlendFactorOneMinusDstColor:
+		return "VK_BLEND_FACTOR_ONE_MINUS_DST_COLOR"
+	case BlendFactorSrcAlpha:
+		return "VK_BLEND_FACTOR_SRC_ALPHA"
+	case BlendFactorOneMinusSrcAlpha:
+		return "VK_BLEND_FACTOR_ONE_MINUS_SRC_ALPHA"
+	case BlendFactorDstAlpha:
+		return "VK_BLEND_FACTOR_DST_ALPHA"
+	case BlendFactorOneMinusDstAlpha:
+		return "VK_BLEND_FACTOR_ONE_MINUS_DST_ALPHA"
+	case BlendFactorConstantColor:
+		return "VK_BLEND_FACTOR_CONSTANT_COLOR"
+	case BlendFactorOneMinusConstantColor:
+		return "VK_BLEND_FACTOR_ONE_MINUS_CONSTANT_COLOR"
+	case BlendFactorConstantAlpha:
+		return "VK_BLEND_FACTOR_CONSTANT_ALPHA"
+	case BlendFactorOneMinusConstantAlpha:
+		return "VK_BLEND_FACTOR_ONE_MINUS_CONSTANT_ALPHA"
+	case BlendFactorSrcAlphaSaturate:
+		return "VK_BLEND_FACTOR_SRC_ALPHA_SATURATE"
+	case BlendFactorSrc1Color:
+		return "VK_BLEND_FACTOR_SRC1_COLOR"
+	case BlendFactorOneMinusSrc1Color:
+		return "VK_BLEND_FACTOR_ONE_MINUS_SRC1_COLOR"
+	case BlendFactorSrc1Alpha:
+		return "VK_BLEND_FACTOR_SRC1_ALPHA"
+	case BlendFactorOneMinusSrc1Alpha:
+		return "VK_BLEND_FACTOR_ONE_MINUS_SRC1_ALPHA"
+	}
+	return fmt.Sprintf("VkBlendFactor(%d)", int32(v))
+}
+
+// BlendOp is a Vulkan enum (VkBlendOp).
+type BlendOp int32
+
+// Values of BlendOp.
+const (
+	BlendOpAdd             BlendOp = 0 // VK_BLEND_OP_ADD
+	BlendOpSubtract        BlendOp = 1 // VK_BLEND_OP_SUBTRACT
+	BlendOpReverseSubtract BlendOp = 2 // VK_BLEND_OP_REVERSE_SUBTRACT
+	BlendOpMin             BlendOp = 3 // VK_BLEND_OP_MIN
+	BlendOpMax             BlendOp = 4 // VK_BLEND_OP_MAX
+)
+
+// String returns the name of the VkBlendOp value.
+func (v BlendOp) String() string {
+	switch v {
+	case BlendOpAdd:
+		return "VK_BLEND_OP_ADD"
+	case BlendOpSubtract:
+		return "VK_BLEND_OP_SUBTRACT"
+	case BlendOpReverseSubtract:
+		return "VK_BLEND_OP_REVERSE_SUBTRACT"
+	case BlendOpMin:
+		return "VK_BLEND_OP_MIN"
+	case BlendOpMax:
+		return "VK_BLEND_OP_MAX"
+	}
+	return fmt.Sprintf("VkBlendOp(%d)", int32(v))
+}
+
+// Format is a Vulkan enum (VkFormat).
+type Format int32
+
+// Values of Format.
+const (
+	FormatUndefined                Format = 0   // VK_FORMAT_UNDEFINED
+	FormatR4g4UnormPack8           Format = 1   // VK_FORMAT_R4G4_UNORM_PACK8
+	FormatR4g4b4a4UnormPack16      Format = 2   // VK_FORMAT_R4G4B4A4_UNORM_PACK16
+	FormatB4g4r4a4UnormPack16      Format = 3   // VK_FORMAT_B4G4R4A4_UNORM_PACK16
+	FormatR5g6b5UnormPack16        Format = 4   // VK_FORMAT_R5G6B5_UNORM_PACK16
+	FormatB5g6r5UnormPack16        Format = 5   // VK_FORMAT_B5G6R5_UNORM_PACK16
+	FormatR5g5b5a1UnormPack16      Format = 6   // VK_FORMAT_R5G5B5A1_UNORM_PACK16
+	FormatB5g5r5a1UnormPack16      Format = 7   // VK_FORMAT_B5G5R5A1_UNORM_PACK16
+	FormatA1r5g5b5UnormPack16      Format = 8   // VK_FORMAT_A1R5G5B5_UNORM_PACK16
+	FormatR8Unorm                  Format = 9   // VK_FORMAT_R8_UNORM
+	FormatR8Snorm                  Format = 10  // VK_FORMAT_R8_SNORM
+	FormatR8Uscaled                Format = 11  // VK_FORMAT_R8_USCALED
+	FormatR8Sscaled                Format = 12  // VK_FORMAT_R8_SSCALED
+	FormatR8Uint                   Format = 13  // VK_FORMAT_R8_UINT
+	FormatR8Sint                   Format = 14  // VK_FORMAT_R8_SINT
+	FormatR8SRGB                   Format = 15  // VK_FORMAT_R8_SRGB
+	FormatR8g8Unorm                Format = 16  // VK_FORMAT_R8G8_UNORM
+	FormatR8g8Snorm                Format = 17  // VK_FORMAT_R8G8_SNORM
+	FormatR8g8Uscaled              Format = 18  // VK_FORMAT_R8G8_USCALED
+	FormatR8g8Sscaled              Format = 19  // VK_FORMAT_R8G8_SSCALED
+	FormatR8g8Uint                 Format = 20  // VK_FORMAT_R8G8_UINT
+	FormatR8g8Sint                 Format = 21  // VK_FORMAT_R8G8_SINT
+	FormatR8g8SRGB                 Format = 22  // VK_FORMAT_R8G8_SRGB
+	FormatR8g8b8Unorm              Format = 23  // VK_FORMAT_R8G8B8_UNORM
+	FormatR8g8b8Snorm              Format = 24  // VK_FORMAT_R8G8B8_SNORM
+	FormatR8g8b8Uscaled            Format = 25  // VK_FORMAT_R8G8B8_USCALED
+	FormatR8g8b8Sscaled            Format = 26  // VK_FORMAT_R8G8B8_SSCALED
+	FormatR8g8b8Uint               Format = 27  // VK_FORMAT_R8G8B8_UINT
+	FormatR8g8b8Sint               Format = 28  // VK_FORMAT_R8G8B8_SINT
+	FormatR8g8b8SRGB               Format = 29  // VK_FORMAT_R8G8B8_SRGB
+	FormatB8g8r8Unorm              Format = 30  // VK_FORMAT_B8G8R8_UNORM
+	FormatB8g8r8Snorm              Format = 31  // VK_FORMAT_B8G8R8_SNORM
+	FormatB8g8r8Uscaled            Format = 32  // VK_FORMAT_B8G8R8_USCALED
+	FormatB8g8r8Sscaled            Format = 33  // VK_FORMAT_B8G8R8_SSCALED
+	FormatB8g8r8Uint               Format = 34  // VK_FORMAT_B8G8R8_UINT
+	FormatB8g8r8Sint               Format = 35  // VK_FORMAT_B8G8R8_SINT
+	FormatB8g8r8SRGB               Format = 36  // VK_FORMAT_B8G8R8_SRGB
+	FormatR8g8b8a8Unorm            Format = 37  // VK_FORMAT_R8G8B8A8_UNORM
+	FormatR8g8b8a8Snorm            Format = 38  // VK_FORMAT_R8G8B8A8_SNORM
+	FormatR8g8b8a8Uscaled          Format = 39  // VK_FORMAT_R8G8B8A8_USCALED
+	FormatR8g8b8a8Sscaled          Format = 40  // VK_FORMAT_R8G8B8A8_SSCALED
+	FormatR8g8b8a8Uint             Format = 41  // VK_FORMAT_R8G8B8A8_UINT
+	FormatR8g8b8a8Sint             Format = 42  // VK_FORMAT_R8G8B8A8_SINT
+	FormatR8g8b8a8SRGB             Format = 43  // VK_FORMAT_R8G8B8A8_SRGB
+	FormatB8g8r8a8Unorm            Format = 44  // VK_FORMAT_B8G8R8A8_UNORM
+	FormatB8g8r8a8Snorm            Format = 45  // VK_FORMAT_B8G8R8A8_SNORM
+	FormatB8g8r8a8Uscaled          Format = 46  // VK_FORMAT_B8G8R8A8_USCALED
+	FormatB8g8r8a8Sscaled          Format = 47  // VK_FORMAT_B8G8R8A8_SSCALED
+	FormatB8g8r8a8Uint             Format = 48  // VK_FORMAT_B8G8R8A8_UINT
+	FormatB8g8r8a8Sint             Format = 49  // VK_FORMAT_B8G8R8A8_SINT
+	FormatB8g8r8a8SRGB             Format = 50  // VK_FORMAT_B8G8R8A8_SRGB
+	FormatA8b8g8r8UnormPack32      Format = 51  // VK_FORMAT_A8B8G8R8_UNORM_PACK32
+	FormatA8b8g8r8SnormPack32      Format = 52  // VK_FORMAT_A8B8G8R8_SNORM_PACK32
+	FormatA8b8g8r8UscaledPack32    Format = 53  // VK_FORMAT_A8B8G8R8_USCALED_PACK32
+	FormatA8b8g8r8SscaledPack32    Format = 54  // VK_FORMAT_A8B8G8R8_SSCALED_PACK32
+	FormatA8b8g8r8UintPack32       Format = 55  // VK_FORMAT_A8B8G8R8_UINT_PACK32
+	FormatA8b8g8r8SintPack32       Format = 56  // VK_FORMAT_A8B8G8R8_SINT_PACK32
+	FormatA8b8g8r8SRGBPack32       Format = 57  // VK_FORMAT_A8B8G8R8_SRGB_PACK32
+	FormatA2r10g10b10UnormPack32   Format = 58  // VK_FORMAT_A2R10G10B10_UNORM_PACK32
+	FormatA2r10g10b10SnormPack32   Format = 59  // VK_FORMAT_A2R10G10B10_SNORM_PACK32
+	FormatA2r10g10b10UscaledPack32 Format = 60  // VK_FORMAT_A2R10G10B10_USCALED_PACK32
+	FormatA2r10g10b10SscaledPack32 Format = 61  // VK_FORMAT_A2R10G10B10_SSCALED_PACK32
+	FormatA2r10g10b10UintPack32    Format = 62  // VK_FORMAT_A2R10G10B10_UINT_PACK32
+	FormatA2r10g10b10SintPack32    Format = 63  // VK_FORMAT_A2R10G10B10_SINT_PACK32
+	FormatA2b10g10r10UnormPack32   Format = 64  // VK_FORMAT_A2B10G10R10_UNORM_PACK32
+	FormatA2b10g10r10SnormPack32   Format = 65  // VK_FORMAT_A2B10G10R10_SNORM_PACK32
+	FormatA2b10g10r10UscaledPack32 Format = 66  // VK_FORMAT_A2B10G10R10_USCALED_PACK32
+	FormatA2b10g10r10SscaledPack32 Format = 67  // VK_FORMAT_A2B10G10R10_SSCALED_PACK32
+	FormatA2b10g10r10UintPack32    Format = 68  // VK_FORMAT_A2B10G10R10_UINT_PACK32
+	FormatA2b10g10r10SintPack32    Format = 69  // VK_FORMAT_A2B10G10R10_SINT_PACK32
+	FormatR16Unorm                 Format = 70  // VK_FORMAT_R16_UNORM
+	FormatR16Snorm                 Format = 71  // VK_FORMAT_R16_SNORM
+	FormatR16Uscaled               Format = 72  // VK_FORMAT_R16_USCALED
+	FormatR16Sscaled               Format = 73  // VK_FORMAT_R16_SSCALED
+	FormatR16Uint                  Format = 74  // VK_FORMAT_R16_UINT
+	FormatR16Sint                  Format = 75  // VK_FORMAT_R16_SINT
+	FormatR16Sfloat                Format = 76  // VK_FORMAT_R16_SFLOAT
+	FormatR16g16Unorm              Format = 77  // VK_FORMAT_R16G16_UNORM
+	FormatR16g16Snorm              Format = 78  // VK_FORMAT_R16G16_SNORM
+	FormatR16g16Uscaled            Format = 79  // VK_FORMAT_R16G16_USCALED
+	FormatR16g16Sscaled            Format = 80  // VK_FORMAT_R16G16_SSCALED
+	FormatR16g16Uint               Format = 81  // VK_FORMAT_R16G16_UINT
+	FormatR16g16Sint               Format = 82  // VK_FORMAT_R16G16_SINT
+	FormatR16g16Sfloat             Format = 83  // VK_FORMAT_R16G16_SFLOAT
+	FormatR16g16b16Unorm           Format = 84  // VK_FORMAT_R16G16B16_UNORM
+	FormatR16g16b16Snorm           Format = 85  // VK_FORMAT_R16G16B16_SNORM
+	FormatR16g16b16Uscaled         Format = 86  // VK_FORMAT_R16G16B16_USCALED
+	FormatR16g16b16Sscaled         Format = 87  // VK_FORMAT_R16G16B16_SSCALED
+	FormatR16g16b16Uint            Format = 88  // VK_FORMAT_R16G16B16_UINT
+	FormatR16g16b16Sint            Format = 89  // VK_FORMAT_R16G16B16_SINT
+	FormatR16g16b16Sfloat          Format = 90  // VK_FORMAT_R16G16B16_SFLOAT
+	FormatR16g16b16a16Unorm        Format = 91  // VK_FORMAT_R16G16B16A16_UNORM
+	FormatR16g16b16a16Snorm        Format = 92  // VK_FORMAT_R16G16B16A16_SNORM
+	FormatR16g16b16a16Uscaled      Format = 93  // VK_FORMAT_R16G16B16A16_USCALED
+	FormatR16g16b16a16Sscaled      Format = 94  // VK_FORMAT_R16G16B16A16_SSCALED
+	FormatR16g16b16a16Uint         Format = 95  // VK_FORMAT_R16G16B16A16_UINT
+	FormatR16g16b16a16Sint         Format = 96  // VK_FORMAT_R16G16B16A16_SINT
+	FormatR16g16b16a16Sfloat       Format = 97  // VK_FORMAT_R16G16B16A16_SFLOAT
+	FormatR32Uint                  Format = 98  // VK_FORMAT_R32_UINT
+	FormatR32Sint                  Format = 99  // VK_FORMAT_R32_SINT
+	FormatR32Sfloat                Format = 100 // VK_FORMAT_R32_SFLOAT
+	FormatR32g32Uint               Format = 101 // VK_FORMAT_R32G32_UINT
+	FormatR32g32Sint               Format = 102 // VK_FORMAT_R32G32_SINT
+	FormatR32g32Sfloat             Format = 103 // VK_FORMAT_R32G32_SFLOAT
+	FormatR32g32b32Uint            Format = 104 // VK_FORMAT_R32G32B32_UINT
+	FormatR32g32b32Sint            Format = 105 // VK_FORMAT_R32G32B32_SINT
+	FormatR32g32b32Sfloat          Format = 106 // VK_FORMAT_R32G32B32_SFLOAT
+	FormatR32g32b32a32Uint         Format = 107 // VK_FORMAT_R32G32B32A32_UINT
+	FormatR32g32b32a32Sint         Format = 108 // VK_FORMAT_R32G32B32A32_SINT
+	FormatR32g32b32a32Sfloat       Format = 109 // VK_FORMAT_R32G32B32A32_SFLOAT
+	FormatR64Uint                  Format = 110 // VK_FORMAT_R64_UINT
+	FormatR64Sint                  Format = 111 // VK_FORMAT_R64_SINT
+	FormatR64Sfloat                Format = 112 // VK_FORMAT_R64_SFLOAT
+	FormatR64g64Uint               Format = 113 // VK_FORMAT_R64G64_UINT
+	FormatR64g64Sint               Format = 114 // VK_FORMAT_R64G64_SINT
+	FormatR64g64Sfloat             Format = 115 // VK_FORMAT_R64G64_SFLOAT
+	FormatR64g64b64Uint            Format = 116 // VK_FORMAT_R64G64B64_UINT
+	FormatR64g64b64Sint            Format = 117 // VK_FORMAT_R64G64B64_SINT
+	FormatR64g64b64Sfloat          Format = 118 // VK_FORMAT_R64G64B64_SFLOAT
+	FormatR64g64b64a64Uint         Format = 119 // VK_FORMAT_R64G64B64A64_UINT
+	FormatR64g64b64a64Sint         Format = 120 // VK_FORMAT_R64G64B64A64_SINT
+	FormatR64g64b64a64Sfloat       Format = 121 // VK_FORMAT_R64G64B64A64_SFLOAT
+	FormatB10g11r11UfloatPack32    Format = 122 // VK_FORMAT_B10G11R11_UFLOAT_PACK32
+	FormatE5b9g9r9UfloatPack32     Format = 123 // VK_FORMAT_E5B9G9R9_UFLOAT_PACK32
+	FormatD16Unorm                 Format = 124 // VK_FORMAT_D16_UNORM
+	FormatX8D24UnormPack32         Format = 125 // VK_FORMAT_X8_D24_UNORM_PACK32
+	FormatD32Sfloat                Format = 126 // VK_FORMAT_D32_SFLOAT
+	FormatS8Uint                   Format = 127 // VK_FORMAT_S8_UINT
+	FormatD16UnormS8Uint           Format = 128 // VK_FORMAT_D16_UNORM_S8_UINT
+	FormatD24UnormS8Uint           Format = 129 // VK_FORMAT_D24_UNORM_S8_UINT
+	FormatD32SfloatS8Uint          Format = 130 // VK_FORMAT_D32_SFLOAT_S8_UINT
+)
+
+// String returns the name of the VkFormat value.
+func (v Format) String() string {
+	switch v {
+	case FormatUndefined:
+		return "VK_FORMAT_UNDEFINED"
+	case FormatR4g4UnormPack8:
+		return "VK_FORMAT_R4G4_UNORM_PACK8"
+	case FormatR4g4b4a4UnormPack16:
+		return "VK_FORMAT_R4G4B4A4_UNORM_PACK16"
+	case FormatB4g4r4a4UnormPack16:
+		return "VK_FORMAT_B4G4R4A4_UNORM_PACK16"
+	case FormatR5g6b5UnormPack16:
+		return "VK_FORMAT_R5G6B5_UNORM_PACK16"
+	case FormatB5g6r5UnormPack16:
+		return "VK_FORMAT_B5G6R5_UNORM_PACK16"
+	case FormatR5g5b5a1UnormPack16:
+		return "VK_FORMAT_R5G5B5A1_UNORM_PACK16"
+	case FormatB5g5r5a1UnormPack16:
+		return "VK_FORMAT_B5G5R5A1_UNORM_PACK16"
+	case FormatA1r5g5b5UnormPack16:
+		return "VK_FORMAT_A1R5G5B5_UNORM_PACK16"
+	case FormatR8Unorm:
+		return "VK_FORMAT_R8_UNORM"
+	case FormatR8Snorm:
+		return "VK_FORMAT_R8_SNORM"
+	case FormatR8Uscaled:
+		return "VK_FORMAT_R8_USCALED"
+	case FormatR8Sscaled:
+		return "VK_FORMAT_R8_SSCALED"
+	case FormatR8Uint:
+		return "VK_FORMAT_R8_UINT"
+	case FormatR8Sint:
+		return "VK_FORMAT_R8_SINT"
+	case FormatR8SRGB:
+		return "VK_FORMAT_R8_SRGB"
+	case FormatR8g8Unorm:
+		return "VK_FORMAT_R8G8_UNORM"
+	case FormatR8g8Snorm:
+		return "VK_FORMAT_R8G8_SNORM"
+	case FormatR8g8Uscaled:
+		return "VK_FORMAT_R8G8_USCALED"
+	case FormatR8g8Sscaled:
+		return "VK_FORMAT_R8G8_SSCALED"
+	case FormatR8g8Uint:
+		return "VK_FORMAT_R8G8_UINT"
+	case FormatR8g8Sint:
+		return "VK_FORMAT_R8G8_SINT"
+	case FormatR8g8SRGB:
+		return "VK_FORMAT_R8G8_SRGB"
+	case FormatR8g8b8Unorm:
+		return "VK_FORMAT_R8G8B8_UNORM"
+	case FormatR8g8b8Snorm:
+		return "VK_FORMAT_R8G8B8_SNORM"
+	case FormatR8g8b8Uscaled:
+		return "VK_FORMAT_R8G8B8_USCALED"
+	case FormatR8g8b8Sscaled:
+		return "VK_FORMAT_R8G8B8_SSCALED"
+	case FormatR8g8b8Uint:
+		return "VK_FORMAT_R8G8B8_UINT"
+	case FormatR8g8b8Sint:
+		return "VK_FORMAT_R8G8B8_SINT"
+	case FormatR8g8b8SRGB:
+		return "VK_FORMAT_R8G8B8_SRGB"
+	case FormatB8g8r8Unorm:
+		return "VK_FORMAT_B8G8R8_UNORM"
+	case FormatB8g8r8Snorm:
+		return "VK_FORMAT_B8G8R8_SNORM"
+	case FormatB8g8r8Uscaled:
+		return "VK_FORMAT_B8G8R8_USCALED"
+	case FormatB8g8r8Sscaled:
+		return "VK_FORMAT_B8G8R8_SSCALED"
+	case FormatB8g8r8Uint:
+		return "VK_FORMAT_B8G8R8_UINT"
+	case FormatB8g8r8Sint:
+		return "VK_FORMAT_B8G8R8_SINT"
+	case FormatB8g8r8SRGB:
+		return "VK_FORMAT_B8G8R8_SRGB"
+	case FormatR8g8b8a8Unorm:
+		return "VK_FORMAT_R8G8B8A8_UNORM"
+	case FormatR8g8b8a8Snorm:
+		return "VK_FORMAT_R8G8B8A8_SNORM"
+	case FormatR8g8b8a8Uscaled:
+		return "VK_FORMAT_R8G8B8A8_USCALED"
+	case FormatR8g8b8a8Sscaled:
+		return "VK_FORMAT_R8G8B8A8_SSCALED"
+	case FormatR8g8b8a8Uint:
+		return "VK_FORMAT_R8G8B8A8_UINT"
+	case FormatR8g8b8a8Sint:
+		return "VK_FORMAT_R8G8B8A8_SINT"
+	case FormatR8g8b8a8SRGB:
+		return "VK_FORMAT_R8G8B8A8_SRGB"
+	case FormatB8g8r8a8Unorm:
+		return "VK_FORMAT_B8G8R8A8_UNORM"
+	case FormatB8g8r8a8Snorm:
+		return "VK_FORMAT_B8G8R8A8_SNORM"
+	case FormatB8g8r8a8Uscaled:
+		return "VK_FORMAT_B8G8R8A8_USCALED"
+	case FormatB8g8r8a8Sscaled:
+		return "VK_FORMAT_B8G8R8A8_SSCALED"
+	case FormatB8g8r8a8Uint:
+		return "VK_FORMAT_B8G8R8A8_UINT"
+	case FormatB8g8r8a8Sint:
+		return "VK_FORMAT_B8G8R8A8_SINT"
+	case FormatB8g8r8a8SRGB:
+		return "VK_FORMAT_B8G8R8A8_SRGB"
+	case FormatA8b8g8r8UnormPack32:
+		return "VK_FORMAT_A8B8G8R8_UNORM_PACK32"
+	case FormatA8b8g8r8SnormPack32:
+		return "VK_FORMAT_A8B8G8R8_SNORM_PACK32"
+	case FormatA8b8g8r8UscaledPack32:
+		return "VK_FORMAT_A8B8G8R8_USCALED_PACK32"
+	case FormatA8b8g8r8SscaledPack32:
+		return "VK_FORMAT_A8B8G8R8_SSCALED_PACK32"
+	case FormatA8b8g8r8UintPack32:
+		return "VK_FORMAT_A8B8G8R8_UINT_PACK32"
+	case FormatA8b8g8r8SintPack32:
+		return "VK_FORMAT_A8B8G8R8_SINT_PACK32"
+	case FormatA8b8g8r8SRGBPack32:
+		return "VK_FORMAT_A8B8G8R8_SRGB_PACK32"
+	case FormatA2r10g10b10UnormPack32:
+		return "VK_FORMAT_A2R10G10B10_UNORM_PACK32"
+	case FormatA2r10g10b10SnormPack32:
+		return "VK_FORMAT_A2R10G10B10_SNORM_PACK32"
+	case FormatA2r10g10b10UscaledPack32:
+		return "VK_FORMAT_A2R10G10B10_USCALED_PACK32"
+	case FormatA2r10g10b10SscaledPack32:
+		return "VK_FORMAT_A2R10G10B10_SSCALED_PACK32"
+	case FormatA2r10g10b10UintPack32:
+		return "VK_FORMAT_A2R10G10B10_UINT_PACK32"
+	case FormatA2r10g10b10SintPack32:
+		return "VK_FORMAT_A2R10G10B10_SINT_PACK32"
+	case FormatA2b10g10r10UnormPack32:
+		return "VK_FORMAT_A2B10G10R10_UNORM_PACK32"
+	case FormatA2b10g10r10SnormPack32:
+		return "VK_FORMAT_A2B10G10R10_SNORM_PACK32"
+	case FormatA2b10g10r10UscaledPack32:
+		return "VK_FORMAT_A2B10G10R10_USCALED_PACK32"
+	case FormatA2b10g10r10SscaledPack32:
+		return "VK_FORMAT_A2B10G10R10_SSCALED_PACK32"
+	case FormatA2b10g10r10UintPack32:
+		return "VK_FORMAT_A2B10G10R10_UINT_PACK32"
+	case FormatA2b10g10r10SintPack32:
+		return "VK_FORMAT_A2B10G10R10_SINT_PACK32"
+	case FormatR16Unorm:
+		return "VK_FORMAT_R16_UNORM"
+	case FormatR16Snorm:
+		return "VK_FORMAT_R16_SNORM"
+	case FormatR16Uscaled:
+		return "VK_FORMAT_R16_USCALED"
+	case FormatR16Sscaled:
+		return "VK_FORMAT_R16_SSCALED"
+	case FormatR16Uint:
+		return "VK_FORMAT_R16_UINT"
+	case FormatR16Sint:
+		return "VK_FORMAT_R16_SINT"
+	case FormatR16Sfloat:
+		return "VK_FORMAT_R16_SFLOAT"
+	case FormatR16g16Unorm:
+		return "VK_FORMAT_R16G16_UNORM"
+	case FormatR16g16Snorm:
+		return "VK_FORMAT_R16G16_SNORM"
+	case FormatR16g16Uscaled:
+		return "VK_FORMAT_R16G16_USCALED"
+	case FormatR16g16Sscaled:
+		return "VK_FORMAT_R16G16_SSCALED"
+	case FormatR16g16Uint:
+		return "VK_FORMAT_R16G16_UINT"
+	case FormatR16g16Sint:
+		return "VK_FORMAT_R16G16_SINT"
+	case FormatR16g16Sfloat:
+		return "VK_FORMAT_R16G16_SFLOAT"
+	case FormatR16g16b16Unorm:
+		return "VK_FORMAT_R16G16B16_UNORM"
+	case FormatR16g16b16Snorm:
+		return "VK_FORMAT_R16G16B16_SNORM"
+	case FormatR16g16b16Uscaled:
+		return "VK_FORMAT_R16G16B16_USCALED"
+	case FormatR16g16b16Sscaled:
+		return "VK_FORMAT_R16G16B16_SSCALED"
+	case FormatR16g16b16Uint:
+		return "VK_FORMAT_R16G16B16_UINT"
+	case FormatR16g16b16Sint:
+		return "VK_FORMAT_R16G16B16_SINT"
+	case FormatR16g16b16Sfloat:
+		return "VK_FORMAT_R16G16B16_SFLOAT"
+	case FormatR16g16b16a16Unorm:
+		return "VK_FORMAT_R16G16B16A16_UNORM"
+	case FormatR16g16b16a16Snorm:
+		return "VK_FORMAT_R16G16B16A16_SNORM"
+	case FormatR16g16b16a16Uscaled:
+		return "VK_FORMAT_R16G16B16A16_USCALED"
+	case FormatR16g16b16a16Sscaled:
+		return "VK_FORMAT_R16G16B16A16_SSCALED"
+	case FormatR16g16b16a16Uint:
+		return "VK_FORMAT_R16G16B16A16_UINT"
+	case FormatR16g16b16a16Sint:
+		return "VK_FORMAT_R16G16B16A16_SINT"
+	case FormatR16g16b16a16Sfloat:
+		return "VK_FORMAT_R16G16B16A16_SFLOAT"
+	case FormatR32Uint:
+		return "VK_FORMAT_R32_UINT"
+	case FormatR32Sint:
+		return "VK_FORMAT_R32_SINT"
+	case FormatR32Sfloat:
+		return "VK_FORMAT_R32_SFLOAT"
+	case FormatR32g32Uint:
+		return "VK_FORMAT_R32G32_UINT"
+	case FormatR32g32Sint:
+		return "VK_FORMAT_R32G32_SINT"
+	case FormatR32g32Sfloat:
+		return "VK_FORMAT_R32G32_SFLOAT"
+	case FormatR32g32b32Uint:
+		return "VK_FORMAT_R32G32B32_UINT"
+	case FormatR32g32b32Sint:
+		return "VK_FORMAT_R32G32B32_SINT"
+	case FormatR32g32b32Sfloat:
+		return "VK_FORMAT_R32G32B32_SFLOAT"
+	case FormatR32g32b32a32Uint:
+		return "VK_FORMAT_R32G32B32A32_UINT"
+	case FormatR32g32b32a32Sint:
+		return "VK_FORMAT_R32G32B32A32_SINT"
+	case FormatR32g32b32a32Sfloat:
+		return "VK_FORMAT_R32G32B32A32_SFLOAT"
+	case FormatR64Uint:
+		return "VK_FORMAT_R64_UINT"
+	case FormatR64Sint:
+		return "VK_FORMAT_R64_SINT"
+	case FormatR64Sfloat:
+		return "VK_FORMAT_R64_SFLOAT"
+	case FormatR64g64Uint:
+		return "VK_FORMAT_R64G64_UINT"
+	case FormatR64g64Sint:
+		return "VK_FORMAT_R64G64_SINT"
+	case FormatR64g64Sfloat:
+		return "VK_FORMAT_R64G64_SFLOAT"
+	case FormatR64g64b64Uint:
+		return "VK_FORMAT_R64G64B64_UINT"
+	case FormatR64g64b64Sint:
+		return "VK_FORMAT_R64G64B64_SINT"
+	case FormatR64g64b64Sfloat:
+		return "VK_FORMAT_R64G64B64_SFLOAT"
+	case FormatR64g64b64a64Uint:
+		return "VK_FORMAT_R64G64B64A64_UINT"
+	case FormatR64g64b64a64Sint:
+		return "VK_FORMAT_R64G64B64A64_SINT"
+	case FormatR64g64b64a64Sfloat:
+		return "VK_FORMAT_R64G64B64A64_SFLOAT"
+	case FormatB10g11r11UfloatPack32:
+		return "VK_FORMAT_B10G11R11_UFLOAT_PACK32"
+	case FormatE5b9g9r9UfloatPack32:
+		return "VK_FORMAT_E5B9G9R9_UFLOAT_PACK32"
+	case FormatD16Unorm:
+		return "VK_FORMAT_D16_UNORM"
+	case FormatX8D24UnormPack32:
+		return "VK_FORMAT_X8_D24_UNORM_PACK32"
+	case FormatD32Sfloat:
+		return "VK_FORMAT_D32_SFLOAT"
+	case FormatS8Uint:
+		return "VK_FORMAT_S8_UINT"
+	case FormatD16UnormS8Uint:
+		return "VK_FORMAT_D16_UNORM_S8_UINT"
+	case FormatD24UnormS8Uint:
+		return "VK_FORMAT_D24_UNORM_S8_UINT"
+	case FormatD32SfloatS8Uint:
+		return "VK_FORMAT_D32_SFLOAT_S8_UINT"
+	}
+	return fmt.Sprintf("VkFormat(%d)", int32(v))
+}
+
+// VertexInputRate is a Vulkan enum (VkVertexInputRate).
+type VertexInputRate int32
+
+// Values of VertexInputRate.
+const (
+	VertexInputRateVertex   VertexInputRate = 0 // VK_VERTEX_INPUT_RATE_VERTEX
+	VertexInputRateInstance VertexInputRate = 1 // VK_VERTEX_INPUT_RATE_INSTANCE
+)
+
+// String returns the name of the VkVertexInputRate value.
+func (v VertexInputRate) String() string {
+	switch v {
+	case VertexInputRateVertex:
+		return "VK_VERTEX_INPUT_RATE_VERTEX"
+	case VertexInputRateInstance:
+		return "VK_VERTEX_INPUT_RATE_INSTANCE"
+	}
+	return fmt.Sprintf("VkVertexInputRate(%d)", int32(v))
+}
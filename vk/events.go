@@ -6,9 +6,16 @@ import "C"
 
 import (
 	"time"
+
+	"github.com/pkg/errors"
 )
 
-func EventLoop(app *App) {
+// EventLoop renders frames until the window is closed. If ValidationErrorMode
+// is ValidationFail, the event loop stops at the first frame which triggers a
+// validation error, and returns it as a *ValidationError. If the device or the
+// window surface is lost, the event loop stops and returns the error (matching
+// ErrDeviceLost or ErrSurfaceLost).
+func EventLoop(app *App) error {
 	// Poll events.
 	dbg.Println("vk.EventLoop")
 	currentFrame := 0
@@ -16,19 +23,58 @@ func EventLoop(app *App) {
 	for C.glfwWindowShouldClose(app.win) == 0 {
 		currentFrame++
 		C.glfwPollEvents()
+		if app.onFrame != nil {
+			app.onFrame(app)
+		}
 
 		// Render frame.
 		if err := drawFrame(app); err != nil {
+			if errors.Is(err, ErrDeviceLost) || errors.Is(err, ErrSurfaceLost) {
+				// unrecoverable; stop rendering.
+				waitIdle(app)
+				return errors.WithStack(err)
+			}
 			warn.Printf("%+v", err) // print warning and continue
 		}
+		if err := checkValidationErrors(); err != nil {
+			waitIdle(app)
+			return errors.WithStack(err)
+		}
 		if time.Since(now) >= time.Second {
 			now = time.Now()
-			dbg.Println("fps:", currentFrame)
+			app.frameStats = FrameStats{
+				FPS:         currentFrame,
+				PresentMode: app.swapchainPresentMode,
+			}
+			dbg.Printf("fps: %d (present mode: %v)", app.frameStats.FPS, app.frameStats.PresentMode)
 			currentFrame = 0
 		}
 	}
+	waitIdle(app)
+	return nil
+}
+
+// FrameStats are statistics of the frames rendered to the window, updated once
+// per second by the event loop.
+type FrameStats struct {
+	// Number of frames rendered during the last second.
+	FPS int
+	// Present mode of the window swapchain at the end of the last second.
+	PresentMode PresentMode
+}
+
+// FrameStats returns the statistics of the frames rendered to the window during
+// the last second; the zero value until the event loop has run for a second.
+// Use PresentMode for the present mode of the current frame.
+func (app *App) FrameStats() FrameStats {
+	return app.frameStats
+}
+
+// waitIdle waits for the device to become idle, so that resources may be
+// released.
+func waitIdle(app *App) {
 	dbg.Println("waiting for device to become idle")
-	if result := C.vkDeviceWaitIdle(*app.device); result != C.VK_SUCCESS {
-		warn.Printf("unable to wait for device to become idle (result=%d)", result)
+	if result := app.deviceProcs.DeviceWaitIdle(*app.device); result != C.VK_SUCCESS {
+		warn.Printf("unable to wait for device to become idle: %v", Result(result))
 	}
 }
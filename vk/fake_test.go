@@ -0,0 +1,58 @@
+package vk
+
+// newFakePhysicalDevice returns the properties of a fake physical device, to
+// exercise the device selection and swapchain creation logic without a GPU.
+//
+// The fake device is a discrete GPU with a single queue family supporting
+// graphics, compute, transfer and present operations, and the required device
+// extensions. Its window surface has a current extent of 800x600 pixels, and
+// supports two to eight B8G8R8A8_SRGB images presented in FIFO or mailbox
+// mode. Framebuffers support up to 8 samples per pixel, and sample shading;
+// samplers support anisotropic filtering up to 16x. The device supports Vulkan
+// 1.3, and thus dynamic rendering and timeline semaphores.
+//
+// Callers may modify the returned properties to model other devices; e.g. an
+// unlimited number of images (maxImageCount of 0), separate graphics and
+// present queue families, no supported surface formats, or a surface whose
+// extent is determined by the swapchain (currentExtent of undefinedExtent).
+func newFakePhysicalDevice() physicalDeviceInfo {
+	extensions := make([]string, len(RequiredDeviceExtensions))
+	copy(extensions, RequiredDeviceExtensions)
+	return physicalDeviceInfo{
+		name:       "fake GPU",
+		deviceType: PhysicalDeviceTypeDiscreteGPU,
+		apiVersion: apiVersion1_3,
+		queueFamilies: []queueFamilyInfo{
+			{
+				flags:          QueueFlagGraphics | QueueFlagCompute | QueueFlagTransfer,
+				presentSupport: true,
+			},
+		},
+		extensions: extensions,
+		swapchainSupport: swapchainSupport{
+			capabilities: surfaceCapabilities{
+				minImageCount:    2,
+				maxImageCount:    8,
+				currentExtent:    extent2D{width: 800, height: 600},
+				minImageExtent:   extent2D{width: 1, height: 1},
+				maxImageExtent:   extent2D{width: 4096, height: 4096},
+				currentTransform: 0x00000001, // VK_SURFACE_TRANSFORM_IDENTITY_BIT_KHR
+				supportedUsage:   ImageUsageColorAttachment | ImageUsageTransferSrc | ImageUsageTransferDst,
+			},
+			formats: []surfaceFormat{
+				{format: 44, colorSpace: preferredSurfaceColorSpace}, // VK_FORMAT_B8G8R8A8_UNORM
+				{format: preferredSurfaceFormat, colorSpace: preferredSurfaceColorSpace},
+			},
+			presentModes: []PresentMode{
+				PresentModeFifo,
+				PresentModeMailbox,
+			},
+		},
+		framebufferSampleCounts: SampleCount1 | SampleCount2 | SampleCount4 | SampleCount8,
+		sampleRateShading:       true,
+		samplerAnisotropy:       true,
+		maxSamplerAnisotropy:    16,
+		dynamicRendering:        true,
+		timelineSemaphore:       true,
+	}
+}
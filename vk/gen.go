@@ -0,0 +1,6 @@
+package vk
+
+// Go bindings generated from the Vulkan API registry (vk.xml) of the installed
+// Vulkan headers; see vkgen.conf.
+
+//go:generate go run ../cmd/vkgen -q vkgen.conf
@@ -0,0 +1,176 @@
+package vk
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/mewmew/laki/golden"
+)
+
+// The scenes of laki are rendered offscreen and compared against reference
+// images (golden images) in the testdata directory of the repository, using
+// the lavapipe software rasterizer for reproducible output. To update the
+// reference images after an intended change to the rendered output, run:
+//
+//	go test ./vk -run TestGolden -update
+
+var update = flag.Bool("update", false, "update reference images of golden tests")
+
+// Resolution and seed of pseudo-random numbers of golden images.
+const (
+	goldenWidth  = 320
+	goldenHeight = 240
+	goldenSeed   = 1
+)
+
+// rootDir is the root directory of the repository, relative to the directory
+// of the vk package, containing the shaders and reference images of scenes.
+const rootDir = ".."
+
+// lavapipeICDs specifies the default paths of the lavapipe driver manifest.
+var lavapipeICDs = []string{
+	"/usr/share/vulkan/icd.d/lvp_icd.x86_64.json",
+	"/usr/share/vulkan/icd.d/lvp_icd.aarch64.json",
+	"/usr/share/vulkan/icd.d/lvp_icd.json",
+}
+
+var (
+	// loadOnce loads Vulkan once for all tests.
+	loadOnce sync.Once
+	// Error loading Vulkan; or nil if loaded.
+	loadErr error
+	// Vulkan driver selected by VK_DRIVER_FILES or VK_ICD_FILENAMES; either by
+	// the user, or set to lavapipe if present.
+	driverSelected bool
+)
+
+// requireVulkan loads Vulkan, or skips the test if Vulkan is not available.
+// Unless a Vulkan driver is selected by VK_DRIVER_FILES or VK_ICD_FILENAMES,
+// the lavapipe software rasterizer is used if installed.
+func requireVulkan(t *testing.T) {
+	t.Helper()
+	loadOnce.Do(func() {
+		driverSelected = len(os.Getenv("VK_DRIVER_FILES")) > 0 || len(os.Getenv("VK_ICD_FILENAMES")) > 0
+		if !driverSelected {
+			for _, icd := range lavapipeICDs {
+				if _, err := os.Stat(icd); err == nil {
+					os.Setenv("VK_DRIVER_FILES", icd)
+					os.Setenv("VK_ICD_FILENAMES", icd)
+					driverSelected = true
+					break
+				}
+			}
+		}
+		loadErr = LoadVulkan("")
+	})
+	if loadErr != nil {
+		t.Skipf("Vulkan not available: %v", loadErr)
+	}
+}
+
+// requireGolden prepares rendering of golden images, or skips the test if the
+// lavapipe software rasterizer (or an explicitly selected driver) or the
+// compiled shaders are not available.
+func requireGolden(t *testing.T) {
+	t.Helper()
+	requireVulkan(t)
+	if !driverSelected {
+		t.Skip("lavapipe not found; set VK_DRIVER_FILES to the lavapipe driver manifest (lvp_icd.*.json)")
+	}
+	requireScenes(t)
+}
+
+// requireScenes prepares rendering of scenes, or skips the test if the
+// compiled shaders are not available. The working directory is changed to the
+// root directory of the repository for the duration of the test, and
+// validation errors fail rendering.
+func requireScenes(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(rootDir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatal(err)
+		}
+	})
+	spvs, err := filepath.Glob("shaders/*.spv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spvs) == 0 {
+		t.Skip("shaders not compiled; run make")
+	}
+	mode := ValidationErrorMode
+	ValidationErrorMode = ValidationFail
+	t.Cleanup(func() {
+		ValidationErrorMode = mode
+	})
+}
+
+func TestGolden(t *testing.T) {
+	requireGolden(t)
+	for _, name := range SceneNames() {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			// Reference images are generated on lavapipe by -update; scenes
+			// without a reference image are skipped, rather than failed.
+			refPath := filepath.Join("testdata", name+".png")
+			if _, err := os.Stat(refPath); !*update && os.IsNotExist(err) {
+				t.Skipf("reference image %q not found; generate it with lavapipe by running: go test ./vk -run TestGolden -update", refPath)
+			}
+			img, err := RenderScene(name, goldenWidth, goldenHeight, goldenSeed)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if err := golden.Check("testdata", name, img, *update, golden.DefaultOptions); err != nil {
+				t.Errorf("%+v", err)
+			}
+		})
+	}
+}
+
+// TestBinarySemaphores renders the scenes with timeline semaphores and with the
+// fallback of binary semaphores and fences, and compares the rendered images;
+// the images must be equal, regardless of synchronization. Any Vulkan driver
+// may be used. On devices without timeline semaphores, both renderings use
+// binary semaphores.
+func TestBinarySemaphores(t *testing.T) {
+	requireVulkan(t)
+	requireScenes(t)
+	binarySemaphores := BinarySemaphores
+	defer func() {
+		BinarySemaphores = binarySemaphores
+	}()
+	for _, name := range SceneNames() {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			BinarySemaphores = false
+			timelineImg, err := RenderScene(name, goldenWidth, goldenHeight, goldenSeed)
+			if err != nil {
+				t.Fatalf("timeline semaphores: %+v", err)
+			}
+			BinarySemaphores = true
+			binaryImg, err := RenderScene(name, goldenWidth, goldenHeight, goldenSeed)
+			if err != nil {
+				t.Fatalf("binary semaphores: %+v", err)
+			}
+			// Exact match.
+			opts := golden.Options{Tolerance: 0, Budget: 0}
+			res, err := golden.Compare(binaryImg, timelineImg, opts)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if !res.OK(opts) {
+				t.Errorf("image mismatch between binary and timeline semaphores; %v", res)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// Device is a Vulkan device running compute kernels over Go slices.
+//
+// No window or surface is created for the device, and thus no display is
+// required (e.g. when running compute kernels with the lavapipe software
+// rasterizer in unit tests).
+type Device struct {
+	app *App
+}
+
+// NewDevice initializes a Vulkan device for running compute kernels. The
+// device should be closed when no longer in use.
+func NewDevice() (*Device, error) {
+	if !isVulkanLoaded() {
+		if err := LoadVulkan(""); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	app := newApp()
+	app.headless = true
+	if err := initVulkanDevice(app); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &Device{app: app}, nil
+}
+
+// Close destroys the device. Kernels of the device must be closed first.
+func (dev *Device) Close() {
+	app := dev.app
+	app.deviceProcs.DeviceWaitIdle(*app.device)
+	cleanupVulkanDevice(app)
+}
+
+// Kernel is a compute kernel of a device, accessing Go slices through storage
+// buffers.
+type Kernel struct {
+	// Device of the kernel.
+	dev *Device
+	// Compute pipeline of the kernel.
+	cp *computePipeline
+}
+
+// NewKernel creates a compute kernel with the given name from the given SPIR-V
+// module. The entry point "main" of the module accesses nbuffers storage
+// buffers at bindings [0, nbuffers) of descriptor set 0, and push constants of
+// the given size in bytes.
+func (dev *Device) NewKernel(name string, spirv []byte, nbuffers, pushConstantSize int) (*Kernel, error) {
+	descriptorTypes := make([]C.VkDescriptorType, nbuffers)
+	for i := range descriptorTypes {
+		descriptorTypes[i] = C.VK_DESCRIPTOR_TYPE_STORAGE_BUFFER
+	}
+	const nsets = 1
+	cp, err := createComputePipeline(dev.app, name, spirv, descriptorTypes, pushConstantSize, nsets)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &Kernel{dev: dev, cp: cp}, nil
+}
+
+// Close destroys the kernel.
+func (k *Kernel) Close() {
+	destroyComputePipeline(k.dev.app, k.cp)
+}
+
+// Run uploads the given slices to the storage buffers of the kernel, in
+// binding order, dispatches the given number of workgroups, and reads the
+// contents of the storage buffers back into the slices once the kernel has
+// completed.
+//
+// The buffers must be non-empty slices, and the push constants a pointer (or
+// nil if the kernel has no push constants), of plain-old-data types; i.e.
+// fixed-size numbers, and arrays and structs thereof. The memory layout of the
+// Go types must match the layout expected by the kernel (e.g. std430).
+func (k *Kernel) Run(groupCountX, groupCountY, groupCountZ int, pushConstants interface{}, buffers ...interface{}) (err error) {
+	app := k.dev.app
+	if len(buffers) != len(k.cp.descriptorTypes) {
+		return errors.Errorf("buffer count mismatch of kernel %q; expected %d, got %d", k.cp.name, len(k.cp.descriptorTypes), len(buffers))
+	}
+	// Locate the Go memory of the push constants and buffers.
+	var pushConstantBytes []byte
+	if pushConstants != nil {
+		pushConstantBytes, err = podBytes(pushConstants)
+		if err != nil {
+			return errors.Wrapf(err, "invalid push constants of kernel %q", k.cp.name)
+		}
+	}
+	if len(pushConstantBytes) != k.cp.pushConstantSize {
+		return errors.Errorf("push constant size mismatch of kernel %q; expected %d bytes, got %d bytes", k.cp.name, k.cp.pushConstantSize, len(pushConstantBytes))
+	}
+	datas := make([][]byte, len(buffers))
+	for i, buffer := range buffers {
+		data, err := podBytes(buffer)
+		if err != nil {
+			return errors.Wrapf(err, "invalid buffer %d of kernel %q", i, k.cp.name)
+		}
+		if len(data) == 0 {
+			return errors.Errorf("invalid buffer %d of kernel %q; expected non-empty slice", i, k.cp.name)
+		}
+		datas[i] = data
+	}
+
+	// Upload buffers to host visible storage buffers.
+	scratch := newArena()
+	defer scratch.free()
+	storageBuffers := make([]*C.VkBuffer, len(datas))
+	storageBufferMems := make([]*C.VkDeviceMemory, len(datas))
+	defer func() {
+		for i := range storageBuffers {
+			if storageBuffers[i] != nil {
+				destroyBuffer(app, storageBuffers[i], storageBufferMems[i])
+			}
+		}
+	}()
+	resources := make([]descriptorResource, len(datas))
+	usage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_STORAGE_BUFFER_BIT)
+	properties := C.VkMemoryPropertyFlags(C.VK_MEMORY_PROPERTY_HOST_VISIBLE_BIT | C.VK_MEMORY_PROPERTY_HOST_COHERENT_BIT)
+	for i, data := range datas {
+		buffer, bufferMem, err := createBuffer(app, k.cp.name+"StorageBuffer", C.VkDeviceSize(len(data)), usage, properties)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		storageBuffers[i] = buffer
+		storageBufferMems[i] = bufferMem
+		if err := copyMemory(app, bufferMem, data, true); err != nil {
+			return errors.WithStack(err)
+		}
+		resources[i] = descriptorResource{buffer: *buffer}
+	}
+	const set = 0
+	if err := k.cp.updateDescriptorSet(app, set, resources); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Dispatch kernel on compute queue.
+	commandBuffer, err := beginComputeCommands(app, scratch, k.cp.name+"CommandBuffer")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	k.cp.cmdDispatch(app, scratch, commandBuffer, set, pushConstantBytes, groupCountX, groupCountY, groupCountZ)
+	// Make shader writes visible to the host.
+	barriers := scratch.makeVkBufferMemoryBarrierSlice(len(storageBuffers))
+	for i, buffer := range storageBuffers {
+		barriers[i] = C.VkBufferMemoryBarrier{
+			sType:               C.VK_STRUCTURE_TYPE_BUFFER_MEMORY_BARRIER,
+			srcAccessMask:       C.VK_ACCESS_SHADER_WRITE_BIT,
+			dstAccessMask:       C.VK_ACCESS_HOST_READ_BIT,
+			srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			buffer:              *buffer,
+			offset:              0,
+			size:                C.VK_WHOLE_SIZE,
+		}
+	}
+	app.deviceProcs.CmdPipelineBarrier(commandBuffer, C.VK_PIPELINE_STAGE_COMPUTE_SHADER_BIT, C.VK_PIPELINE_STAGE_HOST_BIT, 0, 0, nil, C.uint(len(barriers)), &barriers[0], 0, nil)
+	if err := endComputeCommands(app, scratch, commandBuffer); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Read back buffers.
+	for i, data := range datas {
+		if err := copyMemory(app, storageBufferMems[i], data, false); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if err := checkValidationErrors(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// copyMemory copies data to the given host visible device memory if upload is
+// set, and from the device memory to data otherwise.
+func copyMemory(app *App, mem *C.VkDeviceMemory, data []byte, upload bool) error {
+	const offset = 0
+	var p unsafe.Pointer
+	size := C.VkDeviceSize(len(data))
+	if result := app.deviceProcs.MapMemory(*app.device, *mem, offset, size, 0, &p); result != C.VK_SUCCESS {
+		return errors.Wrapf(Result(result), "unable to map memory with size=%d", size)
+	}
+	if upload {
+		copy(unsafe.Slice((*byte)(p), size), data)
+	} else {
+		copy(data, unsafe.Slice((*byte)(p), size))
+	}
+	app.deviceProcs.UnmapMemory(*app.device, *mem)
+	return nil
+}
+
+// beginComputeCommands allocates a temporary command buffer of the compute
+// queue family with the given name, and begins recording commands to be
+// submitted once by endComputeCommands.
+func beginComputeCommands(app *App, scratch *arena, name string) (C.VkCommandBuffer, error) {
+	tmpCommandBuffers := scratch.makeVkCommandBufferSlice(1)
+	commandBufferAllocateInfo := C.VkCommandBufferAllocateInfo{
+		sType:              C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_ALLOCATE_INFO,
+		commandPool:        *app.computeCommandPool,
+		level:              C.VK_COMMAND_BUFFER_LEVEL_PRIMARY,
+		commandBufferCount: C.uint(len(tmpCommandBuffers)),
+	}
+	if result := app.deviceProcs.AllocateCommandBuffers(*app.device, &commandBufferAllocateInfo, &tmpCommandBuffers[0]); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to create compute command buffers")
+	}
+	trackObject(app, C.VK_OBJECT_TYPE_COMMAND_BUFFER, unsafe.Pointer(tmpCommandBuffers[0]), name)
+	commandBufferBeginInfo := C.VkCommandBufferBeginInfo{
+		sType: C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_BEGIN_INFO,
+		flags: C.VK_COMMAND_BUFFER_USAGE_ONE_TIME_SUBMIT_BIT,
+	}
+	if result := app.deviceProcs.BeginCommandBuffer(tmpCommandBuffers[0], &commandBufferBeginInfo); result != C.VK_SUCCESS {
+		freeComputeCommandBuffer(app, scratch, tmpCommandBuffers[0])
+		return nil, errors.Wrap(Result(result), "unable to begin recording command buffer")
+	}
+	return tmpCommandBuffers[0], nil
+}
+
+// endComputeCommands ends recording the given temporary command buffer,
+// submits it to the compute queue and waits for its completion, before freeing
+// the command buffer.
+func endComputeCommands(app *App, scratch *arena, commandBuffer C.VkCommandBuffer) error {
+	defer freeComputeCommandBuffer(app, scratch, commandBuffer)
+	if result := app.deviceProcs.EndCommandBuffer(commandBuffer); result != C.VK_SUCCESS {
+		return errors.Wrap(Result(result), "unable to record command buffer")
+	}
+	var signals []submitSemaphore
+	value := app.computeTimeline.next()
+	if app.timelineSemaphores {
+		signals = append(signals, app.computeTimeline.signal(value))
+	}
+	submitInfo := newSubmitInfo(app, scratch, []C.VkCommandBuffer{commandBuffer}, nil, signals)
+	submits := scratch.newVkSubmitInfoSlice(submitInfo)
+	if result := app.deviceProcs.QueueSubmit(*app.computeQueue, C.uint(len(submits)), &submits[0], nil); result != C.VK_SUCCESS {
+		return errors.Wrap(Result(result), "unable to submit command buffers to compute queue")
+	}
+	if err := app.computeTimeline.waitSubmitted(app, *app.computeQueue, value); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// freeComputeCommandBuffer frees the given command buffer of the compute
+// command pool.
+func freeComputeCommandBuffer(app *App, scratch *arena, commandBuffer C.VkCommandBuffer) {
+	untrackObject(C.VK_OBJECT_TYPE_COMMAND_BUFFER, unsafe.Pointer(commandBuffer))
+	tmpCommandBuffers := scratch.newVkCommandBufferSlice(commandBuffer)
+	app.deviceProcs.FreeCommandBuffers(*app.device, *app.computeCommandPool, C.uint(len(tmpCommandBuffers)), &tmpCommandBuffers[0])
+}
+
+// podBytes returns the Go memory of the given non-empty slice or non-nil
+// pointer of a plain-old-data type.
+func podBytes(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice:
+		if !isPOD(rv.Type().Elem()) {
+			return nil, errors.Errorf("invalid element type %v of slice; expected plain-old-data type", rv.Type().Elem())
+		}
+		size := uintptr(rv.Len()) * rv.Type().Elem().Size()
+		if size == 0 {
+			return nil, errors.Errorf("invalid empty slice of type %v", rv.Type())
+		}
+		return unsafe.Slice((*byte)(unsafe.Pointer(rv.Pointer())), size), nil
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil, errors.Errorf("invalid nil pointer of type %v", rv.Type())
+		}
+		if !isPOD(rv.Type().Elem()) {
+			return nil, errors.Errorf("invalid element type %v of pointer; expected plain-old-data type", rv.Type().Elem())
+		}
+		return unsafe.Slice((*byte)(unsafe.Pointer(rv.Pointer())), rv.Type().Elem().Size()), nil
+	default:
+		return nil, errors.Errorf("invalid type %T; expected slice or pointer", v)
+	}
+}
+
+// isPOD reports whether the given type is a plain-old-data type; i.e. a
+// fixed-size number, or an array or struct thereof.
+//
+// Types of platform-dependent size (int, uint, uintptr) and booleans (4 bytes
+// in shaders) are not plain-old-data types, as their memory layout differs
+// between Go and shaders.
+func isPOD(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Array:
+		return isPOD(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !isPOD(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,305 @@
+package vk
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestKernelRun(t *testing.T) {
+	requireVulkan(t)
+	mode := ValidationErrorMode
+	ValidationErrorMode = ValidationFail
+	defer func() {
+		ValidationErrorMode = mode
+	}()
+	dev, err := NewDevice()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer dev.Close()
+	kernel, err := dev.NewKernel("add", addKernel(), 2, 0)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer kernel.Close()
+	xs := make([]float32, addKernelLocalSize)
+	ys := make([]float32, addKernelLocalSize)
+	want := make([]float32, addKernelLocalSize)
+	for i := range xs {
+		xs[i] = float32(i)
+		ys[i] = 0.5 * float32(i*i)
+		want[i] = xs[i] + ys[i]
+	}
+	if err := kernel.Run(1, 1, 1, nil, xs, ys); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !reflect.DeepEqual(ys, want) {
+		t.Errorf("result mismatch; expected %v, got %v", want, ys)
+	}
+	// Buffer count mismatch.
+	if err := kernel.Run(1, 1, 1, nil, xs); err == nil {
+		t.Errorf("expected error on buffer count mismatch")
+	}
+	// Push constant size mismatch.
+	var a float32
+	if err := kernel.Run(1, 1, 1, &a, xs, ys); err == nil {
+		t.Errorf("expected error on push constant size mismatch")
+	}
+}
+
+func TestNewKernelInvalidSPIRV(t *testing.T) {
+	requireVulkan(t)
+	dev, err := NewDevice()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer dev.Close()
+	golden := []struct {
+		name  string
+		spirv []byte
+	}{
+		{name: "nil", spirv: nil},
+		{name: "empty", spirv: []byte{}},
+		{name: "truncated", spirv: addKernel()[:len(addKernel())-1]},
+	}
+	for _, g := range golden {
+		// The resources created before the shader module are released on
+		// error, rather than panicking.
+		kernel, err := dev.NewKernel(g.name, g.spirv, 2, 0)
+		if err == nil {
+			kernel.Close()
+			t.Errorf("%s: expected error on invalid SPIR-V code", g.name)
+		}
+	}
+}
+
+func TestPODBytes(t *testing.T) {
+	type vec4 struct {
+		X, Y, Z, W float32
+	}
+	type particle struct {
+		Pos   [2]vec4
+		Color [4]uint8
+		ID    uint32
+	}
+	var (
+		f32    float32
+		nilPtr *float32
+		i      int
+		b      bool
+		p      = &f32
+		part   = particle{ID: 1}
+	)
+	golden := []struct {
+		name     string
+		v        interface{}
+		wantSize int
+		wantErr  bool
+	}{
+		{name: "float32 slice", v: []float32{1, 2, 3}, wantSize: 12},
+		{name: "uint8 slice", v: []uint8{1, 2, 3}, wantSize: 3},
+		{name: "float32 pointer", v: &f32, wantSize: 4},
+		{name: "nested arrays", v: [][2][3]int16{{{1, 2, 3}, {4, 5, 6}}}, wantSize: 12},
+		{name: "nested structs", v: []particle{{}, {}}, wantSize: 2 * (32 + 4 + 4)},
+		{name: "struct pointer", v: &part, wantSize: 32 + 4 + 4},
+		{name: "int slice", v: []int{1}, wantErr: true},
+		{name: "uint pointer", v: new(uint), wantErr: true},
+		{name: "bool slice", v: []bool{true}, wantErr: true},
+		{name: "pointer slice", v: []*float32{p}, wantErr: true},
+		{name: "struct with int field", v: []struct{ A int }{{1}}, wantErr: true},
+		{name: "struct with bool field", v: &struct{ A bool }{true}, wantErr: true},
+		{name: "struct with pointer field", v: &struct{ P *float32 }{p}, wantErr: true},
+		{name: "array of ints", v: []([2]int){{1, 2}}, wantErr: true},
+		{name: "empty slice", v: []float32{}, wantErr: true},
+		{name: "nil slice", v: []float32(nil), wantErr: true},
+		{name: "nil pointer", v: nilPtr, wantErr: true},
+		{name: "non-pointer value", v: f32, wantErr: true},
+		{name: "int value", v: i, wantErr: true},
+		{name: "bool value", v: b, wantErr: true},
+		{name: "nil", v: nil, wantErr: true},
+	}
+	for _, g := range golden {
+		data, err := podBytes(g.v)
+		if g.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %d bytes", g.name, len(data))
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", g.name, err)
+			continue
+		}
+		if len(data) != g.wantSize {
+			t.Errorf("%s: size mismatch; expected %d bytes, got %d bytes", g.name, g.wantSize, len(data))
+		}
+	}
+	// The bytes alias the Go memory.
+	xs := []uint32{0x04030201}
+	data, err := podBytes(xs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data[0] = 0xFF
+	if xs[0] != 0x040302FF {
+		t.Errorf("Go memory not aliased; expected 0x040302FF, got 0x%08X", xs[0])
+	}
+}
+
+// addKernelLocalSize is the workgroup size of addKernel.
+const addKernelLocalSize = 64
+
+// addKernel returns the SPIR-V module of a compute kernel adding the elements
+// of the float32 storage buffer at binding 0 to those of the storage buffer at
+// binding 1, one element per invocation. The module is equivalent to:
+//
+//	#version 450
+//
+//	layout(local_size_x = 64) in;
+//
+//	layout(binding = 0) buffer X { float xs[]; };
+//	layout(binding = 1) buffer Y { float ys[]; };
+//
+//	void main() {
+//		uint i = gl_GlobalInvocationID.x;
+//		ys[i] += xs[i];
+//	}
+func addKernel() []byte {
+	// Result IDs.
+	const (
+		idMain = iota + 1
+		idGlobalInvocationID
+		idVoid
+		idMainType
+		idUint
+		idUvec3
+		idInputUvec3Ptr
+		idFloat
+		idFloatArray
+		idBuffer
+		idUniformBufferPtr
+		idXs
+		idYs
+		idInt
+		idInt0
+		idUniformFloatPtr
+		idInputUintPtr
+		idUint0
+		idLabel
+		idIndexPtr
+		idIndex
+		idXPtr
+		idX
+		idYPtr
+		idY
+		idSum
+		idBound
+	)
+	// Enumerants.
+	const (
+		capabilityShader          = 1
+		addressingModelLogical    = 0
+		memoryModelGLSL450        = 1
+		executionModelGLCompute   = 5
+		executionModeLocalSize    = 17
+		decorationBufferBlock     = 3
+		decorationArrayStride     = 6
+		decorationBuiltIn         = 11
+		decorationBinding         = 33
+		decorationDescriptorSet   = 34
+		decorationOffset          = 35
+		builtInGlobalInvocationID = 28
+		storageClassInput         = 1
+		storageClassUniform       = 2
+		functionControlNone       = 0
+	)
+	// Opcodes.
+	const (
+		opMemoryModel      = 14
+		opEntryPoint       = 15
+		opExecutionMode    = 16
+		opCapability       = 17
+		opTypeVoid         = 19
+		opTypeInt          = 21
+		opTypeFloat        = 22
+		opTypeVector       = 23
+		opTypeRuntimeArray = 29
+		opTypeStruct       = 30
+		opTypePointer      = 32
+		opTypeFunction     = 33
+		opConstant         = 43
+		opFunction         = 54
+		opFunctionEnd      = 56
+		opVariable         = 59
+		opLoad             = 61
+		opStore            = 62
+		opAccessChain      = 65
+		opDecorate         = 71
+		opMemberDecorate   = 72
+		opFAdd             = 129
+		opLabel            = 248
+		opReturn           = 253
+	)
+	words := []uint32{
+		0x07230203, // magic number
+		0x00010000, // version 1.0
+		0,          // generator
+		idBound,    // bound of result IDs
+		0,          // schema
+	}
+	op := func(opcode uint32, operands ...uint32) {
+		words = append(words, uint32(len(operands)+1)<<16|opcode)
+		words = append(words, operands...)
+	}
+	// "main" as a nul-terminated literal string.
+	main := []uint32{'m' | 'a'<<8 | 'i'<<16 | 'n'<<24, 0}
+
+	op(opCapability, capabilityShader)
+	op(opMemoryModel, addressingModelLogical, memoryModelGLSL450)
+	op(opEntryPoint, append(append([]uint32{executionModelGLCompute, idMain}, main...), idGlobalInvocationID)...)
+	op(opExecutionMode, idMain, executionModeLocalSize, addKernelLocalSize, 1, 1)
+	op(opDecorate, idGlobalInvocationID, decorationBuiltIn, builtInGlobalInvocationID)
+	op(opDecorate, idFloatArray, decorationArrayStride, 4)
+	op(opMemberDecorate, idBuffer, 0, decorationOffset, 0)
+	op(opDecorate, idBuffer, decorationBufferBlock)
+	op(opDecorate, idXs, decorationDescriptorSet, 0)
+	op(opDecorate, idXs, decorationBinding, 0)
+	op(opDecorate, idYs, decorationDescriptorSet, 0)
+	op(opDecorate, idYs, decorationBinding, 1)
+	op(opTypeVoid, idVoid)
+	op(opTypeFunction, idMainType, idVoid)
+	op(opTypeInt, idUint, 32, 0)
+	op(opTypeVector, idUvec3, idUint, 3)
+	op(opTypePointer, idInputUvec3Ptr, storageClassInput, idUvec3)
+	op(opVariable, idInputUvec3Ptr, idGlobalInvocationID, storageClassInput)
+	op(opTypeFloat, idFloat, 32)
+	op(opTypeRuntimeArray, idFloatArray, idFloat)
+	op(opTypeStruct, idBuffer, idFloatArray)
+	op(opTypePointer, idUniformBufferPtr, storageClassUniform, idBuffer)
+	op(opVariable, idUniformBufferPtr, idXs, storageClassUniform)
+	op(opVariable, idUniformBufferPtr, idYs, storageClassUniform)
+	op(opTypeInt, idInt, 32, 1)
+	op(opConstant, idInt, idInt0, 0)
+	op(opTypePointer, idUniformFloatPtr, storageClassUniform, idFloat)
+	op(opTypePointer, idInputUintPtr, storageClassInput, idUint)
+	op(opConstant, idUint, idUint0, 0)
+	op(opFunction, idVoid, idMain, functionControlNone, idMainType)
+	op(opLabel, idLabel)
+	op(opAccessChain, idInputUintPtr, idIndexPtr, idGlobalInvocationID, idUint0)
+	op(opLoad, idUint, idIndex, idIndexPtr)
+	op(opAccessChain, idUniformFloatPtr, idXPtr, idXs, idInt0, idIndex)
+	op(opLoad, idFloat, idX, idXPtr)
+	op(opAccessChain, idUniformFloatPtr, idYPtr, idYs, idInt0, idIndex)
+	op(opLoad, idFloat, idY, idYPtr)
+	op(opFAdd, idFloat, idSum, idY, idX)
+	op(opStore, idYPtr, idSum)
+	op(opReturn)
+	op(opFunctionEnd)
+
+	spirv := make([]byte, 4*len(words))
+	for i, word := range words {
+		binary.LittleEndian.PutUint32(spirv[4*i:], word)
+	}
+	return spirv
+}
@@ -0,0 +1,124 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// createImage creates a 2D image with the given name and a single mip level,
+// and binds it to memory with the given properties. The handles are allocated
+// in the given arena.
+func createImage(app *App, a *arena, name string, extent C.VkExtent2D, format C.VkFormat, samples C.VkSampleCountFlagBits, usage C.VkImageUsageFlags, properties C.VkMemoryPropertyFlags) (*C.VkImage, *C.VkDeviceMemory, error) {
+	return createSharedImage(app, a, name, extent, 1, format, samples, usage, properties, nil)
+}
+
+// createSharedImage creates a 2D image with the given name and number of mip
+// levels, accessed concurrently by the given queue families without transfer
+// of ownership. The image is exclusively owned by one queue family at a time if
+// less than two unique queue families are given.
+func createSharedImage(app *App, a *arena, name string, extent C.VkExtent2D, mipLevels int, format C.VkFormat, samples C.VkSampleCountFlagBits, usage C.VkImageUsageFlags, properties C.VkMemoryPropertyFlags, queueFamilyIndices []int) (*C.VkImage, *C.VkDeviceMemory, error) {
+	scratch := newArena()
+	defer scratch.free()
+	imageCreateInfo := C.VkImageCreateInfo{
+		sType:     C.VK_STRUCTURE_TYPE_IMAGE_CREATE_INFO,
+		imageType: C.VK_IMAGE_TYPE_2D,
+		format:    format,
+		extent: C.VkExtent3D{
+			width:  extent.width,
+			height: extent.height,
+			depth:  1,
+		},
+		mipLevels:     C.uint32_t(mipLevels),
+		arrayLayers:   1,
+		samples:       samples,
+		tiling:        C.VK_IMAGE_TILING_OPTIMAL,
+		usage:         usage,
+		sharingMode:   C.VK_SHARING_MODE_EXCLUSIVE,
+		initialLayout: C.VK_IMAGE_LAYOUT_UNDEFINED,
+	}
+	if indices := unique(queueFamilyIndices...); len(indices) > 1 {
+		cIndices := scratch.makeCUint32Slice(len(indices))
+		for i, index := range indices {
+			cIndices[i] = C.uint32_t(index)
+		}
+		imageCreateInfo.sharingMode = C.VK_SHARING_MODE_CONCURRENT
+		imageCreateInfo.queueFamilyIndexCount = C.uint(len(cIndices))
+		imageCreateInfo.pQueueFamilyIndices = &cIndices[0]
+	}
+	image := a.newVkImage(nil)
+	if result := app.deviceProcs.CreateImage(*app.device, &imageCreateInfo, nil, image); result != C.VK_SUCCESS {
+		return nil, nil, errors.Wrapf(Result(result), "unable to create image %q", name)
+	}
+	trackObject(app, C.VK_OBJECT_TYPE_IMAGE, unsafe.Pointer(*image), name)
+	// Allocate memory.
+	var memRequirements C.VkMemoryRequirements
+	app.deviceProcs.GetImageMemoryRequirements(*app.device, *image, &memRequirements)
+	memoryTypeIndex, err := findMemoryType(app, memRequirements.memoryTypeBits, properties)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	memAllocInfo := C.VkMemoryAllocateInfo{
+		sType:           C.VK_STRUCTURE_TYPE_MEMORY_ALLOCATE_INFO,
+		allocationSize:  memRequirements.size,
+		memoryTypeIndex: C.uint(memoryTypeIndex),
+	}
+	imageMem := a.newVkDeviceMemory(nil)
+	if result := app.deviceProcs.AllocateMemory(*app.device, &memAllocInfo, nil, imageMem); result != C.VK_SUCCESS {
+		return nil, nil, errors.Wrapf(Result(result), "unable to allocate memory of size=%d", memRequirements.size)
+	}
+	trackObject(app, C.VK_OBJECT_TYPE_DEVICE_MEMORY, unsafe.Pointer(*imageMem), name+"Mem")
+	const memoryOffset = 0
+	if result := app.deviceProcs.BindImageMemory(*app.device, *image, *imageMem, memoryOffset); result != C.VK_SUCCESS {
+		return nil, nil, errors.Wrapf(Result(result), "unable to bind memory of image %q", name)
+	}
+	return image, imageMem, nil
+}
+
+// createImageView creates a view with the given name of the color aspect of
+// the given 2D image, and its first mipLevels mip levels. The handle is
+// allocated in the given arena.
+func createImageView(app *App, a *arena, name string, image C.VkImage, format C.VkFormat, mipLevels int) (*C.VkImageView, error) {
+	createInfo := C.VkImageViewCreateInfo{
+		sType:    C.VK_STRUCTURE_TYPE_IMAGE_VIEW_CREATE_INFO,
+		image:    image,
+		viewType: C.VK_IMAGE_VIEW_TYPE_2D,
+		format:   format,
+		components: C.VkComponentMapping{
+			r: C.VK_COMPONENT_SWIZZLE_IDENTITY,
+			g: C.VK_COMPONENT_SWIZZLE_IDENTITY,
+			b: C.VK_COMPONENT_SWIZZLE_IDENTITY,
+			a: C.VK_COMPONENT_SWIZZLE_IDENTITY,
+		},
+		subresourceRange: C.VkImageSubresourceRange{
+			aspectMask:     C.VK_IMAGE_ASPECT_COLOR_BIT,
+			baseMipLevel:   0,
+			levelCount:     C.uint32_t(mipLevels),
+			baseArrayLayer: 0,
+			layerCount:     1,
+		},
+	}
+	imageView := a.newVkImageView(nil)
+	if result := app.deviceProcs.CreateImageView(*app.device, &createInfo, nil, imageView); result != C.VK_SUCCESS {
+		return nil, errors.Wrapf(Result(result), "unable to create view of image %q", name)
+	}
+	trackObject(app, C.VK_OBJECT_TYPE_IMAGE_VIEW, unsafe.Pointer(*imageView), name)
+	return imageView, nil
+}
+
+// destroyImage destroys the given image and frees its memory.
+func destroyImage(app *App, image *C.VkImage, imageMem *C.VkDeviceMemory) {
+	untrackObject(C.VK_OBJECT_TYPE_IMAGE, unsafe.Pointer(*image))
+	app.deviceProcs.DestroyImage(*app.device, *image, nil)
+	untrackObject(C.VK_OBJECT_TYPE_DEVICE_MEMORY, unsafe.Pointer(*imageMem))
+	app.deviceProcs.FreeMemory(*app.device, *imageMem, nil)
+}
+
+// destroyImageView destroys the given image view.
+func destroyImageView(app *App, imageView *C.VkImageView) {
+	untrackObject(C.VK_OBJECT_TYPE_IMAGE_VIEW, unsafe.Pointer(*imageView))
+	app.deviceProcs.DestroyImageView(*app.device, *imageView, nil)
+}
@@ -0,0 +1,116 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// Instance is the per-instance data of instanced rendering, read by the
+// instanced vertex shader from vertex buffer binding 1 once per instance.
+//
+// The transform of an instance is 2D; the vertices of the mesh are scaled,
+// rotated and translated in the xy plane of the node, before the world
+// transform of the node is applied.
+type Instance struct {
+	// Translation of the instance in the xy plane.
+	Offset Vec2
+	// Uniform scale of the instance.
+	Scale float32
+	// Rotation of the instance in radians, counter-clockwise about the z axis.
+	Angle float32
+	// Color multiplied with the vertex colors of the instance.
+	Color Vec3
+}
+
+// Path of the vertex shader of instanced rendering.
+const instancedVertexShader = "shaders/instanced_vert.spv"
+
+// instancedVertexLayout is the vertex input layout of instanced rendering; the
+// vertices of the mesh read from binding 0 per vertex, and the instances read
+// from binding 1 per instance.
+var instancedVertexLayout = mustVertexLayoutOf(
+	VertexStruct{Elem: Vertex{}, InputRate: VertexInputRateVertex},
+	VertexStruct{Elem: Instance{}, InputRate: VertexInputRateInstance},
+)
+
+// instanceBuffer is a host-visible buffer of per-instance data, persistently
+// mapped for updates every frame.
+type instanceBuffer struct {
+	buffer    *C.VkBuffer
+	bufferMem *C.VkDeviceMemory
+	// Mapped memory of the buffer.
+	data unsafe.Pointer
+	// Capacity of the buffer in number of instances.
+	cap int
+}
+
+// minInstanceBufferCap specifies the minimum capacity of instance buffers in
+// number of instances.
+const minInstanceBufferCap = 256
+
+// updateInstances writes the given per-instance data to the instance buffer of
+// the current frame in flight, and returns the buffer. The instance buffer is
+// grown as needed, releasing the previous buffer once no longer in use by the
+// GPU.
+//
+// The instance buffer of a frame in flight is only written once the fence of
+// the frame has signalled, and is thus not read by the GPU while updated.
+// Writes to the host-coherent memory are visible to the GPU once the command
+// buffers of the frame are submitted.
+func updateInstances(app *App, instances []Instance) (C.VkBuffer, error) {
+	ib := &app.instanceBuffers[app.curFrame]
+	if len(instances) > ib.cap {
+		newCap := minInstanceBufferCap
+		for newCap < len(instances) {
+			newCap *= 2
+		}
+		size := C.VkDeviceSize(newCap * int(unsafe.Sizeof(Instance{})))
+		usage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_VERTEX_BUFFER_BIT)
+		properties := C.VkMemoryPropertyFlags(C.VK_MEMORY_PROPERTY_HOST_VISIBLE_BIT | C.VK_MEMORY_PROPERTY_HOST_COHERENT_BIT)
+		buffer, bufferMem, err := createBuffer(app, "instanceBuffer", size, usage, properties)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		var data unsafe.Pointer
+		const offset = 0
+		if result := app.deviceProcs.MapMemory(*app.device, *bufferMem, offset, size, 0, &data); result != C.VK_SUCCESS {
+			destroyBuffer(app, buffer, bufferMem)
+			return nil, errors.Wrap(Result(result), "unable to map memory of instance buffer")
+		}
+		if ib.buffer != nil {
+			// The memory of the previous buffer is unmapped when freed.
+			deferDestroyBuffer(app, ib.buffer, ib.bufferMem)
+		}
+		*ib = instanceBuffer{
+			buffer:    buffer,
+			bufferMem: bufferMem,
+			data:      data,
+			cap:       newCap,
+		}
+	}
+	if len(instances) > 0 {
+		dst := unsafe.Slice((*Instance)(ib.data), ib.cap)
+		copy(dst, instances)
+	}
+	return *ib.buffer, nil
+}
+
+// destroyInstanceBuffers destroys the instance buffers of the frames in flight.
+func destroyInstanceBuffers(app *App) {
+	for i := range app.instanceBuffers {
+		if app.instanceBuffers[i].buffer != nil {
+			destroyInstanceBuffer(app, &app.instanceBuffers[i])
+		}
+		app.instanceBuffers[i] = instanceBuffer{}
+	}
+}
+
+// destroyInstanceBuffer unmaps and destroys the given instance buffer.
+func destroyInstanceBuffer(app *App, ib *instanceBuffer) {
+	app.deviceProcs.UnmapMemory(*app.device, *ib.bufferMem)
+	destroyBuffer(app, ib.buffer, ib.bufferMem)
+}
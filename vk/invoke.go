@@ -1,7 +1,822 @@
+// Code generated by vkgen. DO NOT EDIT.
+
+// Trampolines calling Vulkan function pointers.
+
 package vk
 
 // #include "invoke.h"
 //
+// PFN_vkVoidFunction invoke_GetInstanceProcAddr(
+// 	PFN_vkGetInstanceProcAddr fn,
+// 	VkInstance instance,
+// 	const char *pName) {
+// 	return fn(instance, pName);
+// }
+//
+// PFN_vkVoidFunction invoke_GetDeviceProcAddr(
+// 	PFN_vkGetDeviceProcAddr fn,
+// 	VkDevice device,
+// 	const char *pName) {
+// 	return fn(device, pName);
+// }
+//
+// VkResult invoke_CreateInstance(
+// 	PFN_vkCreateInstance fn,
+// 	const VkInstanceCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkInstance *pInstance) {
+// 	return fn(pCreateInfo, pAllocator, pInstance);
+// }
+//
+// VkResult invoke_EnumerateInstanceExtensionProperties(
+// 	PFN_vkEnumerateInstanceExtensionProperties fn,
+// 	const char *pLayerName,
+// 	uint32_t *pPropertyCount,
+// 	VkExtensionProperties *pProperties) {
+// 	return fn(pLayerName, pPropertyCount, pProperties);
+// }
+//
+// VkResult invoke_EnumerateInstanceLayerProperties(
+// 	PFN_vkEnumerateInstanceLayerProperties fn,
+// 	uint32_t *pPropertyCount,
+// 	VkLayerProperties *pProperties) {
+// 	return fn(pPropertyCount, pProperties);
+// }
+//
+// VkResult invoke_EnumerateInstanceVersion(
+// 	PFN_vkEnumerateInstanceVersion fn,
+// 	uint32_t *pApiVersion) {
+// 	return fn(pApiVersion);
+// }
+//
+// VkResult invoke_CreateDevice(
+// 	PFN_vkCreateDevice fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	const VkDeviceCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkDevice *pDevice) {
+// 	return fn(physicalDevice, pCreateInfo, pAllocator, pDevice);
+// }
+//
+// void invoke_DestroyInstance(
+// 	PFN_vkDestroyInstance fn,
+// 	VkInstance instance,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(instance, pAllocator);
+// }
+//
+// VkResult invoke_EnumerateDeviceExtensionProperties(
+// 	PFN_vkEnumerateDeviceExtensionProperties fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	const char *pLayerName,
+// 	uint32_t *pPropertyCount,
+// 	VkExtensionProperties *pProperties) {
+// 	return fn(physicalDevice, pLayerName, pPropertyCount, pProperties);
+// }
+//
+// VkResult invoke_EnumeratePhysicalDevices(
+// 	PFN_vkEnumeratePhysicalDevices fn,
+// 	VkInstance instance,
+// 	uint32_t *pPhysicalDeviceCount,
+// 	VkPhysicalDevice *pPhysicalDevices) {
+// 	return fn(instance, pPhysicalDeviceCount, pPhysicalDevices);
+// }
+//
+// void invoke_GetPhysicalDeviceFeatures(
+// 	PFN_vkGetPhysicalDeviceFeatures fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	VkPhysicalDeviceFeatures *pFeatures) {
+// 	fn(physicalDevice, pFeatures);
+// }
+//
+// void invoke_GetPhysicalDeviceFeatures2(
+// 	PFN_vkGetPhysicalDeviceFeatures2 fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	VkPhysicalDeviceFeatures2 *pFeatures) {
+// 	fn(physicalDevice, pFeatures);
+// }
+//
+// void invoke_GetPhysicalDeviceFormatProperties(
+// 	PFN_vkGetPhysicalDeviceFormatProperties fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	VkFormat format,
+// 	VkFormatProperties *pFormatProperties) {
+// 	fn(physicalDevice, format, pFormatProperties);
+// }
+//
+// void invoke_GetPhysicalDeviceMemoryProperties(
+// 	PFN_vkGetPhysicalDeviceMemoryProperties fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	VkPhysicalDeviceMemoryProperties *pMemoryProperties) {
+// 	fn(physicalDevice, pMemoryProperties);
+// }
+//
+// void invoke_GetPhysicalDeviceProperties(
+// 	PFN_vkGetPhysicalDeviceProperties fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	VkPhysicalDeviceProperties *pProperties) {
+// 	fn(physicalDevice, pProperties);
+// }
+//
+// void invoke_GetPhysicalDeviceQueueFamilyProperties(
+// 	PFN_vkGetPhysicalDeviceQueueFamilyProperties fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	uint32_t *pQueueFamilyPropertyCount,
+// 	VkQueueFamilyProperties *pQueueFamilyProperties) {
+// 	fn(physicalDevice, pQueueFamilyPropertyCount, pQueueFamilyProperties);
+// }
+//
+// VkResult invoke_AllocateCommandBuffers(
+// 	PFN_vkAllocateCommandBuffers fn,
+// 	VkDevice device,
+// 	const VkCommandBufferAllocateInfo *pAllocateInfo,
+// 	VkCommandBuffer *pCommandBuffers) {
+// 	return fn(device, pAllocateInfo, pCommandBuffers);
+// }
+//
+// VkResult invoke_AllocateDescriptorSets(
+// 	PFN_vkAllocateDescriptorSets fn,
+// 	VkDevice device,
+// 	const VkDescriptorSetAllocateInfo *pAllocateInfo,
+// 	VkDescriptorSet *pDescriptorSets) {
+// 	return fn(device, pAllocateInfo, pDescriptorSets);
+// }
+//
+// VkResult invoke_AllocateMemory(
+// 	PFN_vkAllocateMemory fn,
+// 	VkDevice device,
+// 	const VkMemoryAllocateInfo *pAllocateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkDeviceMemory *pMemory) {
+// 	return fn(device, pAllocateInfo, pAllocator, pMemory);
+// }
+//
+// VkResult invoke_BeginCommandBuffer(
+// 	PFN_vkBeginCommandBuffer fn,
+// 	VkCommandBuffer commandBuffer,
+// 	const VkCommandBufferBeginInfo *pBeginInfo) {
+// 	return fn(commandBuffer, pBeginInfo);
+// }
+//
+// VkResult invoke_BindBufferMemory(
+// 	PFN_vkBindBufferMemory fn,
+// 	VkDevice device,
+// 	VkBuffer buffer,
+// 	VkDeviceMemory memory,
+// 	VkDeviceSize memoryOffset) {
+// 	return fn(device, buffer, memory, memoryOffset);
+// }
+//
+// VkResult invoke_BindImageMemory(
+// 	PFN_vkBindImageMemory fn,
+// 	VkDevice device,
+// 	VkImage image,
+// 	VkDeviceMemory memory,
+// 	VkDeviceSize memoryOffset) {
+// 	return fn(device, image, memory, memoryOffset);
+// }
+//
+// void invoke_CmdBeginRenderPass(
+// 	PFN_vkCmdBeginRenderPass fn,
+// 	VkCommandBuffer commandBuffer,
+// 	const VkRenderPassBeginInfo *pRenderPassBegin,
+// 	VkSubpassContents contents) {
+// 	fn(commandBuffer, pRenderPassBegin, contents);
+// }
+//
+// void invoke_CmdBeginRendering(
+// 	PFN_vkCmdBeginRendering fn,
+// 	VkCommandBuffer commandBuffer,
+// 	const VkRenderingInfo *pRenderingInfo) {
+// 	fn(commandBuffer, pRenderingInfo);
+// }
+//
+// void invoke_CmdBindDescriptorSets(
+// 	PFN_vkCmdBindDescriptorSets fn,
+// 	VkCommandBuffer commandBuffer,
+// 	VkPipelineBindPoint pipelineBindPoint,
+// 	VkPipelineLayout layout,
+// 	uint32_t firstSet,
+// 	uint32_t descriptorSetCount,
+// 	const VkDescriptorSet *pDescriptorSets,
+// 	uint32_t dynamicOffsetCount,
+// 	const uint32_t *pDynamicOffsets) {
+// 	fn(commandBuffer, pipelineBindPoint, layout, firstSet, descriptorSetCount, pDescriptorSets, dynamicOffsetCount, pDynamicOffsets);
+// }
+//
+// void invoke_CmdBindIndexBuffer(
+// 	PFN_vkCmdBindIndexBuffer fn,
+// 	VkCommandBuffer commandBuffer,
+// 	VkBuffer buffer,
+// 	VkDeviceSize offset,
+// 	VkIndexType indexType) {
+// 	fn(commandBuffer, buffer, offset, indexType);
+// }
+//
+// void invoke_CmdBindPipeline(
+// 	PFN_vkCmdBindPipeline fn,
+// 	VkCommandBuffer commandBuffer,
+// 	VkPipelineBindPoint pipelineBindPoint,
+// 	VkPipeline pipeline) {
+// 	fn(commandBuffer, pipelineBindPoint, pipeline);
+// }
+//
+// void invoke_CmdBindVertexBuffers(
+// 	PFN_vkCmdBindVertexBuffers fn,
+// 	VkCommandBuffer commandBuffer,
+// 	uint32_t firstBinding,
+// 	uint32_t bindingCount,
+// 	const VkBuffer *pBuffers,
+// 	const VkDeviceSize *pOffsets) {
+// 	fn(commandBuffer, firstBinding, bindingCount, pBuffers, pOffsets);
+// }
+//
+// void invoke_CmdBlitImage(
+// 	PFN_vkCmdBlitImage fn,
+// 	VkCommandBuffer commandBuffer,
+// 	VkImage srcImage,
+// 	VkImageLayout srcImageLayout,
+// 	VkImage dstImage,
+// 	VkImageLayout dstImageLayout,
+// 	uint32_t regionCount,
+// 	const VkImageBlit *pRegions,
+// 	VkFilter filter) {
+// 	fn(commandBuffer, srcImage, srcImageLayout, dstImage, dstImageLayout, regionCount, pRegions, filter);
+// }
+//
+// void invoke_CmdCopyBuffer(
+// 	PFN_vkCmdCopyBuffer fn,
+// 	VkCommandBuffer commandBuffer,
+// 	VkBuffer srcBuffer,
+// 	VkBuffer dstBuffer,
+// 	uint32_t regionCount,
+// 	const VkBufferCopy *pRegions) {
+// 	fn(commandBuffer, srcBuffer, dstBuffer, regionCount, pRegions);
+// }
+//
+// void invoke_CmdCopyBufferToImage(
+// 	PFN_vkCmdCopyBufferToImage fn,
+// 	VkCommandBuffer commandBuffer,
+// 	VkBuffer srcBuffer,
+// 	VkImage dstImage,
+// 	VkImageLayout dstImageLayout,
+// 	uint32_t regionCount,
+// 	const VkBufferImageCopy *pRegions) {
+// 	fn(commandBuffer, srcBuffer, dstImage, dstImageLayout, regionCount, pRegions);
+// }
+//
+// void invoke_CmdCopyImageToBuffer(
+// 	PFN_vkCmdCopyImageToBuffer fn,
+// 	VkCommandBuffer commandBuffer,
+// 	VkImage srcImage,
+// 	VkImageLayout srcImageLayout,
+// 	VkBuffer dstBuffer,
+// 	uint32_t regionCount,
+// 	const VkBufferImageCopy *pRegions) {
+// 	fn(commandBuffer, srcImage, srcImageLayout, dstBuffer, regionCount, pRegions);
+// }
+//
+// void invoke_CmdDispatch(
+// 	PFN_vkCmdDispatch fn,
+// 	VkCommandBuffer commandBuffer,
+// 	uint32_t groupCountX,
+// 	uint32_t groupCountY,
+// 	uint32_t groupCountZ) {
+// 	fn(commandBuffer, groupCountX, groupCountY, groupCountZ);
+// }
+//
+// void invoke_CmdDrawIndexed(
+// 	PFN_vkCmdDrawIndexed fn,
+// 	VkCommandBuffer commandBuffer,
+// 	uint32_t indexCount,
+// 	uint32_t instanceCount,
+// 	uint32_t firstIndex,
+// 	int32_t vertexOffset,
+// 	uint32_t firstInstance) {
+// 	fn(commandBuffer, indexCount, instanceCount, firstIndex, vertexOffset, firstInstance);
+// }
+//
+// void invoke_CmdEndRenderPass(
+// 	PFN_vkCmdEndRenderPass fn,
+// 	VkCommandBuffer commandBuffer) {
+// 	fn(commandBuffer);
+// }
+//
+// void invoke_CmdEndRendering(
+// 	PFN_vkCmdEndRendering fn,
+// 	VkCommandBuffer commandBuffer) {
+// 	fn(commandBuffer);
+// }
+//
+// void invoke_CmdPipelineBarrier(
+// 	PFN_vkCmdPipelineBarrier fn,
+// 	VkCommandBuffer commandBuffer,
+// 	VkPipelineStageFlags srcStageMask,
+// 	VkPipelineStageFlags dstStageMask,
+// 	VkDependencyFlags dependencyFlags,
+// 	uint32_t memoryBarrierCount,
+// 	const VkMemoryBarrier *pMemoryBarriers,
+// 	uint32_t bufferMemoryBarrierCount,
+// 	const VkBufferMemoryBarrier *pBufferMemoryBarriers,
+// 	uint32_t imageMemoryBarrierCount,
+// 	const VkImageMemoryBarrier *pImageMemoryBarriers) {
+// 	fn(commandBuffer, srcStageMask, dstStageMask, dependencyFlags, memoryBarrierCount, pMemoryBarriers, bufferMemoryBarrierCount, pBufferMemoryBarriers, imageMemoryBarrierCount, pImageMemoryBarriers);
+// }
+//
+// void invoke_CmdPushConstants(
+// 	PFN_vkCmdPushConstants fn,
+// 	VkCommandBuffer commandBuffer,
+// 	VkPipelineLayout layout,
+// 	VkShaderStageFlags stageFlags,
+// 	uint32_t offset,
+// 	uint32_t size,
+// 	const void *pValues) {
+// 	fn(commandBuffer, layout, stageFlags, offset, size, pValues);
+// }
+//
+// VkResult invoke_CreateBuffer(
+// 	PFN_vkCreateBuffer fn,
+// 	VkDevice device,
+// 	const VkBufferCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkBuffer *pBuffer) {
+// 	return fn(device, pCreateInfo, pAllocator, pBuffer);
+// }
+//
+// VkResult invoke_CreateCommandPool(
+// 	PFN_vkCreateCommandPool fn,
+// 	VkDevice device,
+// 	const VkCommandPoolCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkCommandPool *pCommandPool) {
+// 	return fn(device, pCreateInfo, pAllocator, pCommandPool);
+// }
+//
+// VkResult invoke_CreateComputePipelines(
+// 	PFN_vkCreateComputePipelines fn,
+// 	VkDevice device,
+// 	VkPipelineCache pipelineCache,
+// 	uint32_t createInfoCount,
+// 	const VkComputePipelineCreateInfo *pCreateInfos,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkPipeline *pPipelines) {
+// 	return fn(device, pipelineCache, createInfoCount, pCreateInfos, pAllocator, pPipelines);
+// }
+//
+// VkResult invoke_CreateDescriptorPool(
+// 	PFN_vkCreateDescriptorPool fn,
+// 	VkDevice device,
+// 	const VkDescriptorPoolCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkDescriptorPool *pDescriptorPool) {
+// 	return fn(device, pCreateInfo, pAllocator, pDescriptorPool);
+// }
+//
+// VkResult invoke_CreateDescriptorSetLayout(
+// 	PFN_vkCreateDescriptorSetLayout fn,
+// 	VkDevice device,
+// 	const VkDescriptorSetLayoutCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkDescriptorSetLayout *pSetLayout) {
+// 	return fn(device, pCreateInfo, pAllocator, pSetLayout);
+// }
+//
+// VkResult invoke_CreateFence(
+// 	PFN_vkCreateFence fn,
+// 	VkDevice device,
+// 	const VkFenceCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkFence *pFence) {
+// 	return fn(device, pCreateInfo, pAllocator, pFence);
+// }
+//
+// VkResult invoke_CreateFramebuffer(
+// 	PFN_vkCreateFramebuffer fn,
+// 	VkDevice device,
+// 	const VkFramebufferCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkFramebuffer *pFramebuffer) {
+// 	return fn(device, pCreateInfo, pAllocator, pFramebuffer);
+// }
+//
+// VkResult invoke_CreateGraphicsPipelines(
+// 	PFN_vkCreateGraphicsPipelines fn,
+// 	VkDevice device,
+// 	VkPipelineCache pipelineCache,
+// 	uint32_t createInfoCount,
+// 	const VkGraphicsPipelineCreateInfo *pCreateInfos,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkPipeline *pPipelines) {
+// 	return fn(device, pipelineCache, createInfoCount, pCreateInfos, pAllocator, pPipelines);
+// }
+//
+// VkResult invoke_CreateImage(
+// 	PFN_vkCreateImage fn,
+// 	VkDevice device,
+// 	const VkImageCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkImage *pImage) {
+// 	return fn(device, pCreateInfo, pAllocator, pImage);
+// }
+//
+// VkResult invoke_CreateImageView(
+// 	PFN_vkCreateImageView fn,
+// 	VkDevice device,
+// 	const VkImageViewCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkImageView *pView) {
+// 	return fn(device, pCreateInfo, pAllocator, pView);
+// }
+//
+// VkResult invoke_CreatePipelineLayout(
+// 	PFN_vkCreatePipelineLayout fn,
+// 	VkDevice device,
+// 	const VkPipelineLayoutCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkPipelineLayout *pPipelineLayout) {
+// 	return fn(device, pCreateInfo, pAllocator, pPipelineLayout);
+// }
+//
+// VkResult invoke_CreateRenderPass(
+// 	PFN_vkCreateRenderPass fn,
+// 	VkDevice device,
+// 	const VkRenderPassCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkRenderPass *pRenderPass) {
+// 	return fn(device, pCreateInfo, pAllocator, pRenderPass);
+// }
+//
+// VkResult invoke_CreateSampler(
+// 	PFN_vkCreateSampler fn,
+// 	VkDevice device,
+// 	const VkSamplerCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkSampler *pSampler) {
+// 	return fn(device, pCreateInfo, pAllocator, pSampler);
+// }
+//
+// VkResult invoke_CreateSemaphore(
+// 	PFN_vkCreateSemaphore fn,
+// 	VkDevice device,
+// 	const VkSemaphoreCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkSemaphore *pSemaphore) {
+// 	return fn(device, pCreateInfo, pAllocator, pSemaphore);
+// }
+//
+// VkResult invoke_CreateShaderModule(
+// 	PFN_vkCreateShaderModule fn,
+// 	VkDevice device,
+// 	const VkShaderModuleCreateInfo *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkShaderModule *pShaderModule) {
+// 	return fn(device, pCreateInfo, pAllocator, pShaderModule);
+// }
+//
+// void invoke_DestroyBuffer(
+// 	PFN_vkDestroyBuffer fn,
+// 	VkDevice device,
+// 	VkBuffer buffer,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, buffer, pAllocator);
+// }
+//
+// void invoke_DestroyCommandPool(
+// 	PFN_vkDestroyCommandPool fn,
+// 	VkDevice device,
+// 	VkCommandPool commandPool,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, commandPool, pAllocator);
+// }
+//
+// void invoke_DestroyDescriptorPool(
+// 	PFN_vkDestroyDescriptorPool fn,
+// 	VkDevice device,
+// 	VkDescriptorPool descriptorPool,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, descriptorPool, pAllocator);
+// }
+//
+// void invoke_DestroyDescriptorSetLayout(
+// 	PFN_vkDestroyDescriptorSetLayout fn,
+// 	VkDevice device,
+// 	VkDescriptorSetLayout descriptorSetLayout,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, descriptorSetLayout, pAllocator);
+// }
+//
+// void invoke_DestroyDevice(
+// 	PFN_vkDestroyDevice fn,
+// 	VkDevice device,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, pAllocator);
+// }
+//
+// void invoke_DestroyFence(
+// 	PFN_vkDestroyFence fn,
+// 	VkDevice device,
+// 	VkFence fence,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, fence, pAllocator);
+// }
+//
+// void invoke_DestroyFramebuffer(
+// 	PFN_vkDestroyFramebuffer fn,
+// 	VkDevice device,
+// 	VkFramebuffer framebuffer,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, framebuffer, pAllocator);
+// }
+//
+// void invoke_DestroyImage(
+// 	PFN_vkDestroyImage fn,
+// 	VkDevice device,
+// 	VkImage image,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, image, pAllocator);
+// }
+//
+// void invoke_DestroyImageView(
+// 	PFN_vkDestroyImageView fn,
+// 	VkDevice device,
+// 	VkImageView imageView,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, imageView, pAllocator);
+// }
+//
+// void invoke_DestroyPipeline(
+// 	PFN_vkDestroyPipeline fn,
+// 	VkDevice device,
+// 	VkPipeline pipeline,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, pipeline, pAllocator);
+// }
+//
+// void invoke_DestroyPipelineLayout(
+// 	PFN_vkDestroyPipelineLayout fn,
+// 	VkDevice device,
+// 	VkPipelineLayout pipelineLayout,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, pipelineLayout, pAllocator);
+// }
+//
+// void invoke_DestroyRenderPass(
+// 	PFN_vkDestroyRenderPass fn,
+// 	VkDevice device,
+// 	VkRenderPass renderPass,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, renderPass, pAllocator);
+// }
+//
+// void invoke_DestroySampler(
+// 	PFN_vkDestroySampler fn,
+// 	VkDevice device,
+// 	VkSampler sampler,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, sampler, pAllocator);
+// }
+//
+// void invoke_DestroySemaphore(
+// 	PFN_vkDestroySemaphore fn,
+// 	VkDevice device,
+// 	VkSemaphore semaphore,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, semaphore, pAllocator);
+// }
+//
+// void invoke_DestroyShaderModule(
+// 	PFN_vkDestroyShaderModule fn,
+// 	VkDevice device,
+// 	VkShaderModule shaderModule,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, shaderModule, pAllocator);
+// }
+//
+// VkResult invoke_DeviceWaitIdle(
+// 	PFN_vkDeviceWaitIdle fn,
+// 	VkDevice device) {
+// 	return fn(device);
+// }
+//
+// VkResult invoke_EndCommandBuffer(
+// 	PFN_vkEndCommandBuffer fn,
+// 	VkCommandBuffer commandBuffer) {
+// 	return fn(commandBuffer);
+// }
+//
+// void invoke_FreeCommandBuffers(
+// 	PFN_vkFreeCommandBuffers fn,
+// 	VkDevice device,
+// 	VkCommandPool commandPool,
+// 	uint32_t commandBufferCount,
+// 	const VkCommandBuffer *pCommandBuffers) {
+// 	fn(device, commandPool, commandBufferCount, pCommandBuffers);
+// }
+//
+// void invoke_FreeMemory(
+// 	PFN_vkFreeMemory fn,
+// 	VkDevice device,
+// 	VkDeviceMemory memory,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, memory, pAllocator);
+// }
+//
+// void invoke_GetBufferMemoryRequirements(
+// 	PFN_vkGetBufferMemoryRequirements fn,
+// 	VkDevice device,
+// 	VkBuffer buffer,
+// 	VkMemoryRequirements *pMemoryRequirements) {
+// 	fn(device, buffer, pMemoryRequirements);
+// }
+//
+// void invoke_GetDeviceQueue(
+// 	PFN_vkGetDeviceQueue fn,
+// 	VkDevice device,
+// 	uint32_t queueFamilyIndex,
+// 	uint32_t queueIndex,
+// 	VkQueue *pQueue) {
+// 	fn(device, queueFamilyIndex, queueIndex, pQueue);
+// }
+//
+// VkResult invoke_GetFenceStatus(
+// 	PFN_vkGetFenceStatus fn,
+// 	VkDevice device,
+// 	VkFence fence) {
+// 	return fn(device, fence);
+// }
+//
+// void invoke_GetImageMemoryRequirements(
+// 	PFN_vkGetImageMemoryRequirements fn,
+// 	VkDevice device,
+// 	VkImage image,
+// 	VkMemoryRequirements *pMemoryRequirements) {
+// 	fn(device, image, pMemoryRequirements);
+// }
+//
+// VkResult invoke_GetSemaphoreCounterValue(
+// 	PFN_vkGetSemaphoreCounterValue fn,
+// 	VkDevice device,
+// 	VkSemaphore semaphore,
+// 	uint64_t *pValue) {
+// 	return fn(device, semaphore, pValue);
+// }
+//
+// VkResult invoke_MapMemory(
+// 	PFN_vkMapMemory fn,
+// 	VkDevice device,
+// 	VkDeviceMemory memory,
+// 	VkDeviceSize offset,
+// 	VkDeviceSize size,
+// 	VkMemoryMapFlags flags,
+// 	void * *ppData) {
+// 	return fn(device, memory, offset, size, flags, ppData);
+// }
+//
+// VkResult invoke_QueueSubmit(
+// 	PFN_vkQueueSubmit fn,
+// 	VkQueue queue,
+// 	uint32_t submitCount,
+// 	const VkSubmitInfo *pSubmits,
+// 	VkFence fence) {
+// 	return fn(queue, submitCount, pSubmits, fence);
+// }
+//
+// VkResult invoke_QueueWaitIdle(
+// 	PFN_vkQueueWaitIdle fn,
+// 	VkQueue queue) {
+// 	return fn(queue);
+// }
+//
+// VkResult invoke_ResetFences(
+// 	PFN_vkResetFences fn,
+// 	VkDevice device,
+// 	uint32_t fenceCount,
+// 	const VkFence *pFences) {
+// 	return fn(device, fenceCount, pFences);
+// }
+//
+// void invoke_UnmapMemory(
+// 	PFN_vkUnmapMemory fn,
+// 	VkDevice device,
+// 	VkDeviceMemory memory) {
+// 	fn(device, memory);
+// }
+//
+// void invoke_UpdateDescriptorSets(
+// 	PFN_vkUpdateDescriptorSets fn,
+// 	VkDevice device,
+// 	uint32_t descriptorWriteCount,
+// 	const VkWriteDescriptorSet *pDescriptorWrites,
+// 	uint32_t descriptorCopyCount,
+// 	const void *pDescriptorCopies) {
+// 	fn(device, descriptorWriteCount, pDescriptorWrites, descriptorCopyCount, pDescriptorCopies);
+// }
+//
+// VkResult invoke_WaitForFences(
+// 	PFN_vkWaitForFences fn,
+// 	VkDevice device,
+// 	uint32_t fenceCount,
+// 	const VkFence *pFences,
+// 	VkBool32 waitAll,
+// 	uint64_t timeout) {
+// 	return fn(device, fenceCount, pFences, waitAll, timeout);
+// }
+//
+// VkResult invoke_WaitSemaphores(
+// 	PFN_vkWaitSemaphores fn,
+// 	VkDevice device,
+// 	const VkSemaphoreWaitInfo *pWaitInfo,
+// 	uint64_t timeout) {
+// 	return fn(device, pWaitInfo, timeout);
+// }
+//
+// void invoke_DestroySurfaceKHR(
+// 	PFN_vkDestroySurfaceKHR fn,
+// 	VkInstance instance,
+// 	VkSurfaceKHR surface,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(instance, surface, pAllocator);
+// }
+//
+// VkResult invoke_GetPhysicalDeviceSurfaceCapabilitiesKHR(
+// 	PFN_vkGetPhysicalDeviceSurfaceCapabilitiesKHR fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	VkSurfaceKHR surface,
+// 	VkSurfaceCapabilitiesKHR *pSurfaceCapabilities) {
+// 	return fn(physicalDevice, surface, pSurfaceCapabilities);
+// }
+//
+// VkResult invoke_GetPhysicalDeviceSurfaceFormatsKHR(
+// 	PFN_vkGetPhysicalDeviceSurfaceFormatsKHR fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	VkSurfaceKHR surface,
+// 	uint32_t *pSurfaceFormatCount,
+// 	VkSurfaceFormatKHR *pSurfaceFormats) {
+// 	return fn(physicalDevice, surface, pSurfaceFormatCount, pSurfaceFormats);
+// }
+//
+// VkResult invoke_GetPhysicalDeviceSurfacePresentModesKHR(
+// 	PFN_vkGetPhysicalDeviceSurfacePresentModesKHR fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	VkSurfaceKHR surface,
+// 	uint32_t *pPresentModeCount,
+// 	VkPresentModeKHR *pPresentModes) {
+// 	return fn(physicalDevice, surface, pPresentModeCount, pPresentModes);
+// }
+//
+// VkResult invoke_GetPhysicalDeviceSurfaceSupportKHR(
+// 	PFN_vkGetPhysicalDeviceSurfaceSupportKHR fn,
+// 	VkPhysicalDevice physicalDevice,
+// 	uint32_t queueFamilyIndex,
+// 	VkSurfaceKHR surface,
+// 	VkBool32 *pSupported) {
+// 	return fn(physicalDevice, queueFamilyIndex, surface, pSupported);
+// }
+//
+// VkResult invoke_AcquireNextImageKHR(
+// 	PFN_vkAcquireNextImageKHR fn,
+// 	VkDevice device,
+// 	VkSwapchainKHR swapchain,
+// 	uint64_t timeout,
+// 	VkSemaphore semaphore,
+// 	VkFence fence,
+// 	uint32_t *pImageIndex) {
+// 	return fn(device, swapchain, timeout, semaphore, fence, pImageIndex);
+// }
+//
+// VkResult invoke_CreateSwapchainKHR(
+// 	PFN_vkCreateSwapchainKHR fn,
+// 	VkDevice device,
+// 	const VkSwapchainCreateInfoKHR *pCreateInfo,
+// 	const VkAllocationCallbacks *pAllocator,
+// 	VkSwapchainKHR *pSwapchain) {
+// 	return fn(device, pCreateInfo, pAllocator, pSwapchain);
+// }
+//
+// void invoke_DestroySwapchainKHR(
+// 	PFN_vkDestroySwapchainKHR fn,
+// 	VkDevice device,
+// 	VkSwapchainKHR swapchain,
+// 	const VkAllocationCallbacks *pAllocator) {
+// 	fn(device, swapchain, pAllocator);
+// }
+//
+// VkResult invoke_GetSwapchainImagesKHR(
+// 	PFN_vkGetSwapchainImagesKHR fn,
+// 	VkDevice device,
+// 	VkSwapchainKHR swapchain,
+// 	uint32_t *pSwapchainImageCount,
+// 	VkImage *pSwapchainImages) {
+// 	return fn(device, swapchain, pSwapchainImageCount, pSwapchainImages);
+// }
+//
+// VkResult invoke_QueuePresentKHR(
+// 	PFN_vkQueuePresentKHR fn,
+// 	VkQueue queue,
+// 	const VkPresentInfoKHR *pPresentInfo) {
+// 	return fn(queue, pPresentInfo);
+// }
+//
 // VkResult invoke_CreateDebugUtilsMessengerEXT(
 // 	PFN_vkCreateDebugUtilsMessengerEXT fn,
 // 	VkInstance instance,
@@ -18,4 +833,24 @@ package vk
 // 	const VkAllocationCallbacks *pAllocator) {
 // 	fn(instance, messenger, pAllocator);
 // }
+//
+// VkResult invoke_SetDebugUtilsObjectNameEXT(
+// 	PFN_vkSetDebugUtilsObjectNameEXT fn,
+// 	VkDevice device,
+// 	const VkDebugUtilsObjectNameInfoEXT *pNameInfo) {
+// 	return fn(device, pNameInfo);
+// }
+//
+// void invoke_CmdBeginDebugUtilsLabelEXT(
+// 	PFN_vkCmdBeginDebugUtilsLabelEXT fn,
+// 	VkCommandBuffer commandBuffer,
+// 	const VkDebugUtilsLabelEXT *pLabelInfo) {
+// 	fn(commandBuffer, pLabelInfo);
+// }
+//
+// void invoke_CmdEndDebugUtilsLabelEXT(
+// 	PFN_vkCmdEndDebugUtilsLabelEXT fn,
+// 	VkCommandBuffer commandBuffer) {
+// 	fn(commandBuffer);
+// }
 import "C"
@@ -1,8 +1,631 @@
+// Code generated by vkgen. DO NOT EDIT.
+
 #ifndef __INVOKE_H__
 #define __INVOKE_H__
 
 #include <vulkan/vulkan.h>
 
+extern PFN_vkVoidFunction invoke_GetInstanceProcAddr(
+	PFN_vkGetInstanceProcAddr fn,
+	VkInstance instance,
+	const char *pName);
+
+extern PFN_vkVoidFunction invoke_GetDeviceProcAddr(
+	PFN_vkGetDeviceProcAddr fn,
+	VkDevice device,
+	const char *pName);
+
+extern VkResult invoke_CreateInstance(
+	PFN_vkCreateInstance fn,
+	const VkInstanceCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkInstance *pInstance);
+
+extern VkResult invoke_EnumerateInstanceExtensionProperties(
+	PFN_vkEnumerateInstanceExtensionProperties fn,
+	const char *pLayerName,
+	uint32_t *pPropertyCount,
+	VkExtensionProperties *pProperties);
+
+extern VkResult invoke_EnumerateInstanceLayerProperties(
+	PFN_vkEnumerateInstanceLayerProperties fn,
+	uint32_t *pPropertyCount,
+	VkLayerProperties *pProperties);
+
+extern VkResult invoke_EnumerateInstanceVersion(
+	PFN_vkEnumerateInstanceVersion fn,
+	uint32_t *pApiVersion);
+
+extern VkResult invoke_CreateDevice(
+	PFN_vkCreateDevice fn,
+	VkPhysicalDevice physicalDevice,
+	const VkDeviceCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkDevice *pDevice);
+
+extern void invoke_DestroyInstance(
+	PFN_vkDestroyInstance fn,
+	VkInstance instance,
+	const VkAllocationCallbacks *pAllocator);
+
+extern VkResult invoke_EnumerateDeviceExtensionProperties(
+	PFN_vkEnumerateDeviceExtensionProperties fn,
+	VkPhysicalDevice physicalDevice,
+	const char *pLayerName,
+	uint32_t *pPropertyCount,
+	VkExtensionProperties *pProperties);
+
+extern VkResult invoke_EnumeratePhysicalDevices(
+	PFN_vkEnumeratePhysicalDevices fn,
+	VkInstance instance,
+	uint32_t *pPhysicalDeviceCount,
+	VkPhysicalDevice *pPhysicalDevices);
+
+extern void invoke_GetPhysicalDeviceFeatures(
+	PFN_vkGetPhysicalDeviceFeatures fn,
+	VkPhysicalDevice physicalDevice,
+	VkPhysicalDeviceFeatures *pFeatures);
+
+extern void invoke_GetPhysicalDeviceFeatures2(
+	PFN_vkGetPhysicalDeviceFeatures2 fn,
+	VkPhysicalDevice physicalDevice,
+	VkPhysicalDeviceFeatures2 *pFeatures);
+
+extern void invoke_GetPhysicalDeviceFormatProperties(
+	PFN_vkGetPhysicalDeviceFormatProperties fn,
+	VkPhysicalDevice physicalDevice,
+	VkFormat format,
+	VkFormatProperties *pFormatProperties);
+
+extern void invoke_GetPhysicalDeviceMemoryProperties(
+	PFN_vkGetPhysicalDeviceMemoryProperties fn,
+	VkPhysicalDevice physicalDevice,
+	VkPhysicalDeviceMemoryProperties *pMemoryProperties);
+
+extern void invoke_GetPhysicalDeviceProperties(
+	PFN_vkGetPhysicalDeviceProperties fn,
+	VkPhysicalDevice physicalDevice,
+	VkPhysicalDeviceProperties *pProperties);
+
+extern void invoke_GetPhysicalDeviceQueueFamilyProperties(
+	PFN_vkGetPhysicalDeviceQueueFamilyProperties fn,
+	VkPhysicalDevice physicalDevice,
+	uint32_t *pQueueFamilyPropertyCount,
+	VkQueueFamilyProperties *pQueueFamilyProperties);
+
+extern VkResult invoke_AllocateCommandBuffers(
+	PFN_vkAllocateCommandBuffers fn,
+	VkDevice device,
+	const VkCommandBufferAllocateInfo *pAllocateInfo,
+	VkCommandBuffer *pCommandBuffers);
+
+extern VkResult invoke_AllocateDescriptorSets(
+	PFN_vkAllocateDescriptorSets fn,
+	VkDevice device,
+	const VkDescriptorSetAllocateInfo *pAllocateInfo,
+	VkDescriptorSet *pDescriptorSets);
+
+extern VkResult invoke_AllocateMemory(
+	PFN_vkAllocateMemory fn,
+	VkDevice device,
+	const VkMemoryAllocateInfo *pAllocateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkDeviceMemory *pMemory);
+
+extern VkResult invoke_BeginCommandBuffer(
+	PFN_vkBeginCommandBuffer fn,
+	VkCommandBuffer commandBuffer,
+	const VkCommandBufferBeginInfo *pBeginInfo);
+
+extern VkResult invoke_BindBufferMemory(
+	PFN_vkBindBufferMemory fn,
+	VkDevice device,
+	VkBuffer buffer,
+	VkDeviceMemory memory,
+	VkDeviceSize memoryOffset);
+
+extern VkResult invoke_BindImageMemory(
+	PFN_vkBindImageMemory fn,
+	VkDevice device,
+	VkImage image,
+	VkDeviceMemory memory,
+	VkDeviceSize memoryOffset);
+
+extern void invoke_CmdBeginRenderPass(
+	PFN_vkCmdBeginRenderPass fn,
+	VkCommandBuffer commandBuffer,
+	const VkRenderPassBeginInfo *pRenderPassBegin,
+	VkSubpassContents contents);
+
+extern void invoke_CmdBeginRendering(
+	PFN_vkCmdBeginRendering fn,
+	VkCommandBuffer commandBuffer,
+	const VkRenderingInfo *pRenderingInfo);
+
+extern void invoke_CmdBindDescriptorSets(
+	PFN_vkCmdBindDescriptorSets fn,
+	VkCommandBuffer commandBuffer,
+	VkPipelineBindPoint pipelineBindPoint,
+	VkPipelineLayout layout,
+	uint32_t firstSet,
+	uint32_t descriptorSetCount,
+	const VkDescriptorSet *pDescriptorSets,
+	uint32_t dynamicOffsetCount,
+	const uint32_t *pDynamicOffsets);
+
+extern void invoke_CmdBindIndexBuffer(
+	PFN_vkCmdBindIndexBuffer fn,
+	VkCommandBuffer commandBuffer,
+	VkBuffer buffer,
+	VkDeviceSize offset,
+	VkIndexType indexType);
+
+extern void invoke_CmdBindPipeline(
+	PFN_vkCmdBindPipeline fn,
+	VkCommandBuffer commandBuffer,
+	VkPipelineBindPoint pipelineBindPoint,
+	VkPipeline pipeline);
+
+extern void invoke_CmdBindVertexBuffers(
+	PFN_vkCmdBindVertexBuffers fn,
+	VkCommandBuffer commandBuffer,
+	uint32_t firstBinding,
+	uint32_t bindingCount,
+	const VkBuffer *pBuffers,
+	const VkDeviceSize *pOffsets);
+
+extern void invoke_CmdBlitImage(
+	PFN_vkCmdBlitImage fn,
+	VkCommandBuffer commandBuffer,
+	VkImage srcImage,
+	VkImageLayout srcImageLayout,
+	VkImage dstImage,
+	VkImageLayout dstImageLayout,
+	uint32_t regionCount,
+	const VkImageBlit *pRegions,
+	VkFilter filter);
+
+extern void invoke_CmdCopyBuffer(
+	PFN_vkCmdCopyBuffer fn,
+	VkCommandBuffer commandBuffer,
+	VkBuffer srcBuffer,
+	VkBuffer dstBuffer,
+	uint32_t regionCount,
+	const VkBufferCopy *pRegions);
+
+extern void invoke_CmdCopyBufferToImage(
+	PFN_vkCmdCopyBufferToImage fn,
+	VkCommandBuffer commandBuffer,
+	VkBuffer srcBuffer,
+	VkImage dstImage,
+	VkImageLayout dstImageLayout,
+	uint32_t regionCount,
+	const VkBufferImageCopy *pRegions);
+
+extern void invoke_CmdCopyImageToBuffer(
+	PFN_vkCmdCopyImageToBuffer fn,
+	VkCommandBuffer commandBuffer,
+	VkImage srcImage,
+	VkImageLayout srcImageLayout,
+	VkBuffer dstBuffer,
+	uint32_t regionCount,
+	const VkBufferImageCopy *pRegions);
+
+extern void invoke_CmdDispatch(
+	PFN_vkCmdDispatch fn,
+	VkCommandBuffer commandBuffer,
+	uint32_t groupCountX,
+	uint32_t groupCountY,
+	uint32_t groupCountZ);
+
+extern void invoke_CmdDrawIndexed(
+	PFN_vkCmdDrawIndexed fn,
+	VkCommandBuffer commandBuffer,
+	uint32_t indexCount,
+	uint32_t instanceCount,
+	uint32_t firstIndex,
+	int32_t vertexOffset,
+	uint32_t firstInstance);
+
+extern void invoke_CmdEndRenderPass(
+	PFN_vkCmdEndRenderPass fn,
+	VkCommandBuffer commandBuffer);
+
+extern void invoke_CmdEndRendering(
+	PFN_vkCmdEndRendering fn,
+	VkCommandBuffer commandBuffer);
+
+extern void invoke_CmdPipelineBarrier(
+	PFN_vkCmdPipelineBarrier fn,
+	VkCommandBuffer commandBuffer,
+	VkPipelineStageFlags srcStageMask,
+	VkPipelineStageFlags dstStageMask,
+	VkDependencyFlags dependencyFlags,
+	uint32_t memoryBarrierCount,
+	const VkMemoryBarrier *pMemoryBarriers,
+	uint32_t bufferMemoryBarrierCount,
+	const VkBufferMemoryBarrier *pBufferMemoryBarriers,
+	uint32_t imageMemoryBarrierCount,
+	const VkImageMemoryBarrier *pImageMemoryBarriers);
+
+extern void invoke_CmdPushConstants(
+	PFN_vkCmdPushConstants fn,
+	VkCommandBuffer commandBuffer,
+	VkPipelineLayout layout,
+	VkShaderStageFlags stageFlags,
+	uint32_t offset,
+	uint32_t size,
+	const void *pValues);
+
+extern VkResult invoke_CreateBuffer(
+	PFN_vkCreateBuffer fn,
+	VkDevice device,
+	const VkBufferCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkBuffer *pBuffer);
+
+extern VkResult invoke_CreateCommandPool(
+	PFN_vkCreateCommandPool fn,
+	VkDevice device,
+	const VkCommandPoolCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkCommandPool *pCommandPool);
+
+extern VkResult invoke_CreateComputePipelines(
+	PFN_vkCreateComputePipelines fn,
+	VkDevice device,
+	VkPipelineCache pipelineCache,
+	uint32_t createInfoCount,
+	const VkComputePipelineCreateInfo *pCreateInfos,
+	const VkAllocationCallbacks *pAllocator,
+	VkPipeline *pPipelines);
+
+extern VkResult invoke_CreateDescriptorPool(
+	PFN_vkCreateDescriptorPool fn,
+	VkDevice device,
+	const VkDescriptorPoolCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkDescriptorPool *pDescriptorPool);
+
+extern VkResult invoke_CreateDescriptorSetLayout(
+	PFN_vkCreateDescriptorSetLayout fn,
+	VkDevice device,
+	const VkDescriptorSetLayoutCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkDescriptorSetLayout *pSetLayout);
+
+extern VkResult invoke_CreateFence(
+	PFN_vkCreateFence fn,
+	VkDevice device,
+	const VkFenceCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkFence *pFence);
+
+extern VkResult invoke_CreateFramebuffer(
+	PFN_vkCreateFramebuffer fn,
+	VkDevice device,
+	const VkFramebufferCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkFramebuffer *pFramebuffer);
+
+extern VkResult invoke_CreateGraphicsPipelines(
+	PFN_vkCreateGraphicsPipelines fn,
+	VkDevice device,
+	VkPipelineCache pipelineCache,
+	uint32_t createInfoCount,
+	const VkGraphicsPipelineCreateInfo *pCreateInfos,
+	const VkAllocationCallbacks *pAllocator,
+	VkPipeline *pPipelines);
+
+extern VkResult invoke_CreateImage(
+	PFN_vkCreateImage fn,
+	VkDevice device,
+	const VkImageCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkImage *pImage);
+
+extern VkResult invoke_CreateImageView(
+	PFN_vkCreateImageView fn,
+	VkDevice device,
+	const VkImageViewCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkImageView *pView);
+
+extern VkResult invoke_CreatePipelineLayout(
+	PFN_vkCreatePipelineLayout fn,
+	VkDevice device,
+	const VkPipelineLayoutCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkPipelineLayout *pPipelineLayout);
+
+extern VkResult invoke_CreateRenderPass(
+	PFN_vkCreateRenderPass fn,
+	VkDevice device,
+	const VkRenderPassCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkRenderPass *pRenderPass);
+
+extern VkResult invoke_CreateSampler(
+	PFN_vkCreateSampler fn,
+	VkDevice device,
+	const VkSamplerCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkSampler *pSampler);
+
+extern VkResult invoke_CreateSemaphore(
+	PFN_vkCreateSemaphore fn,
+	VkDevice device,
+	const VkSemaphoreCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkSemaphore *pSemaphore);
+
+extern VkResult invoke_CreateShaderModule(
+	PFN_vkCreateShaderModule fn,
+	VkDevice device,
+	const VkShaderModuleCreateInfo *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkShaderModule *pShaderModule);
+
+extern void invoke_DestroyBuffer(
+	PFN_vkDestroyBuffer fn,
+	VkDevice device,
+	VkBuffer buffer,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyCommandPool(
+	PFN_vkDestroyCommandPool fn,
+	VkDevice device,
+	VkCommandPool commandPool,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyDescriptorPool(
+	PFN_vkDestroyDescriptorPool fn,
+	VkDevice device,
+	VkDescriptorPool descriptorPool,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyDescriptorSetLayout(
+	PFN_vkDestroyDescriptorSetLayout fn,
+	VkDevice device,
+	VkDescriptorSetLayout descriptorSetLayout,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyDevice(
+	PFN_vkDestroyDevice fn,
+	VkDevice device,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyFence(
+	PFN_vkDestroyFence fn,
+	VkDevice device,
+	VkFence fence,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyFramebuffer(
+	PFN_vkDestroyFramebuffer fn,
+	VkDevice device,
+	VkFramebuffer framebuffer,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyImage(
+	PFN_vkDestroyImage fn,
+	VkDevice device,
+	VkImage image,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyImageView(
+	PFN_vkDestroyImageView fn,
+	VkDevice device,
+	VkImageView imageView,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyPipeline(
+	PFN_vkDestroyPipeline fn,
+	VkDevice device,
+	VkPipeline pipeline,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyPipelineLayout(
+	PFN_vkDestroyPipelineLayout fn,
+	VkDevice device,
+	VkPipelineLayout pipelineLayout,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyRenderPass(
+	PFN_vkDestroyRenderPass fn,
+	VkDevice device,
+	VkRenderPass renderPass,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroySampler(
+	PFN_vkDestroySampler fn,
+	VkDevice device,
+	VkSampler sampler,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroySemaphore(
+	PFN_vkDestroySemaphore fn,
+	VkDevice device,
+	VkSemaphore semaphore,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_DestroyShaderModule(
+	PFN_vkDestroyShaderModule fn,
+	VkDevice device,
+	VkShaderModule shaderModule,
+	const VkAllocationCallbacks *pAllocator);
+
+extern VkResult invoke_DeviceWaitIdle(
+	PFN_vkDeviceWaitIdle fn,
+	VkDevice device);
+
+extern VkResult invoke_EndCommandBuffer(
+	PFN_vkEndCommandBuffer fn,
+	VkCommandBuffer commandBuffer);
+
+extern void invoke_FreeCommandBuffers(
+	PFN_vkFreeCommandBuffers fn,
+	VkDevice device,
+	VkCommandPool commandPool,
+	uint32_t commandBufferCount,
+	const VkCommandBuffer *pCommandBuffers);
+
+extern void invoke_FreeMemory(
+	PFN_vkFreeMemory fn,
+	VkDevice device,
+	VkDeviceMemory memory,
+	const VkAllocationCallbacks *pAllocator);
+
+extern void invoke_GetBufferMemoryRequirements(
+	PFN_vkGetBufferMemoryRequirements fn,
+	VkDevice device,
+	VkBuffer buffer,
+	VkMemoryRequirements *pMemoryRequirements);
+
+extern void invoke_GetDeviceQueue(
+	PFN_vkGetDeviceQueue fn,
+	VkDevice device,
+	uint32_t queueFamilyIndex,
+	uint32_t queueIndex,
+	VkQueue *pQueue);
+
+extern VkResult invoke_GetFenceStatus(
+	PFN_vkGetFenceStatus fn,
+	VkDevice device,
+	VkFence fence);
+
+extern void invoke_GetImageMemoryRequirements(
+	PFN_vkGetImageMemoryRequirements fn,
+	VkDevice device,
+	VkImage image,
+	VkMemoryRequirements *pMemoryRequirements);
+
+extern VkResult invoke_GetSemaphoreCounterValue(
+	PFN_vkGetSemaphoreCounterValue fn,
+	VkDevice device,
+	VkSemaphore semaphore,
+	uint64_t *pValue);
+
+extern VkResult invoke_MapMemory(
+	PFN_vkMapMemory fn,
+	VkDevice device,
+	VkDeviceMemory memory,
+	VkDeviceSize offset,
+	VkDeviceSize size,
+	VkMemoryMapFlags flags,
+	void * *ppData);
+
+extern VkResult invoke_QueueSubmit(
+	PFN_vkQueueSubmit fn,
+	VkQueue queue,
+	uint32_t submitCount,
+	const VkSubmitInfo *pSubmits,
+	VkFence fence);
+
+extern VkResult invoke_QueueWaitIdle(
+	PFN_vkQueueWaitIdle fn,
+	VkQueue queue);
+
+extern VkResult invoke_ResetFences(
+	PFN_vkResetFences fn,
+	VkDevice device,
+	uint32_t fenceCount,
+	const VkFence *pFences);
+
+extern void invoke_UnmapMemory(
+	PFN_vkUnmapMemory fn,
+	VkDevice device,
+	VkDeviceMemory memory);
+
+extern void invoke_UpdateDescriptorSets(
+	PFN_vkUpdateDescriptorSets fn,
+	VkDevice device,
+	uint32_t descriptorWriteCount,
+	const VkWriteDescriptorSet *pDescriptorWrites,
+	uint32_t descriptorCopyCount,
+	const void *pDescriptorCopies);
+
+extern VkResult invoke_WaitForFences(
+	PFN_vkWaitForFences fn,
+	VkDevice device,
+	uint32_t fenceCount,
+	const VkFence *pFences,
+	VkBool32 waitAll,
+	uint64_t timeout);
+
+extern VkResult invoke_WaitSemaphores(
+	PFN_vkWaitSemaphores fn,
+	VkDevice device,
+	const VkSemaphoreWaitInfo *pWaitInfo,
+	uint64_t timeout);
+
+extern void invoke_DestroySurfaceKHR(
+	PFN_vkDestroySurfaceKHR fn,
+	VkInstance instance,
+	VkSurfaceKHR surface,
+	const VkAllocationCallbacks *pAllocator);
+
+extern VkResult invoke_GetPhysicalDeviceSurfaceCapabilitiesKHR(
+	PFN_vkGetPhysicalDeviceSurfaceCapabilitiesKHR fn,
+	VkPhysicalDevice physicalDevice,
+	VkSurfaceKHR surface,
+	VkSurfaceCapabilitiesKHR *pSurfaceCapabilities);
+
+extern VkResult invoke_GetPhysicalDeviceSurfaceFormatsKHR(
+	PFN_vkGetPhysicalDeviceSurfaceFormatsKHR fn,
+	VkPhysicalDevice physicalDevice,
+	VkSurfaceKHR surface,
+	uint32_t *pSurfaceFormatCount,
+	VkSurfaceFormatKHR *pSurfaceFormats);
+
+extern VkResult invoke_GetPhysicalDeviceSurfacePresentModesKHR(
+	PFN_vkGetPhysicalDeviceSurfacePresentModesKHR fn,
+	VkPhysicalDevice physicalDevice,
+	VkSurfaceKHR surface,
+	uint32_t *pPresentModeCount,
+	VkPresentModeKHR *pPresentModes);
+
+extern VkResult invoke_GetPhysicalDeviceSurfaceSupportKHR(
+	PFN_vkGetPhysicalDeviceSurfaceSupportKHR fn,
+	VkPhysicalDevice physicalDevice,
+	uint32_t queueFamilyIndex,
+	VkSurfaceKHR surface,
+	VkBool32 *pSupported);
+
+extern VkResult invoke_AcquireNextImageKHR(
+	PFN_vkAcquireNextImageKHR fn,
+	VkDevice device,
+	VkSwapchainKHR swapchain,
+	uint64_t timeout,
+	VkSemaphore semaphore,
+	VkFence fence,
+	uint32_t *pImageIndex);
+
+extern VkResult invoke_CreateSwapchainKHR(
+	PFN_vkCreateSwapchainKHR fn,
+	VkDevice device,
+	const VkSwapchainCreateInfoKHR *pCreateInfo,
+	const VkAllocationCallbacks *pAllocator,
+	VkSwapchainKHR *pSwapchain);
+
+extern void invoke_DestroySwapchainKHR(
+	PFN_vkDestroySwapchainKHR fn,
+	VkDevice device,
+	VkSwapchainKHR swapchain,
+	const VkAllocationCallbacks *pAllocator);
+
+extern VkResult invoke_GetSwapchainImagesKHR(
+	PFN_vkGetSwapchainImagesKHR fn,
+	VkDevice device,
+	VkSwapchainKHR swapchain,
+	uint32_t *pSwapchainImageCount,
+	VkImage *pSwapchainImages);
+
+extern VkResult invoke_QueuePresentKHR(
+	PFN_vkQueuePresentKHR fn,
+	VkQueue queue,
+	const VkPresentInfoKHR *pPresentInfo);
+
 extern VkResult invoke_CreateDebugUtilsMessengerEXT(
 	PFN_vkCreateDebugUtilsMessengerEXT fn,
 	VkInstance instance,
@@ -16,4 +639,18 @@ extern void invoke_DestroyDebugUtilsMessengerEXT(
 	VkDebugUtilsMessengerEXT messenger,
 	const VkAllocationCallbacks *pAllocator);
 
+extern VkResult invoke_SetDebugUtilsObjectNameEXT(
+	PFN_vkSetDebugUtilsObjectNameEXT fn,
+	VkDevice device,
+	const VkDebugUtilsObjectNameInfoEXT *pNameInfo);
+
+extern void invoke_CmdBeginDebugUtilsLabelEXT(
+	PFN_vkCmdBeginDebugUtilsLabelEXT fn,
+	VkCommandBuffer commandBuffer,
+	const VkDebugUtilsLabelEXT *pLabelInfo);
+
+extern void invoke_CmdEndDebugUtilsLabelEXT(
+	PFN_vkCmdEndDebugUtilsLabelEXT fn,
+	VkCommandBuffer commandBuffer);
+
 #endif // #ifndef __INVOKE_H__
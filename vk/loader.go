@@ -0,0 +1,122 @@
+package vk
+
+// #include <stdlib.h>
+// #include "invoke.h"
+import "C"
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// Vulkan commands are not linked statically, but loaded at runtime through
+// vkGetInstanceProcAddr (as done by volk); either from the Vulkan library (see
+// LoadVulkan) or from a user-supplied entry point (see LoadVulkanProcAddr).
+// Global commands are stored in vkGlobal, and instance-level and device-level
+// commands in the dispatch tables app.instanceProcs and app.deviceProcs.
+var (
+	// vkGetInstanceProcAddr is the entry point used to load Vulkan commands; or
+	// nil if Vulkan has not been loaded.
+	vkGetInstanceProcAddr C.PFN_vkGetInstanceProcAddr
+	// vkGlobal holds the global Vulkan commands (e.g. vkCreateInstance).
+	vkGlobal *globalProcs
+)
+
+// LoadVulkan loads the Vulkan library at the given path, or the Vulkan loader
+// of the system if path is empty (libvulkan.so.1 on Linux and vulkan-1.dll on
+// Windows). Of the default libraries, the first which opens and provides
+// vkGetInstanceProcAddr is used.
+//
+// The path may also refer to a Vulkan driver (ICD) such as lavapipe (e.g.
+// libvulkan_lvp.so), in which case the driver is used without the Vulkan
+// loader, and thus without validation layers.
+//
+// Init calls LoadVulkan with an empty path, unless Vulkan has already been
+// loaded.
+func LoadVulkan(path string) error {
+	paths := defaultVulkanLibraries
+	if len(path) > 0 {
+		paths = []string{path}
+	}
+	var errs []string
+	for _, path := range paths {
+		lib, err := openLibrary(path)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		// Vulkan drivers export vk_icdGetInstanceProcAddr, and may not export
+		// vkGetInstanceProcAddr.
+		fn := lookupSymbol(lib, "vkGetInstanceProcAddr")
+		if fn == nil {
+			fn = lookupSymbol(lib, "vk_icdGetInstanceProcAddr")
+		}
+		if fn == nil {
+			closeLibrary(lib)
+			errs = append(errs, fmt.Sprintf("unable to locate vkGetInstanceProcAddr in %q", path))
+			continue
+		}
+		if err := LoadVulkanProcAddr(fn); err != nil {
+			closeLibrary(lib)
+			errs = append(errs, fmt.Sprintf("unable to load %q: %v", path, err))
+			continue
+		}
+		return nil
+	}
+	return errors.Errorf("unable to load Vulkan library; ensure that a Vulkan driver and loader are installed\n\t%s", strings.Join(errs, "\n\t"))
+}
+
+// LoadVulkanProcAddr loads Vulkan commands through the given
+// vkGetInstanceProcAddr function, of C type PFN_vkGetInstanceProcAddr.
+//
+// NOTE: window surfaces are created by GLFW, which is only able to use the
+// given entry point as of GLFW 3.4.
+func LoadVulkanProcAddr(fn unsafe.Pointer) error {
+	if fn == nil {
+		return errors.New("invalid vkGetInstanceProcAddr; nil function pointer")
+	}
+	vkGetInstanceProcAddr = (C.PFN_vkGetInstanceProcAddr)(fn)
+	global := loadGlobalProcs(func(name string) C.PFN_vkVoidFunction {
+		return getInstanceProcAddr(nil, name)
+	})
+	if global.vkCreateInstance == nil {
+		vkGetInstanceProcAddr = nil
+		return errors.New("unable to load vkCreateInstance through vkGetInstanceProcAddr")
+	}
+	vkGlobal = global
+	return nil
+}
+
+// isVulkanLoaded reports whether Vulkan has been loaded.
+func isVulkanLoaded() bool {
+	return vkGlobal != nil
+}
+
+// newInstanceProcs returns the dispatch table of the given instance.
+func newInstanceProcs(instance C.VkInstance) *instanceProcs {
+	return loadInstanceProcs(func(name string) C.PFN_vkVoidFunction {
+		return getInstanceProcAddr(instance, name)
+	})
+}
+
+// newDeviceProcs returns the dispatch table of the given device, loaded
+// through vkGetDeviceProcAddr of the given instance dispatch table.
+func newDeviceProcs(instanceProcs *instanceProcs, device C.VkDevice) *deviceProcs {
+	return loadDeviceProcs(func(name string) C.PFN_vkVoidFunction {
+		cname := C.CString(name)
+		defer C.free(unsafe.Pointer(cname))
+		return instanceProcs.GetDeviceProcAddr(device, cname)
+	})
+}
+
+// getInstanceProcAddr returns the Vulkan command with the given name of the
+// instance, or nil if not present. Global commands are returned for a nil
+// instance.
+func getInstanceProcAddr(instance C.VkInstance, name string) C.PFN_vkVoidFunction {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return C.invoke_GetInstanceProcAddr(vkGetInstanceProcAddr, instance, cname)
+}
@@ -0,0 +1,47 @@
+//go:build !windows
+// +build !windows
+
+package vk
+
+// #cgo linux LDFLAGS: -ldl
+//
+// #include <dlfcn.h>
+// #include <stdlib.h>
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// defaultVulkanLibraries specifies the file names of the Vulkan loader, in
+// order of preference.
+var defaultVulkanLibraries = []string{
+	"libvulkan.so.1",
+	"libvulkan.so",
+}
+
+// openLibrary opens the shared library at the given path.
+func openLibrary(path string) (unsafe.Pointer, error) {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	lib := C.dlopen(cpath, C.RTLD_NOW|C.RTLD_LOCAL)
+	if lib == nil {
+		return nil, errors.Errorf("unable to open %q: %s", path, C.GoString(C.dlerror()))
+	}
+	return lib, nil
+}
+
+// closeLibrary closes the shared library.
+func closeLibrary(lib unsafe.Pointer) {
+	C.dlclose(lib)
+}
+
+// lookupSymbol returns the address of the symbol with the given name in the
+// shared library, or nil if not present.
+func lookupSymbol(lib unsafe.Pointer, name string) unsafe.Pointer {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return C.dlsym(lib, cname)
+}
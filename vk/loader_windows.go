@@ -0,0 +1,41 @@
+package vk
+
+// #include <windows.h>
+// #include <stdlib.h>
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// defaultVulkanLibraries specifies the file names of the Vulkan loader, in
+// order of preference.
+var defaultVulkanLibraries = []string{
+	"vulkan-1.dll",
+}
+
+// openLibrary opens the DLL at the given path.
+func openLibrary(path string) (unsafe.Pointer, error) {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	lib := C.LoadLibraryA(cpath)
+	if lib == nil {
+		return nil, errors.Errorf("unable to open %q: error code %d", path, uint32(C.GetLastError()))
+	}
+	return unsafe.Pointer(lib), nil
+}
+
+// closeLibrary closes the DLL.
+func closeLibrary(lib unsafe.Pointer) {
+	C.FreeLibrary(C.HMODULE(lib))
+}
+
+// lookupSymbol returns the address of the symbol with the given name in the
+// DLL, or nil if not present.
+func lookupSymbol(lib unsafe.Pointer, name string) unsafe.Pointer {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return unsafe.Pointer(C.GetProcAddress(C.HMODULE(lib), cname))
+}
@@ -1,152 +1,248 @@
+// Code generated by vkgen. DO NOT EDIT.
+
+// C values allocated in arenas.
+
 package vk
 
-// #include "malloc.h"
-//
-// #include <stdlib.h>
-//
-// VkInstance * new_VkInstance() {
-//    return calloc(1, sizeof(VkInstance));
-// }
-//
-// VkInstanceCreateInfo * new_VkInstanceCreateInfo() {
-//    return calloc(1, sizeof(VkInstanceCreateInfo));
-// }
-//
-// VkPhysicalDevice * new_VkPhysicalDevice() {
-//    return calloc(1, sizeof(VkPhysicalDevice));
-// }
-//
-// VkDebugUtilsMessengerCreateInfoEXT * new_VkDebugUtilsMessengerCreateInfoEXT() {
-//    return calloc(1, sizeof(VkDebugUtilsMessengerCreateInfoEXT));
-// }
-//
-// VkDebugUtilsMessengerEXT * new_VkDebugUtilsMessengerEXT() {
-//    return calloc(1, sizeof(VkDebugUtilsMessengerEXT));
-// }
-//
-// VkDevice * new_VkDevice() {
-//    return calloc(1, sizeof(VkDevice));
-// }
-//
-// VkPhysicalDeviceFeatures * new_VkPhysicalDeviceFeatures() {
-//    return calloc(1, sizeof(VkPhysicalDeviceFeatures));
-// }
-//
-// VkDeviceCreateInfo * new_VkDeviceCreateInfo() {
-//    return calloc(1, sizeof(VkDeviceCreateInfo));
-// }
-//
-// VkDeviceQueueCreateInfo * new_VkDeviceQueueCreateInfo() {
-//    return calloc(1, sizeof(VkDeviceQueueCreateInfo));
-// }
-//
-// VkQueue * new_VkQueue() {
-//    return calloc(1, sizeof(VkQueue));
-// }
-//
-// VkSurfaceKHR * new_VkSurfaceKHR() {
-//    return calloc(1, sizeof(VkSurfaceKHR));
-// }
-//
-// VkSwapchainKHR * new_VkSwapchainKHR() {
-//    return calloc(1, sizeof(VkSwapchainKHR));
-// }
-//
-// VkShaderModule * new_VkShaderModule() {
-//    return calloc(1, sizeof(VkShaderModule));
-// }
-//
-// VkPipelineLayout * new_VkPipelineLayout() {
-//    return calloc(1, sizeof(VkPipelineLayout));
-// }
-//
-// VkRenderPass * new_VkRenderPass() {
-//    return calloc(1, sizeof(VkRenderPass));
-// }
-//
-// VkCommandPool * new_VkCommandPool() {
-//    return calloc(1, sizeof(VkCommandPool));
-// }
-//
-// VkSemaphore * new_VkSemaphore() {
-//    return calloc(1, sizeof(VkSemaphore));
-// }
-//
-// VkFence * new_VkFence() {
-//    return calloc(1, sizeof(VkFence));
-// }
-//
-// VkBuffer * new_VkBuffer() {
-//    return calloc(1, sizeof(VkBuffer));
-// }
-//
-// VkDeviceMemory * new_VkDeviceMemory() {
-//    return calloc(1, sizeof(VkDeviceMemory));
-// }
-//
-//
-//
-// VkPipeline * new_VkPipelines(size_t n) {
-//    return calloc(n, sizeof(VkPipeline));
-// }
-//
-// VkAttachmentDescription * new_VkAttachmentDescriptions(size_t n) {
-//    return calloc(n, sizeof(VkAttachmentDescription));
-// }
-//
-// VkAttachmentReference * new_VkAttachmentReferences(size_t n) {
-//    return calloc(n, sizeof(VkAttachmentReference));
-// }
-//
-// VkSubpassDescription * new_VkSubpassDescriptions(size_t n) {
-//    return calloc(n, sizeof(VkSubpassDescription));
-// }
-//
-// VkViewport * new_VkViewports(size_t n) {
-//    return calloc(n, sizeof(VkViewport));
-// }
-//
-// VkRect2D * new_VkRect2Ds(size_t n) {
-//    return calloc(n, sizeof(VkRect2D));
-// }
-//
-// VkPipelineColorBlendAttachmentState * new_VkPipelineColorBlendAttachmentStates(size_t n) {
-//    return calloc(n, sizeof(VkPipelineColorBlendAttachmentState));
-// }
-//
-// VkGraphicsPipelineCreateInfo * new_VkGraphicsPipelineCreateInfos(size_t n) {
-//    return calloc(n, sizeof(VkGraphicsPipelineCreateInfo));
-// }
-//
-// VkFramebuffer * new_VkFramebuffers(size_t n) {
-//    return calloc(n, sizeof(VkFramebuffer));
-// }
-//
-// VkImageView * new_VkImageViews(size_t n) {
-//    return calloc(n, sizeof(VkImageView));
-// }
-//
-// VkCommandBuffer * new_VkCommandBuffers(size_t n) {
-//    return calloc(n, sizeof(VkCommandBuffer));
-// }
-//
-// VkClearValue * new_VkClearValues(size_t n) {
-//    return calloc(n, sizeof(VkClearValue));
-// }
-//
-// VkSemaphore * new_VkSemaphores(size_t n) {
-//    return calloc(n, sizeof(VkSemaphore));
-// }
-//
-// VkSubmitInfo * new_VkSubmitInfos(size_t n) {
-//    return calloc(n, sizeof(VkSubmitInfo));
-// }
-//
-// VkSubpassDependency * new_VkSubpassDependencys(size_t n) {
-//    return calloc(n, sizeof(VkSubpassDependency));
-// }
-//
-// VkSwapchainKHR * new_VkSwapchainKHRs(size_t n) {
-//    return calloc(n, sizeof(VkSwapchainKHR));
-// }
+// #include <vulkan/vulkan.h>
 import "C"
+
+func (a *arena) newVkInstance(v C.VkInstance) *C.VkInstance {
+	p := (*C.VkInstance)(a.alloc(C.sizeof_VkInstance))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPhysicalDevice(v C.VkPhysicalDevice) *C.VkPhysicalDevice {
+	p := (*C.VkPhysicalDevice)(a.alloc(C.sizeof_VkPhysicalDevice))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkDebugUtilsMessengerEXT(v C.VkDebugUtilsMessengerEXT) *C.VkDebugUtilsMessengerEXT {
+	p := (*C.VkDebugUtilsMessengerEXT)(a.alloc(C.sizeof_VkDebugUtilsMessengerEXT))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkDevice(v C.VkDevice) *C.VkDevice {
+	p := (*C.VkDevice)(a.alloc(C.sizeof_VkDevice))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkQueue(v C.VkQueue) *C.VkQueue {
+	p := (*C.VkQueue)(a.alloc(C.sizeof_VkQueue))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkSurfaceKHR(v C.VkSurfaceKHR) *C.VkSurfaceKHR {
+	p := (*C.VkSurfaceKHR)(a.alloc(C.sizeof_VkSurfaceKHR))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkSwapchainKHR(v C.VkSwapchainKHR) *C.VkSwapchainKHR {
+	p := (*C.VkSwapchainKHR)(a.alloc(C.sizeof_VkSwapchainKHR))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkShaderModule(v C.VkShaderModule) *C.VkShaderModule {
+	p := (*C.VkShaderModule)(a.alloc(C.sizeof_VkShaderModule))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPipelineLayout(v C.VkPipelineLayout) *C.VkPipelineLayout {
+	p := (*C.VkPipelineLayout)(a.alloc(C.sizeof_VkPipelineLayout))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkRenderPass(v C.VkRenderPass) *C.VkRenderPass {
+	p := (*C.VkRenderPass)(a.alloc(C.sizeof_VkRenderPass))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkCommandPool(v C.VkCommandPool) *C.VkCommandPool {
+	p := (*C.VkCommandPool)(a.alloc(C.sizeof_VkCommandPool))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkSemaphore(v C.VkSemaphore) *C.VkSemaphore {
+	p := (*C.VkSemaphore)(a.alloc(C.sizeof_VkSemaphore))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkFence(v C.VkFence) *C.VkFence {
+	p := (*C.VkFence)(a.alloc(C.sizeof_VkFence))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkBuffer(v C.VkBuffer) *C.VkBuffer {
+	p := (*C.VkBuffer)(a.alloc(C.sizeof_VkBuffer))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkDeviceMemory(v C.VkDeviceMemory) *C.VkDeviceMemory {
+	p := (*C.VkDeviceMemory)(a.alloc(C.sizeof_VkDeviceMemory))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkImage(v C.VkImage) *C.VkImage {
+	p := (*C.VkImage)(a.alloc(C.sizeof_VkImage))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkImageView(v C.VkImageView) *C.VkImageView {
+	p := (*C.VkImageView)(a.alloc(C.sizeof_VkImageView))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkSampler(v C.VkSampler) *C.VkSampler {
+	p := (*C.VkSampler)(a.alloc(C.sizeof_VkSampler))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkDescriptorSetLayout(v C.VkDescriptorSetLayout) *C.VkDescriptorSetLayout {
+	p := (*C.VkDescriptorSetLayout)(a.alloc(C.sizeof_VkDescriptorSetLayout))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkDescriptorPool(v C.VkDescriptorPool) *C.VkDescriptorPool {
+	p := (*C.VkDescriptorPool)(a.alloc(C.sizeof_VkDescriptorPool))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkApplicationInfo(v C.VkApplicationInfo) *C.VkApplicationInfo {
+	p := (*C.VkApplicationInfo)(a.alloc(C.sizeof_VkApplicationInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkInstanceCreateInfo(v C.VkInstanceCreateInfo) *C.VkInstanceCreateInfo {
+	p := (*C.VkInstanceCreateInfo)(a.alloc(C.sizeof_VkInstanceCreateInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkDebugUtilsMessengerCreateInfoEXT(v C.VkDebugUtilsMessengerCreateInfoEXT) *C.VkDebugUtilsMessengerCreateInfoEXT {
+	p := (*C.VkDebugUtilsMessengerCreateInfoEXT)(a.alloc(C.sizeof_VkDebugUtilsMessengerCreateInfoEXT))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPhysicalDeviceFeatures(v C.VkPhysicalDeviceFeatures) *C.VkPhysicalDeviceFeatures {
+	p := (*C.VkPhysicalDeviceFeatures)(a.alloc(C.sizeof_VkPhysicalDeviceFeatures))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkDeviceCreateInfo(v C.VkDeviceCreateInfo) *C.VkDeviceCreateInfo {
+	p := (*C.VkDeviceCreateInfo)(a.alloc(C.sizeof_VkDeviceCreateInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPipelineVertexInputStateCreateInfo(v C.VkPipelineVertexInputStateCreateInfo) *C.VkPipelineVertexInputStateCreateInfo {
+	p := (*C.VkPipelineVertexInputStateCreateInfo)(a.alloc(C.sizeof_VkPipelineVertexInputStateCreateInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPipelineInputAssemblyStateCreateInfo(v C.VkPipelineInputAssemblyStateCreateInfo) *C.VkPipelineInputAssemblyStateCreateInfo {
+	p := (*C.VkPipelineInputAssemblyStateCreateInfo)(a.alloc(C.sizeof_VkPipelineInputAssemblyStateCreateInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPipelineViewportStateCreateInfo(v C.VkPipelineViewportStateCreateInfo) *C.VkPipelineViewportStateCreateInfo {
+	p := (*C.VkPipelineViewportStateCreateInfo)(a.alloc(C.sizeof_VkPipelineViewportStateCreateInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPipelineRasterizationStateCreateInfo(v C.VkPipelineRasterizationStateCreateInfo) *C.VkPipelineRasterizationStateCreateInfo {
+	p := (*C.VkPipelineRasterizationStateCreateInfo)(a.alloc(C.sizeof_VkPipelineRasterizationStateCreateInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPipelineMultisampleStateCreateInfo(v C.VkPipelineMultisampleStateCreateInfo) *C.VkPipelineMultisampleStateCreateInfo {
+	p := (*C.VkPipelineMultisampleStateCreateInfo)(a.alloc(C.sizeof_VkPipelineMultisampleStateCreateInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPipelineDepthStencilStateCreateInfo(v C.VkPipelineDepthStencilStateCreateInfo) *C.VkPipelineDepthStencilStateCreateInfo {
+	p := (*C.VkPipelineDepthStencilStateCreateInfo)(a.alloc(C.sizeof_VkPipelineDepthStencilStateCreateInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPipelineColorBlendStateCreateInfo(v C.VkPipelineColorBlendStateCreateInfo) *C.VkPipelineColorBlendStateCreateInfo {
+	p := (*C.VkPipelineColorBlendStateCreateInfo)(a.alloc(C.sizeof_VkPipelineColorBlendStateCreateInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPhysicalDeviceFeatures2(v C.VkPhysicalDeviceFeatures2) *C.VkPhysicalDeviceFeatures2 {
+	p := (*C.VkPhysicalDeviceFeatures2)(a.alloc(C.sizeof_VkPhysicalDeviceFeatures2))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPhysicalDeviceDynamicRenderingFeatures(v C.VkPhysicalDeviceDynamicRenderingFeatures) *C.VkPhysicalDeviceDynamicRenderingFeatures {
+	p := (*C.VkPhysicalDeviceDynamicRenderingFeatures)(a.alloc(C.sizeof_VkPhysicalDeviceDynamicRenderingFeatures))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPipelineRenderingCreateInfo(v C.VkPipelineRenderingCreateInfo) *C.VkPipelineRenderingCreateInfo {
+	p := (*C.VkPipelineRenderingCreateInfo)(a.alloc(C.sizeof_VkPipelineRenderingCreateInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkRenderingInfo(v C.VkRenderingInfo) *C.VkRenderingInfo {
+	p := (*C.VkRenderingInfo)(a.alloc(C.sizeof_VkRenderingInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkPhysicalDeviceTimelineSemaphoreFeatures(v C.VkPhysicalDeviceTimelineSemaphoreFeatures) *C.VkPhysicalDeviceTimelineSemaphoreFeatures {
+	p := (*C.VkPhysicalDeviceTimelineSemaphoreFeatures)(a.alloc(C.sizeof_VkPhysicalDeviceTimelineSemaphoreFeatures))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkSemaphoreTypeCreateInfo(v C.VkSemaphoreTypeCreateInfo) *C.VkSemaphoreTypeCreateInfo {
+	p := (*C.VkSemaphoreTypeCreateInfo)(a.alloc(C.sizeof_VkSemaphoreTypeCreateInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkTimelineSemaphoreSubmitInfo(v C.VkTimelineSemaphoreSubmitInfo) *C.VkTimelineSemaphoreSubmitInfo {
+	p := (*C.VkTimelineSemaphoreSubmitInfo)(a.alloc(C.sizeof_VkTimelineSemaphoreSubmitInfo))
+	*p = v
+	return p
+}
+
+func (a *arena) newVkSemaphoreWaitInfo(v C.VkSemaphoreWaitInfo) *C.VkSemaphoreWaitInfo {
+	p := (*C.VkSemaphoreWaitInfo)(a.alloc(C.sizeof_VkSemaphoreWaitInfo))
+	*p = v
+	return p
+}
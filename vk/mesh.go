@@ -0,0 +1,125 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// Mesh is an indexed triangle list in GPU memory; a vertex buffer of unique
+// vertices, and an index buffer of 16- or 32-bit indices into the vertex
+// buffer.
+type Mesh struct {
+	// Mesh name, used as debug name of its buffers.
+	name string
+	// Unique ID of the mesh within the application, used to sort draws by mesh.
+	id uint32
+	// Vertex buffer.
+	vertexBuffer    *C.VkBuffer
+	vertexBufferMem *C.VkDeviceMemory
+	// Number of vertices of the vertex buffer.
+	vertexCount int
+	// Index buffer.
+	indexBuffer    *C.VkBuffer
+	indexBufferMem *C.VkDeviceMemory
+	// Number of indices of the index buffer.
+	indexCount int
+	// Type of indices (VK_INDEX_TYPE_UINT16 or VK_INDEX_TYPE_UINT32).
+	indexType C.VkIndexType
+}
+
+// createMesh creates a mesh with the given name of the given triangle list in
+// GPU memory, and queues the upload of its vertex and index buffers. The
+// returned upload completes after the uploads of both buffers.
+//
+// Duplicate vertices of the triangle list are stored once in the vertex buffer.
+// 16-bit indices are used if the number of unique vertices permits. If
+// animated, the vertex buffer is also read as a storage buffer by the compute
+// queue (see initAnimation).
+func createMesh(app *App, name string, vertices []Vertex, animated bool) (_ *Mesh, _ *upload, err error) {
+	if len(vertices) == 0 {
+		return nil, nil, errors.Errorf("invalid mesh %q; no vertices", name)
+	}
+	indices, uniqueVertices := uniqueIndexList(vertices)
+	app.nmeshes++
+	mesh := &Mesh{
+		name:        name,
+		id:          uint32(app.nmeshes),
+		vertexCount: len(uniqueVertices),
+		indexCount:  len(indices),
+		indexType:   C.VK_INDEX_TYPE_UINT32,
+	}
+	// Batch of the last upload to the buffers of the mesh.
+	var lastUpload *upload
+	defer func() {
+		if err == nil {
+			return
+		}
+		// Wait for queued copies to the buffers of the mesh before release.
+		if e := lastUpload.wait(app); e != nil {
+			warn.Printf("%+v", e) // print warning and continue
+		}
+		destroyMesh(app, mesh)
+	}()
+	// Create vertex buffer.
+	vertexBufferSize := getVerticesSize(uniqueVertices)
+	vertexBufferUsage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_TRANSFER_DST_BIT | C.VK_BUFFER_USAGE_VERTEX_BUFFER_BIT)
+	properties := C.VkMemoryPropertyFlags(C.VK_MEMORY_PROPERTY_DEVICE_LOCAL_BIT)
+	queueFamilyIndices := []int{app.graphicsQueueFamilyIndex, app.transferQueueFamilyIndex}
+	if animated {
+		vertexBufferUsage |= C.VK_BUFFER_USAGE_STORAGE_BUFFER_BIT
+		queueFamilyIndices = append(queueFamilyIndices, app.computeQueueFamilyIndex)
+	}
+	vertexBuffer, vertexBufferMem, err := createSharedBuffer(app, name+"VertexBuffer", vertexBufferSize, vertexBufferUsage, properties, queueFamilyIndices)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	mesh.vertexBuffer, mesh.vertexBufferMem = vertexBuffer, vertexBufferMem
+	vertexData := unsafe.Slice((*byte)(unsafe.Pointer(&uniqueVertices[0])), vertexBufferSize)
+	vertexUpload, err := uploadBuffer(app, *vertexBuffer, 0, vertexData)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	lastUpload = vertexUpload
+	// Create index buffer.
+	var indexData []byte
+	if len(uniqueVertices) <= 1<<16 {
+		indices16 := make([]uint16, len(indices))
+		for i, index := range indices {
+			indices16[i] = uint16(index)
+		}
+		mesh.indexType = C.VK_INDEX_TYPE_UINT16
+		indexData = unsafe.Slice((*byte)(unsafe.Pointer(&indices16[0])), len(indices16)*int(unsafe.Sizeof(indices16[0])))
+	} else {
+		indexData = unsafe.Slice((*byte)(unsafe.Pointer(&indices[0])), getIndicesSize(indices))
+	}
+	indexBufferUsage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_TRANSFER_DST_BIT | C.VK_BUFFER_USAGE_INDEX_BUFFER_BIT)
+	queueFamilyIndices = []int{app.graphicsQueueFamilyIndex, app.transferQueueFamilyIndex}
+	indexBuffer, indexBufferMem, err := createSharedBuffer(app, name+"IndexBuffer", C.VkDeviceSize(len(indexData)), indexBufferUsage, properties, queueFamilyIndices)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	mesh.indexBuffer, mesh.indexBufferMem = indexBuffer, indexBufferMem
+	// Batched with the upload of the vertex buffer, unless the staging ring is
+	// full; either way, completed after the upload of the vertex buffer.
+	indexUpload, err := uploadBuffer(app, *indexBuffer, 0, indexData)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	return mesh, indexUpload, nil
+}
+
+// destroyMesh destroys the vertex and index buffers of the given mesh.
+func destroyMesh(app *App, mesh *Mesh) {
+	if mesh.indexBuffer != nil {
+		destroyBuffer(app, mesh.indexBuffer, mesh.indexBufferMem)
+		mesh.indexBuffer, mesh.indexBufferMem = nil, nil
+	}
+	if mesh.vertexBuffer != nil {
+		destroyBuffer(app, mesh.vertexBuffer, mesh.vertexBufferMem)
+		mesh.vertexBuffer, mesh.vertexBufferMem = nil, nil
+	}
+}
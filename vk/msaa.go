@@ -0,0 +1,38 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"github.com/pkg/errors"
+)
+
+// initMSAAColorTarget creates the multisampled color target rendered to in
+// place of the swapchain images when multisampling, and resolved into the
+// swapchain image at the end of the render pass. The color target is shared
+// by all swapchain images, and recreated with the swapchain; nothing is
+// created if app.msaaSamples is 1.
+//
+// TODO: add a multisampled depth target alongside the color target, once the
+// render pass has a depth attachment.
+func initMSAAColorTarget(app *App) error {
+	if app.msaaSamples == C.VK_SAMPLE_COUNT_1_BIT {
+		return nil
+	}
+	dbg.Printf("multisampling: samples=%d, sample shading=%v", app.msaaSamples, app.sampleShading)
+	// The samples are only accessed within the render pass, and may thus be
+	// kept in tile memory by tiling GPUs.
+	usage := C.VkImageUsageFlags(C.VK_IMAGE_USAGE_COLOR_ATTACHMENT_BIT | C.VK_IMAGE_USAGE_TRANSIENT_ATTACHMENT_BIT)
+	msaaColorImg, msaaColorImgMem, err := createImage(app, app.swapchainArena, "msaaColorImg", app.swapchainExtent, app.swapchainImageFormat, app.msaaSamples, usage, C.VK_MEMORY_PROPERTY_DEVICE_LOCAL_BIT)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.msaaColorImg = msaaColorImg
+	app.msaaColorImgMem = msaaColorImgMem
+	msaaColorImgView, err := createImageView(app, app.swapchainArena, "msaaColorImgView", *msaaColorImg, app.swapchainImageFormat, 1)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.msaaColorImgView = msaaColorImgView
+	return nil
+}
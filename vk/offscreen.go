@@ -0,0 +1,167 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"image"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RenderScene renders the named scene offscreen at the given resolution, using
+// the given seed of pseudo-random numbers, and returns the rendered image.
+//
+// No window is created when rendering offscreen, and thus no display is
+// required (e.g. when rendering with the lavapipe software rasterizer in CI).
+// If ValidationErrorMode is ValidationFail, validation errors triggered by the
+// scene are returned as a *ValidationError.
+func RenderScene(name string, width, height int, seed int64) (*image.RGBA, error) {
+	app, err := initOffscreenApp(name, width, height, seed, defaultFrameStep)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer CleanupVulkan(app)
+	img, err := renderOffscreen(app)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := checkValidationErrors(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return img, nil
+}
+
+// RecordScene records consecutive frames of the named scene rendered offscreen
+// at the given resolution and frame rate, using the given seed of
+// pseudo-random numbers. The recorder is closed once the recording is
+// complete.
+//
+// The clock of the scene is advanced by a fixed step of 1/fps seconds per
+// frame, rather than by wall time, so that the recording is reproducible.
+func RecordScene(name string, width, height int, seed int64, fps float64, rec *Recording) (err error) {
+	if fps <= 0 {
+		return errors.Errorf("invalid frame rate %v; expected positive frame rate", fps)
+	}
+	if rec.Frames <= 0 && rec.Duration <= 0 {
+		return errors.Errorf("invalid recording; expected positive number of frames or duration")
+	}
+	defer func() {
+		if e := rec.Recorder.Close(); e != nil && err == nil {
+			err = errors.WithStack(e)
+		}
+	}()
+	step := time.Duration(float64(time.Second) / fps)
+	app, err := initOffscreenApp(name, width, height, seed, step)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer CleanupVulkan(app)
+	nframes := 0
+	for ; !rec.done(nframes, time.Duration(nframes)*step); nframes++ {
+		img, err := renderOffscreen(app)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := checkValidationErrors(); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := rec.Recorder.AddFrame(img, app.clock.now); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	dbg.Printf("recorded %d frames of scene %q", nframes, name)
+	return nil
+}
+
+// defaultFrameStep is the time step per frame of scenes rendered offscreen.
+const defaultFrameStep = time.Second / 60
+
+// initOffscreenApp initializes an application rendering the named scene
+// offscreen at the given resolution, using the given seed of pseudo-random
+// numbers and the given time step per frame.
+func initOffscreenApp(name string, width, height int, seed int64, step time.Duration) (*App, error) {
+	s, err := findScene(name)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if !isVulkanLoaded() {
+		if err := LoadVulkan(""); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	app := newApp()
+	app.headless = true
+	app.scene = s
+	app.seed = seed
+	app.clock = newFixedClock(step)
+	app.swapchainImageFormat = C.VK_FORMAT_R8G8B8A8_SRGB
+	app.swapchainExtent = C.VkExtent2D{width: C.uint32_t(width), height: C.uint32_t(height)}
+	if err := InitVulkan(app); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return app, nil
+}
+
+// initOffscreenImg creates the offscreen image rendered to when headless, in
+// place of swapchain images. The format and extent of the offscreen image are
+// specified by app.swapchainImageFormat and app.swapchainExtent.
+func initOffscreenImg(app *App) error {
+	usage := C.VkImageUsageFlags(C.VK_IMAGE_USAGE_COLOR_ATTACHMENT_BIT | C.VK_IMAGE_USAGE_TRANSFER_SRC_BIT)
+	offscreenImg, offscreenImgMem, err := createImage(app, app.swapchainArena, "offscreenImg", app.swapchainExtent, app.swapchainImageFormat, C.VK_SAMPLE_COUNT_1_BIT, usage, C.VK_MEMORY_PROPERTY_DEVICE_LOCAL_BIT)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.offscreenImg = offscreenImg
+	app.offscreenImgMem = offscreenImgMem
+	app.swapchainImgs = app.swapchainArena.newVkImageSlice(*offscreenImg)
+	return nil
+}
+
+// renderOffscreen renders the next frame of the clock to the offscreen image,
+// and returns its pixels.
+func renderOffscreen(app *App) (*image.RGBA, error) {
+	scratch := newArena()
+	defer scratch.free()
+	app.clock.tick()
+	uploaded, err := app.sceneUpload.waitOp(app, C.VK_PIPELINE_STAGE_VERTEX_INPUT_BIT)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	animatedVertices, err := submitAnimation(app, scratch)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := recordRenderCommandBuffer(app, scratch, 0); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var waits, signals []submitSemaphore
+	if animatedVertices != nil {
+		// Wait for animated vertices.
+		waits = append(waits, *animatedVertices)
+	}
+	if uploaded != nil {
+		// Wait for upload of vertex and index buffers.
+		waits = append(waits, *uploaded)
+	}
+	frameValue := app.graphicsTimeline.next()
+	if app.timelineSemaphores {
+		signals = append(signals, app.graphicsTimeline.signal(frameValue))
+	}
+	submitInfo := newSubmitInfo(app, scratch, app.swapchainCommandBuffers[:1], waits, signals)
+	submits := scratch.newVkSubmitInfoSlice(submitInfo)
+	if result := app.deviceProcs.QueueSubmit(*app.graphicsQueue, C.uint(len(submits)), &submits[0], nil); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to submit command buffers to graphics queue")
+	}
+	if err := app.graphicsTimeline.waitSubmitted(app, *app.graphicsQueue, frameValue); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// Frame completed; release resources no longer in use.
+	flushDeletions(app)
+	img, err := readImage(app, app.swapchainImgs[0], C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL, app.swapchainImageFormat, app.swapchainExtent)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return img, nil
+}
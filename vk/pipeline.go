@@ -0,0 +1,412 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// PipelineDesc describes a graphics pipeline; its shaders, vertex input layout
+// and fixed-function state, and the subpass of the render pass it is used in.
+//
+// PipelineDesc is a comparable value type, and may thus be used as a map key.
+// Graphics pipelines of equal descriptions are shared through the pipeline
+// cache of the application (see getPipeline), so that each material may
+// describe its own pipeline variant without creating duplicate pipelines.
+type PipelineDesc struct {
+	// Paths of the SPIR-V vertex and fragment shaders, with entry point "main".
+	VertexShader   string
+	FragmentShader string
+	// Vertex input layout; compared by identity (see VertexLayoutOf).
+	VertexLayout *VertexLayout
+	// Primitive topology of input assembly.
+	Topology PrimitiveTopology
+	// Rasterization state.
+	Raster RasterState
+	// Depth testing; ignored if the subpass has no depth attachment.
+	Depth DepthState
+	// Color blending of the color attachment.
+	Blend BlendState
+	// Multisampling.
+	Multisample MultisampleState
+	// Subpass of the render pass of the application; ignored with dynamic
+	// rendering.
+	Subpass int
+}
+
+// RasterState describes the rasterization state of a graphics pipeline.
+type RasterState struct {
+	// Rasterization of polygons as filled areas, lines or points.
+	PolygonMode PolygonMode
+	// Faces of triangles to discard.
+	CullMode CullMode
+	// Winding order of front-facing triangles.
+	FrontFace FrontFace
+}
+
+// DepthState describes the depth testing of a graphics pipeline.
+type DepthState struct {
+	// Test fragments against the depth attachment.
+	TestEnable bool
+	// Write the depth of fragments passing the test to the depth attachment.
+	WriteEnable bool
+	// Comparison of the depth of fragments against the depth attachment.
+	CompareOp CompareOp
+}
+
+// BlendState describes the color blending of a graphics pipeline; blending is
+// disabled for the zero value.
+type BlendState struct {
+	// Blend fragment colors with the color attachment.
+	Enable bool
+	// Blend factors and operation of color components.
+	SrcColorFactor BlendFactor
+	DstColorFactor BlendFactor
+	ColorOp        BlendOp
+	// Blend factors and operation of the alpha component.
+	SrcAlphaFactor BlendFactor
+	DstAlphaFactor BlendFactor
+	AlphaOp        BlendOp
+}
+
+// MultisampleState describes the multisampling of a graphics pipeline.
+type MultisampleState struct {
+	// Number of samples per pixel; or 0 to use the number of samples of the
+	// color target of the render pass.
+	Samples SampleCount
+	// Invoke the fragment shader once per sample, rather than once per pixel.
+	// Requires sample shading to be enabled (see Options.SampleShading).
+	SampleShading bool
+}
+
+// VertexLayout describes the vertex input layout of a graphics pipeline; the
+// vertex buffer bindings and the vertex attributes read from them.
+type VertexLayout struct {
+	// Vertex buffer bindings.
+	Bindings []VertexBinding
+	// Vertex attributes.
+	Attributes []VertexAttribute
+}
+
+// VertexBinding describes a vertex buffer binding.
+type VertexBinding struct {
+	// Binding number.
+	Binding int
+	// Distance in bytes between consecutive elements of the buffer.
+	Stride int
+	// Advance to the next element per vertex or per instance.
+	InputRate VertexInputRate
+}
+
+// VertexAttribute describes a vertex attribute.
+type VertexAttribute struct {
+	// Shader input location of the attribute.
+	Location int
+	// Binding number of the vertex buffer the attribute is read from.
+	Binding int
+	// Format of the attribute.
+	Format Format
+	// Offset in bytes of the attribute within an element of the buffer.
+	Offset int
+}
+
+// vkDescs returns the Vulkan vertex binding and attribute descriptions of the
+// vertex layout, allocated in the given arena.
+func (layout *VertexLayout) vkDescs(a *arena) ([]C.VkVertexInputBindingDescription, []C.VkVertexInputAttributeDescription) {
+	bindingDescs := a.makeVkVertexInputBindingDescriptionSlice(len(layout.Bindings))
+	for i, binding := range layout.Bindings {
+		bindingDescs[i] = C.VkVertexInputBindingDescription{
+			binding:   C.uint32_t(binding.Binding),
+			stride:    C.uint32_t(binding.Stride),
+			inputRate: C.VkVertexInputRate(binding.InputRate),
+		}
+	}
+	attrDescs := a.makeVkVertexInputAttributeDescriptionSlice(len(layout.Attributes))
+	for i, attr := range layout.Attributes {
+		attrDescs[i] = C.VkVertexInputAttributeDescription{
+			location: C.uint32_t(attr.Location),
+			binding:  C.uint32_t(attr.Binding),
+			format:   C.VkFormat(attr.Format),
+			offset:   C.uint32_t(attr.Offset),
+		}
+	}
+	return bindingDescs, attrDescs
+}
+
+// defaultPipelineDesc returns the description of the graphics pipeline of
+// scenes; colored triangle lists of Vertex, culling back faces with clockwise
+// winding order.
+func defaultPipelineDesc(app *App) PipelineDesc {
+	return PipelineDesc{
+		VertexShader:   "shaders/shader_vert.spv",
+		FragmentShader: "shaders/shader_frag.spv",
+		VertexLayout:   vertexLayout,
+		Topology:       PrimitiveTopologyTriangleList,
+		Raster: RasterState{
+			PolygonMode: PolygonModeFill,
+			CullMode:    CullModeBack,
+			FrontFace:   FrontFaceClockwise,
+		},
+		Multisample: MultisampleState{
+			SampleShading: app.sampleShading,
+		},
+	}
+}
+
+// getPipeline returns the graphics pipeline of the given description, creating
+// it on first use. Pipelines are cached until the swapchain is recreated.
+func getPipeline(app *App, desc PipelineDesc) (C.VkPipeline, error) {
+	if pipeline, ok := app.pipelines[desc]; ok {
+		return pipeline, nil
+	}
+	pipeline, err := createGraphicsPipeline(app, desc)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if app.pipelines == nil {
+		app.pipelines = make(map[PipelineDesc]C.VkPipeline)
+	}
+	app.pipelines[desc] = pipeline
+	return pipeline, nil
+}
+
+// reloadPipelines recreates the cached graphics pipelines from their shader
+// files. The previous graphics pipelines are kept if the shaders of any
+// pipeline fail to load, and released once no longer in use otherwise.
+func reloadPipelines(app *App) error {
+	pipelines := make(map[PipelineDesc]C.VkPipeline, len(app.pipelines))
+	var old []C.VkPipeline
+	for desc, oldPipeline := range app.pipelines {
+		pipeline, err := createGraphicsPipeline(app, desc)
+		if err != nil {
+			for _, pipeline := range pipelines {
+				destroyPipeline(app, pipeline)
+			}
+			return errors.WithStack(err)
+		}
+		pipelines[desc] = pipeline
+		old = append(old, oldPipeline)
+	}
+	deferDestroyPipelines(app, old)
+	app.pipelines = pipelines
+	return nil
+}
+
+// destroyPipelines destroys the cached graphics pipelines.
+func destroyPipelines(app *App) {
+	for _, pipeline := range app.pipelines {
+		destroyPipeline(app, pipeline)
+	}
+	app.pipelines = nil
+}
+
+// destroyPipeline destroys the given pipeline.
+func destroyPipeline(app *App, pipeline C.VkPipeline) {
+	untrackObject(C.VK_OBJECT_TYPE_PIPELINE, unsafe.Pointer(pipeline))
+	app.deviceProcs.DestroyPipeline(*app.device, pipeline, nil)
+}
+
+// initPipelineLayout creates the pipeline layout shared by the graphics
+// pipelines, with the push constants of the vertex shader, and the texture
+// sampled by the fragment shader at descriptor set 0 (see
+// initTextureSetLayout).
+func initPipelineLayout(app *App) (*C.VkPipelineLayout, error) {
+	scratch := newArena()
+	defer scratch.free()
+	setLayouts := scratch.newVkDescriptorSetLayoutSlice(*app.textureSetLayout)
+	pushConstantRanges := scratch.newVkPushConstantRangeSlice(
+		C.VkPushConstantRange{
+			stageFlags: C.VK_SHADER_STAGE_VERTEX_BIT,
+			offset:     0,
+			size:       C.uint32_t(unsafe.Sizeof(pushConstants{})),
+		},
+	)
+	pipelineLayoutCreateInfo := C.VkPipelineLayoutCreateInfo{
+		sType:                  C.VK_STRUCTURE_TYPE_PIPELINE_LAYOUT_CREATE_INFO,
+		setLayoutCount:         C.uint(len(setLayouts)),
+		pSetLayouts:            &setLayouts[0],
+		pushConstantRangeCount: C.uint(len(pushConstantRanges)),
+		pPushConstantRanges:    &pushConstantRanges[0],
+	}
+	pipelineLayout := app.swapchainArena.newVkPipelineLayout(nil)
+	if result := app.deviceProcs.CreatePipelineLayout(*app.device, &pipelineLayoutCreateInfo, nil, pipelineLayout); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to create pipeline layout")
+	}
+	trackObject(app, C.VK_OBJECT_TYPE_PIPELINE_LAYOUT, unsafe.Pointer(*pipelineLayout), "pipelineLayout")
+	return pipelineLayout, nil
+}
+
+// createGraphicsPipeline creates a graphics pipeline of the given description,
+// using the shared pipeline layout and the render pass of the application.
+func createGraphicsPipeline(app *App, desc PipelineDesc) (C.VkPipeline, error) {
+	if desc.VertexLayout == nil {
+		return nil, errors.New("invalid pipeline description; missing vertex layout")
+	}
+	if desc.Multisample.SampleShading && !app.sampleShading {
+		return nil, errors.New("invalid pipeline description; sample shading not enabled")
+	}
+	scratch := newArena()
+	defer scratch.free()
+	shaderStages, cleanupShaderModules, err := initShaderModules(app, scratch, desc.VertexShader, desc.FragmentShader)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer cleanupShaderModules()
+
+	// Vertex input.
+	bindingDescs, attrDescs := desc.VertexLayout.vkDescs(scratch)
+	vertexInputState := scratch.newVkPipelineVertexInputStateCreateInfo(C.VkPipelineVertexInputStateCreateInfo{
+		sType: C.VK_STRUCTURE_TYPE_PIPELINE_VERTEX_INPUT_STATE_CREATE_INFO,
+	})
+	if len(bindingDescs) > 0 {
+		vertexInputState.vertexBindingDescriptionCount = C.uint(len(bindingDescs))
+		vertexInputState.pVertexBindingDescriptions = &bindingDescs[0]
+	}
+	if len(attrDescs) > 0 {
+		vertexInputState.vertexAttributeDescriptionCount = C.uint(len(attrDescs))
+		vertexInputState.pVertexAttributeDescriptions = &attrDescs[0]
+	}
+
+	// Input assembler    (fixed-function stage)
+	inputAssemblyState := scratch.newVkPipelineInputAssemblyStateCreateInfo(C.VkPipelineInputAssemblyStateCreateInfo{
+		sType:                  C.VK_STRUCTURE_TYPE_PIPELINE_INPUT_ASSEMBLY_STATE_CREATE_INFO,
+		topology:               C.VkPrimitiveTopology(desc.Topology),
+		primitiveRestartEnable: C.VK_FALSE,
+	})
+
+	// Viewports and scissors.
+	viewport := C.VkViewport{
+		x:        0.0,
+		y:        0.0,
+		width:    C.float(app.swapchainExtent.width),
+		height:   C.float(app.swapchainExtent.height),
+		minDepth: 0.0,
+		maxDepth: 1.0,
+	}
+	viewports := scratch.newVkViewportSlice(viewport)
+	scissor := C.VkRect2D{
+		offset: C.VkOffset2D{x: 0, y: 0},
+		extent: app.swapchainExtent,
+	}
+	scissors := scratch.newVkRect2DSlice(scissor)
+	viewportState := scratch.newVkPipelineViewportStateCreateInfo(C.VkPipelineViewportStateCreateInfo{
+		sType:         C.VK_STRUCTURE_TYPE_PIPELINE_VIEWPORT_STATE_CREATE_INFO,
+		viewportCount: C.uint(len(viewports)),
+		pViewports:    &viewports[0],
+		scissorCount:  C.uint(len(scissors)),
+		pScissors:     &scissors[0],
+	})
+
+	// Rasterization      (fixed-function stage)
+	rasterizationState := scratch.newVkPipelineRasterizationStateCreateInfo(C.VkPipelineRasterizationStateCreateInfo{
+		sType:                   C.VK_STRUCTURE_TYPE_PIPELINE_RASTERIZATION_STATE_CREATE_INFO,
+		depthClampEnable:        C.VK_FALSE,
+		rasterizerDiscardEnable: C.VK_FALSE,
+		polygonMode:             C.VkPolygonMode(desc.Raster.PolygonMode),
+		cullMode:                C.VkCullModeFlags(desc.Raster.CullMode),
+		frontFace:               C.VkFrontFace(desc.Raster.FrontFace),
+		depthBiasEnable:         C.VK_FALSE,
+		depthBiasConstantFactor: 0.0, // optional
+		depthBiasClamp:          0.0, // optional
+		depthBiasSlopeFactor:    0.0, // optional
+		lineWidth:               1.0,
+	})
+
+	// Multisampling.
+	samples := C.VkSampleCountFlagBits(desc.Multisample.Samples)
+	if samples == 0 {
+		samples = app.msaaSamples
+	}
+	multisampleState := scratch.newVkPipelineMultisampleStateCreateInfo(C.VkPipelineMultisampleStateCreateInfo{
+		sType:                 C.VK_STRUCTURE_TYPE_PIPELINE_MULTISAMPLE_STATE_CREATE_INFO,
+		rasterizationSamples:  samples,
+		sampleShadingEnable:   vkBool(desc.Multisample.SampleShading),
+		minSampleShading:      1.0,        // optional; shade every sample if sample shading.
+		pSampleMask:           nil,        // optional
+		alphaToCoverageEnable: C.VK_FALSE, // optional
+		alphaToOneEnable:      C.VK_FALSE, // optional
+	})
+
+	// Depth and stencil testing.
+	depthStencilState := scratch.newVkPipelineDepthStencilStateCreateInfo(C.VkPipelineDepthStencilStateCreateInfo{
+		sType:                 C.VK_STRUCTURE_TYPE_PIPELINE_DEPTH_STENCIL_STATE_CREATE_INFO,
+		depthTestEnable:       vkBool(desc.Depth.TestEnable),
+		depthWriteEnable:      vkBool(desc.Depth.WriteEnable),
+		depthCompareOp:        C.VkCompareOp(desc.Depth.CompareOp),
+		depthBoundsTestEnable: C.VK_FALSE,
+		stencilTestEnable:     C.VK_FALSE,
+		minDepthBounds:        0.0, // optional
+		maxDepthBounds:        1.0, // optional
+	})
+
+	// Color blending     (fixed-function stage)
+	colorBlendAttachment := C.VkPipelineColorBlendAttachmentState{
+		blendEnable:         vkBool(desc.Blend.Enable),
+		srcColorBlendFactor: C.VkBlendFactor(desc.Blend.SrcColorFactor),
+		dstColorBlendFactor: C.VkBlendFactor(desc.Blend.DstColorFactor),
+		colorBlendOp:        C.VkBlendOp(desc.Blend.ColorOp),
+		srcAlphaBlendFactor: C.VkBlendFactor(desc.Blend.SrcAlphaFactor),
+		dstAlphaBlendFactor: C.VkBlendFactor(desc.Blend.DstAlphaFactor),
+		alphaBlendOp:        C.VkBlendOp(desc.Blend.AlphaOp),
+		colorWriteMask:      C.VK_COLOR_COMPONENT_R_BIT | C.VK_COLOR_COMPONENT_G_BIT | C.VK_COLOR_COMPONENT_B_BIT | C.VK_COLOR_COMPONENT_A_BIT,
+	}
+	colorBlendAttachments := scratch.newVkPipelineColorBlendAttachmentStateSlice(colorBlendAttachment)
+	colorBlendState := scratch.newVkPipelineColorBlendStateCreateInfo(C.VkPipelineColorBlendStateCreateInfo{
+		sType:           C.VK_STRUCTURE_TYPE_PIPELINE_COLOR_BLEND_STATE_CREATE_INFO,
+		logicOpEnable:   C.VK_FALSE,
+		logicOp:         C.VK_LOGIC_OP_COPY, // optional
+		attachmentCount: C.uint(len(colorBlendAttachments)),
+		pAttachments:    &colorBlendAttachments[0],
+		blendConstants:  [4]C.float{0.0, 0.0, 0.0, 0.0}, // optional
+	})
+
+	graphicsPipelineCreateInfo := C.VkGraphicsPipelineCreateInfo{
+		sType:               C.VK_STRUCTURE_TYPE_GRAPHICS_PIPELINE_CREATE_INFO,
+		stageCount:          C.uint(len(shaderStages)),
+		pStages:             &shaderStages[0],
+		pVertexInputState:   vertexInputState,
+		pInputAssemblyState: inputAssemblyState,
+		pTessellationState:  nil, // optional
+		pViewportState:      viewportState,
+		pRasterizationState: rasterizationState,
+		pMultisampleState:   multisampleState,
+		pDepthStencilState:  depthStencilState,
+		pColorBlendState:    colorBlendState,
+		layout:              *app.pipelineLayout,
+		subpass:             C.uint32_t(desc.Subpass),
+		basePipelineHandle:  nil, // optional
+		basePipelineIndex:   -1,  // optional
+	}
+	if app.renderPass != nil {
+		graphicsPipelineCreateInfo.renderPass = *app.renderPass
+	} else {
+		// Formats of the color attachments of dynamic rendering, in place of
+		// the render pass.
+		colorAttachmentFormats := scratch.newVkFormatSlice(app.swapchainImageFormat)
+		renderingCreateInfo := scratch.newVkPipelineRenderingCreateInfo(C.VkPipelineRenderingCreateInfo{
+			sType:                   C.VK_STRUCTURE_TYPE_PIPELINE_RENDERING_CREATE_INFO,
+			colorAttachmentCount:    C.uint32_t(len(colorAttachmentFormats)),
+			pColorAttachmentFormats: &colorAttachmentFormats[0],
+		})
+		graphicsPipelineCreateInfo.pNext = unsafe.Pointer(renderingCreateInfo)
+		graphicsPipelineCreateInfo.subpass = 0 // no subpasses.
+	}
+	graphicsPipelineCreateInfos := scratch.newVkGraphicsPipelineCreateInfoSlice(graphicsPipelineCreateInfo)
+	graphicsPipelines := scratch.makeVkPipelineSlice(len(graphicsPipelineCreateInfos))
+	if result := app.deviceProcs.CreateGraphicsPipelines(*app.device, nil, C.uint(len(graphicsPipelineCreateInfos)), &graphicsPipelineCreateInfos[0], nil, &graphicsPipelines[0]); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to create graphics pipeline")
+	}
+	trackObjectf(app, C.VK_OBJECT_TYPE_PIPELINE, unsafe.Pointer(graphicsPipelines[0]), "graphicsPipeline (%s, %s)", desc.VertexShader, desc.FragmentShader)
+	return graphicsPipelines[0], nil
+}
+
+// vkBool returns the Vulkan boolean of the given boolean.
+func vkBool(v bool) C.VkBool32 {
+	if v {
+		return C.VK_TRUE
+	}
+	return C.VK_FALSE
+}
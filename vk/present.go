@@ -0,0 +1,51 @@
+package vk
+
+// DefaultPresentModes are the present modes of the window swapchain in order of
+// preference, unless specified by Options.PresentModes: mailbox (triple
+// buffering, without tearing), and FIFO (vertical synchronization).
+var DefaultPresentModes = []PresentMode{PresentModeMailbox, PresentModeFifo}
+
+// Present modes in order of preference with vertical synchronization (VSync)
+// enabled and disabled. FIFO is always supported, and used as the final
+// fallback.
+var (
+	vsyncPresentModes   = []PresentMode{PresentModeFifo}
+	noVSyncPresentModes = []PresentMode{PresentModeImmediate, PresentModeMailbox, PresentModeFifo}
+)
+
+// SetPresentMode sets the present modes of the window swapchain in order of
+// preference; the first present mode supported by the surface is used, and
+// FIFO otherwise. The swapchain is recreated with the new present mode before
+// the next frame. Nil restores DefaultPresentModes.
+func (app *App) SetPresentMode(presentModes ...PresentMode) {
+	app.presentModes = presentModes
+	app.presentModeChanged = true
+}
+
+// SetVSync enables or disables vertical synchronization (VSync) of the window
+// swapchain. With VSync, frames are presented in FIFO order at the refresh rate
+// of the display; without, frames are presented immediately (with tearing),
+// falling back to mailbox and FIFO if not supported by the surface.
+func (app *App) SetVSync(vsync bool) {
+	if vsync {
+		app.SetPresentMode(vsyncPresentModes...)
+	} else {
+		app.SetPresentMode(noVSyncPresentModes...)
+	}
+}
+
+// PresentMode returns the present mode of the window swapchain.
+func (app *App) PresentMode() PresentMode {
+	return app.swapchainPresentMode
+}
+
+// VSync reports whether frames are presented with vertical synchronization
+// (VSync) by the window swapchain; i.e. whether the present mode is FIFO or
+// relaxed FIFO.
+func (app *App) VSync() bool {
+	switch app.swapchainPresentMode {
+	case PresentModeFifo, PresentModeFifoRelaxed:
+		return true
+	}
+	return false
+}
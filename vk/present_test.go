@@ -0,0 +1,53 @@
+package vk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetVSync(t *testing.T) {
+	app := &App{}
+	golden := []struct {
+		vsync bool
+		want  []PresentMode
+	}{
+		{vsync: true, want: []PresentMode{PresentModeFifo}},
+		{vsync: false, want: []PresentMode{PresentModeImmediate, PresentModeMailbox, PresentModeFifo}},
+	}
+	for _, g := range golden {
+		app.presentModeChanged = false
+		app.SetVSync(g.vsync)
+		if !reflect.DeepEqual(app.presentModes, g.want) {
+			t.Errorf("vsync %v: present modes mismatch; expected %v, got %v", g.vsync, g.want, app.presentModes)
+		}
+		if !app.presentModeChanged {
+			t.Errorf("vsync %v: expected swapchain recreation to be requested", g.vsync)
+		}
+	}
+	// Nil restores the default present modes.
+	app.SetPresentMode()
+	if app.presentModes != nil {
+		t.Errorf("expected nil present modes, got %v", app.presentModes)
+	}
+}
+
+func TestVSync(t *testing.T) {
+	golden := []struct {
+		presentMode PresentMode
+		want        bool
+	}{
+		{presentMode: PresentModeFifo, want: true},
+		{presentMode: PresentModeFifoRelaxed, want: true},
+		{presentMode: PresentModeMailbox, want: false},
+		{presentMode: PresentModeImmediate, want: false},
+	}
+	for _, g := range golden {
+		app := &App{swapchainPresentMode: g.presentMode}
+		if got := app.PresentMode(); got != g.presentMode {
+			t.Errorf("present mode mismatch; expected %v, got %v", g.presentMode, got)
+		}
+		if got := app.VSync(); got != g.want {
+			t.Errorf("%v: VSync mismatch; expected %v, got %v", g.presentMode, g.want, got)
+		}
+	}
+}
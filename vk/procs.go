@@ -0,0 +1,1239 @@
+// Code generated by vkgen. DO NOT EDIT.
+
+// Dispatch tables of Vulkan commands loaded through vkGetInstanceProcAddr and
+// vkGetDeviceProcAddr.
+
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"unsafe"
+)
+
+// procAddrFunc returns the Vulkan command with the given name, or nil if not
+// present.
+type procAddrFunc func(name string) C.PFN_vkVoidFunction
+
+// globalProcs holds the global Vulkan commands, loaded through
+// vkGetInstanceProcAddr with a NULL instance. Commands not present are nil.
+type globalProcs struct {
+	vkCreateInstance                       C.PFN_vkCreateInstance
+	vkEnumerateInstanceExtensionProperties C.PFN_vkEnumerateInstanceExtensionProperties
+	vkEnumerateInstanceLayerProperties     C.PFN_vkEnumerateInstanceLayerProperties
+	vkEnumerateInstanceVersion             C.PFN_vkEnumerateInstanceVersion
+}
+
+// loadGlobalProcs loads the global Vulkan commands.
+func loadGlobalProcs(getProcAddr procAddrFunc) *globalProcs {
+	return &globalProcs{
+		vkCreateInstance:                       (C.PFN_vkCreateInstance)(unsafe.Pointer(getProcAddr("vkCreateInstance"))),
+		vkEnumerateInstanceExtensionProperties: (C.PFN_vkEnumerateInstanceExtensionProperties)(unsafe.Pointer(getProcAddr("vkEnumerateInstanceExtensionProperties"))),
+		vkEnumerateInstanceLayerProperties:     (C.PFN_vkEnumerateInstanceLayerProperties)(unsafe.Pointer(getProcAddr("vkEnumerateInstanceLayerProperties"))),
+		vkEnumerateInstanceVersion:             (C.PFN_vkEnumerateInstanceVersion)(unsafe.Pointer(getProcAddr("vkEnumerateInstanceVersion"))),
+	}
+}
+
+// CreateInstance calls vkCreateInstance.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *globalProcs) CreateInstance(pCreateInfo *C.VkInstanceCreateInfo, pAllocator *C.VkAllocationCallbacks, pInstance *C.VkInstance) C.VkResult {
+	if p.vkCreateInstance == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateInstance(p.vkCreateInstance, pCreateInfo, pAllocator, pInstance)
+}
+
+// EnumerateInstanceExtensionProperties calls vkEnumerateInstanceExtensionProperties.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *globalProcs) EnumerateInstanceExtensionProperties(pLayerName *C.char, pPropertyCount *C.uint32_t, pProperties *C.VkExtensionProperties) C.VkResult {
+	if p.vkEnumerateInstanceExtensionProperties == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_EnumerateInstanceExtensionProperties(p.vkEnumerateInstanceExtensionProperties, pLayerName, pPropertyCount, pProperties)
+}
+
+// EnumerateInstanceLayerProperties calls vkEnumerateInstanceLayerProperties.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *globalProcs) EnumerateInstanceLayerProperties(pPropertyCount *C.uint32_t, pProperties *C.VkLayerProperties) C.VkResult {
+	if p.vkEnumerateInstanceLayerProperties == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_EnumerateInstanceLayerProperties(p.vkEnumerateInstanceLayerProperties, pPropertyCount, pProperties)
+}
+
+// EnumerateInstanceVersion calls vkEnumerateInstanceVersion.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *globalProcs) EnumerateInstanceVersion(pApiVersion *C.uint32_t) C.VkResult {
+	if p.vkEnumerateInstanceVersion == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_EnumerateInstanceVersion(p.vkEnumerateInstanceVersion, pApiVersion)
+}
+
+// instanceProcs holds the Vulkan commands of an instance, loaded through
+// vkGetInstanceProcAddr. Commands not present are nil.
+type instanceProcs struct {
+	vkGetDeviceProcAddr                       C.PFN_vkGetDeviceProcAddr
+	vkCreateDevice                            C.PFN_vkCreateDevice
+	vkDestroyInstance                         C.PFN_vkDestroyInstance
+	vkEnumerateDeviceExtensionProperties      C.PFN_vkEnumerateDeviceExtensionProperties
+	vkEnumeratePhysicalDevices                C.PFN_vkEnumeratePhysicalDevices
+	vkGetPhysicalDeviceFeatures               C.PFN_vkGetPhysicalDeviceFeatures
+	vkGetPhysicalDeviceFeatures2              C.PFN_vkGetPhysicalDeviceFeatures2
+	vkGetPhysicalDeviceFormatProperties       C.PFN_vkGetPhysicalDeviceFormatProperties
+	vkGetPhysicalDeviceMemoryProperties       C.PFN_vkGetPhysicalDeviceMemoryProperties
+	vkGetPhysicalDeviceProperties             C.PFN_vkGetPhysicalDeviceProperties
+	vkGetPhysicalDeviceQueueFamilyProperties  C.PFN_vkGetPhysicalDeviceQueueFamilyProperties
+	vkDestroySurfaceKHR                       C.PFN_vkDestroySurfaceKHR
+	vkGetPhysicalDeviceSurfaceCapabilitiesKHR C.PFN_vkGetPhysicalDeviceSurfaceCapabilitiesKHR
+	vkGetPhysicalDeviceSurfaceFormatsKHR      C.PFN_vkGetPhysicalDeviceSurfaceFormatsKHR
+	vkGetPhysicalDeviceSurfacePresentModesKHR C.PFN_vkGetPhysicalDeviceSurfacePresentModesKHR
+	vkGetPhysicalDeviceSurfaceSupportKHR      C.PFN_vkGetPhysicalDeviceSurfaceSupportKHR
+	vkCreateDebugUtilsMessengerEXT            C.PFN_vkCreateDebugUtilsMessengerEXT
+	vkDestroyDebugUtilsMessengerEXT           C.PFN_vkDestroyDebugUtilsMessengerEXT
+	vkSetDebugUtilsObjectNameEXT              C.PFN_vkSetDebugUtilsObjectNameEXT
+	vkCmdBeginDebugUtilsLabelEXT              C.PFN_vkCmdBeginDebugUtilsLabelEXT
+	vkCmdEndDebugUtilsLabelEXT                C.PFN_vkCmdEndDebugUtilsLabelEXT
+}
+
+// loadInstanceProcs loads the Vulkan commands of an instance.
+func loadInstanceProcs(getProcAddr procAddrFunc) *instanceProcs {
+	return &instanceProcs{
+		vkGetDeviceProcAddr:                       (C.PFN_vkGetDeviceProcAddr)(unsafe.Pointer(getProcAddr("vkGetDeviceProcAddr"))),
+		vkCreateDevice:                            (C.PFN_vkCreateDevice)(unsafe.Pointer(getProcAddr("vkCreateDevice"))),
+		vkDestroyInstance:                         (C.PFN_vkDestroyInstance)(unsafe.Pointer(getProcAddr("vkDestroyInstance"))),
+		vkEnumerateDeviceExtensionProperties:      (C.PFN_vkEnumerateDeviceExtensionProperties)(unsafe.Pointer(getProcAddr("vkEnumerateDeviceExtensionProperties"))),
+		vkEnumeratePhysicalDevices:                (C.PFN_vkEnumeratePhysicalDevices)(unsafe.Pointer(getProcAddr("vkEnumeratePhysicalDevices"))),
+		vkGetPhysicalDeviceFeatures:               (C.PFN_vkGetPhysicalDeviceFeatures)(unsafe.Pointer(getProcAddr("vkGetPhysicalDeviceFeatures"))),
+		vkGetPhysicalDeviceFeatures2:              (C.PFN_vkGetPhysicalDeviceFeatures2)(unsafe.Pointer(getProcAddr("vkGetPhysicalDeviceFeatures2"))),
+		vkGetPhysicalDeviceFormatProperties:       (C.PFN_vkGetPhysicalDeviceFormatProperties)(unsafe.Pointer(getProcAddr("vkGetPhysicalDeviceFormatProperties"))),
+		vkGetPhysicalDeviceMemoryProperties:       (C.PFN_vkGetPhysicalDeviceMemoryProperties)(unsafe.Pointer(getProcAddr("vkGetPhysicalDeviceMemoryProperties"))),
+		vkGetPhysicalDeviceProperties:             (C.PFN_vkGetPhysicalDeviceProperties)(unsafe.Pointer(getProcAddr("vkGetPhysicalDeviceProperties"))),
+		vkGetPhysicalDeviceQueueFamilyProperties:  (C.PFN_vkGetPhysicalDeviceQueueFamilyProperties)(unsafe.Pointer(getProcAddr("vkGetPhysicalDeviceQueueFamilyProperties"))),
+		vkDestroySurfaceKHR:                       (C.PFN_vkDestroySurfaceKHR)(unsafe.Pointer(getProcAddr("vkDestroySurfaceKHR"))),
+		vkGetPhysicalDeviceSurfaceCapabilitiesKHR: (C.PFN_vkGetPhysicalDeviceSurfaceCapabilitiesKHR)(unsafe.Pointer(getProcAddr("vkGetPhysicalDeviceSurfaceCapabilitiesKHR"))),
+		vkGetPhysicalDeviceSurfaceFormatsKHR:      (C.PFN_vkGetPhysicalDeviceSurfaceFormatsKHR)(unsafe.Pointer(getProcAddr("vkGetPhysicalDeviceSurfaceFormatsKHR"))),
+		vkGetPhysicalDeviceSurfacePresentModesKHR: (C.PFN_vkGetPhysicalDeviceSurfacePresentModesKHR)(unsafe.Pointer(getProcAddr("vkGetPhysicalDeviceSurfacePresentModesKHR"))),
+		vkGetPhysicalDeviceSurfaceSupportKHR:      (C.PFN_vkGetPhysicalDeviceSurfaceSupportKHR)(unsafe.Pointer(getProcAddr("vkGetPhysicalDeviceSurfaceSupportKHR"))),
+		vkCreateDebugUtilsMessengerEXT:            (C.PFN_vkCreateDebugUtilsMessengerEXT)(unsafe.Pointer(getProcAddr("vkCreateDebugUtilsMessengerEXT"))),
+		vkDestroyDebugUtilsMessengerEXT:           (C.PFN_vkDestroyDebugUtilsMessengerEXT)(unsafe.Pointer(getProcAddr("vkDestroyDebugUtilsMessengerEXT"))),
+		vkSetDebugUtilsObjectNameEXT:              (C.PFN_vkSetDebugUtilsObjectNameEXT)(unsafe.Pointer(getProcAddr("vkSetDebugUtilsObjectNameEXT"))),
+		vkCmdBeginDebugUtilsLabelEXT:              (C.PFN_vkCmdBeginDebugUtilsLabelEXT)(unsafe.Pointer(getProcAddr("vkCmdBeginDebugUtilsLabelEXT"))),
+		vkCmdEndDebugUtilsLabelEXT:                (C.PFN_vkCmdEndDebugUtilsLabelEXT)(unsafe.Pointer(getProcAddr("vkCmdEndDebugUtilsLabelEXT"))),
+	}
+}
+
+// GetDeviceProcAddr calls vkGetDeviceProcAddr.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) GetDeviceProcAddr(device C.VkDevice, pName *C.char) C.PFN_vkVoidFunction {
+	if p.vkGetDeviceProcAddr == nil {
+		var zero C.PFN_vkVoidFunction
+		return zero
+	}
+	return C.invoke_GetDeviceProcAddr(p.vkGetDeviceProcAddr, device, pName)
+}
+
+// CreateDevice calls vkCreateDevice.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *instanceProcs) CreateDevice(physicalDevice C.VkPhysicalDevice, pCreateInfo *C.VkDeviceCreateInfo, pAllocator *C.VkAllocationCallbacks, pDevice *C.VkDevice) C.VkResult {
+	if p.vkCreateDevice == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateDevice(p.vkCreateDevice, physicalDevice, pCreateInfo, pAllocator, pDevice)
+}
+
+// DestroyInstance calls vkDestroyInstance.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) DestroyInstance(instance C.VkInstance, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyInstance == nil {
+		return
+	}
+	C.invoke_DestroyInstance(p.vkDestroyInstance, instance, pAllocator)
+}
+
+// EnumerateDeviceExtensionProperties calls vkEnumerateDeviceExtensionProperties.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *instanceProcs) EnumerateDeviceExtensionProperties(physicalDevice C.VkPhysicalDevice, pLayerName *C.char, pPropertyCount *C.uint32_t, pProperties *C.VkExtensionProperties) C.VkResult {
+	if p.vkEnumerateDeviceExtensionProperties == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_EnumerateDeviceExtensionProperties(p.vkEnumerateDeviceExtensionProperties, physicalDevice, pLayerName, pPropertyCount, pProperties)
+}
+
+// EnumeratePhysicalDevices calls vkEnumeratePhysicalDevices.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *instanceProcs) EnumeratePhysicalDevices(instance C.VkInstance, pPhysicalDeviceCount *C.uint32_t, pPhysicalDevices *C.VkPhysicalDevice) C.VkResult {
+	if p.vkEnumeratePhysicalDevices == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_EnumeratePhysicalDevices(p.vkEnumeratePhysicalDevices, instance, pPhysicalDeviceCount, pPhysicalDevices)
+}
+
+// GetPhysicalDeviceFeatures calls vkGetPhysicalDeviceFeatures.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) GetPhysicalDeviceFeatures(physicalDevice C.VkPhysicalDevice, pFeatures *C.VkPhysicalDeviceFeatures) {
+	if p.vkGetPhysicalDeviceFeatures == nil {
+		return
+	}
+	C.invoke_GetPhysicalDeviceFeatures(p.vkGetPhysicalDeviceFeatures, physicalDevice, pFeatures)
+}
+
+// GetPhysicalDeviceFeatures2 calls vkGetPhysicalDeviceFeatures2.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) GetPhysicalDeviceFeatures2(physicalDevice C.VkPhysicalDevice, pFeatures *C.VkPhysicalDeviceFeatures2) {
+	if p.vkGetPhysicalDeviceFeatures2 == nil {
+		return
+	}
+	C.invoke_GetPhysicalDeviceFeatures2(p.vkGetPhysicalDeviceFeatures2, physicalDevice, pFeatures)
+}
+
+// GetPhysicalDeviceFormatProperties calls vkGetPhysicalDeviceFormatProperties.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) GetPhysicalDeviceFormatProperties(physicalDevice C.VkPhysicalDevice, format C.VkFormat, pFormatProperties *C.VkFormatProperties) {
+	if p.vkGetPhysicalDeviceFormatProperties == nil {
+		return
+	}
+	C.invoke_GetPhysicalDeviceFormatProperties(p.vkGetPhysicalDeviceFormatProperties, physicalDevice, format, pFormatProperties)
+}
+
+// GetPhysicalDeviceMemoryProperties calls vkGetPhysicalDeviceMemoryProperties.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) GetPhysicalDeviceMemoryProperties(physicalDevice C.VkPhysicalDevice, pMemoryProperties *C.VkPhysicalDeviceMemoryProperties) {
+	if p.vkGetPhysicalDeviceMemoryProperties == nil {
+		return
+	}
+	C.invoke_GetPhysicalDeviceMemoryProperties(p.vkGetPhysicalDeviceMemoryProperties, physicalDevice, pMemoryProperties)
+}
+
+// GetPhysicalDeviceProperties calls vkGetPhysicalDeviceProperties.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) GetPhysicalDeviceProperties(physicalDevice C.VkPhysicalDevice, pProperties *C.VkPhysicalDeviceProperties) {
+	if p.vkGetPhysicalDeviceProperties == nil {
+		return
+	}
+	C.invoke_GetPhysicalDeviceProperties(p.vkGetPhysicalDeviceProperties, physicalDevice, pProperties)
+}
+
+// GetPhysicalDeviceQueueFamilyProperties calls vkGetPhysicalDeviceQueueFamilyProperties.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) GetPhysicalDeviceQueueFamilyProperties(physicalDevice C.VkPhysicalDevice, pQueueFamilyPropertyCount *C.uint32_t, pQueueFamilyProperties *C.VkQueueFamilyProperties) {
+	if p.vkGetPhysicalDeviceQueueFamilyProperties == nil {
+		return
+	}
+	C.invoke_GetPhysicalDeviceQueueFamilyProperties(p.vkGetPhysicalDeviceQueueFamilyProperties, physicalDevice, pQueueFamilyPropertyCount, pQueueFamilyProperties)
+}
+
+// DestroySurfaceKHR calls vkDestroySurfaceKHR of VK_KHR_surface.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) DestroySurfaceKHR(instance C.VkInstance, surface C.VkSurfaceKHR, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroySurfaceKHR == nil {
+		return
+	}
+	C.invoke_DestroySurfaceKHR(p.vkDestroySurfaceKHR, instance, surface, pAllocator)
+}
+
+// GetPhysicalDeviceSurfaceCapabilitiesKHR calls vkGetPhysicalDeviceSurfaceCapabilitiesKHR of VK_KHR_surface.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *instanceProcs) GetPhysicalDeviceSurfaceCapabilitiesKHR(physicalDevice C.VkPhysicalDevice, surface C.VkSurfaceKHR, pSurfaceCapabilities *C.VkSurfaceCapabilitiesKHR) C.VkResult {
+	if p.vkGetPhysicalDeviceSurfaceCapabilitiesKHR == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_GetPhysicalDeviceSurfaceCapabilitiesKHR(p.vkGetPhysicalDeviceSurfaceCapabilitiesKHR, physicalDevice, surface, pSurfaceCapabilities)
+}
+
+// GetPhysicalDeviceSurfaceFormatsKHR calls vkGetPhysicalDeviceSurfaceFormatsKHR of VK_KHR_surface.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *instanceProcs) GetPhysicalDeviceSurfaceFormatsKHR(physicalDevice C.VkPhysicalDevice, surface C.VkSurfaceKHR, pSurfaceFormatCount *C.uint32_t, pSurfaceFormats *C.VkSurfaceFormatKHR) C.VkResult {
+	if p.vkGetPhysicalDeviceSurfaceFormatsKHR == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_GetPhysicalDeviceSurfaceFormatsKHR(p.vkGetPhysicalDeviceSurfaceFormatsKHR, physicalDevice, surface, pSurfaceFormatCount, pSurfaceFormats)
+}
+
+// GetPhysicalDeviceSurfacePresentModesKHR calls vkGetPhysicalDeviceSurfacePresentModesKHR of VK_KHR_surface.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *instanceProcs) GetPhysicalDeviceSurfacePresentModesKHR(physicalDevice C.VkPhysicalDevice, surface C.VkSurfaceKHR, pPresentModeCount *C.uint32_t, pPresentModes *C.VkPresentModeKHR) C.VkResult {
+	if p.vkGetPhysicalDeviceSurfacePresentModesKHR == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_GetPhysicalDeviceSurfacePresentModesKHR(p.vkGetPhysicalDeviceSurfacePresentModesKHR, physicalDevice, surface, pPresentModeCount, pPresentModes)
+}
+
+// GetPhysicalDeviceSurfaceSupportKHR calls vkGetPhysicalDeviceSurfaceSupportKHR of VK_KHR_surface.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *instanceProcs) GetPhysicalDeviceSurfaceSupportKHR(physicalDevice C.VkPhysicalDevice, queueFamilyIndex C.uint32_t, surface C.VkSurfaceKHR, pSupported *C.VkBool32) C.VkResult {
+	if p.vkGetPhysicalDeviceSurfaceSupportKHR == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_GetPhysicalDeviceSurfaceSupportKHR(p.vkGetPhysicalDeviceSurfaceSupportKHR, physicalDevice, queueFamilyIndex, surface, pSupported)
+}
+
+// CreateDebugUtilsMessengerEXT calls vkCreateDebugUtilsMessengerEXT of VK_EXT_debug_utils.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *instanceProcs) CreateDebugUtilsMessengerEXT(instance C.VkInstance, pCreateInfo *C.VkDebugUtilsMessengerCreateInfoEXT, pAllocator *C.VkAllocationCallbacks, pMessenger *C.VkDebugUtilsMessengerEXT) C.VkResult {
+	if p.vkCreateDebugUtilsMessengerEXT == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateDebugUtilsMessengerEXT(p.vkCreateDebugUtilsMessengerEXT, instance, pCreateInfo, pAllocator, pMessenger)
+}
+
+// DestroyDebugUtilsMessengerEXT calls vkDestroyDebugUtilsMessengerEXT of VK_EXT_debug_utils.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) DestroyDebugUtilsMessengerEXT(instance C.VkInstance, messenger C.VkDebugUtilsMessengerEXT, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyDebugUtilsMessengerEXT == nil {
+		return
+	}
+	C.invoke_DestroyDebugUtilsMessengerEXT(p.vkDestroyDebugUtilsMessengerEXT, instance, messenger, pAllocator)
+}
+
+// SetDebugUtilsObjectNameEXT calls vkSetDebugUtilsObjectNameEXT of VK_EXT_debug_utils.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *instanceProcs) SetDebugUtilsObjectNameEXT(device C.VkDevice, pNameInfo *C.VkDebugUtilsObjectNameInfoEXT) C.VkResult {
+	if p.vkSetDebugUtilsObjectNameEXT == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_SetDebugUtilsObjectNameEXT(p.vkSetDebugUtilsObjectNameEXT, device, pNameInfo)
+}
+
+// CmdBeginDebugUtilsLabelEXT calls vkCmdBeginDebugUtilsLabelEXT of VK_EXT_debug_utils.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) CmdBeginDebugUtilsLabelEXT(commandBuffer C.VkCommandBuffer, pLabelInfo *C.VkDebugUtilsLabelEXT) {
+	if p.vkCmdBeginDebugUtilsLabelEXT == nil {
+		return
+	}
+	C.invoke_CmdBeginDebugUtilsLabelEXT(p.vkCmdBeginDebugUtilsLabelEXT, commandBuffer, pLabelInfo)
+}
+
+// CmdEndDebugUtilsLabelEXT calls vkCmdEndDebugUtilsLabelEXT of VK_EXT_debug_utils.
+//
+// The call is a no-op if the command is not present.
+func (p *instanceProcs) CmdEndDebugUtilsLabelEXT(commandBuffer C.VkCommandBuffer) {
+	if p.vkCmdEndDebugUtilsLabelEXT == nil {
+		return
+	}
+	C.invoke_CmdEndDebugUtilsLabelEXT(p.vkCmdEndDebugUtilsLabelEXT, commandBuffer)
+}
+
+// deviceProcs holds the Vulkan commands of a device, loaded through
+// vkGetDeviceProcAddr. Commands not present are nil.
+type deviceProcs struct {
+	vkAllocateCommandBuffers      C.PFN_vkAllocateCommandBuffers
+	vkAllocateDescriptorSets      C.PFN_vkAllocateDescriptorSets
+	vkAllocateMemory              C.PFN_vkAllocateMemory
+	vkBeginCommandBuffer          C.PFN_vkBeginCommandBuffer
+	vkBindBufferMemory            C.PFN_vkBindBufferMemory
+	vkBindImageMemory             C.PFN_vkBindImageMemory
+	vkCmdBeginRenderPass          C.PFN_vkCmdBeginRenderPass
+	vkCmdBeginRendering           C.PFN_vkCmdBeginRendering
+	vkCmdBindDescriptorSets       C.PFN_vkCmdBindDescriptorSets
+	vkCmdBindIndexBuffer          C.PFN_vkCmdBindIndexBuffer
+	vkCmdBindPipeline             C.PFN_vkCmdBindPipeline
+	vkCmdBindVertexBuffers        C.PFN_vkCmdBindVertexBuffers
+	vkCmdBlitImage                C.PFN_vkCmdBlitImage
+	vkCmdCopyBuffer               C.PFN_vkCmdCopyBuffer
+	vkCmdCopyBufferToImage        C.PFN_vkCmdCopyBufferToImage
+	vkCmdCopyImageToBuffer        C.PFN_vkCmdCopyImageToBuffer
+	vkCmdDispatch                 C.PFN_vkCmdDispatch
+	vkCmdDrawIndexed              C.PFN_vkCmdDrawIndexed
+	vkCmdEndRenderPass            C.PFN_vkCmdEndRenderPass
+	vkCmdEndRendering             C.PFN_vkCmdEndRendering
+	vkCmdPipelineBarrier          C.PFN_vkCmdPipelineBarrier
+	vkCmdPushConstants            C.PFN_vkCmdPushConstants
+	vkCreateBuffer                C.PFN_vkCreateBuffer
+	vkCreateCommandPool           C.PFN_vkCreateCommandPool
+	vkCreateComputePipelines      C.PFN_vkCreateComputePipelines
+	vkCreateDescriptorPool        C.PFN_vkCreateDescriptorPool
+	vkCreateDescriptorSetLayout   C.PFN_vkCreateDescriptorSetLayout
+	vkCreateFence                 C.PFN_vkCreateFence
+	vkCreateFramebuffer           C.PFN_vkCreateFramebuffer
+	vkCreateGraphicsPipelines     C.PFN_vkCreateGraphicsPipelines
+	vkCreateImage                 C.PFN_vkCreateImage
+	vkCreateImageView             C.PFN_vkCreateImageView
+	vkCreatePipelineLayout        C.PFN_vkCreatePipelineLayout
+	vkCreateRenderPass            C.PFN_vkCreateRenderPass
+	vkCreateSampler               C.PFN_vkCreateSampler
+	vkCreateSemaphore             C.PFN_vkCreateSemaphore
+	vkCreateShaderModule          C.PFN_vkCreateShaderModule
+	vkDestroyBuffer               C.PFN_vkDestroyBuffer
+	vkDestroyCommandPool          C.PFN_vkDestroyCommandPool
+	vkDestroyDescriptorPool       C.PFN_vkDestroyDescriptorPool
+	vkDestroyDescriptorSetLayout  C.PFN_vkDestroyDescriptorSetLayout
+	vkDestroyDevice               C.PFN_vkDestroyDevice
+	vkDestroyFence                C.PFN_vkDestroyFence
+	vkDestroyFramebuffer          C.PFN_vkDestroyFramebuffer
+	vkDestroyImage                C.PFN_vkDestroyImage
+	vkDestroyImageView            C.PFN_vkDestroyImageView
+	vkDestroyPipeline             C.PFN_vkDestroyPipeline
+	vkDestroyPipelineLayout       C.PFN_vkDestroyPipelineLayout
+	vkDestroyRenderPass           C.PFN_vkDestroyRenderPass
+	vkDestroySampler              C.PFN_vkDestroySampler
+	vkDestroySemaphore            C.PFN_vkDestroySemaphore
+	vkDestroyShaderModule         C.PFN_vkDestroyShaderModule
+	vkDeviceWaitIdle              C.PFN_vkDeviceWaitIdle
+	vkEndCommandBuffer            C.PFN_vkEndCommandBuffer
+	vkFreeCommandBuffers          C.PFN_vkFreeCommandBuffers
+	vkFreeMemory                  C.PFN_vkFreeMemory
+	vkGetBufferMemoryRequirements C.PFN_vkGetBufferMemoryRequirements
+	vkGetDeviceQueue              C.PFN_vkGetDeviceQueue
+	vkGetFenceStatus              C.PFN_vkGetFenceStatus
+	vkGetImageMemoryRequirements  C.PFN_vkGetImageMemoryRequirements
+	vkGetSemaphoreCounterValue    C.PFN_vkGetSemaphoreCounterValue
+	vkMapMemory                   C.PFN_vkMapMemory
+	vkQueueSubmit                 C.PFN_vkQueueSubmit
+	vkQueueWaitIdle               C.PFN_vkQueueWaitIdle
+	vkResetFences                 C.PFN_vkResetFences
+	vkUnmapMemory                 C.PFN_vkUnmapMemory
+	vkUpdateDescriptorSets        C.PFN_vkUpdateDescriptorSets
+	vkWaitForFences               C.PFN_vkWaitForFences
+	vkWaitSemaphores              C.PFN_vkWaitSemaphores
+	vkAcquireNextImageKHR         C.PFN_vkAcquireNextImageKHR
+	vkCreateSwapchainKHR          C.PFN_vkCreateSwapchainKHR
+	vkDestroySwapchainKHR         C.PFN_vkDestroySwapchainKHR
+	vkGetSwapchainImagesKHR       C.PFN_vkGetSwapchainImagesKHR
+	vkQueuePresentKHR             C.PFN_vkQueuePresentKHR
+}
+
+// loadDeviceProcs loads the Vulkan commands of a device.
+func loadDeviceProcs(getProcAddr procAddrFunc) *deviceProcs {
+	return &deviceProcs{
+		vkAllocateCommandBuffers:      (C.PFN_vkAllocateCommandBuffers)(unsafe.Pointer(getProcAddr("vkAllocateCommandBuffers"))),
+		vkAllocateDescriptorSets:      (C.PFN_vkAllocateDescriptorSets)(unsafe.Pointer(getProcAddr("vkAllocateDescriptorSets"))),
+		vkAllocateMemory:              (C.PFN_vkAllocateMemory)(unsafe.Pointer(getProcAddr("vkAllocateMemory"))),
+		vkBeginCommandBuffer:          (C.PFN_vkBeginCommandBuffer)(unsafe.Pointer(getProcAddr("vkBeginCommandBuffer"))),
+		vkBindBufferMemory:            (C.PFN_vkBindBufferMemory)(unsafe.Pointer(getProcAddr("vkBindBufferMemory"))),
+		vkBindImageMemory:             (C.PFN_vkBindImageMemory)(unsafe.Pointer(getProcAddr("vkBindImageMemory"))),
+		vkCmdBeginRenderPass:          (C.PFN_vkCmdBeginRenderPass)(unsafe.Pointer(getProcAddr("vkCmdBeginRenderPass"))),
+		vkCmdBeginRendering:           (C.PFN_vkCmdBeginRendering)(unsafe.Pointer(getProcAddr("vkCmdBeginRendering"))),
+		vkCmdBindDescriptorSets:       (C.PFN_vkCmdBindDescriptorSets)(unsafe.Pointer(getProcAddr("vkCmdBindDescriptorSets"))),
+		vkCmdBindIndexBuffer:          (C.PFN_vkCmdBindIndexBuffer)(unsafe.Pointer(getProcAddr("vkCmdBindIndexBuffer"))),
+		vkCmdBindPipeline:             (C.PFN_vkCmdBindPipeline)(unsafe.Pointer(getProcAddr("vkCmdBindPipeline"))),
+		vkCmdBindVertexBuffers:        (C.PFN_vkCmdBindVertexBuffers)(unsafe.Pointer(getProcAddr("vkCmdBindVertexBuffers"))),
+		vkCmdBlitImage:                (C.PFN_vkCmdBlitImage)(unsafe.Pointer(getProcAddr("vkCmdBlitImage"))),
+		vkCmdCopyBuffer:               (C.PFN_vkCmdCopyBuffer)(unsafe.Pointer(getProcAddr("vkCmdCopyBuffer"))),
+		vkCmdCopyBufferToImage:        (C.PFN_vkCmdCopyBufferToImage)(unsafe.Pointer(getProcAddr("vkCmdCopyBufferToImage"))),
+		vkCmdCopyImageToBuffer:        (C.PFN_vkCmdCopyImageToBuffer)(unsafe.Pointer(getProcAddr("vkCmdCopyImageToBuffer"))),
+		vkCmdDispatch:                 (C.PFN_vkCmdDispatch)(unsafe.Pointer(getProcAddr("vkCmdDispatch"))),
+		vkCmdDrawIndexed:              (C.PFN_vkCmdDrawIndexed)(unsafe.Pointer(getProcAddr("vkCmdDrawIndexed"))),
+		vkCmdEndRenderPass:            (C.PFN_vkCmdEndRenderPass)(unsafe.Pointer(getProcAddr("vkCmdEndRenderPass"))),
+		vkCmdEndRendering:             (C.PFN_vkCmdEndRendering)(unsafe.Pointer(getProcAddr("vkCmdEndRendering"))),
+		vkCmdPipelineBarrier:          (C.PFN_vkCmdPipelineBarrier)(unsafe.Pointer(getProcAddr("vkCmdPipelineBarrier"))),
+		vkCmdPushConstants:            (C.PFN_vkCmdPushConstants)(unsafe.Pointer(getProcAddr("vkCmdPushConstants"))),
+		vkCreateBuffer:                (C.PFN_vkCreateBuffer)(unsafe.Pointer(getProcAddr("vkCreateBuffer"))),
+		vkCreateCommandPool:           (C.PFN_vkCreateCommandPool)(unsafe.Pointer(getProcAddr("vkCreateCommandPool"))),
+		vkCreateComputePipelines:      (C.PFN_vkCreateComputePipelines)(unsafe.Pointer(getProcAddr("vkCreateComputePipelines"))),
+		vkCreateDescriptorPool:        (C.PFN_vkCreateDescriptorPool)(unsafe.Pointer(getProcAddr("vkCreateDescriptorPool"))),
+		vkCreateDescriptorSetLayout:   (C.PFN_vkCreateDescriptorSetLayout)(unsafe.Pointer(getProcAddr("vkCreateDescriptorSetLayout"))),
+		vkCreateFence:                 (C.PFN_vkCreateFence)(unsafe.Pointer(getProcAddr("vkCreateFence"))),
+		vkCreateFramebuffer:           (C.PFN_vkCreateFramebuffer)(unsafe.Pointer(getProcAddr("vkCreateFramebuffer"))),
+		vkCreateGraphicsPipelines:     (C.PFN_vkCreateGraphicsPipelines)(unsafe.Pointer(getProcAddr("vkCreateGraphicsPipelines"))),
+		vkCreateImage:                 (C.PFN_vkCreateImage)(unsafe.Pointer(getProcAddr("vkCreateImage"))),
+		vkCreateImageView:             (C.PFN_vkCreateImageView)(unsafe.Pointer(getProcAddr("vkCreateImageView"))),
+		vkCreatePipelineLayout:        (C.PFN_vkCreatePipelineLayout)(unsafe.Pointer(getProcAddr("vkCreatePipelineLayout"))),
+		vkCreateRenderPass:            (C.PFN_vkCreateRenderPass)(unsafe.Pointer(getProcAddr("vkCreateRenderPass"))),
+		vkCreateSampler:               (C.PFN_vkCreateSampler)(unsafe.Pointer(getProcAddr("vkCreateSampler"))),
+		vkCreateSemaphore:             (C.PFN_vkCreateSemaphore)(unsafe.Pointer(getProcAddr("vkCreateSemaphore"))),
+		vkCreateShaderModule:          (C.PFN_vkCreateShaderModule)(unsafe.Pointer(getProcAddr("vkCreateShaderModule"))),
+		vkDestroyBuffer:               (C.PFN_vkDestroyBuffer)(unsafe.Pointer(getProcAddr("vkDestroyBuffer"))),
+		vkDestroyCommandPool:          (C.PFN_vkDestroyCommandPool)(unsafe.Pointer(getProcAddr("vkDestroyCommandPool"))),
+		vkDestroyDescriptorPool:       (C.PFN_vkDestroyDescriptorPool)(unsafe.Pointer(getProcAddr("vkDestroyDescriptorPool"))),
+		vkDestroyDescriptorSetLayout:  (C.PFN_vkDestroyDescriptorSetLayout)(unsafe.Pointer(getProcAddr("vkDestroyDescriptorSetLayout"))),
+		vkDestroyDevice:               (C.PFN_vkDestroyDevice)(unsafe.Pointer(getProcAddr("vkDestroyDevice"))),
+		vkDestroyFence:                (C.PFN_vkDestroyFence)(unsafe.Pointer(getProcAddr("vkDestroyFence"))),
+		vkDestroyFramebuffer:          (C.PFN_vkDestroyFramebuffer)(unsafe.Pointer(getProcAddr("vkDestroyFramebuffer"))),
+		vkDestroyImage:                (C.PFN_vkDestroyImage)(unsafe.Pointer(getProcAddr("vkDestroyImage"))),
+		vkDestroyImageView:            (C.PFN_vkDestroyImageView)(unsafe.Pointer(getProcAddr("vkDestroyImageView"))),
+		vkDestroyPipeline:             (C.PFN_vkDestroyPipeline)(unsafe.Pointer(getProcAddr("vkDestroyPipeline"))),
+		vkDestroyPipelineLayout:       (C.PFN_vkDestroyPipelineLayout)(unsafe.Pointer(getProcAddr("vkDestroyPipelineLayout"))),
+		vkDestroyRenderPass:           (C.PFN_vkDestroyRenderPass)(unsafe.Pointer(getProcAddr("vkDestroyRenderPass"))),
+		vkDestroySampler:              (C.PFN_vkDestroySampler)(unsafe.Pointer(getProcAddr("vkDestroySampler"))),
+		vkDestroySemaphore:            (C.PFN_vkDestroySemaphore)(unsafe.Pointer(getProcAddr("vkDestroySemaphore"))),
+		vkDestroyShaderModule:         (C.PFN_vkDestroyShaderModule)(unsafe.Pointer(getProcAddr("vkDestroyShaderModule"))),
+		vkDeviceWaitIdle:              (C.PFN_vkDeviceWaitIdle)(unsafe.Pointer(getProcAddr("vkDeviceWaitIdle"))),
+		vkEndCommandBuffer:            (C.PFN_vkEndCommandBuffer)(unsafe.Pointer(getProcAddr("vkEndCommandBuffer"))),
+		vkFreeCommandBuffers:          (C.PFN_vkFreeCommandBuffers)(unsafe.Pointer(getProcAddr("vkFreeCommandBuffers"))),
+		vkFreeMemory:                  (C.PFN_vkFreeMemory)(unsafe.Pointer(getProcAddr("vkFreeMemory"))),
+		vkGetBufferMemoryRequirements: (C.PFN_vkGetBufferMemoryRequirements)(unsafe.Pointer(getProcAddr("vkGetBufferMemoryRequirements"))),
+		vkGetDeviceQueue:              (C.PFN_vkGetDeviceQueue)(unsafe.Pointer(getProcAddr("vkGetDeviceQueue"))),
+		vkGetFenceStatus:              (C.PFN_vkGetFenceStatus)(unsafe.Pointer(getProcAddr("vkGetFenceStatus"))),
+		vkGetImageMemoryRequirements:  (C.PFN_vkGetImageMemoryRequirements)(unsafe.Pointer(getProcAddr("vkGetImageMemoryRequirements"))),
+		vkGetSemaphoreCounterValue:    (C.PFN_vkGetSemaphoreCounterValue)(unsafe.Pointer(getProcAddr("vkGetSemaphoreCounterValue"))),
+		vkMapMemory:                   (C.PFN_vkMapMemory)(unsafe.Pointer(getProcAddr("vkMapMemory"))),
+		vkQueueSubmit:                 (C.PFN_vkQueueSubmit)(unsafe.Pointer(getProcAddr("vkQueueSubmit"))),
+		vkQueueWaitIdle:               (C.PFN_vkQueueWaitIdle)(unsafe.Pointer(getProcAddr("vkQueueWaitIdle"))),
+		vkResetFences:                 (C.PFN_vkResetFences)(unsafe.Pointer(getProcAddr("vkResetFences"))),
+		vkUnmapMemory:                 (C.PFN_vkUnmapMemory)(unsafe.Pointer(getProcAddr("vkUnmapMemory"))),
+		vkUpdateDescriptorSets:        (C.PFN_vkUpdateDescriptorSets)(unsafe.Pointer(getProcAddr("vkUpdateDescriptorSets"))),
+		vkWaitForFences:               (C.PFN_vkWaitForFences)(unsafe.Pointer(getProcAddr("vkWaitForFences"))),
+		vkWaitSemaphores:              (C.PFN_vkWaitSemaphores)(unsafe.Pointer(getProcAddr("vkWaitSemaphores"))),
+		vkAcquireNextImageKHR:         (C.PFN_vkAcquireNextImageKHR)(unsafe.Pointer(getProcAddr("vkAcquireNextImageKHR"))),
+		vkCreateSwapchainKHR:          (C.PFN_vkCreateSwapchainKHR)(unsafe.Pointer(getProcAddr("vkCreateSwapchainKHR"))),
+		vkDestroySwapchainKHR:         (C.PFN_vkDestroySwapchainKHR)(unsafe.Pointer(getProcAddr("vkDestroySwapchainKHR"))),
+		vkGetSwapchainImagesKHR:       (C.PFN_vkGetSwapchainImagesKHR)(unsafe.Pointer(getProcAddr("vkGetSwapchainImagesKHR"))),
+		vkQueuePresentKHR:             (C.PFN_vkQueuePresentKHR)(unsafe.Pointer(getProcAddr("vkQueuePresentKHR"))),
+	}
+}
+
+// AllocateCommandBuffers calls vkAllocateCommandBuffers.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) AllocateCommandBuffers(device C.VkDevice, pAllocateInfo *C.VkCommandBufferAllocateInfo, pCommandBuffers *C.VkCommandBuffer) C.VkResult {
+	if p.vkAllocateCommandBuffers == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_AllocateCommandBuffers(p.vkAllocateCommandBuffers, device, pAllocateInfo, pCommandBuffers)
+}
+
+// AllocateDescriptorSets calls vkAllocateDescriptorSets.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) AllocateDescriptorSets(device C.VkDevice, pAllocateInfo *C.VkDescriptorSetAllocateInfo, pDescriptorSets *C.VkDescriptorSet) C.VkResult {
+	if p.vkAllocateDescriptorSets == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_AllocateDescriptorSets(p.vkAllocateDescriptorSets, device, pAllocateInfo, pDescriptorSets)
+}
+
+// AllocateMemory calls vkAllocateMemory.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) AllocateMemory(device C.VkDevice, pAllocateInfo *C.VkMemoryAllocateInfo, pAllocator *C.VkAllocationCallbacks, pMemory *C.VkDeviceMemory) C.VkResult {
+	if p.vkAllocateMemory == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_AllocateMemory(p.vkAllocateMemory, device, pAllocateInfo, pAllocator, pMemory)
+}
+
+// BeginCommandBuffer calls vkBeginCommandBuffer.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) BeginCommandBuffer(commandBuffer C.VkCommandBuffer, pBeginInfo *C.VkCommandBufferBeginInfo) C.VkResult {
+	if p.vkBeginCommandBuffer == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_BeginCommandBuffer(p.vkBeginCommandBuffer, commandBuffer, pBeginInfo)
+}
+
+// BindBufferMemory calls vkBindBufferMemory.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) BindBufferMemory(device C.VkDevice, buffer C.VkBuffer, memory C.VkDeviceMemory, memoryOffset C.VkDeviceSize) C.VkResult {
+	if p.vkBindBufferMemory == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_BindBufferMemory(p.vkBindBufferMemory, device, buffer, memory, memoryOffset)
+}
+
+// BindImageMemory calls vkBindImageMemory.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) BindImageMemory(device C.VkDevice, image C.VkImage, memory C.VkDeviceMemory, memoryOffset C.VkDeviceSize) C.VkResult {
+	if p.vkBindImageMemory == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_BindImageMemory(p.vkBindImageMemory, device, image, memory, memoryOffset)
+}
+
+// CmdBeginRenderPass calls vkCmdBeginRenderPass.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdBeginRenderPass(commandBuffer C.VkCommandBuffer, pRenderPassBegin *C.VkRenderPassBeginInfo, contents C.VkSubpassContents) {
+	if p.vkCmdBeginRenderPass == nil {
+		return
+	}
+	C.invoke_CmdBeginRenderPass(p.vkCmdBeginRenderPass, commandBuffer, pRenderPassBegin, contents)
+}
+
+// CmdBeginRendering calls vkCmdBeginRendering.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdBeginRendering(commandBuffer C.VkCommandBuffer, pRenderingInfo *C.VkRenderingInfo) {
+	if p.vkCmdBeginRendering == nil {
+		return
+	}
+	C.invoke_CmdBeginRendering(p.vkCmdBeginRendering, commandBuffer, pRenderingInfo)
+}
+
+// CmdBindDescriptorSets calls vkCmdBindDescriptorSets.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdBindDescriptorSets(commandBuffer C.VkCommandBuffer, pipelineBindPoint C.VkPipelineBindPoint, layout C.VkPipelineLayout, firstSet C.uint32_t, descriptorSetCount C.uint32_t, pDescriptorSets *C.VkDescriptorSet, dynamicOffsetCount C.uint32_t, pDynamicOffsets *C.uint32_t) {
+	if p.vkCmdBindDescriptorSets == nil {
+		return
+	}
+	C.invoke_CmdBindDescriptorSets(p.vkCmdBindDescriptorSets, commandBuffer, pipelineBindPoint, layout, firstSet, descriptorSetCount, pDescriptorSets, dynamicOffsetCount, pDynamicOffsets)
+}
+
+// CmdBindIndexBuffer calls vkCmdBindIndexBuffer.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdBindIndexBuffer(commandBuffer C.VkCommandBuffer, buffer C.VkBuffer, offset C.VkDeviceSize, indexType C.VkIndexType) {
+	if p.vkCmdBindIndexBuffer == nil {
+		return
+	}
+	C.invoke_CmdBindIndexBuffer(p.vkCmdBindIndexBuffer, commandBuffer, buffer, offset, indexType)
+}
+
+// CmdBindPipeline calls vkCmdBindPipeline.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdBindPipeline(commandBuffer C.VkCommandBuffer, pipelineBindPoint C.VkPipelineBindPoint, pipeline C.VkPipeline) {
+	if p.vkCmdBindPipeline == nil {
+		return
+	}
+	C.invoke_CmdBindPipeline(p.vkCmdBindPipeline, commandBuffer, pipelineBindPoint, pipeline)
+}
+
+// CmdBindVertexBuffers calls vkCmdBindVertexBuffers.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdBindVertexBuffers(commandBuffer C.VkCommandBuffer, firstBinding C.uint32_t, bindingCount C.uint32_t, pBuffers *C.VkBuffer, pOffsets *C.VkDeviceSize) {
+	if p.vkCmdBindVertexBuffers == nil {
+		return
+	}
+	C.invoke_CmdBindVertexBuffers(p.vkCmdBindVertexBuffers, commandBuffer, firstBinding, bindingCount, pBuffers, pOffsets)
+}
+
+// CmdBlitImage calls vkCmdBlitImage.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdBlitImage(commandBuffer C.VkCommandBuffer, srcImage C.VkImage, srcImageLayout C.VkImageLayout, dstImage C.VkImage, dstImageLayout C.VkImageLayout, regionCount C.uint32_t, pRegions *C.VkImageBlit, filter C.VkFilter) {
+	if p.vkCmdBlitImage == nil {
+		return
+	}
+	C.invoke_CmdBlitImage(p.vkCmdBlitImage, commandBuffer, srcImage, srcImageLayout, dstImage, dstImageLayout, regionCount, pRegions, filter)
+}
+
+// CmdCopyBuffer calls vkCmdCopyBuffer.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdCopyBuffer(commandBuffer C.VkCommandBuffer, srcBuffer C.VkBuffer, dstBuffer C.VkBuffer, regionCount C.uint32_t, pRegions *C.VkBufferCopy) {
+	if p.vkCmdCopyBuffer == nil {
+		return
+	}
+	C.invoke_CmdCopyBuffer(p.vkCmdCopyBuffer, commandBuffer, srcBuffer, dstBuffer, regionCount, pRegions)
+}
+
+// CmdCopyBufferToImage calls vkCmdCopyBufferToImage.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdCopyBufferToImage(commandBuffer C.VkCommandBuffer, srcBuffer C.VkBuffer, dstImage C.VkImage, dstImageLayout C.VkImageLayout, regionCount C.uint32_t, pRegions *C.VkBufferImageCopy) {
+	if p.vkCmdCopyBufferToImage == nil {
+		return
+	}
+	C.invoke_CmdCopyBufferToImage(p.vkCmdCopyBufferToImage, commandBuffer, srcBuffer, dstImage, dstImageLayout, regionCount, pRegions)
+}
+
+// CmdCopyImageToBuffer calls vkCmdCopyImageToBuffer.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdCopyImageToBuffer(commandBuffer C.VkCommandBuffer, srcImage C.VkImage, srcImageLayout C.VkImageLayout, dstBuffer C.VkBuffer, regionCount C.uint32_t, pRegions *C.VkBufferImageCopy) {
+	if p.vkCmdCopyImageToBuffer == nil {
+		return
+	}
+	C.invoke_CmdCopyImageToBuffer(p.vkCmdCopyImageToBuffer, commandBuffer, srcImage, srcImageLayout, dstBuffer, regionCount, pRegions)
+}
+
+// CmdDispatch calls vkCmdDispatch.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdDispatch(commandBuffer C.VkCommandBuffer, groupCountX C.uint32_t, groupCountY C.uint32_t, groupCountZ C.uint32_t) {
+	if p.vkCmdDispatch == nil {
+		return
+	}
+	C.invoke_CmdDispatch(p.vkCmdDispatch, commandBuffer, groupCountX, groupCountY, groupCountZ)
+}
+
+// CmdDrawIndexed calls vkCmdDrawIndexed.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdDrawIndexed(commandBuffer C.VkCommandBuffer, indexCount C.uint32_t, instanceCount C.uint32_t, firstIndex C.uint32_t, vertexOffset C.int32_t, firstInstance C.uint32_t) {
+	if p.vkCmdDrawIndexed == nil {
+		return
+	}
+	C.invoke_CmdDrawIndexed(p.vkCmdDrawIndexed, commandBuffer, indexCount, instanceCount, firstIndex, vertexOffset, firstInstance)
+}
+
+// CmdEndRenderPass calls vkCmdEndRenderPass.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdEndRenderPass(commandBuffer C.VkCommandBuffer) {
+	if p.vkCmdEndRenderPass == nil {
+		return
+	}
+	C.invoke_CmdEndRenderPass(p.vkCmdEndRenderPass, commandBuffer)
+}
+
+// CmdEndRendering calls vkCmdEndRendering.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdEndRendering(commandBuffer C.VkCommandBuffer) {
+	if p.vkCmdEndRendering == nil {
+		return
+	}
+	C.invoke_CmdEndRendering(p.vkCmdEndRendering, commandBuffer)
+}
+
+// CmdPipelineBarrier calls vkCmdPipelineBarrier.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdPipelineBarrier(commandBuffer C.VkCommandBuffer, srcStageMask C.VkPipelineStageFlags, dstStageMask C.VkPipelineStageFlags, dependencyFlags C.VkDependencyFlags, memoryBarrierCount C.uint32_t, pMemoryBarriers *C.VkMemoryBarrier, bufferMemoryBarrierCount C.uint32_t, pBufferMemoryBarriers *C.VkBufferMemoryBarrier, imageMemoryBarrierCount C.uint32_t, pImageMemoryBarriers *C.VkImageMemoryBarrier) {
+	if p.vkCmdPipelineBarrier == nil {
+		return
+	}
+	C.invoke_CmdPipelineBarrier(p.vkCmdPipelineBarrier, commandBuffer, srcStageMask, dstStageMask, dependencyFlags, memoryBarrierCount, pMemoryBarriers, bufferMemoryBarrierCount, pBufferMemoryBarriers, imageMemoryBarrierCount, pImageMemoryBarriers)
+}
+
+// CmdPushConstants calls vkCmdPushConstants.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) CmdPushConstants(commandBuffer C.VkCommandBuffer, layout C.VkPipelineLayout, stageFlags C.VkShaderStageFlags, offset C.uint32_t, size C.uint32_t, pValues unsafe.Pointer) {
+	if p.vkCmdPushConstants == nil {
+		return
+	}
+	C.invoke_CmdPushConstants(p.vkCmdPushConstants, commandBuffer, layout, stageFlags, offset, size, pValues)
+}
+
+// CreateBuffer calls vkCreateBuffer.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateBuffer(device C.VkDevice, pCreateInfo *C.VkBufferCreateInfo, pAllocator *C.VkAllocationCallbacks, pBuffer *C.VkBuffer) C.VkResult {
+	if p.vkCreateBuffer == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateBuffer(p.vkCreateBuffer, device, pCreateInfo, pAllocator, pBuffer)
+}
+
+// CreateCommandPool calls vkCreateCommandPool.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateCommandPool(device C.VkDevice, pCreateInfo *C.VkCommandPoolCreateInfo, pAllocator *C.VkAllocationCallbacks, pCommandPool *C.VkCommandPool) C.VkResult {
+	if p.vkCreateCommandPool == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateCommandPool(p.vkCreateCommandPool, device, pCreateInfo, pAllocator, pCommandPool)
+}
+
+// CreateComputePipelines calls vkCreateComputePipelines.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateComputePipelines(device C.VkDevice, pipelineCache C.VkPipelineCache, createInfoCount C.uint32_t, pCreateInfos *C.VkComputePipelineCreateInfo, pAllocator *C.VkAllocationCallbacks, pPipelines *C.VkPipeline) C.VkResult {
+	if p.vkCreateComputePipelines == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateComputePipelines(p.vkCreateComputePipelines, device, pipelineCache, createInfoCount, pCreateInfos, pAllocator, pPipelines)
+}
+
+// CreateDescriptorPool calls vkCreateDescriptorPool.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateDescriptorPool(device C.VkDevice, pCreateInfo *C.VkDescriptorPoolCreateInfo, pAllocator *C.VkAllocationCallbacks, pDescriptorPool *C.VkDescriptorPool) C.VkResult {
+	if p.vkCreateDescriptorPool == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateDescriptorPool(p.vkCreateDescriptorPool, device, pCreateInfo, pAllocator, pDescriptorPool)
+}
+
+// CreateDescriptorSetLayout calls vkCreateDescriptorSetLayout.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateDescriptorSetLayout(device C.VkDevice, pCreateInfo *C.VkDescriptorSetLayoutCreateInfo, pAllocator *C.VkAllocationCallbacks, pSetLayout *C.VkDescriptorSetLayout) C.VkResult {
+	if p.vkCreateDescriptorSetLayout == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateDescriptorSetLayout(p.vkCreateDescriptorSetLayout, device, pCreateInfo, pAllocator, pSetLayout)
+}
+
+// CreateFence calls vkCreateFence.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateFence(device C.VkDevice, pCreateInfo *C.VkFenceCreateInfo, pAllocator *C.VkAllocationCallbacks, pFence *C.VkFence) C.VkResult {
+	if p.vkCreateFence == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateFence(p.vkCreateFence, device, pCreateInfo, pAllocator, pFence)
+}
+
+// CreateFramebuffer calls vkCreateFramebuffer.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateFramebuffer(device C.VkDevice, pCreateInfo *C.VkFramebufferCreateInfo, pAllocator *C.VkAllocationCallbacks, pFramebuffer *C.VkFramebuffer) C.VkResult {
+	if p.vkCreateFramebuffer == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateFramebuffer(p.vkCreateFramebuffer, device, pCreateInfo, pAllocator, pFramebuffer)
+}
+
+// CreateGraphicsPipelines calls vkCreateGraphicsPipelines.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateGraphicsPipelines(device C.VkDevice, pipelineCache C.VkPipelineCache, createInfoCount C.uint32_t, pCreateInfos *C.VkGraphicsPipelineCreateInfo, pAllocator *C.VkAllocationCallbacks, pPipelines *C.VkPipeline) C.VkResult {
+	if p.vkCreateGraphicsPipelines == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateGraphicsPipelines(p.vkCreateGraphicsPipelines, device, pipelineCache, createInfoCount, pCreateInfos, pAllocator, pPipelines)
+}
+
+// CreateImage calls vkCreateImage.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateImage(device C.VkDevice, pCreateInfo *C.VkImageCreateInfo, pAllocator *C.VkAllocationCallbacks, pImage *C.VkImage) C.VkResult {
+	if p.vkCreateImage == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateImage(p.vkCreateImage, device, pCreateInfo, pAllocator, pImage)
+}
+
+// CreateImageView calls vkCreateImageView.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateImageView(device C.VkDevice, pCreateInfo *C.VkImageViewCreateInfo, pAllocator *C.VkAllocationCallbacks, pView *C.VkImageView) C.VkResult {
+	if p.vkCreateImageView == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateImageView(p.vkCreateImageView, device, pCreateInfo, pAllocator, pView)
+}
+
+// CreatePipelineLayout calls vkCreatePipelineLayout.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreatePipelineLayout(device C.VkDevice, pCreateInfo *C.VkPipelineLayoutCreateInfo, pAllocator *C.VkAllocationCallbacks, pPipelineLayout *C.VkPipelineLayout) C.VkResult {
+	if p.vkCreatePipelineLayout == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreatePipelineLayout(p.vkCreatePipelineLayout, device, pCreateInfo, pAllocator, pPipelineLayout)
+}
+
+// CreateRenderPass calls vkCreateRenderPass.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateRenderPass(device C.VkDevice, pCreateInfo *C.VkRenderPassCreateInfo, pAllocator *C.VkAllocationCallbacks, pRenderPass *C.VkRenderPass) C.VkResult {
+	if p.vkCreateRenderPass == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateRenderPass(p.vkCreateRenderPass, device, pCreateInfo, pAllocator, pRenderPass)
+}
+
+// CreateSampler calls vkCreateSampler.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateSampler(device C.VkDevice, pCreateInfo *C.VkSamplerCreateInfo, pAllocator *C.VkAllocationCallbacks, pSampler *C.VkSampler) C.VkResult {
+	if p.vkCreateSampler == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateSampler(p.vkCreateSampler, device, pCreateInfo, pAllocator, pSampler)
+}
+
+// CreateSemaphore calls vkCreateSemaphore.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateSemaphore(device C.VkDevice, pCreateInfo *C.VkSemaphoreCreateInfo, pAllocator *C.VkAllocationCallbacks, pSemaphore *C.VkSemaphore) C.VkResult {
+	if p.vkCreateSemaphore == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateSemaphore(p.vkCreateSemaphore, device, pCreateInfo, pAllocator, pSemaphore)
+}
+
+// CreateShaderModule calls vkCreateShaderModule.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateShaderModule(device C.VkDevice, pCreateInfo *C.VkShaderModuleCreateInfo, pAllocator *C.VkAllocationCallbacks, pShaderModule *C.VkShaderModule) C.VkResult {
+	if p.vkCreateShaderModule == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateShaderModule(p.vkCreateShaderModule, device, pCreateInfo, pAllocator, pShaderModule)
+}
+
+// DestroyBuffer calls vkDestroyBuffer.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyBuffer(device C.VkDevice, buffer C.VkBuffer, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyBuffer == nil {
+		return
+	}
+	C.invoke_DestroyBuffer(p.vkDestroyBuffer, device, buffer, pAllocator)
+}
+
+// DestroyCommandPool calls vkDestroyCommandPool.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyCommandPool(device C.VkDevice, commandPool C.VkCommandPool, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyCommandPool == nil {
+		return
+	}
+	C.invoke_DestroyCommandPool(p.vkDestroyCommandPool, device, commandPool, pAllocator)
+}
+
+// DestroyDescriptorPool calls vkDestroyDescriptorPool.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyDescriptorPool(device C.VkDevice, descriptorPool C.VkDescriptorPool, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyDescriptorPool == nil {
+		return
+	}
+	C.invoke_DestroyDescriptorPool(p.vkDestroyDescriptorPool, device, descriptorPool, pAllocator)
+}
+
+// DestroyDescriptorSetLayout calls vkDestroyDescriptorSetLayout.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyDescriptorSetLayout(device C.VkDevice, descriptorSetLayout C.VkDescriptorSetLayout, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyDescriptorSetLayout == nil {
+		return
+	}
+	C.invoke_DestroyDescriptorSetLayout(p.vkDestroyDescriptorSetLayout, device, descriptorSetLayout, pAllocator)
+}
+
+// DestroyDevice calls vkDestroyDevice.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyDevice(device C.VkDevice, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyDevice == nil {
+		return
+	}
+	C.invoke_DestroyDevice(p.vkDestroyDevice, device, pAllocator)
+}
+
+// DestroyFence calls vkDestroyFence.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyFence(device C.VkDevice, fence C.VkFence, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyFence == nil {
+		return
+	}
+	C.invoke_DestroyFence(p.vkDestroyFence, device, fence, pAllocator)
+}
+
+// DestroyFramebuffer calls vkDestroyFramebuffer.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyFramebuffer(device C.VkDevice, framebuffer C.VkFramebuffer, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyFramebuffer == nil {
+		return
+	}
+	C.invoke_DestroyFramebuffer(p.vkDestroyFramebuffer, device, framebuffer, pAllocator)
+}
+
+// DestroyImage calls vkDestroyImage.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyImage(device C.VkDevice, image C.VkImage, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyImage == nil {
+		return
+	}
+	C.invoke_DestroyImage(p.vkDestroyImage, device, image, pAllocator)
+}
+
+// DestroyImageView calls vkDestroyImageView.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyImageView(device C.VkDevice, imageView C.VkImageView, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyImageView == nil {
+		return
+	}
+	C.invoke_DestroyImageView(p.vkDestroyImageView, device, imageView, pAllocator)
+}
+
+// DestroyPipeline calls vkDestroyPipeline.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyPipeline(device C.VkDevice, pipeline C.VkPipeline, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyPipeline == nil {
+		return
+	}
+	C.invoke_DestroyPipeline(p.vkDestroyPipeline, device, pipeline, pAllocator)
+}
+
+// DestroyPipelineLayout calls vkDestroyPipelineLayout.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyPipelineLayout(device C.VkDevice, pipelineLayout C.VkPipelineLayout, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyPipelineLayout == nil {
+		return
+	}
+	C.invoke_DestroyPipelineLayout(p.vkDestroyPipelineLayout, device, pipelineLayout, pAllocator)
+}
+
+// DestroyRenderPass calls vkDestroyRenderPass.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyRenderPass(device C.VkDevice, renderPass C.VkRenderPass, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyRenderPass == nil {
+		return
+	}
+	C.invoke_DestroyRenderPass(p.vkDestroyRenderPass, device, renderPass, pAllocator)
+}
+
+// DestroySampler calls vkDestroySampler.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroySampler(device C.VkDevice, sampler C.VkSampler, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroySampler == nil {
+		return
+	}
+	C.invoke_DestroySampler(p.vkDestroySampler, device, sampler, pAllocator)
+}
+
+// DestroySemaphore calls vkDestroySemaphore.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroySemaphore(device C.VkDevice, semaphore C.VkSemaphore, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroySemaphore == nil {
+		return
+	}
+	C.invoke_DestroySemaphore(p.vkDestroySemaphore, device, semaphore, pAllocator)
+}
+
+// DestroyShaderModule calls vkDestroyShaderModule.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroyShaderModule(device C.VkDevice, shaderModule C.VkShaderModule, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroyShaderModule == nil {
+		return
+	}
+	C.invoke_DestroyShaderModule(p.vkDestroyShaderModule, device, shaderModule, pAllocator)
+}
+
+// DeviceWaitIdle calls vkDeviceWaitIdle.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) DeviceWaitIdle(device C.VkDevice) C.VkResult {
+	if p.vkDeviceWaitIdle == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_DeviceWaitIdle(p.vkDeviceWaitIdle, device)
+}
+
+// EndCommandBuffer calls vkEndCommandBuffer.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) EndCommandBuffer(commandBuffer C.VkCommandBuffer) C.VkResult {
+	if p.vkEndCommandBuffer == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_EndCommandBuffer(p.vkEndCommandBuffer, commandBuffer)
+}
+
+// FreeCommandBuffers calls vkFreeCommandBuffers.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) FreeCommandBuffers(device C.VkDevice, commandPool C.VkCommandPool, commandBufferCount C.uint32_t, pCommandBuffers *C.VkCommandBuffer) {
+	if p.vkFreeCommandBuffers == nil {
+		return
+	}
+	C.invoke_FreeCommandBuffers(p.vkFreeCommandBuffers, device, commandPool, commandBufferCount, pCommandBuffers)
+}
+
+// FreeMemory calls vkFreeMemory.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) FreeMemory(device C.VkDevice, memory C.VkDeviceMemory, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkFreeMemory == nil {
+		return
+	}
+	C.invoke_FreeMemory(p.vkFreeMemory, device, memory, pAllocator)
+}
+
+// GetBufferMemoryRequirements calls vkGetBufferMemoryRequirements.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) GetBufferMemoryRequirements(device C.VkDevice, buffer C.VkBuffer, pMemoryRequirements *C.VkMemoryRequirements) {
+	if p.vkGetBufferMemoryRequirements == nil {
+		return
+	}
+	C.invoke_GetBufferMemoryRequirements(p.vkGetBufferMemoryRequirements, device, buffer, pMemoryRequirements)
+}
+
+// GetDeviceQueue calls vkGetDeviceQueue.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) GetDeviceQueue(device C.VkDevice, queueFamilyIndex C.uint32_t, queueIndex C.uint32_t, pQueue *C.VkQueue) {
+	if p.vkGetDeviceQueue == nil {
+		return
+	}
+	C.invoke_GetDeviceQueue(p.vkGetDeviceQueue, device, queueFamilyIndex, queueIndex, pQueue)
+}
+
+// GetFenceStatus calls vkGetFenceStatus.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) GetFenceStatus(device C.VkDevice, fence C.VkFence) C.VkResult {
+	if p.vkGetFenceStatus == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_GetFenceStatus(p.vkGetFenceStatus, device, fence)
+}
+
+// GetImageMemoryRequirements calls vkGetImageMemoryRequirements.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) GetImageMemoryRequirements(device C.VkDevice, image C.VkImage, pMemoryRequirements *C.VkMemoryRequirements) {
+	if p.vkGetImageMemoryRequirements == nil {
+		return
+	}
+	C.invoke_GetImageMemoryRequirements(p.vkGetImageMemoryRequirements, device, image, pMemoryRequirements)
+}
+
+// GetSemaphoreCounterValue calls vkGetSemaphoreCounterValue.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) GetSemaphoreCounterValue(device C.VkDevice, semaphore C.VkSemaphore, pValue *C.uint64_t) C.VkResult {
+	if p.vkGetSemaphoreCounterValue == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_GetSemaphoreCounterValue(p.vkGetSemaphoreCounterValue, device, semaphore, pValue)
+}
+
+// MapMemory calls vkMapMemory.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) MapMemory(device C.VkDevice, memory C.VkDeviceMemory, offset C.VkDeviceSize, size C.VkDeviceSize, flags C.VkMemoryMapFlags, ppData *unsafe.Pointer) C.VkResult {
+	if p.vkMapMemory == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_MapMemory(p.vkMapMemory, device, memory, offset, size, flags, ppData)
+}
+
+// QueueSubmit calls vkQueueSubmit.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) QueueSubmit(queue C.VkQueue, submitCount C.uint32_t, pSubmits *C.VkSubmitInfo, fence C.VkFence) C.VkResult {
+	if p.vkQueueSubmit == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_QueueSubmit(p.vkQueueSubmit, queue, submitCount, pSubmits, fence)
+}
+
+// QueueWaitIdle calls vkQueueWaitIdle.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) QueueWaitIdle(queue C.VkQueue) C.VkResult {
+	if p.vkQueueWaitIdle == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_QueueWaitIdle(p.vkQueueWaitIdle, queue)
+}
+
+// ResetFences calls vkResetFences.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) ResetFences(device C.VkDevice, fenceCount C.uint32_t, pFences *C.VkFence) C.VkResult {
+	if p.vkResetFences == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_ResetFences(p.vkResetFences, device, fenceCount, pFences)
+}
+
+// UnmapMemory calls vkUnmapMemory.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) UnmapMemory(device C.VkDevice, memory C.VkDeviceMemory) {
+	if p.vkUnmapMemory == nil {
+		return
+	}
+	C.invoke_UnmapMemory(p.vkUnmapMemory, device, memory)
+}
+
+// UpdateDescriptorSets calls vkUpdateDescriptorSets.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) UpdateDescriptorSets(device C.VkDevice, descriptorWriteCount C.uint32_t, pDescriptorWrites *C.VkWriteDescriptorSet, descriptorCopyCount C.uint32_t, pDescriptorCopies unsafe.Pointer) {
+	if p.vkUpdateDescriptorSets == nil {
+		return
+	}
+	C.invoke_UpdateDescriptorSets(p.vkUpdateDescriptorSets, device, descriptorWriteCount, pDescriptorWrites, descriptorCopyCount, pDescriptorCopies)
+}
+
+// WaitForFences calls vkWaitForFences.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) WaitForFences(device C.VkDevice, fenceCount C.uint32_t, pFences *C.VkFence, waitAll C.VkBool32, timeout C.uint64_t) C.VkResult {
+	if p.vkWaitForFences == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_WaitForFences(p.vkWaitForFences, device, fenceCount, pFences, waitAll, timeout)
+}
+
+// WaitSemaphores calls vkWaitSemaphores.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) WaitSemaphores(device C.VkDevice, pWaitInfo *C.VkSemaphoreWaitInfo, timeout C.uint64_t) C.VkResult {
+	if p.vkWaitSemaphores == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_WaitSemaphores(p.vkWaitSemaphores, device, pWaitInfo, timeout)
+}
+
+// AcquireNextImageKHR calls vkAcquireNextImageKHR of VK_KHR_swapchain.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) AcquireNextImageKHR(device C.VkDevice, swapchain C.VkSwapchainKHR, timeout C.uint64_t, semaphore C.VkSemaphore, fence C.VkFence, pImageIndex *C.uint32_t) C.VkResult {
+	if p.vkAcquireNextImageKHR == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_AcquireNextImageKHR(p.vkAcquireNextImageKHR, device, swapchain, timeout, semaphore, fence, pImageIndex)
+}
+
+// CreateSwapchainKHR calls vkCreateSwapchainKHR of VK_KHR_swapchain.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) CreateSwapchainKHR(device C.VkDevice, pCreateInfo *C.VkSwapchainCreateInfoKHR, pAllocator *C.VkAllocationCallbacks, pSwapchain *C.VkSwapchainKHR) C.VkResult {
+	if p.vkCreateSwapchainKHR == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_CreateSwapchainKHR(p.vkCreateSwapchainKHR, device, pCreateInfo, pAllocator, pSwapchain)
+}
+
+// DestroySwapchainKHR calls vkDestroySwapchainKHR of VK_KHR_swapchain.
+//
+// The call is a no-op if the command is not present.
+func (p *deviceProcs) DestroySwapchainKHR(device C.VkDevice, swapchain C.VkSwapchainKHR, pAllocator *C.VkAllocationCallbacks) {
+	if p.vkDestroySwapchainKHR == nil {
+		return
+	}
+	C.invoke_DestroySwapchainKHR(p.vkDestroySwapchainKHR, device, swapchain, pAllocator)
+}
+
+// GetSwapchainImagesKHR calls vkGetSwapchainImagesKHR of VK_KHR_swapchain.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) GetSwapchainImagesKHR(device C.VkDevice, swapchain C.VkSwapchainKHR, pSwapchainImageCount *C.uint32_t, pSwapchainImages *C.VkImage) C.VkResult {
+	if p.vkGetSwapchainImagesKHR == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_GetSwapchainImagesKHR(p.vkGetSwapchainImagesKHR, device, swapchain, pSwapchainImageCount, pSwapchainImages)
+}
+
+// QueuePresentKHR calls vkQueuePresentKHR of VK_KHR_swapchain.
+//
+// VK_ERROR_EXTENSION_NOT_PRESENT is returned if the command is not present.
+func (p *deviceProcs) QueuePresentKHR(queue C.VkQueue, pPresentInfo *C.VkPresentInfoKHR) C.VkResult {
+	if p.vkQueuePresentKHR == nil {
+		return C.VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	return C.invoke_QueuePresentKHR(p.vkQueuePresentKHR, queue, pPresentInfo)
+}
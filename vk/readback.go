@@ -0,0 +1,172 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"image"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// readImage copies the given color image to host memory, and returns its
+// pixels. The image is accessed in the given layout, to which it is returned
+// after the copy, and must have been created with
+// VK_IMAGE_USAGE_TRANSFER_SRC_BIT. Images of 8-bit RGBA and BGRA formats are
+// supported.
+func readImage(app *App, img C.VkImage, layout C.VkImageLayout, format C.VkFormat, extent C.VkExtent2D) (*image.RGBA, error) {
+	bgra, err := isBGRA(format)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	stagingBuffer, stagingBufferMem, err := createReadbackBuffer(app, "readbackStagingBuffer", extent)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer destroyBuffer(app, stagingBuffer, stagingBufferMem)
+
+	// Copy image to staging buffer.
+	scratch := newArena()
+	defer scratch.free()
+	commandBuffer, err := beginSingleTimeCommands(app, scratch, "readbackCommandBuffer")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	cmdReadImage(app, scratch, commandBuffer, img, layout, extent, *stagingBuffer)
+	if err := endSingleTimeCommands(app, scratch, commandBuffer); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Copy pixels from staging buffer.
+	dst, err := readbackPixels(app, stagingBufferMem, extent)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if bgra {
+		bgraToRGBA(dst)
+	}
+	return dst, nil
+}
+
+// isBGRA reports whether the given image format stores color channels in BGRA
+// order, rather than RGBA order. An error is returned if the format is not an
+// 8-bit RGBA or BGRA format.
+//
+// Pixels of sRGB formats are read as is, since PNG images are sRGB encoded.
+func isBGRA(format C.VkFormat) (bool, error) {
+	switch format {
+	case C.VK_FORMAT_R8G8B8A8_UNORM, C.VK_FORMAT_R8G8B8A8_SRGB:
+		return false, nil
+	case C.VK_FORMAT_B8G8R8A8_UNORM, C.VK_FORMAT_B8G8R8A8_SRGB:
+		return true, nil
+	default:
+		return false, errors.Errorf("support for reading image of format %d not yet implemented", format)
+	}
+}
+
+// createReadbackBuffer creates a host-visible buffer with the given name, large
+// enough to hold the pixels of an 8-bit RGBA image of the given extent.
+func createReadbackBuffer(app *App, name string, extent C.VkExtent2D) (*C.VkBuffer, *C.VkDeviceMemory, error) {
+	size := C.VkDeviceSize(4 * extent.width * extent.height)
+	usage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_TRANSFER_DST_BIT)
+	properties := C.VkMemoryPropertyFlags(C.VK_MEMORY_PROPERTY_HOST_VISIBLE_BIT | C.VK_MEMORY_PROPERTY_HOST_COHERENT_BIT)
+	buffer, bufferMem, err := createBuffer(app, name, size, usage, properties)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	return buffer, bufferMem, nil
+}
+
+// cmdReadImage records commands to copy the given color image to the given
+// readback buffer. The image is accessed in the given layout, to which it is
+// returned after the copy, and the copy is made visible to the host.
+func cmdReadImage(app *App, scratch *arena, commandBuffer C.VkCommandBuffer, img C.VkImage, layout C.VkImageLayout, extent C.VkExtent2D, buffer C.VkBuffer) {
+	beginLabel(app, scratch, commandBuffer, "read image", labelColorCopy)
+	subresourceRange := C.VkImageSubresourceRange{
+		aspectMask:     C.VK_IMAGE_ASPECT_COLOR_BIT,
+		baseMipLevel:   0,
+		levelCount:     1,
+		baseArrayLayer: 0,
+		layerCount:     1,
+	}
+	// Wait for rendering to finish before copying.
+	toTransferBarriers := scratch.newVkImageMemoryBarrierSlice(
+		C.VkImageMemoryBarrier{
+			sType:               C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER,
+			srcAccessMask:       C.VK_ACCESS_COLOR_ATTACHMENT_WRITE_BIT,
+			dstAccessMask:       C.VK_ACCESS_TRANSFER_READ_BIT,
+			oldLayout:           layout,
+			newLayout:           C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL,
+			srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			image:               img,
+			subresourceRange:    subresourceRange,
+		},
+	)
+	app.deviceProcs.CmdPipelineBarrier(commandBuffer, C.VK_PIPELINE_STAGE_COLOR_ATTACHMENT_OUTPUT_BIT, C.VK_PIPELINE_STAGE_TRANSFER_BIT, 0, 0, nil, 0, nil, C.uint(len(toTransferBarriers)), &toTransferBarriers[0])
+	copyRegions := scratch.newVkBufferImageCopySlice(
+		C.VkBufferImageCopy{
+			bufferOffset:      0,
+			bufferRowLength:   0, // tightly packed.
+			bufferImageHeight: 0, // tightly packed.
+			imageSubresource: C.VkImageSubresourceLayers{
+				aspectMask:     C.VK_IMAGE_ASPECT_COLOR_BIT,
+				mipLevel:       0,
+				baseArrayLayer: 0,
+				layerCount:     1,
+			},
+			imageOffset: C.VkOffset3D{x: 0, y: 0, z: 0},
+			imageExtent: C.VkExtent3D{width: extent.width, height: extent.height, depth: 1},
+		},
+	)
+	app.deviceProcs.CmdCopyImageToBuffer(commandBuffer, img, C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL, buffer, C.uint(len(copyRegions)), &copyRegions[0])
+	// Return image to its original layout, and make the copy visible to the
+	// host.
+	fromTransferBarriers := scratch.newVkImageMemoryBarrierSlice(
+		C.VkImageMemoryBarrier{
+			sType:               C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER,
+			srcAccessMask:       C.VK_ACCESS_TRANSFER_READ_BIT,
+			dstAccessMask:       0,
+			oldLayout:           C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL,
+			newLayout:           layout,
+			srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			image:               img,
+			subresourceRange:    subresourceRange,
+		},
+	)
+	hostBarriers := scratch.newVkMemoryBarrierSlice(
+		C.VkMemoryBarrier{
+			sType:         C.VK_STRUCTURE_TYPE_MEMORY_BARRIER,
+			srcAccessMask: C.VK_ACCESS_TRANSFER_WRITE_BIT,
+			dstAccessMask: C.VK_ACCESS_HOST_READ_BIT,
+		},
+	)
+	app.deviceProcs.CmdPipelineBarrier(commandBuffer, C.VK_PIPELINE_STAGE_TRANSFER_BIT, C.VK_PIPELINE_STAGE_HOST_BIT|C.VK_PIPELINE_STAGE_BOTTOM_OF_PIPE_BIT, 0, C.uint(len(hostBarriers)), &hostBarriers[0], 0, nil, C.uint(len(fromTransferBarriers)), &fromTransferBarriers[0])
+	endLabel(app, commandBuffer)
+}
+
+// readbackPixels returns a copy of the pixels of the given readback buffer,
+// holding an image of the given extent. The pixels are returned as stored
+// (i.e. in RGBA or BGRA order).
+func readbackPixels(app *App, bufferMem *C.VkDeviceMemory, extent C.VkExtent2D) (*image.RGBA, error) {
+	width, height := int(extent.width), int(extent.height)
+	size := C.VkDeviceSize(4 * width * height)
+	const offset = 0
+	var data unsafe.Pointer
+	if result := app.deviceProcs.MapMemory(*app.device, *bufferMem, offset, size, 0, &data); result != C.VK_SUCCESS {
+		return nil, errors.Wrapf(Result(result), "unable to map memory of readback buffer with size=%d", size)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	copy(dst.Pix, unsafe.Slice((*byte)(data), size))
+	app.deviceProcs.UnmapMemory(*app.device, *bufferMem)
+	return dst, nil
+}
+
+// bgraToRGBA converts the pixels of the given image from BGRA to RGBA order.
+func bgraToRGBA(img *image.RGBA) {
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i+0], img.Pix[i+2] = img.Pix[i+2], img.Pix[i+0]
+	}
+}
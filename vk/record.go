@@ -0,0 +1,131 @@
+package vk
+
+import (
+	"image"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Recorder receives the frames of a recording (e.g. to write an animated GIF).
+type Recorder interface {
+	// AddFrame adds the next frame of the recording, rendered at the given time
+	// relative to the first frame. The image must not be modified.
+	AddFrame(img *image.RGBA, t time.Duration) error
+	// Close ends the recording.
+	Close() error
+}
+
+// Recording specifies a recording of consecutive frames.
+type Recording struct {
+	// Recorder of frames.
+	Recorder Recorder
+	// Number of frames to record; or 0 to record for the given duration.
+	Frames int
+	// Duration to record for, measured by the clock of the application.
+	Duration time.Duration
+}
+
+// done reports whether the recording is complete, after the given number of
+// frames and at the given time relative to the first frame.
+func (rec *Recording) done(nframes int, t time.Duration) bool {
+	if rec.Frames > 0 {
+		return nframes >= rec.Frames
+	}
+	return t >= rec.Duration
+}
+
+// recording is a recording of presented frames in progress.
+type recording struct {
+	*Recording
+	// Time of the first frame of the recording, measured by the clock of the
+	// application.
+	start time.Duration
+	// Number of frames requested to be captured.
+	nframes int
+	// Number of captures in progress.
+	npending int
+	// Set once all frames of the recording have been requested.
+	stopped bool
+	// First error of the recording.
+	err error
+}
+
+// StartRecording starts recording the presented frames of the application,
+// beginning with the next frame. The recorder is closed once the recording is
+// complete. Errors are logged.
+func StartRecording(app *App, rec *Recording) error {
+	if app.recording != nil {
+		return errors.New("recording already in progress")
+	}
+	if rec.Frames <= 0 && rec.Duration <= 0 {
+		return errors.Errorf("invalid recording; expected positive number of frames or duration")
+	}
+	if err := checkCaptureSupport(app); err != nil {
+		return errors.Wrap(err, "unable to record frames")
+	}
+	app.recording = &recording{Recording: rec, start: -1}
+	return nil
+}
+
+// updateRecording requests a capture of the frame at the given time, if
+// recording.
+func updateRecording(app *App, t time.Duration) {
+	r := app.recording
+	if r == nil || r.stopped {
+		return
+	}
+	if r.start < 0 {
+		r.start = t
+	}
+	frameTime := t - r.start
+	if r.err != nil || r.done(r.nframes, frameTime) {
+		r.stopped = true
+		closeRecording(app)
+		return
+	}
+	r.nframes++
+	r.npending++
+	requestCapture(app, "recording", func(img *image.RGBA) {
+		r.npending--
+		switch {
+		case img == nil:
+			if r.err == nil {
+				r.err = errors.New("unable to capture frame")
+			}
+		case r.err == nil:
+			if err := r.Recorder.AddFrame(img, frameTime); err != nil {
+				r.err = errors.WithStack(err)
+			}
+		}
+		closeRecording(app)
+	})
+}
+
+// closeRecording closes the recorder of the recording, once all frames have
+// been requested and captured.
+func closeRecording(app *App) {
+	r := app.recording
+	if r == nil || !r.stopped || r.npending > 0 {
+		return
+	}
+	app.recording = nil
+	if err := r.Recorder.Close(); err != nil && r.err == nil {
+		r.err = errors.WithStack(err)
+	}
+	if r.err != nil {
+		warn.Printf("unable to record frames: %+v", r.err)
+		return
+	}
+	dbg.Printf("recorded %d frames", r.nframes)
+}
+
+// stopRecording stops the recording of the application, if any; e.g. when the
+// window is closed. Captures in progress must have been finished.
+func stopRecording(app *App) {
+	if app.recording == nil {
+		return
+	}
+	app.recording.stopped = true
+	closeRecording(app)
+}
@@ -0,0 +1,130 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"math/rand"
+
+	"github.com/pkg/errors"
+)
+
+// handleReloadRequests reloads the shaders and switches scenes as requested
+// since the previous frame. Replaced resources are released through the
+// deletion queue, once no longer in use by frames in flight.
+func handleReloadRequests(app *App) error {
+	if app.reloadShadersRequested {
+		app.reloadShadersRequested = false
+		if err := reloadShaders(app); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if app.nextSceneRequested {
+		app.nextSceneRequested = false
+		next := scenes[0]
+		for i, s := range scenes {
+			if s == app.scene {
+				next = scenes[(i+1)%len(scenes)]
+				break
+			}
+		}
+		if err := swapScene(app, next); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// reloadShaders recreates the graphics pipelines from the shader files. The
+// previous graphics pipelines are kept if the shaders fail to load.
+func reloadShaders(app *App) error {
+	dbg.Println("reloading shaders")
+	if err := reloadPipelines(app); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// swapScene replaces the mesh and scene graph of the current scene (and its
+// animation) with those of the given scene. The previous scene is kept if the resources of the
+// given scene fail to be created.
+func swapScene(app *App, s *scene) (err error) {
+	dbg.Printf("switching to scene %q", s.name)
+	prev := struct {
+		scene        *scene
+		sceneMesh    *Mesh
+		sceneTexture *texture
+		sceneRoot    *Node
+		animation    *animation
+		sceneUpload  *upload
+	}{
+		scene:        app.scene,
+		sceneMesh:    app.sceneMesh,
+		sceneTexture: app.sceneTexture,
+		sceneRoot:    app.sceneRoot,
+		animation:    app.animation,
+		sceneUpload:  app.sceneUpload,
+	}
+	app.scene = s
+	app.sceneMesh = nil
+	app.sceneTexture = nil
+	app.animation = nil
+	defer func() {
+		if err == nil {
+			return
+		}
+		// Release resources of the given scene, only used by uploads.
+		if app.sceneUpload == prev.sceneUpload {
+			app.sceneUpload = nil
+		}
+		if e := app.sceneUpload.wait(app); e != nil {
+			warn.Printf("%+v", e) // print warning and continue
+		}
+		if app.animation != nil {
+			destroyAnimation(app, app.animation)
+		}
+		if app.sceneTexture != nil {
+			destroyTexture(app, app.sceneTexture)
+		}
+		if app.sceneMesh != nil {
+			destroyMesh(app, app.sceneMesh)
+		}
+		app.scene = prev.scene
+		app.sceneMesh = prev.sceneMesh
+		app.sceneTexture = prev.sceneTexture
+		app.sceneRoot = prev.sceneRoot
+		app.animation = prev.animation
+		app.sceneUpload = prev.sceneUpload
+	}()
+	// Create mesh of scene.
+	vertices := s.vertices(rand.New(rand.NewSource(app.seed)))
+	mesh, meshUpload, err := createMesh(app, s.name, vertices, len(s.computeShader) > 0)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.sceneMesh = mesh
+	app.sceneRoot = newSceneGraph(s, mesh)
+	app.sceneUpload = meshUpload
+	tex, err := createSceneTexture(app, s)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.sceneTexture = tex
+	if err := flushUploads(app); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := initAnimation(app); err != nil {
+		return errors.WithStack(err)
+	}
+	// Release resources of the previous scene.
+	deferDestroyMesh(app, prev.sceneMesh)
+	if prev.sceneTexture != nil {
+		deferDestroyTexture(app, prev.sceneTexture)
+	}
+	if prev.animation != nil {
+		deferRelease(app, func() {
+			destroyAnimation(app, prev.animation)
+		})
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"unsafe"
+)
+
+// Dynamic rendering (Vulkan 1.3 or VK_KHR_dynamic_rendering) renders directly
+// to image views with vkCmdBeginRendering, without render pass and framebuffer
+// objects to recreate with the swapchain. The layout transitions and
+// dependencies otherwise specified by the render pass are recorded as explicit
+// image memory barriers before and after rendering.
+
+// loadDynamicRenderingProcs loads the commands of dynamic rendering of the
+// device; the commands of VK_KHR_dynamic_rendering on Vulkan 1.2. Render
+// passes are used if the commands are not present.
+func loadDynamicRenderingProcs(app *App) {
+	if app.dynamicRenderingKHR {
+		scratch := newArena()
+		defer scratch.free()
+		p := app.deviceProcs
+		p.vkCmdBeginRendering = (C.PFN_vkCmdBeginRendering)(unsafe.Pointer(app.instanceProcs.GetDeviceProcAddr(*app.device, scratch.cString("vkCmdBeginRenderingKHR"))))
+		p.vkCmdEndRendering = (C.PFN_vkCmdEndRendering)(unsafe.Pointer(app.instanceProcs.GetDeviceProcAddr(*app.device, scratch.cString("vkCmdEndRenderingKHR"))))
+	}
+	if app.deviceProcs.vkCmdBeginRendering == nil || app.deviceProcs.vkCmdEndRendering == nil {
+		warn.Println("unable to load commands of dynamic rendering; using render passes")
+		app.dynamicRendering = false
+		app.dynamicRenderingKHR = false
+		return
+	}
+	dbg.Printf("rendering with dynamic rendering (VK_KHR_dynamic_rendering=%v)", app.dynamicRenderingKHR)
+}
+
+// cmdBeginDynamicRendering records the beginning of dynamic rendering to the
+// swapchain image of the given index, cleared to the given color. When
+// multisampling, the multisampled color target is rendered to, and resolved
+// into the swapchain image at the end of rendering.
+//
+// The contents of the images are discarded, as with the initial layout
+// VK_IMAGE_LAYOUT_UNDEFINED of the render pass (see initRenderPass).
+func cmdBeginDynamicRendering(app *App, scratch *arena, commandBuffer C.VkCommandBuffer, imageIndex int, clearColor C.VkClearValue) {
+	// Transition color targets to color attachment layout, once the swapchain
+	// image has been acquired (the image available semaphore is waited for at
+	// the color attachment output stage), and once the color attachment writes
+	// of earlier frames have completed; the multisampled color target is shared
+	// by the frames in flight (write-after-write hazard).
+	images := []C.VkImage{app.swapchainImgs[imageIndex]}
+	if app.msaaColorImg != nil {
+		images = append(images, *app.msaaColorImg)
+	}
+	barriers := scratch.makeVkImageMemoryBarrierSlice(len(images))
+	for j, image := range images {
+		barriers[j] = C.VkImageMemoryBarrier{
+			sType:               C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER,
+			srcAccessMask:       C.VK_ACCESS_COLOR_ATTACHMENT_WRITE_BIT,
+			dstAccessMask:       C.VK_ACCESS_COLOR_ATTACHMENT_WRITE_BIT,
+			oldLayout:           C.VK_IMAGE_LAYOUT_UNDEFINED,
+			newLayout:           C.VK_IMAGE_LAYOUT_COLOR_ATTACHMENT_OPTIMAL,
+			srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			image:               image,
+			subresourceRange:    colorSubresourceRange(),
+		}
+	}
+	app.deviceProcs.CmdPipelineBarrier(commandBuffer, C.VK_PIPELINE_STAGE_COLOR_ATTACHMENT_OUTPUT_BIT, C.VK_PIPELINE_STAGE_COLOR_ATTACHMENT_OUTPUT_BIT, 0, 0, nil, 0, nil, C.uint(len(barriers)), &barriers[0])
+
+	colorAttachment := C.VkRenderingAttachmentInfo{
+		sType:       C.VK_STRUCTURE_TYPE_RENDERING_ATTACHMENT_INFO,
+		imageView:   app.swapchainImgViews[imageIndex],
+		imageLayout: C.VK_IMAGE_LAYOUT_COLOR_ATTACHMENT_OPTIMAL,
+		resolveMode: C.VK_RESOLVE_MODE_NONE,
+		loadOp:      C.VK_ATTACHMENT_LOAD_OP_CLEAR,
+		storeOp:     C.VK_ATTACHMENT_STORE_OP_STORE,
+		clearValue:  clearColor,
+	}
+	if app.msaaColorImgView != nil {
+		// The multisampled color target is resolved into the swapchain image,
+		// and its samples discarded.
+		colorAttachment.imageView = *app.msaaColorImgView
+		colorAttachment.resolveMode = C.VK_RESOLVE_MODE_AVERAGE_BIT
+		colorAttachment.resolveImageView = app.swapchainImgViews[imageIndex]
+		colorAttachment.resolveImageLayout = C.VK_IMAGE_LAYOUT_COLOR_ATTACHMENT_OPTIMAL
+		colorAttachment.storeOp = C.VK_ATTACHMENT_STORE_OP_DONT_CARE
+	}
+	colorAttachments := scratch.newVkRenderingAttachmentInfoSlice(colorAttachment)
+	renderingInfo := scratch.newVkRenderingInfo(C.VkRenderingInfo{
+		sType: C.VK_STRUCTURE_TYPE_RENDERING_INFO,
+		renderArea: C.VkRect2D{
+			offset: C.VkOffset2D{x: 0, y: 0},
+			extent: app.swapchainExtent,
+		},
+		layerCount:           1,
+		colorAttachmentCount: C.uint32_t(len(colorAttachments)),
+		pColorAttachments:    &colorAttachments[0],
+	})
+	app.deviceProcs.CmdBeginRendering(commandBuffer, renderingInfo)
+}
+
+// cmdEndDynamicRendering records the end of dynamic rendering to the swapchain
+// image of the given index, and transitions the swapchain image to the layout
+// of presentation; or of copies to host memory when headless.
+func cmdEndDynamicRendering(app *App, scratch *arena, commandBuffer C.VkCommandBuffer, imageIndex int) {
+	app.deviceProcs.CmdEndRendering(commandBuffer)
+	newLayout := C.VkImageLayout(C.VK_IMAGE_LAYOUT_PRESENT_SRC_KHR)
+	dstStage := C.VkPipelineStageFlags(C.VK_PIPELINE_STAGE_BOTTOM_OF_PIPE_BIT)
+	dstAccess := C.VkAccessFlags(0) // presentation is synchronized by semaphore.
+	if app.headless {
+		newLayout = C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL
+		dstStage = C.VK_PIPELINE_STAGE_TRANSFER_BIT
+		dstAccess = C.VK_ACCESS_TRANSFER_READ_BIT
+	}
+	barriers := scratch.newVkImageMemoryBarrierSlice(
+		C.VkImageMemoryBarrier{
+			sType:               C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER,
+			srcAccessMask:       C.VK_ACCESS_COLOR_ATTACHMENT_WRITE_BIT,
+			dstAccessMask:       dstAccess,
+			oldLayout:           C.VK_IMAGE_LAYOUT_COLOR_ATTACHMENT_OPTIMAL,
+			newLayout:           newLayout,
+			srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			image:               app.swapchainImgs[imageIndex],
+			subresourceRange:    colorSubresourceRange(),
+		},
+	)
+	app.deviceProcs.CmdPipelineBarrier(commandBuffer, C.VK_PIPELINE_STAGE_COLOR_ATTACHMENT_OUTPUT_BIT, dstStage, 0, 0, nil, 0, nil, C.uint(len(barriers)), &barriers[0])
+}
+
+// colorSubresourceRange returns the subresource range of the single mip level
+// and array layer of color images.
+func colorSubresourceRange() C.VkImageSubresourceRange {
+	return C.VkImageSubresourceRange{
+		aspectMask:     C.VK_IMAGE_ASPECT_COLOR_BIT,
+		baseMipLevel:   0,
+		levelCount:     1,
+		baseArrayLayer: 0,
+		layerCount:     1,
+	}
+}
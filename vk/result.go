@@ -0,0 +1,160 @@
+package vk
+
+import "fmt"
+
+// Result is a Vulkan result code (VkResult). Result implements the error
+// interface, and may be compared against the sentinel values below using
+// errors.Is.
+//
+// Successful completion codes are non-negative, and error codes are negative.
+//
+// ref: https://registry.khronos.org/vulkan/specs/1.3-extensions/html/vkspec.html#fundamentals-returncodes
+type Result int32
+
+// Success codes.
+//
+// NOTE: values are copied from vulkan_core.h, so that result codes of recent
+// extensions are covered even when compiling against older Vulkan headers.
+const (
+	Success                  Result = 0          // VK_SUCCESS
+	NotReady                 Result = 1          // VK_NOT_READY
+	Timeout                  Result = 2          // VK_TIMEOUT
+	EventSet                 Result = 3          // VK_EVENT_SET
+	EventReset               Result = 4          // VK_EVENT_RESET
+	Incomplete               Result = 5          // VK_INCOMPLETE
+	PipelineCompileRequired  Result = 1000297000 // VK_PIPELINE_COMPILE_REQUIRED
+	Suboptimal               Result = 1000001003 // VK_SUBOPTIMAL_KHR
+	ThreadIdle               Result = 1000268000 // VK_THREAD_IDLE_KHR
+	ThreadDone               Result = 1000268001 // VK_THREAD_DONE_KHR
+	OperationDeferred        Result = 1000268002 // VK_OPERATION_DEFERRED_KHR
+	OperationNotDeferred     Result = 1000268003 // VK_OPERATION_NOT_DEFERRED_KHR
+	IncompatibleShaderBinary Result = 1000482000 // VK_INCOMPATIBLE_SHADER_BINARY_EXT
+)
+
+// Error codes.
+const (
+	ErrOutOfHostMemory                     Result = -1          // VK_ERROR_OUT_OF_HOST_MEMORY
+	ErrOutOfDeviceMemory                   Result = -2          // VK_ERROR_OUT_OF_DEVICE_MEMORY
+	ErrInitializationFailed                Result = -3          // VK_ERROR_INITIALIZATION_FAILED
+	ErrDeviceLost                          Result = -4          // VK_ERROR_DEVICE_LOST
+	ErrMemoryMapFailed                     Result = -5          // VK_ERROR_MEMORY_MAP_FAILED
+	ErrLayerNotPresent                     Result = -6          // VK_ERROR_LAYER_NOT_PRESENT
+	ErrExtensionNotPresent                 Result = -7          // VK_ERROR_EXTENSION_NOT_PRESENT
+	ErrFeatureNotPresent                   Result = -8          // VK_ERROR_FEATURE_NOT_PRESENT
+	ErrIncompatibleDriver                  Result = -9          // VK_ERROR_INCOMPATIBLE_DRIVER
+	ErrTooManyObjects                      Result = -10         // VK_ERROR_TOO_MANY_OBJECTS
+	ErrFormatNotSupported                  Result = -11         // VK_ERROR_FORMAT_NOT_SUPPORTED
+	ErrFragmentedPool                      Result = -12         // VK_ERROR_FRAGMENTED_POOL
+	ErrUnknown                             Result = -13         // VK_ERROR_UNKNOWN
+	ErrOutOfPoolMemory                     Result = -1000069000 // VK_ERROR_OUT_OF_POOL_MEMORY
+	ErrInvalidExternalHandle               Result = -1000072003 // VK_ERROR_INVALID_EXTERNAL_HANDLE
+	ErrFragmentation                       Result = -1000161000 // VK_ERROR_FRAGMENTATION
+	ErrInvalidOpaqueCaptureAddress         Result = -1000257000 // VK_ERROR_INVALID_OPAQUE_CAPTURE_ADDRESS
+	ErrSurfaceLost                         Result = -1000000000 // VK_ERROR_SURFACE_LOST_KHR
+	ErrNativeWindowInUse                   Result = -1000000001 // VK_ERROR_NATIVE_WINDOW_IN_USE_KHR
+	ErrOutOfDate                           Result = -1000001004 // VK_ERROR_OUT_OF_DATE_KHR
+	ErrIncompatibleDisplay                 Result = -1000003001 // VK_ERROR_INCOMPATIBLE_DISPLAY_KHR
+	ErrValidationFailed                    Result = -1000011001 // VK_ERROR_VALIDATION_FAILED_EXT
+	ErrInvalidShader                       Result = -1000012000 // VK_ERROR_INVALID_SHADER_NV
+	ErrImageUsageNotSupported              Result = -1000023000 // VK_ERROR_IMAGE_USAGE_NOT_SUPPORTED_KHR
+	ErrVideoPictureLayoutNotSupported      Result = -1000023001 // VK_ERROR_VIDEO_PICTURE_LAYOUT_NOT_SUPPORTED_KHR
+	ErrVideoProfileOperationNotSupported   Result = -1000023002 // VK_ERROR_VIDEO_PROFILE_OPERATION_NOT_SUPPORTED_KHR
+	ErrVideoProfileFormatNotSupported      Result = -1000023003 // VK_ERROR_VIDEO_PROFILE_FORMAT_NOT_SUPPORTED_KHR
+	ErrVideoProfileCodecNotSupported       Result = -1000023004 // VK_ERROR_VIDEO_PROFILE_CODEC_NOT_SUPPORTED_KHR
+	ErrVideoStdVersionNotSupported         Result = -1000023005 // VK_ERROR_VIDEO_STD_VERSION_NOT_SUPPORTED_KHR
+	ErrInvalidDRMFormatModifierPlaneLayout Result = -1000158000 // VK_ERROR_INVALID_DRM_FORMAT_MODIFIER_PLANE_LAYOUT_EXT
+	ErrNotPermitted                        Result = -1000174001 // VK_ERROR_NOT_PERMITTED_KHR
+	ErrFullScreenExclusiveModeLost         Result = -1000255000 // VK_ERROR_FULL_SCREEN_EXCLUSIVE_MODE_LOST_EXT
+	ErrInvalidVideoStdParameters           Result = -1000299000 // VK_ERROR_INVALID_VIDEO_STD_PARAMETERS_KHR
+	ErrCompressionExhausted                Result = -1000338000 // VK_ERROR_COMPRESSION_EXHAUSTED_EXT
+)
+
+// resultInfo specifies the name and description of a Vulkan result code.
+type resultInfo struct {
+	// Name of result code (e.g. "VK_ERROR_DEVICE_LOST").
+	name string
+	// Description of result code, as given by the Vulkan specification.
+	desc string
+}
+
+// resultInfos maps from Vulkan result code to result code information.
+var resultInfos = map[Result]resultInfo{
+	// Success codes.
+	Success:                  {name: "VK_SUCCESS", desc: "command successfully completed"},
+	NotReady:                 {name: "VK_NOT_READY", desc: "a fence or query has not yet completed"},
+	Timeout:                  {name: "VK_TIMEOUT", desc: "a wait operation has not completed in the specified time"},
+	EventSet:                 {name: "VK_EVENT_SET", desc: "an event is signaled"},
+	EventReset:               {name: "VK_EVENT_RESET", desc: "an event is unsignaled"},
+	Incomplete:               {name: "VK_INCOMPLETE", desc: "a return array was too small for the result"},
+	PipelineCompileRequired:  {name: "VK_PIPELINE_COMPILE_REQUIRED", desc: "a requested pipeline creation would have required compilation, but the application requested compilation to not be performed"},
+	Suboptimal:               {name: "VK_SUBOPTIMAL_KHR", desc: "a swapchain no longer matches the surface properties exactly, but can still be used to present to the surface successfully"},
+	ThreadIdle:               {name: "VK_THREAD_IDLE_KHR", desc: "a deferred operation is not complete but there is currently no work for this thread to do at the time of this call"},
+	ThreadDone:               {name: "VK_THREAD_DONE_KHR", desc: "a deferred operation is not complete but there is no work remaining to assign to additional threads"},
+	OperationDeferred:        {name: "VK_OPERATION_DEFERRED_KHR", desc: "a deferred operation was requested and at least some of the work was deferred"},
+	OperationNotDeferred:     {name: "VK_OPERATION_NOT_DEFERRED_KHR", desc: "a deferred operation was requested and no operations were deferred"},
+	IncompatibleShaderBinary: {name: "VK_INCOMPATIBLE_SHADER_BINARY_EXT", desc: "the provided binary shader code is not compatible with this device"},
+	// Error codes.
+	ErrOutOfHostMemory:                     {name: "VK_ERROR_OUT_OF_HOST_MEMORY", desc: "a host memory allocation has failed"},
+	ErrOutOfDeviceMemory:                   {name: "VK_ERROR_OUT_OF_DEVICE_MEMORY", desc: "a device memory allocation has failed"},
+	ErrInitializationFailed:                {name: "VK_ERROR_INITIALIZATION_FAILED", desc: "initialization of an object could not be completed for implementation-specific reasons"},
+	ErrDeviceLost:                          {name: "VK_ERROR_DEVICE_LOST", desc: "the logical or physical device has been lost"},
+	ErrMemoryMapFailed:                     {name: "VK_ERROR_MEMORY_MAP_FAILED", desc: "mapping of a memory object has failed"},
+	ErrLayerNotPresent:                     {name: "VK_ERROR_LAYER_NOT_PRESENT", desc: "a requested layer is not present or could not be loaded"},
+	ErrExtensionNotPresent:                 {name: "VK_ERROR_EXTENSION_NOT_PRESENT", desc: "a requested extension is not supported"},
+	ErrFeatureNotPresent:                   {name: "VK_ERROR_FEATURE_NOT_PRESENT", desc: "a requested feature is not supported"},
+	ErrIncompatibleDriver:                  {name: "VK_ERROR_INCOMPATIBLE_DRIVER", desc: "the requested version of Vulkan is not supported by the driver or is otherwise incompatible for implementation-specific reasons"},
+	ErrTooManyObjects:                      {name: "VK_ERROR_TOO_MANY_OBJECTS", desc: "too many objects of the type have already been created"},
+	ErrFormatNotSupported:                  {name: "VK_ERROR_FORMAT_NOT_SUPPORTED", desc: "a requested format is not supported on this device"},
+	ErrFragmentedPool:                      {name: "VK_ERROR_FRAGMENTED_POOL", desc: "a pool allocation has failed due to fragmentation of the pool's memory"},
+	ErrUnknown:                             {name: "VK_ERROR_UNKNOWN", desc: "an unknown error has occurred; either the application has provided invalid input, or an implementation failure has occurred"},
+	ErrOutOfPoolMemory:                     {name: "VK_ERROR_OUT_OF_POOL_MEMORY", desc: "a pool memory allocation has failed"},
+	ErrInvalidExternalHandle:               {name: "VK_ERROR_INVALID_EXTERNAL_HANDLE", desc: "an external handle is not a valid handle of the specified type"},
+	ErrFragmentation:                       {name: "VK_ERROR_FRAGMENTATION", desc: "a descriptor pool creation has failed due to fragmentation"},
+	ErrInvalidOpaqueCaptureAddress:         {name: "VK_ERROR_INVALID_OPAQUE_CAPTURE_ADDRESS", desc: "a buffer creation or memory allocation failed because the requested address is not available"},
+	ErrSurfaceLost:                         {name: "VK_ERROR_SURFACE_LOST_KHR", desc: "a surface is no longer available"},
+	ErrNativeWindowInUse:                   {name: "VK_ERROR_NATIVE_WINDOW_IN_USE_KHR", desc: "the requested window is already in use by Vulkan or another API in a manner which prevents it from being used again"},
+	ErrOutOfDate:                           {name: "VK_ERROR_OUT_OF_DATE_KHR", desc: "a surface has changed in such a way that it is no longer compatible with the swapchain, and further presentation requests using the swapchain will fail"},
+	ErrIncompatibleDisplay:                 {name: "VK_ERROR_INCOMPATIBLE_DISPLAY_KHR", desc: "the display used by a swapchain does not use the same presentable image layout, or is incompatible in a way that prevents sharing an image"},
+	ErrValidationFailed:                    {name: "VK_ERROR_VALIDATION_FAILED_EXT", desc: "a command failed because invalid usage was detected by the implementation or a validation layer"},
+	ErrInvalidShader:                       {name: "VK_ERROR_INVALID_SHADER_NV", desc: "one or more shaders failed to compile or link"},
+	ErrImageUsageNotSupported:              {name: "VK_ERROR_IMAGE_USAGE_NOT_SUPPORTED_KHR", desc: "the requested image usage flags are not supported"},
+	ErrVideoPictureLayoutNotSupported:      {name: "VK_ERROR_VIDEO_PICTURE_LAYOUT_NOT_SUPPORTED_KHR", desc: "the requested video picture layout is not supported"},
+	ErrVideoProfileOperationNotSupported:   {name: "VK_ERROR_VIDEO_PROFILE_OPERATION_NOT_SUPPORTED_KHR", desc: "a video profile operation specified is not supported"},
+	ErrVideoProfileFormatNotSupported:      {name: "VK_ERROR_VIDEO_PROFILE_FORMAT_NOT_SUPPORTED_KHR", desc: "format parameters in a requested video profile chain are not supported"},
+	ErrVideoProfileCodecNotSupported:       {name: "VK_ERROR_VIDEO_PROFILE_CODEC_NOT_SUPPORTED_KHR", desc: "codec-specific parameters in a requested video profile chain are not supported"},
+	ErrVideoStdVersionNotSupported:         {name: "VK_ERROR_VIDEO_STD_VERSION_NOT_SUPPORTED_KHR", desc: "the specified video Std header version is not supported"},
+	ErrInvalidDRMFormatModifierPlaneLayout: {name: "VK_ERROR_INVALID_DRM_FORMAT_MODIFIER_PLANE_LAYOUT_EXT", desc: "the provided DRM format modifier plane layout is invalid"},
+	ErrNotPermitted:                        {name: "VK_ERROR_NOT_PERMITTED_KHR", desc: "the driver implementation has denied a request to acquire a priority above the default priority because the application does not have sufficient privileges"},
+	ErrFullScreenExclusiveModeLost:         {name: "VK_ERROR_FULL_SCREEN_EXCLUSIVE_MODE_LOST_EXT", desc: "an operation on a swapchain created with application controlled full-screen access failed as it did not have exclusive full-screen access"},
+	ErrInvalidVideoStdParameters:           {name: "VK_ERROR_INVALID_VIDEO_STD_PARAMETERS_KHR", desc: "the specified video Std parameters do not adhere to the syntactic or semantic requirements of the used video compression standard"},
+	ErrCompressionExhausted:                {name: "VK_ERROR_COMPRESSION_EXHAUSTED_EXT", desc: "an image creation failed because internal resources required for compression are exhausted"},
+}
+
+// String returns the name of the result code (e.g. "VK_ERROR_DEVICE_LOST").
+func (r Result) String() string {
+	if info, ok := resultInfos[r]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("VkResult(%d)", int32(r))
+}
+
+// Description returns the description of the result code, as given by the
+// Vulkan specification.
+func (r Result) Description() string {
+	if info, ok := resultInfos[r]; ok {
+		return info.desc
+	}
+	return "unknown result code"
+}
+
+// Error returns the name and description of the result code, e.g.
+//
+//	VK_ERROR_DEVICE_LOST (the logical or physical device has been lost)
+func (r Result) Error() string {
+	return fmt.Sprintf("%s (%s)", r.String(), r.Description())
+}
+
+// IsError reports whether the result code is an error code.
+func (r Result) IsError() bool {
+	return r < 0
+}
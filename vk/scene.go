@@ -0,0 +1,252 @@
+package vk
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/pkg/errors"
+)
+
+// scene is a named scene rendered by the application.
+type scene struct {
+	// Scene name.
+	name string
+	// vertices returns the vertices of the triangle list of the scene, using
+	// the given source of pseudo-random numbers.
+	vertices func(r *rand.Rand) []Vertex
+	// Angular velocity of the scene in radians per second.
+	angularVelocity float32
+	// Path of compute shader animating the vertices of the scene each frame on
+	// the compute queue; or empty if the vertices are static.
+	computeShader string
+	// instances returns the instances of the triangle list of the scene at the
+	// given time in seconds, using the given source of pseudo-random numbers;
+	// or nil if the triangle list is drawn once without instancing.
+	instances func(r *rand.Rand, t float32) []Instance
+	// texture returns the image of the texture sampled by the triangle list of
+	// the scene, using the given source of pseudo-random numbers; or nil if the
+	// scene is untextured. Textured scenes are drawn without instancing.
+	texture func(r *rand.Rand) *image.RGBA
+}
+
+// scenes specifies the scenes of the application; the first scene is rendered
+// by default.
+var scenes = []*scene{
+	{name: "quad", vertices: quadVertices, angularVelocity: math.Pi / 2},
+	{name: "triangles", vertices: randomTriangleVertices},
+	{name: "wave", vertices: gridVertices, computeShader: "shaders/wave_comp.spv"},
+	{name: "particles", vertices: quadVertices, instances: particleInstances},
+	{name: "textured", vertices: quadVertices, angularVelocity: math.Pi / 8, texture: checkerboardImage},
+}
+
+// SceneNames returns the names of the scenes of the application.
+func SceneNames() []string {
+	var names []string
+	for _, s := range scenes {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// findScene returns the scene with the given name.
+func findScene(name string) (*scene, error) {
+	for _, s := range scenes {
+		if s.name == name {
+			return s, nil
+		}
+	}
+	return nil, errors.Errorf("unable to locate scene %q", name)
+}
+
+// pushConstants specifies the push constants of the vertex shader, updated
+// every draw.
+type pushConstants struct {
+	// Transform from the model space of the mesh to clip space.
+	transform Mat4
+}
+
+// newSceneGraph returns the scene graph of the given scene, with a child node
+// drawing the given mesh of the scene.
+func newSceneGraph(s *scene, mesh *Mesh) *Node {
+	root := NewNode(s.name)
+	meshNode := NewNode(mesh.name)
+	meshNode.Mesh = mesh
+	root.AddChild(meshNode)
+	return root
+}
+
+// createSceneTexture creates the texture of the given scene, and returns once
+// the texture is ready to be sampled; or returns nil if the scene is
+// untextured.
+func createSceneTexture(app *App, s *scene) (*texture, error) {
+	if s.texture == nil {
+		return nil, nil
+	}
+	img := s.texture(rand.New(rand.NewSource(app.seed)))
+	tex, err := createTexture(app, s.name+"Texture", img)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return tex, nil
+}
+
+// updateSceneGraph animates the scene graph of the scene for the current frame
+// of the clock; the root node is rotated about the z axis, and the instances of
+// the mesh of the scene are updated.
+func updateSceneGraph(app *App) {
+	t := float32(app.clock.now.Seconds())
+	root := app.sceneRoot
+	root.SetRotation(QuatAxisAngle(Vec3{0, 0, 1}, app.scene.angularVelocity*t))
+	if app.scene.instances == nil {
+		return
+	}
+	instances := app.scene.instances(rand.New(rand.NewSource(app.seed)), t)
+	root.Walk(func(n *Node) {
+		if n.Mesh == app.sceneMesh {
+			n.Instances = instances
+		}
+	})
+}
+
+// quadVertices returns the vertices of a quad with one color per corner.
+func quadVertices(r *rand.Rand) []Vertex {
+	// top-left
+	topLeft := Vertex{
+		pos:   vec2(-0.5, -0.5),    // x, y
+		color: vec3(1.0, 0.0, 0.0), // red
+	}
+	// top-right
+	topRight := Vertex{
+		pos:   vec2(0.5, -0.5),     // x, y
+		color: vec3(0.0, 1.0, 0.0), // green
+	}
+	// bottom-right
+	bottomRight := Vertex{
+		pos:   vec2(0.5, 0.5),      // x, y
+		color: vec3(0.0, 0.0, 1.0), // blue
+	}
+	// bottom-left
+	bottomLeft := Vertex{
+		pos:   vec2(-0.5, 0.5),     // x, y
+		color: vec3(1.0, 1.0, 1.0), // white
+	}
+	return []Vertex{
+		// first triangle.
+		topLeft,
+		topRight,
+		bottomRight,
+		// second triangle.
+		bottomRight,
+		bottomLeft,
+		topLeft,
+	}
+}
+
+// randomTriangleVertices returns the vertices of randomly placed and colored
+// triangles.
+func randomTriangleVertices(r *rand.Rand) []Vertex {
+	const ntriangles = 8
+	randVertex := func() Vertex {
+		return Vertex{
+			pos:   vec2(2*r.Float32()-1, 2*r.Float32()-1),
+			color: vec3(r.Float32(), r.Float32(), r.Float32()),
+		}
+	}
+	var vertices []Vertex
+	for i := 0; i < ntriangles; i++ {
+		a, b, c := randVertex(), randVertex(), randVertex()
+		// Use clockwise winding order, as back faces are culled.
+		if cross := (b.pos[0]-a.pos[0])*(c.pos[1]-a.pos[1]) - (b.pos[1]-a.pos[1])*(c.pos[0]-a.pos[0]); cross < 0 {
+			b, c = c, b
+		}
+		vertices = append(vertices, a, b, c)
+	}
+	return vertices
+}
+
+// gridVertices returns the vertices of a grid of quads, with colors blending
+// from left to right.
+func gridVertices(r *rand.Rand) []Vertex {
+	const (
+		ncols = 32
+		nrows = 4
+	)
+	vertex := func(col, row int) Vertex {
+		x := float32(col) / ncols
+		y := float32(row) / nrows
+		return Vertex{
+			pos:   vec2(1.6*x-0.8, 0.8*y-0.4),
+			color: vec3(1-x, 0.5, x),
+		}
+	}
+	var vertices []Vertex
+	for row := 0; row < nrows; row++ {
+		for col := 0; col < ncols; col++ {
+			topLeft := vertex(col, row)
+			topRight := vertex(col+1, row)
+			bottomRight := vertex(col+1, row+1)
+			bottomLeft := vertex(col, row+1)
+			vertices = append(vertices,
+				// first triangle.
+				topLeft,
+				topRight,
+				bottomRight,
+				// second triangle.
+				bottomRight,
+				bottomLeft,
+				topLeft,
+			)
+		}
+	}
+	return vertices
+}
+
+// particleInstances returns the instances of particles orbiting the center of
+// the scene at random distances and speeds, at the given time in seconds.
+func particleInstances(r *rand.Rand, t float32) []Instance {
+	const nparticles = 2048
+	instances := make([]Instance, nparticles)
+	for i := range instances {
+		radius := 0.1 + 0.8*r.Float32()
+		phase := 2 * math.Pi * r.Float32()
+		// Angular velocity in radians per second; faster closer to the center.
+		velocity := (0.1 + 0.2*r.Float32()) / radius
+		angle := float64(phase + velocity*t)
+		instances[i] = Instance{
+			Offset: vec2(radius*float32(math.Cos(angle)), radius*float32(math.Sin(angle))),
+			Scale:  0.01 + 0.03*r.Float32(),
+			Angle:  float32(2 * angle),
+			Color:  vec3(0.5+0.5*r.Float32(), 0.5+0.5*r.Float32(), 1.0),
+		}
+	}
+	return instances
+}
+
+// checkerboardImage returns a checkerboard image of white and randomly colored
+// squares; repeated across textured meshes, its mip levels blend the squares
+// as the mesh is minified.
+func checkerboardImage(r *rand.Rand) *image.RGBA {
+	const (
+		// Width and height of the image in pixels.
+		size = 128
+		// Width and height of squares in pixels.
+		squareSize = 16
+	)
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y += squareSize {
+		for x := 0; x < size; x += squareSize {
+			c := color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+			if (x/squareSize+y/squareSize)%2 == 1 {
+				c = color.RGBA{R: uint8(r.Intn(256)), G: uint8(r.Intn(256)), B: uint8(r.Intn(256)), A: 0xFF}
+			}
+			for yy := y; yy < y+squareSize; yy++ {
+				for xx := x; xx < x+squareSize; xx++ {
+					img.SetRGBA(xx, yy, c)
+				}
+			}
+		}
+	}
+	return img
+}
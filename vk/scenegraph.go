@@ -0,0 +1,233 @@
+package vk
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Node is a node of a scene graph, with a local transform relative to its
+// parent node, and optionally an attached mesh, camera or light.
+//
+// The world transform of a node is the product of the local transforms of the
+// node and its ancestors. World transforms are cached, and recomputed on demand
+// once the local transform of the node or of an ancestor has changed.
+type Node struct {
+	// Node name.
+	Name string
+	// Mesh drawn with the world transform of the node; or nil.
+	Mesh *Mesh
+	// Graphics pipeline of the mesh; or nil to use the default pipeline.
+	Pipeline *PipelineDesc
+	// Instances of the mesh, drawn in a single instanced draw; or nil to draw
+	// the mesh once. The instance transforms are 2D, and relative to the node
+	// (see Instance); the slice may be replaced or updated every frame.
+	Instances []Instance
+	// Camera viewing along the -z axis of the node; or nil.
+	Camera *Camera
+	// Light emitting from the node, along the -z axis of the node if
+	// directional; or nil. Not yet used by the renderer (see Light).
+	Light *Light
+
+	// Parent node; or nil if root.
+	parent *Node
+	// Child nodes.
+	children []*Node
+	// Local transform; translation, rotation and scale.
+	translation Vec3
+	rotation    Quat
+	scale       Vec3
+	// Cached world transform, valid unless dirty. If a node is dirty, so are its
+	// descendants.
+	world Mat4
+	dirty bool
+}
+
+// NewNode returns a new node with the given name and an identity transform.
+func NewNode(name string) *Node {
+	return &Node{
+		Name:     name,
+		rotation: IdentityQuat(),
+		scale:    Vec3{1, 1, 1},
+		dirty:    true,
+	}
+}
+
+// Parent returns the parent node of the node; or nil if root.
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Children returns a copy of the child nodes of the node, unaffected by later
+// changes to the children of the node.
+func (n *Node) Children() []*Node {
+	return append([]*Node(nil), n.children...)
+}
+
+// AddChild adds the given node as the last child of the node, detaching it
+// from its previous parent. AddChild panics if the given node is the node
+// itself or one of its ancestors, as the scene graph would contain a cycle.
+func (n *Node) AddChild(child *Node) {
+	for ancestor := n; ancestor != nil; ancestor = ancestor.parent {
+		if ancestor == child {
+			panic(errors.Errorf("unable to add node %q as child of node %q; cycle in scene graph", child.Name, n.Name))
+		}
+	}
+	if child.parent != nil {
+		child.parent.RemoveChild(child)
+	}
+	child.parent = n
+	n.children = append(n.children, child)
+	child.markDirty()
+}
+
+// RemoveChild detaches the given child node from the node.
+func (n *Node) RemoveChild(child *Node) {
+	for i, c := range n.children {
+		if c == child {
+			copy(n.children[i:], n.children[i+1:])
+			n.children[len(n.children)-1] = nil // release reference to moved node.
+			n.children = n.children[:len(n.children)-1]
+			child.parent = nil
+			child.markDirty()
+			return
+		}
+	}
+}
+
+// Translation returns the translation of the local transform of the node.
+func (n *Node) Translation() Vec3 {
+	return n.translation
+}
+
+// SetTranslation sets the translation of the local transform of the node.
+func (n *Node) SetTranslation(t Vec3) {
+	n.translation = t
+	n.markDirty()
+}
+
+// Rotation returns the rotation of the local transform of the node.
+func (n *Node) Rotation() Quat {
+	return n.rotation
+}
+
+// SetRotation sets the rotation of the local transform of the node.
+func (n *Node) SetRotation(r Quat) {
+	n.rotation = r
+	n.markDirty()
+}
+
+// Scale returns the scale of the local transform of the node.
+func (n *Node) Scale() Vec3 {
+	return n.scale
+}
+
+// SetScale sets the scale of the local transform of the node.
+func (n *Node) SetScale(s Vec3) {
+	n.scale = s
+	n.markDirty()
+}
+
+// LocalTransform returns the transform of the node relative to its parent.
+func (n *Node) LocalTransform() Mat4 {
+	return TRS(n.translation, n.rotation, n.scale)
+}
+
+// WorldTransform returns the transform of the node relative to the root of the
+// scene graph.
+func (n *Node) WorldTransform() Mat4 {
+	if n.dirty {
+		if n.parent != nil {
+			n.world = n.parent.WorldTransform().Mul(n.LocalTransform())
+		} else {
+			n.world = n.LocalTransform()
+		}
+		n.dirty = false
+	}
+	return n.world
+}
+
+// Walk calls f for the node and its descendants, in depth-first pre-order.
+func (n *Node) Walk(f func(n *Node)) {
+	f(n)
+	for _, child := range n.children {
+		child.Walk(f)
+	}
+}
+
+// markDirty invalidates the cached world transforms of the node and its
+// descendants.
+func (n *Node) markDirty() {
+	if n.dirty {
+		// Descendants of dirty nodes are dirty.
+		return
+	}
+	n.dirty = true
+	for _, child := range n.children {
+		child.markDirty()
+	}
+}
+
+// Camera is a camera of a scene graph, viewing along the -z axis of its node
+// with y up.
+type Camera struct {
+	// Use perspective projection; orthographic projection otherwise.
+	Perspective bool
+	// Vertical field of view in radians of perspective projection.
+	FovY float32
+	// Height of the view volume of orthographic projection.
+	Height float32
+	// Distances to the near and far clipping planes.
+	Near, Far float32
+}
+
+// Projection returns the projection of the camera for the given aspect ratio
+// (width/height).
+func (c *Camera) Projection(aspect float32) Mat4 {
+	if c.Perspective {
+		return Perspective(c.FovY, aspect, c.Near, c.Far)
+	}
+	halfHeight := c.Height / 2
+	halfWidth := halfHeight * aspect
+	return Orthographic(-halfWidth, halfWidth, -halfHeight, halfHeight, c.Near, c.Far)
+}
+
+// LightKind specifies the kind of a light.
+type LightKind int
+
+// Kinds of lights.
+const (
+	// Light of parallel rays along the -z axis of its node (e.g. sunlight).
+	DirectionalLight LightKind = iota
+	// Light emitted in all directions from the position of its node.
+	PointLight
+)
+
+// Light is a light of a scene graph.
+//
+// Lights are part of the scene graph model (e.g. for importers), but are not
+// yet used by the renderer; the shaders do not shade meshes by light.
+type Light struct {
+	// Kind of light.
+	Kind LightKind
+	// Linear color and intensity of the light.
+	Color     Vec3
+	Intensity float32
+	// Distance beyond which point lights have no effect; or 0 for no limit.
+	Range float32
+}
+
+// sceneViewProjection returns the view-projection transform of the first
+// camera of the scene graph of the given root node, in depth-first pre-order.
+// If the scene graph has no camera, world space is used as clip space.
+func sceneViewProjection(root *Node, aspect float32) Mat4 {
+	var camera *Node
+	root.Walk(func(n *Node) {
+		if camera == nil && n.Camera != nil {
+			camera = n
+		}
+	})
+	if camera == nil {
+		return Identity4()
+	}
+	view := camera.WorldTransform().InverseAffine()
+	return camera.Camera.Projection(aspect).Mul(view)
+}
@@ -0,0 +1,83 @@
+package vk
+
+import (
+	"testing"
+)
+
+func TestAddChildCycle(t *testing.T) {
+	root := NewNode("root")
+	child := NewNode("child")
+	grandchild := NewNode("grandchild")
+	root.AddChild(child)
+	child.AddChild(grandchild)
+	golden := []struct {
+		name   string
+		parent *Node
+		child  *Node
+	}{
+		{name: "self", parent: root, child: root},
+		{name: "parent", parent: child, child: root},
+		{name: "grandparent", parent: grandchild, child: root},
+		{name: "child of self", parent: grandchild, child: grandchild},
+	}
+	for _, g := range golden {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic on cycle", g.name)
+				}
+			}()
+			g.parent.AddChild(g.child)
+		}()
+		// The scene graph is unchanged.
+		if root.Parent() != nil || child.Parent() != root || grandchild.Parent() != child {
+			t.Fatalf("%s: scene graph changed by rejected AddChild", g.name)
+		}
+	}
+	// Moving a node below a sibling is not a cycle.
+	sibling := NewNode("sibling")
+	root.AddChild(sibling)
+	sibling.AddChild(grandchild)
+	if grandchild.Parent() != sibling {
+		t.Errorf("parent mismatch; expected %q, got %v", sibling.Name, grandchild.Parent())
+	}
+	if n := len(child.Children()); n != 0 {
+		t.Errorf("number of children of previous parent mismatch; expected 0, got %d", n)
+	}
+}
+
+func TestChildren(t *testing.T) {
+	root := NewNode("root")
+	a, b, c := NewNode("a"), NewNode("b"), NewNode("c")
+	root.AddChild(a)
+	root.AddChild(b)
+	root.AddChild(c)
+	children := root.Children()
+	root.RemoveChild(a)
+	// The returned slice is unaffected by later changes.
+	want := []*Node{a, b, c}
+	for i := range want {
+		if children[i] != want[i] {
+			t.Errorf("child %d mismatch; expected %q, got %q", i, want[i].Name, children[i].Name)
+		}
+	}
+	// Modifying the returned slice does not change the node.
+	children = root.Children()
+	children[0] = a
+	got := root.Children()
+	if len(got) != 2 || got[0] != b || got[1] != c {
+		t.Errorf("children mismatch; expected [b c], got %v", nodeNames(got))
+	}
+	if a.Parent() != nil {
+		t.Errorf("expected nil parent of removed child, got %q", a.Parent().Name)
+	}
+}
+
+// nodeNames returns the names of the given nodes.
+func nodeNames(nodes []*Node) []string {
+	var names []string
+	for _, n := range nodes {
+		names = append(names, n.Name)
+	}
+	return names
+}
@@ -0,0 +1,54 @@
+package vk
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RequestScreenshot requests a screenshot of the next presented frame, which is
+// written to the given PNG file. An empty path is replaced by a path of the
+// working directory based on the current time.
+//
+// The swapchain image is copied to host memory as part of rendering the frame,
+// and the PNG file is written in the background once the frame is complete,
+// so as not to stall the next frames. Errors are logged.
+func RequestScreenshot(app *App, path string) {
+	if len(path) == 0 {
+		path = fmt.Sprintf("screenshot_%s.png", time.Now().Format("20060102_150405.000"))
+	}
+	requestCapture(app, path, func(img *image.RGBA) {
+		if img == nil {
+			return // capture failed; error already logged.
+		}
+		app.screenshotWrites.Add(1)
+		go func() {
+			defer app.screenshotWrites.Done()
+			if err := writePNG(path, img); err != nil {
+				warn.Printf("unable to write screenshot: %+v", err)
+				return
+			}
+			dbg.Printf("screenshot written to %q", path)
+		}()
+	})
+}
+
+// writePNG writes the image to the given PNG file.
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "unable to encode %q", path)
+	}
+	if err := f.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,423 @@
+package vk
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// The decision logic of physical device selection and swapchain creation
+// operates on the plain Go data types below, rather than on Vulkan structures,
+// so that it may be exercised without a GPU (see newFakePhysicalDevice). The
+// data types are populated from Vulkan by queryPhysicalDevice.
+
+// Surface format preferred for swapchain images.
+const (
+	// VK_FORMAT_B8G8R8A8_SRGB
+	preferredSurfaceFormat = 50
+	// VK_COLOR_SPACE_SRGB_NONLINEAR_KHR
+	preferredSurfaceColorSpace = 0
+)
+
+// Vulkan API versions, as encoded by VK_MAKE_API_VERSION.
+const (
+	apiVersion1_0 = 1<<22 | 0<<12
+	apiVersion1_1 = 1<<22 | 1<<12
+	apiVersion1_2 = 1<<22 | 2<<12
+	apiVersion1_3 = 1<<22 | 3<<12
+)
+
+// Device extensions of features promoted to core Vulkan.
+const (
+	// Dynamic rendering, prior to Vulkan 1.3.
+	dynamicRenderingExtension = "VK_KHR_dynamic_rendering"
+	// Timeline semaphores, prior to Vulkan 1.2.
+	timelineSemaphoreExtension = "VK_KHR_timeline_semaphore"
+)
+
+// undefinedExtent is the current extent of surfaces whose size is determined
+// by the extent of the swapchain targeting the surface.
+const undefinedExtent = math.MaxUint32
+
+// physicalDeviceInfo specifies the properties of a physical device (GPU)
+// relevant to device selection and swapchain creation.
+type physicalDeviceInfo struct {
+	// Device name (e.g. "AMD Radeon RX 6800").
+	name string
+	// Device type.
+	deviceType PhysicalDeviceType
+	// Vulkan API version supported by the device.
+	apiVersion uint32
+	// Queue families, indexed by queue family index.
+	queueFamilies []queueFamilyInfo
+	// Names of supported device extensions.
+	extensions []string
+	// Swapchain support of the window surface.
+	swapchainSupport swapchainSupport
+	// Sample counts supported by both color and depth framebuffer attachments.
+	framebufferSampleCounts SampleCount
+	// Sample shading supported (sampleRateShading feature).
+	sampleRateShading bool
+	// Anisotropic filtering supported (samplerAnisotropy feature), and the
+	// maximum anisotropy of samplers.
+	samplerAnisotropy    bool
+	maxSamplerAnisotropy float32
+	// Dynamic rendering supported (dynamicRendering feature of Vulkan 1.3 or of
+	// VK_KHR_dynamic_rendering).
+	dynamicRendering bool
+	// Timeline semaphores supported (timelineSemaphore feature of Vulkan 1.2
+	// or of VK_KHR_timeline_semaphore).
+	timelineSemaphore bool
+}
+
+// queueFamilyInfo specifies the properties of a queue family.
+type queueFamilyInfo struct {
+	// Operations supported by queues of the queue family.
+	flags QueueFlag
+	// Queues of the queue family support presentation to the window surface.
+	presentSupport bool
+}
+
+// swapchainSupport specifies the swapchain support of a window surface.
+type swapchainSupport struct {
+	// Surface capabilities.
+	capabilities surfaceCapabilities
+	// Supported surface formats.
+	formats []surfaceFormat
+	// Supported present modes.
+	presentModes []PresentMode
+}
+
+// surfaceCapabilities specifies the capabilities of a window surface.
+type surfaceCapabilities struct {
+	// Minimum number of swapchain images.
+	minImageCount uint32
+	// Maximum number of swapchain images; or 0 if unlimited.
+	maxImageCount uint32
+	// Current extent of the surface; or undefinedExtent (width and height) if
+	// determined by the swapchain.
+	currentExtent extent2D
+	// Minimum and maximum extent of swapchain images.
+	minImageExtent extent2D
+	maxImageExtent extent2D
+	// Current transform of the surface (VkSurfaceTransformFlagBitsKHR).
+	currentTransform uint32
+	// Supported usage of swapchain images.
+	supportedUsage ImageUsage
+}
+
+// surfaceFormat specifies a format and color space of swapchain images.
+type surfaceFormat struct {
+	// Image format (VkFormat).
+	format uint32
+	// Color space (VkColorSpaceKHR).
+	colorSpace uint32
+}
+
+// extent2D is a two-dimensional extent in pixels.
+type extent2D struct {
+	width  uint32
+	height uint32
+}
+
+// String returns the string representation of the extent (e.g. "800x600").
+func (extent extent2D) String() string {
+	return fmt.Sprintf("%dx%d", extent.width, extent.height)
+}
+
+// swapchainConfig specifies the configuration of a swapchain.
+type swapchainConfig struct {
+	// Extent of swapchain images.
+	extent extent2D
+	// Format and color space of swapchain images.
+	format surfaceFormat
+	// Present mode.
+	presentMode PresentMode
+	// Minimum number of swapchain images.
+	imageCount uint32
+	// Usage of swapchain images.
+	imageUsage ImageUsage
+	// Unique queue family indices accessing swapchain images; images are shared
+	// concurrently if accessed by more than one queue family.
+	queueFamilyIndices []int
+}
+
+// selectPhysicalDevice returns the index of the physical device to use, among
+// the given physical devices. Presentation to a window surface is not required
+// if headless.
+func selectPhysicalDevice(physicalDevices []physicalDeviceInfo, requiredExtensions []string, headless bool) (int, error) {
+	// TODO: rank physical devices by score if more than one is present. E.g.
+	// prefer dedicated graphics card with capability for larger textures.
+	//
+	// ref: https://vulkan-tutorial.com/en/Drawing_a_triangle/Setup/Physical_devices_and_queue_families#page_Base-device-suitability-checks
+	if len(physicalDevices) == 0 {
+		return 0, errors.Errorf("unable to locate physical device (GPU)")
+	}
+	if len(physicalDevices) > 1 {
+		warn.Printf("multiple (%d) physical device (GPU) located; support for ranking physical devices not yet implemented", len(physicalDevices))
+	}
+	for i, physicalDevice := range physicalDevices {
+		if err := checkPhysicalDevice(physicalDevice, requiredExtensions, headless); err != nil {
+			dbg.Printf("physical device %q not suitable; %v", physicalDevice.name, err)
+			continue
+		}
+		return i, nil
+	}
+	return 0, errors.Errorf("unable to locate suitable physical device (GPU)")
+}
+
+// checkPhysicalDevice checks whether the given physical device is able to
+// render to the window surface (or offscreen if headless), and returns an
+// error describing why not otherwise.
+func checkPhysicalDevice(physicalDevice physicalDeviceInfo, requiredExtensions []string, headless bool) error {
+	if _, _, err := selectQueueFamilies(physicalDevice.queueFamilies, headless); err != nil {
+		return errors.WithStack(err)
+	}
+	if missing := missingExtensions(physicalDevice.extensions, requiredExtensions); len(missing) > 0 {
+		return errors.Errorf("missing required device extensions %q", missing)
+	}
+	if headless {
+		return nil
+	}
+	if len(physicalDevice.swapchainSupport.formats) == 0 {
+		return errors.Errorf("no supported surface formats")
+	}
+	if len(physicalDevice.swapchainSupport.presentModes) == 0 {
+		return errors.Errorf("no supported present modes")
+	}
+	return nil
+}
+
+// selectQueueFamilies returns the indices of the queue families to use for
+// graphics and present operations. A single queue family supporting both is
+// preferred. Nothing is presented if headless, in which case the graphics
+// queue family is used in place of the present queue family.
+func selectQueueFamilies(queueFamilies []queueFamilyInfo, headless bool) (graphics, present int, err error) {
+	graphics, present = -1, -1
+	for queueFamilyIndex, queueFamily := range queueFamilies {
+		supportsGraphics := queueFamily.flags&QueueFlagGraphics != 0
+		if supportsGraphics && (queueFamily.presentSupport || headless) {
+			return queueFamilyIndex, queueFamilyIndex, nil
+		}
+		if supportsGraphics && graphics == -1 {
+			graphics = queueFamilyIndex
+		}
+		if queueFamily.presentSupport && present == -1 {
+			present = queueFamilyIndex
+		}
+	}
+	if graphics == -1 {
+		return 0, 0, errors.Errorf("unable to locate queue family with support for graphics operations")
+	}
+	if present == -1 {
+		return 0, 0, errors.Errorf("unable to locate queue family with support for present operations")
+	}
+	return graphics, present, nil
+}
+
+// selectComputeQueueFamily returns the index of the queue family to use for
+// compute operations. A queue family supporting compute but not graphics
+// operations (i.e. an async compute queue) is preferred, so that compute work
+// may execute concurrently with graphics work; otherwise, the given graphics
+// queue family is used, as graphics queue families always support compute
+// operations.
+func selectComputeQueueFamily(queueFamilies []queueFamilyInfo, graphics int) int {
+	for queueFamilyIndex, queueFamily := range queueFamilies {
+		if queueFamily.flags&QueueFlagCompute != 0 && queueFamily.flags&QueueFlagGraphics == 0 {
+			return queueFamilyIndex
+		}
+	}
+	return graphics
+}
+
+// selectTransferQueueFamily returns the index of the queue family to use for
+// uploads. A queue family supporting transfer but neither graphics nor compute
+// operations (i.e. a dedicated transfer queue, typically backed by a DMA
+// engine) is preferred, so that uploads may execute concurrently with
+// rendering; otherwise, the given graphics queue family is used, as graphics
+// queue families always support transfer operations.
+func selectTransferQueueFamily(queueFamilies []queueFamilyInfo, graphics int) int {
+	for queueFamilyIndex, queueFamily := range queueFamilies {
+		if queueFamily.flags&QueueFlagTransfer != 0 && queueFamily.flags&(QueueFlagGraphics|QueueFlagCompute) == 0 {
+			return queueFamilyIndex
+		}
+	}
+	return graphics
+}
+
+// chooseSampleCount returns the number of samples per pixel of multisampled
+// render targets; the largest sample count supported by the framebuffer
+// attachments, not exceeding the requested number of samples. A single sample
+// (i.e. no multisampling) is always supported.
+func chooseSampleCount(requested int, supported SampleCount) SampleCount {
+	samples := SampleCount1
+	for count := SampleCount2; count <= SampleCount64 && int(count) <= requested; count <<= 1 {
+		if supported&count != 0 {
+			samples = count
+		}
+	}
+	return samples
+}
+
+// chooseAnisotropy returns the maximum anisotropy of texture samplers; the
+// requested anisotropy clamped to the maximum supported anisotropy, or 1 (i.e.
+// anisotropic filtering disabled) if not requested or not supported.
+func chooseAnisotropy(requested float32, supported bool, max float32) float32 {
+	if !supported || requested <= 1 {
+		return 1
+	}
+	if requested > max {
+		return max
+	}
+	return requested
+}
+
+// chooseDynamicRendering reports whether to render with dynamic rendering on
+// the given physical device, through an instance of the given Vulkan API
+// version; and whether the VK_KHR_dynamic_rendering extension must be enabled
+// to do so. Dynamic rendering is part of Vulkan 1.3, and provided by the
+// extension on Vulkan 1.2. Otherwise, render passes are used.
+func chooseDynamicRendering(instanceVersion uint32, physicalDevice physicalDeviceInfo) (dynamicRendering, extension bool) {
+	if !physicalDevice.dynamicRendering {
+		return false, false
+	}
+	version := deviceAPIVersion(instanceVersion, physicalDevice)
+	switch {
+	case version >= apiVersion1_3:
+		return true, false
+	case version >= apiVersion1_2 && contains(physicalDevice.extensions, dynamicRenderingExtension):
+		return true, true
+	}
+	return false, false
+}
+
+// chooseTimelineSemaphores reports whether to synchronize with timeline
+// semaphores on the given physical device, through an instance of the given
+// Vulkan API version; and whether the VK_KHR_timeline_semaphore extension must
+// be enabled to do so. Timeline semaphores are part of Vulkan 1.2, and
+// provided by the extension on Vulkan 1.1. Otherwise, binary semaphores and
+// fences are used.
+func chooseTimelineSemaphores(instanceVersion uint32, physicalDevice physicalDeviceInfo) (timelineSemaphores, extension bool) {
+	if !physicalDevice.timelineSemaphore {
+		return false, false
+	}
+	version := deviceAPIVersion(instanceVersion, physicalDevice)
+	switch {
+	case version >= apiVersion1_2:
+		return true, false
+	case version >= apiVersion1_1 && contains(physicalDevice.extensions, timelineSemaphoreExtension):
+		return true, true
+	}
+	return false, false
+}
+
+// deviceAPIVersion returns the Vulkan API version usable with the given
+// physical device through an instance of the given Vulkan API version; the
+// lesser of both versions.
+func deviceAPIVersion(instanceVersion uint32, physicalDevice physicalDeviceInfo) uint32 {
+	if instanceVersion < physicalDevice.apiVersion {
+		return instanceVersion
+	}
+	return physicalDevice.apiVersion
+}
+
+// missingExtensions returns the required extensions not present among the
+// available extensions.
+func missingExtensions(availableExtensions, requiredExtensions []string) []string {
+	var missing []string
+	for _, requiredExtension := range requiredExtensions {
+		if !contains(availableExtensions, requiredExtension) {
+			missing = append(missing, requiredExtension)
+		}
+	}
+	return missing
+}
+
+// configureSwapchain returns the configuration of a swapchain presenting to
+// the window surface, based on the swapchain support of the surface, the
+// preferred present modes, the selected graphics and present queue families,
+// and the framebuffer size of the window.
+func configureSwapchain(support swapchainSupport, preferredPresentModes []PresentMode, graphicsQueueFamilyIndex, presentQueueFamilyIndex int, framebufferWidth, framebufferHeight int) (*swapchainConfig, error) {
+	format, ok := chooseSwapSurfaceFormat(support.formats)
+	if !ok {
+		return nil, errors.Errorf("unable to locate surface format of swapchain; no supported surface formats")
+	}
+	config := &swapchainConfig{
+		extent:             chooseSwapExtent(support.capabilities, framebufferWidth, framebufferHeight),
+		format:             format,
+		presentMode:        chooseSwapPresentMode(support.presentModes, preferredPresentModes),
+		imageCount:         chooseSwapImageCount(support.capabilities),
+		imageUsage:         chooseSwapImageUsage(support.capabilities),
+		queueFamilyIndices: unique(graphicsQueueFamilyIndex, presentQueueFamilyIndex),
+	}
+	return config, nil
+}
+
+// chooseSwapExtent returns the extent of swapchain images; the current extent
+// of the surface if defined, and the framebuffer size of the window clamped to
+// the supported image extents otherwise.
+func chooseSwapExtent(capabilities surfaceCapabilities, framebufferWidth, framebufferHeight int) extent2D {
+	if capabilities.currentExtent.width != undefinedExtent && capabilities.currentExtent.height != undefinedExtent {
+		return capabilities.currentExtent
+	}
+	return extent2D{
+		width:  uint32(clamp(framebufferWidth, int(capabilities.minImageExtent.width), int(capabilities.maxImageExtent.width))),
+		height: uint32(clamp(framebufferHeight, int(capabilities.minImageExtent.height), int(capabilities.maxImageExtent.height))),
+	}
+}
+
+// chooseSwapSurfaceFormat returns the surface format of swapchain images;
+// B8G8R8A8_SRGB with sRGB color space if supported, and the first supported
+// surface format otherwise. The boolean return value indicates success.
+func chooseSwapSurfaceFormat(formats []surfaceFormat) (surfaceFormat, bool) {
+	if len(formats) == 0 {
+		return surfaceFormat{}, false
+	}
+	for _, format := range formats {
+		if format.format == preferredSurfaceFormat && format.colorSpace == preferredSurfaceColorSpace {
+			return format, true
+		}
+	}
+	return formats[0], true
+}
+
+// chooseSwapPresentMode returns the present mode of the swapchain; the first
+// supported of the preferred present modes (DefaultPresentModes if nil), and
+// FIFO (always supported) otherwise.
+func chooseSwapPresentMode(presentModes, preferredPresentModes []PresentMode) PresentMode {
+	if preferredPresentModes == nil {
+		preferredPresentModes = DefaultPresentModes
+	}
+	for _, preferredPresentMode := range preferredPresentModes {
+		for _, presentMode := range presentModes {
+			if presentMode == preferredPresentMode {
+				return presentMode
+			}
+		}
+	}
+	return PresentModeFifo
+}
+
+// chooseSwapImageCount returns the minimum number of swapchain images; one
+// more than the minimum supported, to avoid having to wait for the driver
+// before acquiring the next image, if within the maximum supported.
+func chooseSwapImageCount(capabilities surfaceCapabilities) uint32 {
+	imageCount := capabilities.minImageCount
+	// Max image count of zero means unlimited max image count.
+	if capabilities.maxImageCount == 0 || imageCount+1 <= capabilities.maxImageCount {
+		imageCount++
+	}
+	return imageCount
+}
+
+// chooseSwapImageUsage returns the usage of swapchain images; as color
+// attachments, and as the source of transfer operations (e.g. screenshots) if
+// supported by the surface.
+func chooseSwapImageUsage(capabilities surfaceCapabilities) ImageUsage {
+	usage := ImageUsageColorAttachment
+	if capabilities.supportedUsage&ImageUsageTransferSrc != 0 {
+		usage |= ImageUsageTransferSrc
+	}
+	return usage
+}
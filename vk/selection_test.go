@@ -0,0 +1,460 @@
+package vk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelectPhysicalDevice(t *testing.T) {
+	golden := []struct {
+		name     string
+		modify   func(info *physicalDeviceInfo)
+		headless bool
+		wantErr  bool
+	}{
+		{
+			name:   "fake",
+			modify: func(info *physicalDeviceInfo) {},
+		},
+		{
+			name: "separate graphics and present queue families",
+			modify: func(info *physicalDeviceInfo) {
+				info.queueFamilies = []queueFamilyInfo{
+					{flags: QueueFlagGraphics | QueueFlagCompute | QueueFlagTransfer},
+					{flags: QueueFlagTransfer, presentSupport: true},
+				}
+			},
+		},
+		{
+			name: "no present queue family",
+			modify: func(info *physicalDeviceInfo) {
+				info.queueFamilies[0].presentSupport = false
+			},
+			wantErr: true,
+		},
+		{
+			name: "no present queue family headless",
+			modify: func(info *physicalDeviceInfo) {
+				info.queueFamilies[0].presentSupport = false
+			},
+			headless: true,
+		},
+		{
+			name: "missing device extension",
+			modify: func(info *physicalDeviceInfo) {
+				info.extensions = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "no surface formats",
+			modify: func(info *physicalDeviceInfo) {
+				info.swapchainSupport.formats = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "no surface formats headless",
+			modify: func(info *physicalDeviceInfo) {
+				info.swapchainSupport.formats = nil
+			},
+			headless: true,
+		},
+		{
+			name: "no present modes",
+			modify: func(info *physicalDeviceInfo) {
+				info.swapchainSupport.presentModes = nil
+			},
+			wantErr: true,
+		},
+	}
+	for _, g := range golden {
+		info := newFakePhysicalDevice()
+		g.modify(&info)
+		got, err := selectPhysicalDevice([]physicalDeviceInfo{info}, RequiredDeviceExtensions, g.headless)
+		if g.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got physical device %d", g.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", g.name, err)
+			continue
+		}
+		if got != 0 {
+			t.Errorf("%s: physical device mismatch; expected 0, got %d", g.name, got)
+		}
+	}
+}
+
+func TestSelectQueueFamilies(t *testing.T) {
+	golden := []struct {
+		name          string
+		queueFamilies []queueFamilyInfo
+		headless      bool
+		wantGraphics  int
+		wantPresent   int
+		wantErr       bool
+	}{
+		{
+			name:          "single queue family",
+			queueFamilies: newFakePhysicalDevice().queueFamilies,
+			wantGraphics:  0,
+			wantPresent:   0,
+		},
+		{
+			name: "separate graphics and present queue families",
+			queueFamilies: []queueFamilyInfo{
+				{flags: QueueFlagTransfer, presentSupport: true},
+				{flags: QueueFlagGraphics | QueueFlagCompute | QueueFlagTransfer},
+			},
+			wantGraphics: 1,
+			wantPresent:  0,
+		},
+		{
+			name: "prefer queue family supporting graphics and present",
+			queueFamilies: []queueFamilyInfo{
+				{flags: QueueFlagGraphics},
+				{flags: QueueFlagTransfer, presentSupport: true},
+				{flags: QueueFlagGraphics, presentSupport: true},
+			},
+			wantGraphics: 2,
+			wantPresent:  2,
+		},
+		{
+			name: "headless without present support",
+			queueFamilies: []queueFamilyInfo{
+				{flags: QueueFlagTransfer},
+				{flags: QueueFlagGraphics},
+			},
+			headless:     true,
+			wantGraphics: 1,
+			wantPresent:  1,
+		},
+		{
+			name: "no graphics queue family",
+			queueFamilies: []queueFamilyInfo{
+				{flags: QueueFlagCompute, presentSupport: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no present queue family",
+			queueFamilies: []queueFamilyInfo{
+				{flags: QueueFlagGraphics},
+			},
+			wantErr: true,
+		},
+	}
+	for _, g := range golden {
+		graphics, present, err := selectQueueFamilies(g.queueFamilies, g.headless)
+		if g.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got queue families (graphics=%d, present=%d)", g.name, graphics, present)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", g.name, err)
+			continue
+		}
+		if graphics != g.wantGraphics || present != g.wantPresent {
+			t.Errorf("%s: queue families mismatch; expected (graphics=%d, present=%d), got (graphics=%d, present=%d)", g.name, g.wantGraphics, g.wantPresent, graphics, present)
+		}
+	}
+}
+
+func TestConfigureSwapchain(t *testing.T) {
+	info := newFakePhysicalDevice()
+	config, err := configureSwapchain(info.swapchainSupport, nil, 0, 1, 1024, 768)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &swapchainConfig{
+		extent:             extent2D{width: 800, height: 600},
+		format:             surfaceFormat{format: preferredSurfaceFormat, colorSpace: preferredSurfaceColorSpace},
+		presentMode:        PresentModeMailbox,
+		imageCount:         3,
+		imageUsage:         ImageUsageColorAttachment | ImageUsageTransferSrc,
+		queueFamilyIndices: []int{0, 1},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("swapchain configuration mismatch; expected %+v, got %+v", want, config)
+	}
+	// No supported surface formats.
+	info.swapchainSupport.formats = nil
+	if _, err := configureSwapchain(info.swapchainSupport, nil, 0, 0, 1024, 768); err == nil {
+		t.Errorf("expected error for surface without supported surface formats")
+	}
+}
+
+func TestChooseSwapSurfaceFormat(t *testing.T) {
+	preferred := surfaceFormat{format: preferredSurfaceFormat, colorSpace: preferredSurfaceColorSpace}
+	unorm := surfaceFormat{format: 44, colorSpace: preferredSurfaceColorSpace} // VK_FORMAT_B8G8R8A8_UNORM
+	golden := []struct {
+		name    string
+		formats []surfaceFormat
+		want    surfaceFormat
+		wantOK  bool
+	}{
+		{name: "preferred", formats: []surfaceFormat{unorm, preferred}, want: preferred, wantOK: true},
+		{name: "first if preferred not supported", formats: []surfaceFormat{unorm}, want: unorm, wantOK: true},
+		{name: "empty", formats: nil, wantOK: false},
+	}
+	for _, g := range golden {
+		got, ok := chooseSwapSurfaceFormat(g.formats)
+		if ok != g.wantOK {
+			t.Errorf("%s: ok mismatch; expected %v, got %v", g.name, g.wantOK, ok)
+			continue
+		}
+		if got != g.want {
+			t.Errorf("%s: surface format mismatch; expected %+v, got %+v", g.name, g.want, got)
+		}
+	}
+}
+
+func TestChooseSwapExtent(t *testing.T) {
+	undefined := extent2D{width: undefinedExtent, height: undefinedExtent}
+	golden := []struct {
+		name                                string
+		currentExtent                       extent2D
+		framebufferWidth, framebufferHeight int
+		want                                extent2D
+	}{
+		{
+			name:              "current extent",
+			currentExtent:     extent2D{width: 800, height: 600},
+			framebufferWidth:  1024,
+			framebufferHeight: 768,
+			want:              extent2D{width: 800, height: 600},
+		},
+		{
+			name:              "framebuffer size",
+			currentExtent:     undefined,
+			framebufferWidth:  1024,
+			framebufferHeight: 768,
+			want:              extent2D{width: 1024, height: 768},
+		},
+		{
+			name:              "clamped to min image extent",
+			currentExtent:     undefined,
+			framebufferWidth:  0,
+			framebufferHeight: 0,
+			want:              extent2D{width: 16, height: 8},
+		},
+		{
+			name:              "clamped to max image extent",
+			currentExtent:     undefined,
+			framebufferWidth:  8192,
+			framebufferHeight: 100,
+			want:              extent2D{width: 4096, height: 100},
+		},
+	}
+	for _, g := range golden {
+		capabilities := newFakePhysicalDevice().swapchainSupport.capabilities
+		capabilities.currentExtent = g.currentExtent
+		capabilities.minImageExtent = extent2D{width: 16, height: 8}
+		got := chooseSwapExtent(capabilities, g.framebufferWidth, g.framebufferHeight)
+		if got != g.want {
+			t.Errorf("%s: extent mismatch; expected %v, got %v", g.name, g.want, got)
+		}
+	}
+}
+
+func TestChooseSwapImageCount(t *testing.T) {
+	golden := []struct {
+		name          string
+		minImageCount uint32
+		maxImageCount uint32
+		want          uint32
+	}{
+		{name: "one more than minimum", minImageCount: 2, maxImageCount: 8, want: 3},
+		{name: "unlimited", minImageCount: 2, maxImageCount: 0, want: 3},
+		{name: "limited to maximum", minImageCount: 3, maxImageCount: 3, want: 3},
+	}
+	for _, g := range golden {
+		capabilities := surfaceCapabilities{minImageCount: g.minImageCount, maxImageCount: g.maxImageCount}
+		if got := chooseSwapImageCount(capabilities); got != g.want {
+			t.Errorf("%s: image count mismatch; expected %d, got %d", g.name, g.want, got)
+		}
+	}
+}
+
+func TestChooseSwapPresentMode(t *testing.T) {
+	golden := []struct {
+		name      string
+		supported []PresentMode
+		preferred []PresentMode
+		want      PresentMode
+	}{
+		{
+			name:      "default prefers mailbox",
+			supported: []PresentMode{PresentModeFifo, PresentModeMailbox},
+			want:      PresentModeMailbox,
+		},
+		{
+			name:      "default falls back to FIFO",
+			supported: []PresentMode{PresentModeFifo, PresentModeImmediate},
+			want:      PresentModeFifo,
+		},
+		{
+			name:      "first supported preference",
+			supported: []PresentMode{PresentModeFifo, PresentModeMailbox, PresentModeImmediate},
+			preferred: []PresentMode{PresentModeFifoRelaxed, PresentModeImmediate, PresentModeMailbox},
+			want:      PresentModeImmediate,
+		},
+		{
+			name:      "no supported preference falls back to FIFO",
+			supported: []PresentMode{PresentModeFifo, PresentModeMailbox},
+			preferred: []PresentMode{PresentModeImmediate},
+			want:      PresentModeFifo,
+		},
+		{
+			name:      "VSync disabled falls back to mailbox",
+			supported: []PresentMode{PresentModeFifo, PresentModeMailbox},
+			preferred: noVSyncPresentModes,
+			want:      PresentModeMailbox,
+		},
+	}
+	for _, g := range golden {
+		if got := chooseSwapPresentMode(g.supported, g.preferred); got != g.want {
+			t.Errorf("%s: present mode mismatch; expected %v, got %v", g.name, g.want, got)
+		}
+	}
+}
+
+func TestChooseSampleCount(t *testing.T) {
+	golden := []struct {
+		requested int
+		supported SampleCount
+		want      SampleCount
+	}{
+		{requested: 1, supported: SampleCount1 | SampleCount2 | SampleCount4 | SampleCount8, want: SampleCount1},
+		{requested: 4, supported: SampleCount1 | SampleCount2 | SampleCount4 | SampleCount8, want: SampleCount4},
+		{requested: 8, supported: SampleCount1 | SampleCount2 | SampleCount4, want: SampleCount4},
+		{requested: 3, supported: SampleCount1 | SampleCount2 | SampleCount4, want: SampleCount2},
+		{requested: 2, supported: SampleCount1 | SampleCount4, want: SampleCount1},
+		{requested: 8, supported: SampleCount1, want: SampleCount1},
+	}
+	for _, g := range golden {
+		if got := chooseSampleCount(g.requested, g.supported); got != g.want {
+			t.Errorf("sample count mismatch of %d requested samples (supported 0x%X); expected %d, got %d", g.requested, uint32(g.supported), g.want, got)
+		}
+	}
+}
+
+func TestChooseDynamicRendering(t *testing.T) {
+	golden := []struct {
+		name            string
+		instanceVersion uint32
+		modify          func(info *physicalDeviceInfo)
+		want            bool
+		wantExtension   bool
+	}{
+		{
+			name:            "Vulkan 1.3",
+			instanceVersion: apiVersion1_3,
+			modify:          func(info *physicalDeviceInfo) {},
+			want:            true,
+		},
+		{
+			name:            "Vulkan 1.2 instance with extension",
+			instanceVersion: apiVersion1_2,
+			modify: func(info *physicalDeviceInfo) {
+				info.extensions = append(info.extensions, dynamicRenderingExtension)
+			},
+			want:          true,
+			wantExtension: true,
+		},
+		{
+			name:            "Vulkan 1.2 device without extension",
+			instanceVersion: apiVersion1_3,
+			modify: func(info *physicalDeviceInfo) {
+				info.apiVersion = apiVersion1_2
+			},
+		},
+		{
+			name:            "Vulkan 1.1 with extension",
+			instanceVersion: apiVersion1_1,
+			modify: func(info *physicalDeviceInfo) {
+				info.extensions = append(info.extensions, dynamicRenderingExtension)
+			},
+		},
+		{
+			name:            "feature not supported",
+			instanceVersion: apiVersion1_3,
+			modify: func(info *physicalDeviceInfo) {
+				info.dynamicRendering = false
+			},
+		},
+	}
+	for _, g := range golden {
+		info := newFakePhysicalDevice()
+		g.modify(&info)
+		got, gotExtension := chooseDynamicRendering(g.instanceVersion, info)
+		if got != g.want || gotExtension != g.wantExtension {
+			t.Errorf("%s: dynamic rendering mismatch; expected (%v, extension=%v), got (%v, extension=%v)", g.name, g.want, g.wantExtension, got, gotExtension)
+		}
+	}
+}
+
+func TestChooseTimelineSemaphores(t *testing.T) {
+	golden := []struct {
+		name            string
+		instanceVersion uint32
+		modify          func(info *physicalDeviceInfo)
+		want            bool
+		wantExtension   bool
+	}{
+		{
+			name:            "Vulkan 1.3",
+			instanceVersion: apiVersion1_3,
+			modify:          func(info *physicalDeviceInfo) {},
+			want:            true,
+		},
+		{
+			name:            "Vulkan 1.2",
+			instanceVersion: apiVersion1_2,
+			modify:          func(info *physicalDeviceInfo) {},
+			want:            true,
+		},
+		{
+			name:            "Vulkan 1.1 device with extension",
+			instanceVersion: apiVersion1_3,
+			modify: func(info *physicalDeviceInfo) {
+				info.apiVersion = apiVersion1_1
+				info.extensions = append(info.extensions, timelineSemaphoreExtension)
+			},
+			want:          true,
+			wantExtension: true,
+		},
+		{
+			name:            "Vulkan 1.1 without extension",
+			instanceVersion: apiVersion1_1,
+			modify:          func(info *physicalDeviceInfo) {},
+		},
+		{
+			name:            "Vulkan 1.0 with extension",
+			instanceVersion: apiVersion1_0,
+			modify: func(info *physicalDeviceInfo) {
+				info.extensions = append(info.extensions, timelineSemaphoreExtension)
+			},
+		},
+		{
+			name:            "feature not supported",
+			instanceVersion: apiVersion1_3,
+			modify: func(info *physicalDeviceInfo) {
+				info.timelineSemaphore = false
+			},
+		},
+	}
+	for _, g := range golden {
+		info := newFakePhysicalDevice()
+		g.modify(&info)
+		got, gotExtension := chooseTimelineSemaphores(g.instanceVersion, info)
+		if got != g.want || gotExtension != g.wantExtension {
+			t.Errorf("%s: timeline semaphores mismatch; expected (%v, extension=%v), got (%v, extension=%v)", g.name, g.want, g.wantExtension, got, gotExtension)
+		}
+	}
+}
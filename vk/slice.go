@@ -1,271 +1,453 @@
-// Go slices backed by C memory.
+// Code generated by vkgen. DO NOT EDIT.
+
+// Go slices backed by C memory allocated in arenas.
 
 package vk
 
+// #include <stdint.h>
 // #include <vulkan/vulkan.h>
-//
-// #include <stdlib.h>
-//
-// #include "malloc.h"
 import "C"
 
 import (
-	"reflect"
 	"unsafe"
 )
 
-func newVkPipelineSlice(elems ...C.VkPipeline) []C.VkPipeline {
-	n := len(elems)
-	data := C.new_VkPipelines(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkPipeline)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkAttachmentDescriptionSlice(elems ...C.VkAttachmentDescription) []C.VkAttachmentDescription {
-	n := len(elems)
-	data := C.new_VkAttachmentDescriptions(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkAttachmentDescription)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkAttachmentReferenceSlice(elems ...C.VkAttachmentReference) []C.VkAttachmentReference {
-	n := len(elems)
-	data := C.new_VkAttachmentReferences(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkAttachmentReference)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkSubpassDescriptionSlice(elems ...C.VkSubpassDescription) []C.VkSubpassDescription {
-	n := len(elems)
-	data := C.new_VkSubpassDescriptions(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkSubpassDescription)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkViewportSlice(elems ...C.VkViewport) []C.VkViewport {
-	n := len(elems)
-	data := C.new_VkViewports(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkViewport)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkRect2DSlice(elems ...C.VkRect2D) []C.VkRect2D {
-	n := len(elems)
-	data := C.new_VkRect2Ds(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkRect2D)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkPipelineColorBlendAttachmentStateSlice(elems ...C.VkPipelineColorBlendAttachmentState) []C.VkPipelineColorBlendAttachmentState {
-	n := len(elems)
-	data := C.new_VkPipelineColorBlendAttachmentStates(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkPipelineColorBlendAttachmentState)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkGraphicsPipelineCreateInfoSlice(elems ...C.VkGraphicsPipelineCreateInfo) []C.VkGraphicsPipelineCreateInfo {
-	n := len(elems)
-	data := C.new_VkGraphicsPipelineCreateInfos(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkGraphicsPipelineCreateInfo)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkFramebufferSlice(elems ...C.VkFramebuffer) []C.VkFramebuffer {
-	n := len(elems)
-	data := C.new_VkFramebuffers(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkFramebuffer)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkImageViewSlice(elems ...C.VkImageView) []C.VkImageView {
-	n := len(elems)
-	data := C.new_VkImageViews(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkImageView)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkCommandBufferSlice(elems ...C.VkCommandBuffer) []C.VkCommandBuffer {
-	n := len(elems)
-	data := C.new_VkCommandBuffers(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkCommandBuffer)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkClearValueSlice(elems ...C.VkClearValue) []C.VkClearValue {
-	n := len(elems)
-	data := C.new_VkClearValues(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkClearValue)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkSemaphoreSlice(elems ...C.VkSemaphore) []C.VkSemaphore {
-	n := len(elems)
-	data := C.new_VkSemaphores(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkSemaphore)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkSubmitInfoSlice(elems ...C.VkSubmitInfo) []C.VkSubmitInfo {
-	n := len(elems)
-	data := C.new_VkSubmitInfos(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkSubmitInfo)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkSubpassDependencySlice(elems ...C.VkSubpassDependency) []C.VkSubpassDependency {
-	n := len(elems)
-	data := C.new_VkSubpassDependencys(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkSubpassDependency)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newVkSwapchainKHRSlice(elems ...C.VkSwapchainKHR) []C.VkSwapchainKHR {
-	n := len(elems)
-	data := C.new_VkSwapchainKHRs(C.size_t(n))
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.VkSwapchainKHR)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
-	return dst
-}
-
-func newCUint32Slice(elems ...C.uint32_t) []C.uint32_t {
-	n := len(elems)
-	const sizeof_uint32_t = 4
-	data := C.calloc(C.size_t(n), sizeof_uint32_t)
-	sh := reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(data)),
-		Len:  n,
-		Cap:  n,
-	}
-	dst := *(*[]C.uint32_t)(unsafe.Pointer(&sh))
-	for i := range elems {
-		dst[i] = elems[i]
-	}
+func (a *arena) newVkPipelineSlice(elems ...C.VkPipeline) []C.VkPipeline {
+	dst := a.makeVkPipelineSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkPipelineSlice(n int) []C.VkPipeline {
+	return unsafe.Slice((*C.VkPipeline)(a.alloc(uintptr(n)*C.sizeof_VkPipeline)), n)
+}
+
+func (a *arena) newVkAttachmentDescriptionSlice(elems ...C.VkAttachmentDescription) []C.VkAttachmentDescription {
+	dst := a.makeVkAttachmentDescriptionSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkAttachmentDescriptionSlice(n int) []C.VkAttachmentDescription {
+	return unsafe.Slice((*C.VkAttachmentDescription)(a.alloc(uintptr(n)*C.sizeof_VkAttachmentDescription)), n)
+}
+
+func (a *arena) newVkAttachmentReferenceSlice(elems ...C.VkAttachmentReference) []C.VkAttachmentReference {
+	dst := a.makeVkAttachmentReferenceSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkAttachmentReferenceSlice(n int) []C.VkAttachmentReference {
+	return unsafe.Slice((*C.VkAttachmentReference)(a.alloc(uintptr(n)*C.sizeof_VkAttachmentReference)), n)
+}
+
+func (a *arena) newVkSubpassDescriptionSlice(elems ...C.VkSubpassDescription) []C.VkSubpassDescription {
+	dst := a.makeVkSubpassDescriptionSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkSubpassDescriptionSlice(n int) []C.VkSubpassDescription {
+	return unsafe.Slice((*C.VkSubpassDescription)(a.alloc(uintptr(n)*C.sizeof_VkSubpassDescription)), n)
+}
+
+func (a *arena) newVkViewportSlice(elems ...C.VkViewport) []C.VkViewport {
+	dst := a.makeVkViewportSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkViewportSlice(n int) []C.VkViewport {
+	return unsafe.Slice((*C.VkViewport)(a.alloc(uintptr(n)*C.sizeof_VkViewport)), n)
+}
+
+func (a *arena) newVkRect2DSlice(elems ...C.VkRect2D) []C.VkRect2D {
+	dst := a.makeVkRect2DSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkRect2DSlice(n int) []C.VkRect2D {
+	return unsafe.Slice((*C.VkRect2D)(a.alloc(uintptr(n)*C.sizeof_VkRect2D)), n)
+}
+
+func (a *arena) newVkPipelineColorBlendAttachmentStateSlice(elems ...C.VkPipelineColorBlendAttachmentState) []C.VkPipelineColorBlendAttachmentState {
+	dst := a.makeVkPipelineColorBlendAttachmentStateSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkPipelineColorBlendAttachmentStateSlice(n int) []C.VkPipelineColorBlendAttachmentState {
+	return unsafe.Slice((*C.VkPipelineColorBlendAttachmentState)(a.alloc(uintptr(n)*C.sizeof_VkPipelineColorBlendAttachmentState)), n)
+}
+
+func (a *arena) newVkGraphicsPipelineCreateInfoSlice(elems ...C.VkGraphicsPipelineCreateInfo) []C.VkGraphicsPipelineCreateInfo {
+	dst := a.makeVkGraphicsPipelineCreateInfoSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkGraphicsPipelineCreateInfoSlice(n int) []C.VkGraphicsPipelineCreateInfo {
+	return unsafe.Slice((*C.VkGraphicsPipelineCreateInfo)(a.alloc(uintptr(n)*C.sizeof_VkGraphicsPipelineCreateInfo)), n)
+}
+
+func (a *arena) newVkFramebufferSlice(elems ...C.VkFramebuffer) []C.VkFramebuffer {
+	dst := a.makeVkFramebufferSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkFramebufferSlice(n int) []C.VkFramebuffer {
+	return unsafe.Slice((*C.VkFramebuffer)(a.alloc(uintptr(n)*C.sizeof_VkFramebuffer)), n)
+}
+
+func (a *arena) newVkImageSlice(elems ...C.VkImage) []C.VkImage {
+	dst := a.makeVkImageSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkImageSlice(n int) []C.VkImage {
+	return unsafe.Slice((*C.VkImage)(a.alloc(uintptr(n)*C.sizeof_VkImage)), n)
+}
+
+func (a *arena) newVkImageViewSlice(elems ...C.VkImageView) []C.VkImageView {
+	dst := a.makeVkImageViewSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkImageViewSlice(n int) []C.VkImageView {
+	return unsafe.Slice((*C.VkImageView)(a.alloc(uintptr(n)*C.sizeof_VkImageView)), n)
+}
+
+func (a *arena) newVkCommandBufferSlice(elems ...C.VkCommandBuffer) []C.VkCommandBuffer {
+	dst := a.makeVkCommandBufferSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkCommandBufferSlice(n int) []C.VkCommandBuffer {
+	return unsafe.Slice((*C.VkCommandBuffer)(a.alloc(uintptr(n)*C.sizeof_VkCommandBuffer)), n)
+}
+
+func (a *arena) newVkClearValueSlice(elems ...C.VkClearValue) []C.VkClearValue {
+	dst := a.makeVkClearValueSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkClearValueSlice(n int) []C.VkClearValue {
+	return unsafe.Slice((*C.VkClearValue)(a.alloc(uintptr(n)*C.sizeof_VkClearValue)), n)
+}
+
+func (a *arena) newVkSemaphoreSlice(elems ...C.VkSemaphore) []C.VkSemaphore {
+	dst := a.makeVkSemaphoreSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkSemaphoreSlice(n int) []C.VkSemaphore {
+	return unsafe.Slice((*C.VkSemaphore)(a.alloc(uintptr(n)*C.sizeof_VkSemaphore)), n)
+}
+
+func (a *arena) newVkSubmitInfoSlice(elems ...C.VkSubmitInfo) []C.VkSubmitInfo {
+	dst := a.makeVkSubmitInfoSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkSubmitInfoSlice(n int) []C.VkSubmitInfo {
+	return unsafe.Slice((*C.VkSubmitInfo)(a.alloc(uintptr(n)*C.sizeof_VkSubmitInfo)), n)
+}
+
+func (a *arena) newVkSubpassDependencySlice(elems ...C.VkSubpassDependency) []C.VkSubpassDependency {
+	dst := a.makeVkSubpassDependencySlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkSubpassDependencySlice(n int) []C.VkSubpassDependency {
+	return unsafe.Slice((*C.VkSubpassDependency)(a.alloc(uintptr(n)*C.sizeof_VkSubpassDependency)), n)
+}
+
+func (a *arena) newVkSwapchainKHRSlice(elems ...C.VkSwapchainKHR) []C.VkSwapchainKHR {
+	dst := a.makeVkSwapchainKHRSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkSwapchainKHRSlice(n int) []C.VkSwapchainKHR {
+	return unsafe.Slice((*C.VkSwapchainKHR)(a.alloc(uintptr(n)*C.sizeof_VkSwapchainKHR)), n)
+}
+
+func (a *arena) newVkDeviceQueueCreateInfoSlice(elems ...C.VkDeviceQueueCreateInfo) []C.VkDeviceQueueCreateInfo {
+	dst := a.makeVkDeviceQueueCreateInfoSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkDeviceQueueCreateInfoSlice(n int) []C.VkDeviceQueueCreateInfo {
+	return unsafe.Slice((*C.VkDeviceQueueCreateInfo)(a.alloc(uintptr(n)*C.sizeof_VkDeviceQueueCreateInfo)), n)
+}
+
+func (a *arena) newVkPipelineShaderStageCreateInfoSlice(elems ...C.VkPipelineShaderStageCreateInfo) []C.VkPipelineShaderStageCreateInfo {
+	dst := a.makeVkPipelineShaderStageCreateInfoSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkPipelineShaderStageCreateInfoSlice(n int) []C.VkPipelineShaderStageCreateInfo {
+	return unsafe.Slice((*C.VkPipelineShaderStageCreateInfo)(a.alloc(uintptr(n)*C.sizeof_VkPipelineShaderStageCreateInfo)), n)
+}
+
+func (a *arena) newVkVertexInputBindingDescriptionSlice(elems ...C.VkVertexInputBindingDescription) []C.VkVertexInputBindingDescription {
+	dst := a.makeVkVertexInputBindingDescriptionSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkVertexInputBindingDescriptionSlice(n int) []C.VkVertexInputBindingDescription {
+	return unsafe.Slice((*C.VkVertexInputBindingDescription)(a.alloc(uintptr(n)*C.sizeof_VkVertexInputBindingDescription)), n)
+}
+
+func (a *arena) newVkVertexInputAttributeDescriptionSlice(elems ...C.VkVertexInputAttributeDescription) []C.VkVertexInputAttributeDescription {
+	dst := a.makeVkVertexInputAttributeDescriptionSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkVertexInputAttributeDescriptionSlice(n int) []C.VkVertexInputAttributeDescription {
+	return unsafe.Slice((*C.VkVertexInputAttributeDescription)(a.alloc(uintptr(n)*C.sizeof_VkVertexInputAttributeDescription)), n)
+}
+
+func (a *arena) newVkBufferSlice(elems ...C.VkBuffer) []C.VkBuffer {
+	dst := a.makeVkBufferSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkBufferSlice(n int) []C.VkBuffer {
+	return unsafe.Slice((*C.VkBuffer)(a.alloc(uintptr(n)*C.sizeof_VkBuffer)), n)
+}
+
+func (a *arena) newVkDeviceSizeSlice(elems ...C.VkDeviceSize) []C.VkDeviceSize {
+	dst := a.makeVkDeviceSizeSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkDeviceSizeSlice(n int) []C.VkDeviceSize {
+	return unsafe.Slice((*C.VkDeviceSize)(a.alloc(uintptr(n)*C.sizeof_VkDeviceSize)), n)
+}
+
+func (a *arena) newVkPipelineStageFlagsSlice(elems ...C.VkPipelineStageFlags) []C.VkPipelineStageFlags {
+	dst := a.makeVkPipelineStageFlagsSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkPipelineStageFlagsSlice(n int) []C.VkPipelineStageFlags {
+	return unsafe.Slice((*C.VkPipelineStageFlags)(a.alloc(uintptr(n)*C.sizeof_VkPipelineStageFlags)), n)
+}
+
+func (a *arena) newVkBufferCopySlice(elems ...C.VkBufferCopy) []C.VkBufferCopy {
+	dst := a.makeVkBufferCopySlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkBufferCopySlice(n int) []C.VkBufferCopy {
+	return unsafe.Slice((*C.VkBufferCopy)(a.alloc(uintptr(n)*C.sizeof_VkBufferCopy)), n)
+}
+
+func (a *arena) newVkBufferImageCopySlice(elems ...C.VkBufferImageCopy) []C.VkBufferImageCopy {
+	dst := a.makeVkBufferImageCopySlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkBufferImageCopySlice(n int) []C.VkBufferImageCopy {
+	return unsafe.Slice((*C.VkBufferImageCopy)(a.alloc(uintptr(n)*C.sizeof_VkBufferImageCopy)), n)
+}
+
+func (a *arena) newVkMemoryBarrierSlice(elems ...C.VkMemoryBarrier) []C.VkMemoryBarrier {
+	dst := a.makeVkMemoryBarrierSlice(len(elems))
+	copy(dst, elems)
 	return dst
 }
+
+func (a *arena) makeVkMemoryBarrierSlice(n int) []C.VkMemoryBarrier {
+	return unsafe.Slice((*C.VkMemoryBarrier)(a.alloc(uintptr(n)*C.sizeof_VkMemoryBarrier)), n)
+}
+
+func (a *arena) newVkImageMemoryBarrierSlice(elems ...C.VkImageMemoryBarrier) []C.VkImageMemoryBarrier {
+	dst := a.makeVkImageMemoryBarrierSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkImageMemoryBarrierSlice(n int) []C.VkImageMemoryBarrier {
+	return unsafe.Slice((*C.VkImageMemoryBarrier)(a.alloc(uintptr(n)*C.sizeof_VkImageMemoryBarrier)), n)
+}
+
+func (a *arena) newVkImageBlitSlice(elems ...C.VkImageBlit) []C.VkImageBlit {
+	dst := a.makeVkImageBlitSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkImageBlitSlice(n int) []C.VkImageBlit {
+	return unsafe.Slice((*C.VkImageBlit)(a.alloc(uintptr(n)*C.sizeof_VkImageBlit)), n)
+}
+
+func (a *arena) newVkPushConstantRangeSlice(elems ...C.VkPushConstantRange) []C.VkPushConstantRange {
+	dst := a.makeVkPushConstantRangeSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkPushConstantRangeSlice(n int) []C.VkPushConstantRange {
+	return unsafe.Slice((*C.VkPushConstantRange)(a.alloc(uintptr(n)*C.sizeof_VkPushConstantRange)), n)
+}
+
+func (a *arena) newVkBufferMemoryBarrierSlice(elems ...C.VkBufferMemoryBarrier) []C.VkBufferMemoryBarrier {
+	dst := a.makeVkBufferMemoryBarrierSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkBufferMemoryBarrierSlice(n int) []C.VkBufferMemoryBarrier {
+	return unsafe.Slice((*C.VkBufferMemoryBarrier)(a.alloc(uintptr(n)*C.sizeof_VkBufferMemoryBarrier)), n)
+}
+
+func (a *arena) newVkComputePipelineCreateInfoSlice(elems ...C.VkComputePipelineCreateInfo) []C.VkComputePipelineCreateInfo {
+	dst := a.makeVkComputePipelineCreateInfoSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkComputePipelineCreateInfoSlice(n int) []C.VkComputePipelineCreateInfo {
+	return unsafe.Slice((*C.VkComputePipelineCreateInfo)(a.alloc(uintptr(n)*C.sizeof_VkComputePipelineCreateInfo)), n)
+}
+
+func (a *arena) newVkDescriptorSetLayoutBindingSlice(elems ...C.VkDescriptorSetLayoutBinding) []C.VkDescriptorSetLayoutBinding {
+	dst := a.makeVkDescriptorSetLayoutBindingSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkDescriptorSetLayoutBindingSlice(n int) []C.VkDescriptorSetLayoutBinding {
+	return unsafe.Slice((*C.VkDescriptorSetLayoutBinding)(a.alloc(uintptr(n)*C.sizeof_VkDescriptorSetLayoutBinding)), n)
+}
+
+func (a *arena) newVkDescriptorPoolSizeSlice(elems ...C.VkDescriptorPoolSize) []C.VkDescriptorPoolSize {
+	dst := a.makeVkDescriptorPoolSizeSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkDescriptorPoolSizeSlice(n int) []C.VkDescriptorPoolSize {
+	return unsafe.Slice((*C.VkDescriptorPoolSize)(a.alloc(uintptr(n)*C.sizeof_VkDescriptorPoolSize)), n)
+}
+
+func (a *arena) newVkDescriptorSetLayoutSlice(elems ...C.VkDescriptorSetLayout) []C.VkDescriptorSetLayout {
+	dst := a.makeVkDescriptorSetLayoutSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkDescriptorSetLayoutSlice(n int) []C.VkDescriptorSetLayout {
+	return unsafe.Slice((*C.VkDescriptorSetLayout)(a.alloc(uintptr(n)*C.sizeof_VkDescriptorSetLayout)), n)
+}
+
+func (a *arena) newVkDescriptorSetSlice(elems ...C.VkDescriptorSet) []C.VkDescriptorSet {
+	dst := a.makeVkDescriptorSetSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkDescriptorSetSlice(n int) []C.VkDescriptorSet {
+	return unsafe.Slice((*C.VkDescriptorSet)(a.alloc(uintptr(n)*C.sizeof_VkDescriptorSet)), n)
+}
+
+func (a *arena) newVkWriteDescriptorSetSlice(elems ...C.VkWriteDescriptorSet) []C.VkWriteDescriptorSet {
+	dst := a.makeVkWriteDescriptorSetSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkWriteDescriptorSetSlice(n int) []C.VkWriteDescriptorSet {
+	return unsafe.Slice((*C.VkWriteDescriptorSet)(a.alloc(uintptr(n)*C.sizeof_VkWriteDescriptorSet)), n)
+}
+
+func (a *arena) newVkDescriptorBufferInfoSlice(elems ...C.VkDescriptorBufferInfo) []C.VkDescriptorBufferInfo {
+	dst := a.makeVkDescriptorBufferInfoSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkDescriptorBufferInfoSlice(n int) []C.VkDescriptorBufferInfo {
+	return unsafe.Slice((*C.VkDescriptorBufferInfo)(a.alloc(uintptr(n)*C.sizeof_VkDescriptorBufferInfo)), n)
+}
+
+func (a *arena) newVkDescriptorImageInfoSlice(elems ...C.VkDescriptorImageInfo) []C.VkDescriptorImageInfo {
+	dst := a.makeVkDescriptorImageInfoSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkDescriptorImageInfoSlice(n int) []C.VkDescriptorImageInfo {
+	return unsafe.Slice((*C.VkDescriptorImageInfo)(a.alloc(uintptr(n)*C.sizeof_VkDescriptorImageInfo)), n)
+}
+
+func (a *arena) newVkRenderingAttachmentInfoSlice(elems ...C.VkRenderingAttachmentInfo) []C.VkRenderingAttachmentInfo {
+	dst := a.makeVkRenderingAttachmentInfoSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkRenderingAttachmentInfoSlice(n int) []C.VkRenderingAttachmentInfo {
+	return unsafe.Slice((*C.VkRenderingAttachmentInfo)(a.alloc(uintptr(n)*C.sizeof_VkRenderingAttachmentInfo)), n)
+}
+
+func (a *arena) newVkFormatSlice(elems ...C.VkFormat) []C.VkFormat {
+	dst := a.makeVkFormatSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeVkFormatSlice(n int) []C.VkFormat {
+	return unsafe.Slice((*C.VkFormat)(a.alloc(uintptr(n)*C.sizeof_VkFormat)), n)
+}
+
+func (a *arena) newCFloatSlice(elems ...C.float) []C.float {
+	dst := a.makeCFloatSlice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeCFloatSlice(n int) []C.float {
+	return unsafe.Slice((*C.float)(a.alloc(uintptr(n)*C.sizeof_float)), n)
+}
+
+func (a *arena) newCUint32Slice(elems ...C.uint32_t) []C.uint32_t {
+	dst := a.makeCUint32Slice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeCUint32Slice(n int) []C.uint32_t {
+	return unsafe.Slice((*C.uint32_t)(a.alloc(uintptr(n)*C.sizeof_uint32_t)), n)
+}
+
+func (a *arena) newCUint64Slice(elems ...C.uint64_t) []C.uint64_t {
+	dst := a.makeCUint64Slice(len(elems))
+	copy(dst, elems)
+	return dst
+}
+
+func (a *arena) makeCUint64Slice(n int) []C.uint64_t {
+	return unsafe.Slice((*C.uint64_t)(a.alloc(uintptr(n)*C.sizeof_uint64_t)), n)
+}
@@ -0,0 +1,448 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"image"
+	"math"
+	"math/bits"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// Format of texture images; 8-bit sRGB color with linear alpha.
+const textureFormat = C.VK_FORMAT_R8G8B8A8_SRGB
+
+// Paths of the vertex and fragment shaders of textured rendering.
+const (
+	texturedVertexShader   = "shaders/textured_vert.spv"
+	texturedFragmentShader = "shaders/textured_frag.spv"
+)
+
+// texture is a sampled color image with a full chain of mip levels.
+type texture struct {
+	// Texture name, used as debug name of its Vulkan objects.
+	name string
+	// Image of the texture, and its memory and view.
+	image     *C.VkImage
+	imageMem  *C.VkDeviceMemory
+	imageView *C.VkImageView
+	// Sampler of the texture, filtering within and between mip levels.
+	sampler *C.VkSampler
+	// Extent of the first mip level, and number of mip levels.
+	extent    C.VkExtent2D
+	mipLevels int
+	// Descriptor set binding the texture to the fragment shader, and the
+	// descriptor pool it is allocated from.
+	descriptorPool *C.VkDescriptorPool
+	descriptorSet  C.VkDescriptorSet
+}
+
+// createTexture creates a texture with the given name from the given image,
+// and returns once the texture is ready to be sampled by the fragment shader
+// (in VK_IMAGE_LAYOUT_SHADER_READ_ONLY_OPTIMAL layout), through the descriptor
+// set of the texture.
+//
+// The mip levels of the texture are generated on the GPU by successive linear
+// blits of the first mip level, or in Go if the texture format does not support
+// linear filtering of blits.
+func createTexture(app *App, name string, img *image.RGBA) (_ *texture, err error) {
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return nil, errors.Errorf("invalid texture %q; expected non-empty image", name)
+	}
+	extent := C.VkExtent2D{width: C.uint32_t(bounds.Dx()), height: C.uint32_t(bounds.Dy())}
+	tex := &texture{
+		name:      name,
+		extent:    extent,
+		mipLevels: mipLevelCount(extent),
+	}
+	defer func() {
+		if err != nil {
+			destroyTexture(app, tex)
+		}
+	}()
+	// Create image accessed by uploads on the transfer queue, and by blits and
+	// shaders on the graphics queue.
+	usage := C.VkImageUsageFlags(C.VK_IMAGE_USAGE_TRANSFER_SRC_BIT | C.VK_IMAGE_USAGE_TRANSFER_DST_BIT | C.VK_IMAGE_USAGE_SAMPLED_BIT)
+	queueFamilyIndices := []int{app.graphicsQueueFamilyIndex, app.transferQueueFamilyIndex}
+	textureImg, textureImgMem, err := createSharedImage(app, app.arena, name, extent, tex.mipLevels, textureFormat, C.VK_SAMPLE_COUNT_1_BIT, usage, C.VK_MEMORY_PROPERTY_DEVICE_LOCAL_BIT, queueFamilyIndices)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	tex.image, tex.imageMem = textureImg, textureImgMem
+	// Upload pixels and generate mip levels.
+	pixels := rgbaPixels(img)
+	if supportsLinearBlit(app, textureFormat) {
+		batch, err := uploadImage(app, *tex.image, extent, [][]byte{pixels}, C.VK_IMAGE_LAYOUT_TRANSFER_DST_OPTIMAL)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if err := batch.wait(app); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		scratch := newArena()
+		defer scratch.free()
+		commandBuffer, err := beginSingleTimeCommands(app, scratch, "mipmapCommandBuffer")
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		cmdGenerateMipmaps(app, scratch, commandBuffer, *tex.image, extent, tex.mipLevels)
+		if err := endSingleTimeCommands(app, scratch, commandBuffer); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	} else {
+		dbg.Printf("linear blit of texture format not supported; generating mip levels of texture %q on CPU", name)
+		levels := downsampleMipLevels(pixels, extent, tex.mipLevels)
+		batch, err := uploadImage(app, *tex.image, extent, levels, C.VK_IMAGE_LAYOUT_SHADER_READ_ONLY_OPTIMAL)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if err := batch.wait(app); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	// Create image view and sampler.
+	imageView, err := createImageView(app, app.arena, name+"View", *tex.image, textureFormat, tex.mipLevels)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	tex.imageView = imageView
+	sampler, err := createSampler(app, name+"Sampler", tex.mipLevels)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	tex.sampler = sampler
+	// Create descriptor set.
+	if err := initTextureDescriptorSet(app, tex); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return tex, nil
+}
+
+// destroyTexture destroys the given texture; its Vulkan objects created so far
+// if partially created.
+func destroyTexture(app *App, tex *texture) {
+	if tex.descriptorPool != nil {
+		// Descriptor set freed with the descriptor pool.
+		untrackObject(C.VK_OBJECT_TYPE_DESCRIPTOR_POOL, unsafe.Pointer(*tex.descriptorPool))
+		app.deviceProcs.DestroyDescriptorPool(*app.device, *tex.descriptorPool, nil)
+		tex.descriptorPool, tex.descriptorSet = nil, nil
+	}
+	if tex.sampler != nil {
+		untrackObject(C.VK_OBJECT_TYPE_SAMPLER, unsafe.Pointer(*tex.sampler))
+		app.deviceProcs.DestroySampler(*app.device, *tex.sampler, nil)
+		tex.sampler = nil
+	}
+	if tex.imageView != nil {
+		destroyImageView(app, tex.imageView)
+		tex.imageView = nil
+	}
+	if tex.image != nil {
+		destroyImage(app, tex.image, tex.imageMem)
+		tex.image, tex.imageMem = nil, nil
+	}
+}
+
+// createSampler creates a sampler with the given name, filtering linearly
+// within and between the given number of mip levels, and anisotropically if
+// enabled.
+func createSampler(app *App, name string, mipLevels int) (*C.VkSampler, error) {
+	samplerCreateInfo := C.VkSamplerCreateInfo{
+		sType:                   C.VK_STRUCTURE_TYPE_SAMPLER_CREATE_INFO,
+		magFilter:               C.VK_FILTER_LINEAR,
+		minFilter:               C.VK_FILTER_LINEAR,
+		mipmapMode:              C.VK_SAMPLER_MIPMAP_MODE_LINEAR,
+		addressModeU:            C.VK_SAMPLER_ADDRESS_MODE_REPEAT,
+		addressModeV:            C.VK_SAMPLER_ADDRESS_MODE_REPEAT,
+		addressModeW:            C.VK_SAMPLER_ADDRESS_MODE_REPEAT,
+		mipLodBias:              0,
+		anisotropyEnable:        C.VK_FALSE,
+		maxAnisotropy:           1,
+		compareEnable:           C.VK_FALSE,
+		compareOp:               C.VK_COMPARE_OP_ALWAYS,
+		minLod:                  0,
+		maxLod:                  C.float(mipLevels - 1), // level of detail range covering all mip levels.
+		borderColor:             C.VK_BORDER_COLOR_INT_OPAQUE_BLACK,
+		unnormalizedCoordinates: C.VK_FALSE,
+	}
+	if app.maxSamplerAnisotropy > 1 {
+		samplerCreateInfo.anisotropyEnable = C.VK_TRUE
+		samplerCreateInfo.maxAnisotropy = C.float(app.maxSamplerAnisotropy)
+	}
+	sampler := app.arena.newVkSampler(nil)
+	if result := app.deviceProcs.CreateSampler(*app.device, &samplerCreateInfo, nil, sampler); result != C.VK_SUCCESS {
+		return nil, errors.Wrapf(Result(result), "unable to create sampler %q", name)
+	}
+	trackObject(app, C.VK_OBJECT_TYPE_SAMPLER, unsafe.Pointer(*sampler), name)
+	return sampler, nil
+}
+
+// initTextureSetLayout creates the descriptor set layout of textures, shared by
+// the graphics pipelines; a combined image sampler at binding 0, sampled by the
+// fragment shader.
+func initTextureSetLayout(app *App) (*C.VkDescriptorSetLayout, error) {
+	scratch := newArena()
+	defer scratch.free()
+	bindings := scratch.newVkDescriptorSetLayoutBindingSlice(
+		C.VkDescriptorSetLayoutBinding{
+			binding:         0,
+			descriptorType:  C.VK_DESCRIPTOR_TYPE_COMBINED_IMAGE_SAMPLER,
+			descriptorCount: 1,
+			stageFlags:      C.VK_SHADER_STAGE_FRAGMENT_BIT,
+		},
+	)
+	descriptorSetLayoutCreateInfo := C.VkDescriptorSetLayoutCreateInfo{
+		sType:        C.VK_STRUCTURE_TYPE_DESCRIPTOR_SET_LAYOUT_CREATE_INFO,
+		bindingCount: C.uint32_t(len(bindings)),
+		pBindings:    &bindings[0],
+	}
+	descriptorSetLayout := app.arena.newVkDescriptorSetLayout(nil)
+	if result := app.deviceProcs.CreateDescriptorSetLayout(*app.device, &descriptorSetLayoutCreateInfo, nil, descriptorSetLayout); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to create descriptor set layout of textures")
+	}
+	trackObject(app, C.VK_OBJECT_TYPE_DESCRIPTOR_SET_LAYOUT, unsafe.Pointer(*descriptorSetLayout), "textureSetLayout")
+	return descriptorSetLayout, nil
+}
+
+// destroyTextureSetLayout destroys the descriptor set layout of textures.
+func destroyTextureSetLayout(app *App) {
+	if app.textureSetLayout == nil {
+		return
+	}
+	untrackObject(C.VK_OBJECT_TYPE_DESCRIPTOR_SET_LAYOUT, unsafe.Pointer(*app.textureSetLayout))
+	app.deviceProcs.DestroyDescriptorSetLayout(*app.device, *app.textureSetLayout, nil)
+	app.textureSetLayout = nil
+}
+
+// initTextureDescriptorSet allocates the descriptor set of the given texture
+// from a descriptor pool of its own, and binds the image view and sampler of
+// the texture to it.
+func initTextureDescriptorSet(app *App, tex *texture) error {
+	scratch := newArena()
+	defer scratch.free()
+	// Create descriptor pool.
+	poolSizes := scratch.newVkDescriptorPoolSizeSlice(
+		C.VkDescriptorPoolSize{
+			_type:           C.VK_DESCRIPTOR_TYPE_COMBINED_IMAGE_SAMPLER,
+			descriptorCount: 1,
+		},
+	)
+	descriptorPoolCreateInfo := C.VkDescriptorPoolCreateInfo{
+		sType:         C.VK_STRUCTURE_TYPE_DESCRIPTOR_POOL_CREATE_INFO,
+		maxSets:       1,
+		poolSizeCount: C.uint32_t(len(poolSizes)),
+		pPoolSizes:    &poolSizes[0],
+	}
+	descriptorPool := app.arena.newVkDescriptorPool(nil)
+	if result := app.deviceProcs.CreateDescriptorPool(*app.device, &descriptorPoolCreateInfo, nil, descriptorPool); result != C.VK_SUCCESS {
+		return errors.Wrapf(Result(result), "unable to create descriptor pool of texture %q", tex.name)
+	}
+	tex.descriptorPool = descriptorPool
+	trackObject(app, C.VK_OBJECT_TYPE_DESCRIPTOR_POOL, unsafe.Pointer(*descriptorPool), tex.name+"DescriptorPool")
+	// Allocate descriptor set; freed with the descriptor pool.
+	setLayouts := scratch.newVkDescriptorSetLayoutSlice(*app.textureSetLayout)
+	descriptorSetAllocateInfo := C.VkDescriptorSetAllocateInfo{
+		sType:              C.VK_STRUCTURE_TYPE_DESCRIPTOR_SET_ALLOCATE_INFO,
+		descriptorPool:     *descriptorPool,
+		descriptorSetCount: C.uint32_t(len(setLayouts)),
+		pSetLayouts:        &setLayouts[0],
+	}
+	descriptorSets := scratch.makeVkDescriptorSetSlice(len(setLayouts))
+	if result := app.deviceProcs.AllocateDescriptorSets(*app.device, &descriptorSetAllocateInfo, &descriptorSets[0]); result != C.VK_SUCCESS {
+		return errors.Wrapf(Result(result), "unable to allocate descriptor set of texture %q", tex.name)
+	}
+	tex.descriptorSet = descriptorSets[0]
+	setObjectNamef(app, C.VK_OBJECT_TYPE_DESCRIPTOR_SET, unsafe.Pointer(tex.descriptorSet), "%sDescriptorSet", tex.name)
+	// Bind image view and sampler.
+	imageInfos := scratch.newVkDescriptorImageInfoSlice(
+		C.VkDescriptorImageInfo{
+			sampler:     *tex.sampler,
+			imageView:   *tex.imageView,
+			imageLayout: C.VK_IMAGE_LAYOUT_SHADER_READ_ONLY_OPTIMAL,
+		},
+	)
+	writes := scratch.newVkWriteDescriptorSetSlice(
+		C.VkWriteDescriptorSet{
+			sType:           C.VK_STRUCTURE_TYPE_WRITE_DESCRIPTOR_SET,
+			dstSet:          tex.descriptorSet,
+			dstBinding:      0,
+			dstArrayElement: 0,
+			descriptorCount: C.uint32_t(len(imageInfos)),
+			descriptorType:  C.VK_DESCRIPTOR_TYPE_COMBINED_IMAGE_SAMPLER,
+			pImageInfo:      &imageInfos[0],
+		},
+	)
+	app.deviceProcs.UpdateDescriptorSets(*app.device, C.uint32_t(len(writes)), &writes[0], 0, nil)
+	return nil
+}
+
+// supportsLinearBlit reports whether optimally tiled images of the given
+// format support blits with linear filtering, as used to generate mip levels.
+func supportsLinearBlit(app *App, format C.VkFormat) bool {
+	var formatProperties C.VkFormatProperties
+	app.instanceProcs.GetPhysicalDeviceFormatProperties(*app.physicalDevice, format, &formatProperties)
+	const required = C.VK_FORMAT_FEATURE_BLIT_SRC_BIT | C.VK_FORMAT_FEATURE_BLIT_DST_BIT | C.VK_FORMAT_FEATURE_SAMPLED_IMAGE_FILTER_LINEAR_BIT
+	return formatProperties.optimalTilingFeatures&required == required
+}
+
+// cmdGenerateMipmaps records the generation of mip levels [1, mipLevels) of the
+// given image by successive linear blits, each mip level downsampled from the
+// previous. The first mip level must be in VK_IMAGE_LAYOUT_TRANSFER_DST_OPTIMAL
+// layout; all mip levels are transitioned to
+// VK_IMAGE_LAYOUT_SHADER_READ_ONLY_OPTIMAL layout.
+func cmdGenerateMipmaps(app *App, scratch *arena, commandBuffer C.VkCommandBuffer, img C.VkImage, extent C.VkExtent2D, mipLevels int) {
+	beginLabel(app, scratch, commandBuffer, "generate mipmaps", labelColorCopy)
+	barrier := func(baseMipLevel, levelCount int, oldLayout, newLayout C.VkImageLayout, srcAccessMask, dstAccessMask C.VkAccessFlags, srcStageMask, dstStageMask C.VkPipelineStageFlags) {
+		barriers := scratch.newVkImageMemoryBarrierSlice(
+			C.VkImageMemoryBarrier{
+				sType:               C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER,
+				srcAccessMask:       srcAccessMask,
+				dstAccessMask:       dstAccessMask,
+				oldLayout:           oldLayout,
+				newLayout:           newLayout,
+				srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+				dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+				image:               img,
+				subresourceRange: C.VkImageSubresourceRange{
+					aspectMask:     C.VK_IMAGE_ASPECT_COLOR_BIT,
+					baseMipLevel:   C.uint32_t(baseMipLevel),
+					levelCount:     C.uint32_t(levelCount),
+					baseArrayLayer: 0,
+					layerCount:     1,
+				},
+			},
+		)
+		app.deviceProcs.CmdPipelineBarrier(commandBuffer, srcStageMask, dstStageMask, 0, 0, nil, 0, nil, C.uint(len(barriers)), &barriers[0])
+	}
+	if mipLevels > 1 {
+		// Transition remaining mip levels to blit destinations.
+		barrier(1, mipLevels-1, C.VK_IMAGE_LAYOUT_UNDEFINED, C.VK_IMAGE_LAYOUT_TRANSFER_DST_OPTIMAL, 0, C.VK_ACCESS_TRANSFER_WRITE_BIT, C.VK_PIPELINE_STAGE_TOP_OF_PIPE_BIT, C.VK_PIPELINE_STAGE_TRANSFER_BIT)
+	}
+	for level := 1; level < mipLevels; level++ {
+		// Previous mip level written; use as blit source.
+		barrier(level-1, 1, C.VK_IMAGE_LAYOUT_TRANSFER_DST_OPTIMAL, C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL, C.VK_ACCESS_TRANSFER_WRITE_BIT, C.VK_ACCESS_TRANSFER_READ_BIT, C.VK_PIPELINE_STAGE_TRANSFER_BIT, C.VK_PIPELINE_STAGE_TRANSFER_BIT)
+		srcExtent := mipExtent(extent, level-1)
+		dstExtent := mipExtent(extent, level)
+		blit := C.VkImageBlit{
+			srcSubresource: C.VkImageSubresourceLayers{
+				aspectMask:     C.VK_IMAGE_ASPECT_COLOR_BIT,
+				mipLevel:       C.uint32_t(level - 1),
+				baseArrayLayer: 0,
+				layerCount:     1,
+			},
+			dstSubresource: C.VkImageSubresourceLayers{
+				aspectMask:     C.VK_IMAGE_ASPECT_COLOR_BIT,
+				mipLevel:       C.uint32_t(level),
+				baseArrayLayer: 0,
+				layerCount:     1,
+			},
+		}
+		blit.srcOffsets[1] = C.VkOffset3D{x: C.int32_t(srcExtent.width), y: C.int32_t(srcExtent.height), z: 1}
+		blit.dstOffsets[1] = C.VkOffset3D{x: C.int32_t(dstExtent.width), y: C.int32_t(dstExtent.height), z: 1}
+		blits := scratch.newVkImageBlitSlice(blit)
+		app.deviceProcs.CmdBlitImage(commandBuffer, img, C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL, img, C.VK_IMAGE_LAYOUT_TRANSFER_DST_OPTIMAL, C.uint32_t(len(blits)), &blits[0], C.VK_FILTER_LINEAR)
+		// Previous mip level complete.
+		barrier(level-1, 1, C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL, C.VK_IMAGE_LAYOUT_SHADER_READ_ONLY_OPTIMAL, C.VK_ACCESS_TRANSFER_READ_BIT, C.VK_ACCESS_SHADER_READ_BIT, C.VK_PIPELINE_STAGE_TRANSFER_BIT, C.VK_PIPELINE_STAGE_FRAGMENT_SHADER_BIT)
+	}
+	// Last mip level complete.
+	barrier(mipLevels-1, 1, C.VK_IMAGE_LAYOUT_TRANSFER_DST_OPTIMAL, C.VK_IMAGE_LAYOUT_SHADER_READ_ONLY_OPTIMAL, C.VK_ACCESS_TRANSFER_WRITE_BIT, C.VK_ACCESS_SHADER_READ_BIT, C.VK_PIPELINE_STAGE_TRANSFER_BIT, C.VK_PIPELINE_STAGE_FRAGMENT_SHADER_BIT)
+	endLabel(app, commandBuffer)
+}
+
+// mipLevelCount returns the number of mip levels of a full mip chain of an
+// image of the given extent, down to a single pixel.
+func mipLevelCount(extent C.VkExtent2D) int {
+	max := extent.width
+	if extent.height > max {
+		max = extent.height
+	}
+	return bits.Len32(uint32(max))
+}
+
+// mipExtent returns the extent of the given mip level of an image of the given
+// extent; halved per mip level, rounded down, and at least one pixel.
+func mipExtent(extent C.VkExtent2D, level int) C.VkExtent2D {
+	width, height := extent.width>>uint(level), extent.height>>uint(level)
+	if width == 0 {
+		width = 1
+	}
+	if height == 0 {
+		height = 1
+	}
+	return C.VkExtent2D{width: width, height: height}
+}
+
+// rgbaPixels returns the tightly packed pixels of the given image.
+func rgbaPixels(img *image.RGBA) []byte {
+	bounds := img.Bounds()
+	rowSize := 4 * bounds.Dx()
+	pixels := make([]byte, 0, rowSize*bounds.Dy())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		start := img.PixOffset(bounds.Min.X, y)
+		pixels = append(pixels, img.Pix[start:start+rowSize]...)
+	}
+	return pixels
+}
+
+// downsampleMipLevels returns the tightly packed pixels of mip levels
+// [0, mipLevels) of an sRGB image with the given pixels and extent, each mip
+// level downsampled from the previous by a 2x2 box filter in linear color
+// space. Used in place of blits if the image format does not support linear
+// filtering of blits.
+func downsampleMipLevels(pixels []byte, extent C.VkExtent2D, mipLevels int) [][]byte {
+	levels := [][]byte{pixels}
+	for level := 1; level < mipLevels; level++ {
+		src, srcExtent := levels[level-1], mipExtent(extent, level-1)
+		dstExtent := mipExtent(extent, level)
+		srcWidth, srcHeight := int(srcExtent.width), int(srcExtent.height)
+		dstWidth, dstHeight := int(dstExtent.width), int(dstExtent.height)
+		dst := make([]byte, 4*dstWidth*dstHeight)
+		for y := 0; y < dstHeight; y++ {
+			for x := 0; x < dstWidth; x++ {
+				// Source pixels of the 2x2 box, clamped to the source extent
+				// along dimensions of a single pixel.
+				var sum [4]float64
+				for _, sy := range [2]int{2 * y, clamp(2*y+1, 0, srcHeight-1)} {
+					for _, sx := range [2]int{2 * x, clamp(2*x+1, 0, srcWidth-1)} {
+						p := src[4*(sy*srcWidth+sx):]
+						sum[0] += srgbToLinear(p[0])
+						sum[1] += srgbToLinear(p[1])
+						sum[2] += srgbToLinear(p[2])
+						sum[3] += float64(p[3]) / 255 // alpha is linear.
+					}
+				}
+				q := dst[4*(y*dstWidth+x):]
+				q[0] = linearToSRGB(sum[0] / 4)
+				q[1] = linearToSRGB(sum[1] / 4)
+				q[2] = linearToSRGB(sum[2] / 4)
+				q[3] = uint8(math.Round(sum[3] / 4 * 255))
+			}
+		}
+		levels = append(levels, dst)
+	}
+	return levels
+}
+
+// srgbToLinear converts the given 8-bit sRGB color component to linear color
+// space, in range [0, 1].
+func srgbToLinear(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB converts the given color component in linear color space, in
+// range [0, 1], to 8-bit sRGB.
+func linearToSRGB(v float64) uint8 {
+	if v <= 0.0031308 {
+		v *= 12.92
+	} else {
+		v = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(math.Min(math.Max(v, 0), 1) * 255))
+}
@@ -0,0 +1,270 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// BinarySemaphores forces synchronization with binary semaphores and fences,
+// even on devices supporting timeline semaphores; e.g. to test the fallback
+// used on devices without timeline semaphores.
+//
+// BinarySemaphores must be set before InitVulkan is invoked.
+var BinarySemaphores = false
+
+// Submissions to the graphics, compute and transfer queues are synchronized by
+// one timeline semaphore per queue (Vulkan 1.2 or VK_KHR_timeline_semaphore),
+// the counter of which is incremented by each submission signalling it. The
+// host waits for a submission by waiting for its value, and submissions to
+// other queues wait for it in the same way, without a semaphore or fence per
+// submission.
+//
+// On devices without timeline semaphores, submissions are instead synchronized
+// by binary semaphores between queues, and by fences with the host. The
+// counters of the timelines are still incremented to identify submissions, but
+// the timelines have no semaphore.
+
+// timeline is the timeline of submissions to a queue.
+type timeline struct {
+	// Timeline semaphore signalled by submissions to the queue; or nil if
+	// binary semaphores.
+	semaphore *C.VkSemaphore
+	// Value signalled by the last submission to the queue.
+	value uint64
+	// Wait info of host waits for the timeline semaphore, and the value waited
+	// for; allocated once, as the host waits for frames every frame.
+	waitInfo  *C.VkSemaphoreWaitInfo
+	waitValue *C.uint64_t
+}
+
+// initTimelines creates the timelines of the graphics, compute and transfer
+// queues.
+func initTimelines(app *App) error {
+	graphicsTimeline, err := createTimeline(app, "graphicsTimeline")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.graphicsTimeline = graphicsTimeline
+	computeTimeline, err := createTimeline(app, "computeTimeline")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.computeTimeline = computeTimeline
+	transferTimeline, err := createTimeline(app, "transferTimeline")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.transferTimeline = transferTimeline
+	return nil
+}
+
+// destroyTimelines destroys the timelines of the graphics, compute and transfer
+// queues.
+func destroyTimelines(app *App) {
+	for _, tl := range []*timeline{app.graphicsTimeline, app.computeTimeline, app.transferTimeline} {
+		if tl != nil {
+			destroySemaphore(app, tl.semaphore)
+		}
+	}
+	app.graphicsTimeline = nil
+	app.computeTimeline = nil
+	app.transferTimeline = nil
+}
+
+// createTimeline creates a timeline with the given name, with a timeline
+// semaphore of initial value 0 if timeline semaphores are enabled.
+func createTimeline(app *App, name string) (*timeline, error) {
+	tl := &timeline{}
+	if !app.timelineSemaphores {
+		return tl, nil
+	}
+	scratch := newArena()
+	defer scratch.free()
+	semaphoreTypeCreateInfo := scratch.newVkSemaphoreTypeCreateInfo(C.VkSemaphoreTypeCreateInfo{
+		sType:         C.VK_STRUCTURE_TYPE_SEMAPHORE_TYPE_CREATE_INFO,
+		semaphoreType: C.VK_SEMAPHORE_TYPE_TIMELINE,
+		initialValue:  0,
+	})
+	semaphoreCreateInfo := C.VkSemaphoreCreateInfo{
+		sType: C.VK_STRUCTURE_TYPE_SEMAPHORE_CREATE_INFO,
+		pNext: unsafe.Pointer(semaphoreTypeCreateInfo),
+	}
+	semaphore := app.arena.newVkSemaphore(nil)
+	if result := app.deviceProcs.CreateSemaphore(*app.device, &semaphoreCreateInfo, nil, semaphore); result != C.VK_SUCCESS {
+		return nil, errors.Wrapf(Result(result), "unable to create timeline semaphore %q", name)
+	}
+	trackObject(app, C.VK_OBJECT_TYPE_SEMAPHORE, unsafe.Pointer(*semaphore), name)
+	tl.semaphore = semaphore
+	semaphores := app.arena.newVkSemaphoreSlice(*semaphore)
+	values := app.arena.newCUint64Slice(0)
+	tl.waitInfo = app.arena.newVkSemaphoreWaitInfo(C.VkSemaphoreWaitInfo{
+		sType:          C.VK_STRUCTURE_TYPE_SEMAPHORE_WAIT_INFO,
+		semaphoreCount: C.uint32_t(len(semaphores)),
+		pSemaphores:    &semaphores[0],
+		pValues:        &values[0],
+	})
+	tl.waitValue = &values[0]
+	return tl, nil
+}
+
+// next increments the counter of the timeline, and returns the value to be
+// signalled by the next submission to the queue.
+func (tl *timeline) next() uint64 {
+	tl.value++
+	return tl.value
+}
+
+// signal returns the signal operation of the timeline semaphore with the given
+// value.
+func (tl *timeline) signal(value uint64) submitSemaphore {
+	return submitSemaphore{semaphore: *tl.semaphore, value: value}
+}
+
+// completed returns the value signalled by the last completed submission of
+// the timeline. Requires a timeline semaphore.
+func (tl *timeline) completed(app *App) (uint64, error) {
+	var value C.uint64_t
+	if result := app.deviceProcs.GetSemaphoreCounterValue(*app.device, *tl.semaphore, &value); result != C.VK_SUCCESS {
+		return 0, errors.Wrap(Result(result), "unable to get value of timeline semaphore")
+	}
+	return uint64(value), nil
+}
+
+// wait waits for the submission of the timeline signalling the given value to
+// complete. Requires a timeline semaphore.
+func (tl *timeline) wait(app *App, value uint64) error {
+	*tl.waitValue = C.uint64_t(value)
+	const timeout = C.UINT64_MAX // disable timeout
+	if result := app.deviceProcs.WaitSemaphores(*app.device, tl.waitInfo, timeout); result != C.VK_SUCCESS {
+		return errors.Wrap(Result(result), "unable to wait for timeline semaphore")
+	}
+	return nil
+}
+
+// waitSubmitted waits for the submission of the timeline signalling the given
+// value to the given queue to complete. With binary semaphores, the host waits
+// for the queue to become idle instead.
+func (tl *timeline) waitSubmitted(app *App, queue C.VkQueue, value uint64) error {
+	if app.timelineSemaphores {
+		return tl.wait(app, value)
+	}
+	if result := app.deviceProcs.QueueWaitIdle(queue); result != C.VK_SUCCESS {
+		return errors.Wrap(Result(result), "unable to wait for queue to become idle")
+	}
+	return nil
+}
+
+// waitFrame waits for the frame of the graphics timeline signalling the given
+// value to complete; or returns immediately if the value is 0.
+//
+// With binary semaphores, the fence of the frame in flight is waited for,
+// unless since reused by a later frame; in which case the frame has already
+// completed.
+func waitFrame(app *App, value uint64) error {
+	if value == 0 {
+		return nil
+	}
+	if app.timelineSemaphores {
+		return app.graphicsTimeline.wait(app, value)
+	}
+	for frame, frameValue := range app.frameValues {
+		if frameValue != value {
+			continue
+		}
+		const (
+			nfences = 1
+			timeout = C.UINT64_MAX // disable timeout
+		)
+		if result := app.deviceProcs.WaitForFences(*app.device, nfences, app.framesInFlightFences[frame], C.VK_TRUE, timeout); result != C.VK_SUCCESS {
+			return errors.Wrap(Result(result), "unable to wait for fence of frame")
+		}
+	}
+	return nil
+}
+
+// submitSemaphore is a semaphore waited for or signalled by a queue
+// submission.
+type submitSemaphore struct {
+	semaphore C.VkSemaphore
+	// Value of timeline semaphores; ignored for binary semaphores.
+	value uint64
+	// Pipeline stages waiting for the semaphore; ignored for signals.
+	stage C.VkPipelineStageFlags
+}
+
+// newSubmitInfo returns the submission of the given command buffers, waiting
+// for and signalling the given binary and timeline semaphores. The arrays of
+// the submission are allocated in the given arena.
+func newSubmitInfo(app *App, scratch *arena, commandBuffers []C.VkCommandBuffer, waits, signals []submitSemaphore) C.VkSubmitInfo {
+	submitInfo := C.VkSubmitInfo{
+		sType: C.VK_STRUCTURE_TYPE_SUBMIT_INFO,
+	}
+	if len(commandBuffers) > 0 {
+		buffers := scratch.newVkCommandBufferSlice(commandBuffers...)
+		submitInfo.commandBufferCount = C.uint(len(buffers))
+		submitInfo.pCommandBuffers = &buffers[0]
+	}
+	var timelineSubmitInfo *C.VkTimelineSemaphoreSubmitInfo
+	if app.timelineSemaphores {
+		timelineSubmitInfo = scratch.newVkTimelineSemaphoreSubmitInfo(C.VkTimelineSemaphoreSubmitInfo{
+			sType: C.VK_STRUCTURE_TYPE_TIMELINE_SEMAPHORE_SUBMIT_INFO,
+		})
+		submitInfo.pNext = unsafe.Pointer(timelineSubmitInfo)
+	}
+	if len(waits) > 0 {
+		semaphores := scratch.makeVkSemaphoreSlice(len(waits))
+		stages := scratch.makeVkPipelineStageFlagsSlice(len(waits))
+		values := scratch.makeCUint64Slice(len(waits))
+		for i, wait := range waits {
+			semaphores[i] = wait.semaphore
+			stages[i] = wait.stage
+			values[i] = C.uint64_t(wait.value)
+		}
+		submitInfo.waitSemaphoreCount = C.uint(len(semaphores))
+		submitInfo.pWaitSemaphores = &semaphores[0]
+		submitInfo.pWaitDstStageMask = &stages[0]
+		if timelineSubmitInfo != nil {
+			timelineSubmitInfo.waitSemaphoreValueCount = C.uint32_t(len(values))
+			timelineSubmitInfo.pWaitSemaphoreValues = &values[0]
+		}
+	}
+	if len(signals) > 0 {
+		semaphores := scratch.makeVkSemaphoreSlice(len(signals))
+		values := scratch.makeCUint64Slice(len(signals))
+		for i, signal := range signals {
+			semaphores[i] = signal.semaphore
+			values[i] = C.uint64_t(signal.value)
+		}
+		submitInfo.signalSemaphoreCount = C.uint(len(semaphores))
+		submitInfo.pSignalSemaphores = &semaphores[0]
+		if timelineSubmitInfo != nil {
+			timelineSubmitInfo.signalSemaphoreValueCount = C.uint32_t(len(values))
+			timelineSubmitInfo.pSignalSemaphoreValues = &values[0]
+		}
+	}
+	return submitInfo
+}
+
+// loadTimelineSemaphoreProcs loads the commands of timeline semaphores of the
+// device; the commands of VK_KHR_timeline_semaphore on Vulkan 1.1. Binary
+// semaphores are used if the commands are not present.
+func loadTimelineSemaphoreProcs(app *App) {
+	if app.timelineSemaphoresKHR {
+		scratch := newArena()
+		defer scratch.free()
+		p := app.deviceProcs
+		p.vkWaitSemaphores = (C.PFN_vkWaitSemaphores)(unsafe.Pointer(app.instanceProcs.GetDeviceProcAddr(*app.device, scratch.cString("vkWaitSemaphoresKHR"))))
+		p.vkGetSemaphoreCounterValue = (C.PFN_vkGetSemaphoreCounterValue)(unsafe.Pointer(app.instanceProcs.GetDeviceProcAddr(*app.device, scratch.cString("vkGetSemaphoreCounterValueKHR"))))
+	}
+	if app.deviceProcs.vkWaitSemaphores == nil || app.deviceProcs.vkGetSemaphoreCounterValue == nil {
+		warn.Println("unable to load commands of timeline semaphores; using binary semaphores")
+		app.timelineSemaphores = false
+		app.timelineSemaphoresKHR = false
+		return
+	}
+	dbg.Printf("synchronizing with timeline semaphores (VK_KHR_timeline_semaphore=%v)", app.timelineSemaphoresKHR)
+}
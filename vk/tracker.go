@@ -0,0 +1,241 @@
+package vk
+
+// #include <stdlib.h>
+// #include <vulkan/vulkan.h>
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// resourceKey identifies a tracked resource.
+type resourceKey struct {
+	// Vulkan object type; or allocKind for C allocations.
+	kind string
+	// Vulkan handle or address of C allocation.
+	handle uintptr
+}
+
+// allocKind is the resource kind of C allocations.
+const allocKind = "C allocation"
+
+// trackedResource is a tracked Vulkan object or C allocation.
+type trackedResource struct {
+	// Debug name of Vulkan object, or element type of C allocation.
+	name string
+	// Stack trace of creation.
+	created []uintptr
+	// Stack trace of destruction; or nil if alive.
+	destroyed []uintptr
+}
+
+// resourceTracker records the Vulkan objects and C allocations created by
+// laki, to report objects which are leaked at shutdown or destroyed twice.
+//
+// NOTE: Vulkan may reuse handles of destroyed objects, so destroyed resources
+// are forgotten once a new resource with the same handle is created.
+type resourceTracker struct {
+	mu sync.Mutex
+	// Live resources.
+	live map[resourceKey]*trackedResource
+	// Destroyed resources.
+	dead map[resourceKey]*trackedResource
+}
+
+// tracker tracks the lifetime of Vulkan objects and C allocations.
+var tracker = &resourceTracker{
+	live: make(map[resourceKey]*trackedResource),
+	dead: make(map[resourceKey]*trackedResource),
+}
+
+// track records the creation of the given resource.
+func (t *resourceTracker) track(key resourceKey, name string) {
+	res := &trackedResource{
+		name:    name,
+		created: callers(),
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.dead, key)
+	if prev, ok := t.live[key]; ok {
+		warn.Printf("%s %q created twice with handle 0x%X; previously created at:%s", key.kind, prev.name, key.handle, formatStack(prev.created))
+	}
+	t.live[key] = res
+}
+
+// untrack records the destruction of the given resource, reporting double
+// destroys.
+func (t *resourceTracker) untrack(key resourceKey) {
+	destroyed := callers()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	res, ok := t.live[key]
+	if !ok {
+		msg := fmt.Sprintf("destroy of untracked %s with handle 0x%X", key.kind, key.handle)
+		if prev, ok := t.dead[key]; ok {
+			msg = fmt.Sprintf("double destroy of %s %q with handle 0x%X\n   created at:%s\n   first destroyed at:%s", key.kind, prev.name, key.handle, formatStack(prev.created), formatStack(prev.destroyed))
+		}
+		reportTrackerError(msg + "\n   destroyed at:" + formatStack(destroyed))
+		return
+	}
+	delete(t.live, key)
+	res.destroyed = destroyed
+	t.dead[key] = res
+}
+
+// reportLeaks reports the resources which are still alive. Leaked Vulkan
+// objects are reported individually with their creation stack trace, while
+// leaked C allocations are summarized per element type.
+func (t *resourceTracker) reportLeaks() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var objects []string
+	allocs := make(map[string]int)
+	allocSites := make(map[string][]uintptr)
+	for key, res := range t.live {
+		if key.kind == allocKind {
+			allocs[res.name]++
+			allocSites[res.name] = res.created
+			continue
+		}
+		objects = append(objects, fmt.Sprintf("leaked %s %q with handle 0x%X\n   created at:%s", key.kind, res.name, key.handle, formatStack(res.created)))
+	}
+	sort.Strings(objects)
+	for _, object := range objects {
+		reportTrackerError(object)
+	}
+	var typeNames []string
+	for typeName := range allocs {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+	for _, typeName := range typeNames {
+		warn.Printf("leaked %d C allocation(s) of %s; e.g. allocated at:%s", allocs[typeName], typeName, formatStack(allocSites[typeName]))
+	}
+}
+
+// reportTrackerError reports the given lifetime error, panicking if
+// ValidationErrorMode is ValidationPanic.
+func reportTrackerError(msg string) {
+	if validationMode() == ValidationPanic {
+		panic(msg)
+	}
+	warn.Print(msg)
+}
+
+// trackObject records the creation of the given Vulkan object and sets its
+// debug name. The handle is the Vulkan handle of the object (e.g.
+// unsafe.Pointer(*mesh.vertexBuffer)).
+func trackObject(app *App, objectType C.VkObjectType, handle unsafe.Pointer, name string) {
+	tracker.track(resourceKey{kind: objectTypeName(objectType), handle: uintptr(handle)}, name)
+	setObjectName(app, objectType, handle, name)
+}
+
+// trackObjectf records the creation of the given Vulkan object and sets its
+// debug name, based on the given format specifier.
+func trackObjectf(app *App, objectType C.VkObjectType, handle unsafe.Pointer, format string, args ...interface{}) {
+	trackObject(app, objectType, handle, fmt.Sprintf(format, args...))
+}
+
+// untrackObject records the destruction of the given Vulkan object.
+func untrackObject(objectType C.VkObjectType, handle unsafe.Pointer) {
+	if handle == nil {
+		return
+	}
+	tracker.untrack(resourceKey{kind: objectTypeName(objectType), handle: uintptr(handle)})
+}
+
+// trackAlloc records the given C allocation of elements with the given type
+// name.
+func trackAlloc(p unsafe.Pointer, typeName string) {
+	if p == nil {
+		return
+	}
+	tracker.track(resourceKey{kind: allocKind, handle: uintptr(p)}, typeName)
+}
+
+// freeAlloc releases the given tracked C allocation.
+func freeAlloc(p unsafe.Pointer) {
+	if p == nil {
+		return
+	}
+	tracker.untrack(resourceKey{kind: allocKind, handle: uintptr(p)})
+	C.free(p)
+}
+
+// callers returns the stack trace of the caller of the tracker.
+func callers() []uintptr {
+	pcs := make([]uintptr, 32)
+	// Skip runtime.Callers, callers, resourceTracker method and tracker
+	// helper.
+	n := runtime.Callers(4, pcs)
+	return pcs[:n]
+}
+
+// formatStack returns a string representation of the given stack trace.
+func formatStack(pcs []uintptr) string {
+	buf := &strings.Builder{}
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			fmt.Fprintf(buf, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return buf.String()
+}
+
+// objectTypeName returns the Vulkan type name of the given object type.
+func objectTypeName(objectType C.VkObjectType) string {
+	switch objectType {
+	case C.VK_OBJECT_TYPE_INSTANCE:
+		return "VkInstance"
+	case C.VK_OBJECT_TYPE_PHYSICAL_DEVICE:
+		return "VkPhysicalDevice"
+	case C.VK_OBJECT_TYPE_DEVICE:
+		return "VkDevice"
+	case C.VK_OBJECT_TYPE_QUEUE:
+		return "VkQueue"
+	case C.VK_OBJECT_TYPE_SEMAPHORE:
+		return "VkSemaphore"
+	case C.VK_OBJECT_TYPE_COMMAND_BUFFER:
+		return "VkCommandBuffer"
+	case C.VK_OBJECT_TYPE_FENCE:
+		return "VkFence"
+	case C.VK_OBJECT_TYPE_DEVICE_MEMORY:
+		return "VkDeviceMemory"
+	case C.VK_OBJECT_TYPE_BUFFER:
+		return "VkBuffer"
+	case C.VK_OBJECT_TYPE_IMAGE:
+		return "VkImage"
+	case C.VK_OBJECT_TYPE_IMAGE_VIEW:
+		return "VkImageView"
+	case C.VK_OBJECT_TYPE_SHADER_MODULE:
+		return "VkShaderModule"
+	case C.VK_OBJECT_TYPE_PIPELINE_LAYOUT:
+		return "VkPipelineLayout"
+	case C.VK_OBJECT_TYPE_RENDER_PASS:
+		return "VkRenderPass"
+	case C.VK_OBJECT_TYPE_PIPELINE:
+		return "VkPipeline"
+	case C.VK_OBJECT_TYPE_FRAMEBUFFER:
+		return "VkFramebuffer"
+	case C.VK_OBJECT_TYPE_COMMAND_POOL:
+		return "VkCommandPool"
+	case C.VK_OBJECT_TYPE_SURFACE_KHR:
+		return "VkSurfaceKHR"
+	case C.VK_OBJECT_TYPE_SWAPCHAIN_KHR:
+		return "VkSwapchainKHR"
+	case C.VK_OBJECT_TYPE_DEBUG_UTILS_MESSENGER_EXT:
+		return "VkDebugUtilsMessengerEXT"
+	}
+	return fmt.Sprintf("VkObjectType(%d)", uint32(objectType))
+}
@@ -0,0 +1,172 @@
+package vk
+
+import "math"
+
+// Mat4 is a 4x4 matrix of column-major order, as used by shaders; m[col*4+row]
+// is the element at the given column and row.
+type Mat4 [16]float32
+
+// Identity4 returns the 4x4 identity matrix.
+func Identity4() Mat4 {
+	return Mat4{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	}
+}
+
+// Translate4 returns the matrix translating by the given vector.
+func Translate4(v Vec3) Mat4 {
+	m := Identity4()
+	m[12], m[13], m[14] = v[0], v[1], v[2]
+	return m
+}
+
+// Scale4 returns the matrix scaling by the given factors along each axis.
+func Scale4(v Vec3) Mat4 {
+	m := Identity4()
+	m[0], m[5], m[10] = v[0], v[1], v[2]
+	return m
+}
+
+// TRS returns the matrix of the given translation, rotation and scale, applied
+// in reverse order (scale first).
+func TRS(t Vec3, r Quat, s Vec3) Mat4 {
+	m := r.Mat4()
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			m[col*4+row] *= s[col]
+		}
+	}
+	m[12], m[13], m[14] = t[0], t[1], t[2]
+	return m
+}
+
+// Mul returns the matrix product a*b; i.e. the transform b followed by a.
+func (a Mat4) Mul(b Mat4) Mat4 {
+	var m Mat4
+	for col := 0; col < 4; col++ {
+		for row := 0; row < 4; row++ {
+			var sum float32
+			for k := 0; k < 4; k++ {
+				sum += a[k*4+row] * b[col*4+k]
+			}
+			m[col*4+row] = sum
+		}
+	}
+	return m
+}
+
+// MulPoint returns the given point transformed by the affine matrix m.
+func (m Mat4) MulPoint(p Vec3) Vec3 {
+	return Vec3{
+		m[0]*p[0] + m[4]*p[1] + m[8]*p[2] + m[12],
+		m[1]*p[0] + m[5]*p[1] + m[9]*p[2] + m[13],
+		m[2]*p[0] + m[6]*p[1] + m[10]*p[2] + m[14],
+	}
+}
+
+// MulDir returns the given direction transformed by the affine matrix m,
+// ignoring translation.
+func (m Mat4) MulDir(d Vec3) Vec3 {
+	return Vec3{
+		m[0]*d[0] + m[4]*d[1] + m[8]*d[2],
+		m[1]*d[0] + m[5]*d[1] + m[9]*d[2],
+		m[2]*d[0] + m[6]*d[1] + m[10]*d[2],
+	}
+}
+
+// InverseAffine returns the inverse of the affine matrix m; i.e. a matrix with
+// a last row of (0, 0, 0, 1). The identity matrix is returned if m is
+// singular.
+func (m Mat4) InverseAffine() Mat4 {
+	// Inverse of the upper 3x3 matrix, by cofactors.
+	a, b, c := m[0], m[4], m[8]
+	d, e, f := m[1], m[5], m[9]
+	g, h, i := m[2], m[6], m[10]
+	c00 := e*i - f*h
+	c01 := f*g - d*i
+	c02 := d*h - e*g
+	det := a*c00 + b*c01 + c*c02
+	if det == 0 {
+		return Identity4()
+	}
+	inv := 1 / det
+	var r Mat4
+	r[0], r[4], r[8] = c00*inv, (c*h-b*i)*inv, (b*f-c*e)*inv
+	r[1], r[5], r[9] = c01*inv, (a*i-c*g)*inv, (c*d-a*f)*inv
+	r[2], r[6], r[10] = c02*inv, (b*g-a*h)*inv, (a*e-b*d)*inv
+	// Inverse translation.
+	t := r.MulDir(Vec3{m[12], m[13], m[14]})
+	r[12], r[13], r[14] = -t[0], -t[1], -t[2]
+	r[15] = 1
+	return r
+}
+
+// Perspective returns the perspective projection of the given vertical field
+// of view in radians and aspect ratio (width/height), mapping view space (right
+// handed, looking down -z, y up) to Vulkan clip space (y down, depth in [0, 1]
+// from the near to the far plane).
+func Perspective(fovY, aspect, near, far float32) Mat4 {
+	f := float32(1 / math.Tan(float64(fovY)/2))
+	var m Mat4
+	m[0] = f / aspect
+	m[5] = -f
+	m[10] = far / (near - far)
+	m[11] = -1
+	m[14] = near * far / (near - far)
+	return m
+}
+
+// Orthographic returns the orthographic projection of the given view volume,
+// mapping view space (right handed, looking down -z, y up) to Vulkan clip space
+// (y down, depth in [0, 1] from the near to the far plane).
+func Orthographic(left, right, bottom, top, near, far float32) Mat4 {
+	m := Identity4()
+	m[0] = 2 / (right - left)
+	m[5] = -2 / (top - bottom)
+	m[10] = -1 / (far - near)
+	m[12] = -(right + left) / (right - left)
+	m[13] = (top + bottom) / (top - bottom)
+	m[14] = -near / (far - near)
+	return m
+}
+
+// Quat is a unit quaternion (x, y, z, w) representing a rotation.
+type Quat [4]float32
+
+// IdentityQuat returns the quaternion of no rotation.
+func IdentityQuat() Quat {
+	return Quat{0, 0, 0, 1}
+}
+
+// QuatAxisAngle returns the quaternion rotating by the given angle in radians
+// about the given unit axis; counter-clockwise when looking down the axis
+// towards the origin.
+func QuatAxisAngle(axis Vec3, angle float32) Quat {
+	s := float32(math.Sin(float64(angle) / 2))
+	c := float32(math.Cos(float64(angle) / 2))
+	return Quat{axis[0] * s, axis[1] * s, axis[2] * s, c}
+}
+
+// Mul returns the quaternion product q*r; i.e. the rotation r followed by q.
+func (q Quat) Mul(r Quat) Quat {
+	return Quat{
+		q[3]*r[0] + q[0]*r[3] + q[1]*r[2] - q[2]*r[1],
+		q[3]*r[1] - q[0]*r[2] + q[1]*r[3] + q[2]*r[0],
+		q[3]*r[2] + q[0]*r[1] - q[1]*r[0] + q[2]*r[3],
+		q[3]*r[3] - q[0]*r[0] - q[1]*r[1] - q[2]*r[2],
+	}
+}
+
+// Mat4 returns the rotation matrix of the quaternion.
+func (q Quat) Mat4() Mat4 {
+	x, y, z, w := q[0], q[1], q[2], q[3]
+	return Mat4{
+		1 - 2*(y*y+z*z), 2 * (x*y + z*w), 2 * (x*z - y*w), 0,
+		2 * (x*y - z*w), 1 - 2*(x*x+z*z), 2 * (y*z + x*w), 0,
+		2 * (x*z + y*w), 2 * (y*z - x*w), 1 - 2*(x*x+y*y), 0,
+		0, 0, 0, 1,
+	}
+}
@@ -0,0 +1,516 @@
+package vk
+
+// #include "invoke.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// Size in bytes of the staging ring buffer of uploads. Uploads larger than the
+// ring use dedicated staging buffers.
+const uploadRingSize = 4 << 20 // 4 MiB
+
+// Alignment in bytes of uploads within the staging ring buffer; a multiple of
+// the texel size of uncompressed formats, as required for buffer to image
+// copies.
+const uploadAlignment = 16
+
+// uploader uploads buffers and images through a staging ring buffer, batching
+// the copies queued between flushes into one submission to the transfer queue.
+//
+// Destination buffers and images must be accessible by the transfer queue
+// family; e.g. created by createSharedBuffer with the graphics and transfer
+// queue families.
+type uploader struct {
+	// Staging ring buffer in CPU memory, persistently mapped.
+	ring      stagingRing
+	buffer    *C.VkBuffer
+	bufferMem *C.VkDeviceMemory
+	data      unsafe.Pointer
+	// Command pool of the transfer queue family.
+	commandPool *C.VkCommandPool
+	// Batch of copies being recorded; or nil.
+	batch *upload
+	// Submitted batches of copies not yet retired, in submission order.
+	pending []*upload
+	// Number of batches recorded, used as debug name of batches.
+	nbatches int
+	// C memory of the handles of the uploader.
+	arena *arena
+}
+
+// upload is a batch of buffer and image copies submitted at once to the
+// transfer queue, and a handle to wait for the completion of the copies.
+type upload struct {
+	// Command buffer recording the copies of the batch.
+	commandBuffer C.VkCommandBuffer
+	// Fence signalled once the copies have completed; or nil if not yet
+	// submitted, or if timeline semaphores.
+	fence *C.VkFence
+	// Value of the transfer timeline signalled once the copies have completed;
+	// or 0 if not yet submitted, or if binary semaphores.
+	value uint64
+	// Number of bytes of the staging ring used by the batch, including
+	// alignment padding.
+	ringSize uint64
+	// Dedicated staging buffers of uploads larger than the staging ring.
+	stagingBuffers    []*C.VkBuffer
+	stagingBufferMems []*C.VkDeviceMemory
+	// Copies of the batch have completed, and its resources have been released.
+	done bool
+	// C memory of the handles of the batch.
+	arena *arena
+}
+
+// initUploader creates the staging ring buffer and command pool of uploads.
+func initUploader(app *App) (*uploader, error) {
+	u := &uploader{
+		ring:  stagingRing{size: uploadRingSize},
+		arena: newArena(),
+	}
+	// Create staging ring buffer.
+	usage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_TRANSFER_SRC_BIT)
+	properties := C.VkMemoryPropertyFlags(C.VK_MEMORY_PROPERTY_HOST_VISIBLE_BIT | C.VK_MEMORY_PROPERTY_HOST_COHERENT_BIT)
+	buffer, bufferMem, err := createBuffer(app, "uploadRingBuffer", uploadRingSize, usage, properties)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	u.buffer = buffer
+	u.bufferMem = bufferMem
+	const offset = 0
+	if result := app.deviceProcs.MapMemory(*app.device, *bufferMem, offset, uploadRingSize, 0, &u.data); result != C.VK_SUCCESS {
+		destroyBuffer(app, buffer, bufferMem)
+		return nil, errors.Wrapf(Result(result), "unable to map memory of upload ring buffer with size=%d", uploadRingSize)
+	}
+	// Create command pool of transfer queue family.
+	commandPoolCreateInfo := C.VkCommandPoolCreateInfo{
+		sType:            C.VK_STRUCTURE_TYPE_COMMAND_POOL_CREATE_INFO,
+		flags:            C.VK_COMMAND_POOL_CREATE_TRANSIENT_BIT, // command buffers are short-lived.
+		queueFamilyIndex: C.uint(app.transferQueueFamilyIndex),
+	}
+	commandPool := u.arena.newVkCommandPool(nil)
+	if result := app.deviceProcs.CreateCommandPool(*app.device, &commandPoolCreateInfo, nil, commandPool); result != C.VK_SUCCESS {
+		app.deviceProcs.UnmapMemory(*app.device, *bufferMem)
+		destroyBuffer(app, buffer, bufferMem)
+		return nil, errors.Wrap(Result(result), "unable to create upload command pool")
+	}
+	u.commandPool = commandPool
+	trackObject(app, C.VK_OBJECT_TYPE_COMMAND_POOL, unsafe.Pointer(*commandPool), "uploadCommandPool")
+	return u, nil
+}
+
+// cleanupUploader waits for pending uploads, and releases the resources of the
+// uploader. Copies recorded but not yet submitted are discarded.
+func cleanupUploader(app *App) {
+	u := app.uploader
+	if u == nil {
+		return
+	}
+	if len(u.pending) > 0 {
+		if err := u.pending[len(u.pending)-1].wait(app); err != nil {
+			warn.Printf("%+v", err) // print warning and continue
+		}
+	}
+	if u.batch != nil {
+		retireUpload(app, u.batch)
+		u.batch = nil
+	}
+	untrackObject(C.VK_OBJECT_TYPE_COMMAND_POOL, unsafe.Pointer(*u.commandPool))
+	app.deviceProcs.DestroyCommandPool(*app.device, *u.commandPool, nil)
+	app.deviceProcs.UnmapMemory(*app.device, *u.bufferMem)
+	destroyBuffer(app, u.buffer, u.bufferMem)
+	u.arena.free()
+	app.uploader = nil
+}
+
+// uploadBuffer queues a copy of data to the given buffer at the given offset,
+// and returns the batch of the copy. The copy is submitted by the next call to
+// flushUploads.
+func uploadBuffer(app *App, dstBuffer C.VkBuffer, dstOffset C.VkDeviceSize, data []byte) (*upload, error) {
+	batch, srcBuffer, srcOffset, err := stageUpload(app, data)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	scratch := newArena()
+	defer scratch.free()
+	copyRegions := scratch.newVkBufferCopySlice(
+		C.VkBufferCopy{
+			srcOffset: srcOffset,
+			dstOffset: dstOffset,
+			size:      C.VkDeviceSize(len(data)),
+		},
+	)
+	beginLabel(app, scratch, batch.commandBuffer, "upload buffer", labelColorCopy)
+	app.deviceProcs.CmdCopyBuffer(batch.commandBuffer, srcBuffer, dstBuffer, C.uint(len(copyRegions)), &copyRegions[0])
+	endLabel(app, batch.commandBuffer)
+	return batch, nil
+}
+
+// uploadImage queues a copy of tightly packed pixel data to the first
+// len(levels) mip levels of the given color image of the given extent, and
+// returns the batch of the copy; levels[i] holds the pixels of mip level i,
+// with an extent of max(1, extent>>i). The previous contents of the mip levels
+// are discarded, and the mip levels are transitioned to the given layout once
+// copied. The copy is submitted by the next call to flushUploads.
+func uploadImage(app *App, dstImage C.VkImage, extent C.VkExtent2D, levels [][]byte, finalLayout C.VkImageLayout) (*upload, error) {
+	if len(levels) == 0 {
+		return nil, errors.New("invalid image upload; expected at least one mip level")
+	}
+	// Stage mip levels together, as the levels of uncompressed formats are
+	// multiples of the texel size, and thus remain aligned.
+	var data []byte
+	levelOffsets := make([]int, len(levels))
+	for level, pixels := range levels {
+		levelOffsets[level] = len(data)
+		data = append(data, pixels...)
+	}
+	batch, srcBuffer, srcOffset, err := stageUpload(app, data)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	scratch := newArena()
+	defer scratch.free()
+	subresourceRange := C.VkImageSubresourceRange{
+		aspectMask:     C.VK_IMAGE_ASPECT_COLOR_BIT,
+		baseMipLevel:   0,
+		levelCount:     C.uint32_t(len(levels)),
+		baseArrayLayer: 0,
+		layerCount:     1,
+	}
+	beginLabel(app, scratch, batch.commandBuffer, "upload image", labelColorCopy)
+	// Transition image to transfer destination layout.
+	preCopyBarriers := scratch.newVkImageMemoryBarrierSlice(
+		C.VkImageMemoryBarrier{
+			sType:               C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER,
+			srcAccessMask:       0,
+			dstAccessMask:       C.VK_ACCESS_TRANSFER_WRITE_BIT,
+			oldLayout:           C.VK_IMAGE_LAYOUT_UNDEFINED,
+			newLayout:           C.VK_IMAGE_LAYOUT_TRANSFER_DST_OPTIMAL,
+			srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			image:               dstImage,
+			subresourceRange:    subresourceRange,
+		},
+	)
+	app.deviceProcs.CmdPipelineBarrier(batch.commandBuffer, C.VK_PIPELINE_STAGE_TOP_OF_PIPE_BIT, C.VK_PIPELINE_STAGE_TRANSFER_BIT, 0, 0, nil, 0, nil, C.uint(len(preCopyBarriers)), &preCopyBarriers[0])
+	regions := scratch.makeVkBufferImageCopySlice(len(levels))
+	for level := range levels {
+		levelExtent := mipExtent(extent, level)
+		regions[level] = C.VkBufferImageCopy{
+			bufferOffset:      srcOffset + C.VkDeviceSize(levelOffsets[level]),
+			bufferRowLength:   0, // tightly packed
+			bufferImageHeight: 0, // tightly packed
+			imageSubresource: C.VkImageSubresourceLayers{
+				aspectMask:     C.VK_IMAGE_ASPECT_COLOR_BIT,
+				mipLevel:       C.uint32_t(level),
+				baseArrayLayer: 0,
+				layerCount:     1,
+			},
+			imageOffset: C.VkOffset3D{x: 0, y: 0, z: 0},
+			imageExtent: C.VkExtent3D{width: levelExtent.width, height: levelExtent.height, depth: 1},
+		}
+	}
+	app.deviceProcs.CmdCopyBufferToImage(batch.commandBuffer, srcBuffer, dstImage, C.VK_IMAGE_LAYOUT_TRANSFER_DST_OPTIMAL, C.uint(len(regions)), &regions[0])
+	// Transition image to final layout; made visible to later submissions by
+	// waiting for the batch.
+	postCopyBarriers := scratch.newVkImageMemoryBarrierSlice(
+		C.VkImageMemoryBarrier{
+			sType:               C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER,
+			srcAccessMask:       C.VK_ACCESS_TRANSFER_WRITE_BIT,
+			dstAccessMask:       0,
+			oldLayout:           C.VK_IMAGE_LAYOUT_TRANSFER_DST_OPTIMAL,
+			newLayout:           finalLayout,
+			srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+			image:               dstImage,
+			subresourceRange:    subresourceRange,
+		},
+	)
+	app.deviceProcs.CmdPipelineBarrier(batch.commandBuffer, C.VK_PIPELINE_STAGE_TRANSFER_BIT, C.VK_PIPELINE_STAGE_BOTTOM_OF_PIPE_BIT, 0, 0, nil, 0, nil, C.uint(len(postCopyBarriers)), &postCopyBarriers[0])
+	endLabel(app, batch.commandBuffer)
+	return batch, nil
+}
+
+// stageUpload copies data to staging memory, and returns the batch of copies
+// to record the upload to, and the staging buffer and offset of the data.
+//
+// If the staging ring is full, the current batch is submitted and stageUpload
+// waits for pending batches to release space in the ring.
+func stageUpload(app *App, data []byte) (batch *upload, buffer C.VkBuffer, offset C.VkDeviceSize, err error) {
+	if len(data) == 0 {
+		return nil, nil, 0, errors.New("invalid upload; expected non-empty data")
+	}
+	u := app.uploader
+	size := uint64(len(data))
+	if size > u.ring.size {
+		// Use dedicated staging buffer, destroyed once the batch has completed.
+		batch, err := currentUpload(app)
+		if err != nil {
+			return nil, nil, 0, errors.WithStack(err)
+		}
+		usage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_TRANSFER_SRC_BIT)
+		properties := C.VkMemoryPropertyFlags(C.VK_MEMORY_PROPERTY_HOST_VISIBLE_BIT | C.VK_MEMORY_PROPERTY_HOST_COHERENT_BIT)
+		stagingBuffer, stagingBufferMem, err := createBuffer(app, "uploadStagingBuffer", C.VkDeviceSize(size), usage, properties)
+		if err != nil {
+			return nil, nil, 0, errors.WithStack(err)
+		}
+		batch.stagingBuffers = append(batch.stagingBuffers, stagingBuffer)
+		batch.stagingBufferMems = append(batch.stagingBufferMems, stagingBufferMem)
+		if err := copyMemory(app, stagingBufferMem, data, true); err != nil {
+			return nil, nil, 0, errors.WithStack(err)
+		}
+		return batch, *stagingBuffer, 0, nil
+	}
+	for {
+		batch, err := currentUpload(app)
+		if err != nil {
+			return nil, nil, 0, errors.WithStack(err)
+		}
+		ringOffset, consumed, ok := u.ring.alloc(size, uploadAlignment)
+		if ok {
+			batch.ringSize += consumed
+			copy(unsafe.Slice((*byte)(unsafe.Add(u.data, ringOffset)), size), data)
+			return batch, *u.buffer, C.VkDeviceSize(ringOffset), nil
+		}
+		// Staging ring full; submit current batch and wait for the oldest
+		// pending batch to release its space.
+		if err := flushUploads(app); err != nil {
+			return nil, nil, 0, errors.WithStack(err)
+		}
+		if len(u.pending) == 0 {
+			return nil, nil, 0, errors.Errorf("unable to allocate %d bytes of upload ring buffer", size)
+		}
+		if err := u.pending[0].wait(app); err != nil {
+			return nil, nil, 0, errors.WithStack(err)
+		}
+	}
+}
+
+// currentUpload returns the batch of copies being recorded, beginning a new
+// batch if needed.
+func currentUpload(app *App) (*upload, error) {
+	u := app.uploader
+	if u.batch != nil {
+		return u.batch, nil
+	}
+	batch := &upload{arena: newArena()}
+	commandBuffers := batch.arena.makeVkCommandBufferSlice(1)
+	commandBufferAllocateInfo := C.VkCommandBufferAllocateInfo{
+		sType:              C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_ALLOCATE_INFO,
+		commandPool:        *u.commandPool,
+		level:              C.VK_COMMAND_BUFFER_LEVEL_PRIMARY,
+		commandBufferCount: C.uint(len(commandBuffers)),
+	}
+	if result := app.deviceProcs.AllocateCommandBuffers(*app.device, &commandBufferAllocateInfo, &commandBuffers[0]); result != C.VK_SUCCESS {
+		batch.arena.free()
+		return nil, errors.Wrap(Result(result), "unable to create upload command buffers")
+	}
+	batch.commandBuffer = commandBuffers[0]
+	trackObjectf(app, C.VK_OBJECT_TYPE_COMMAND_BUFFER, unsafe.Pointer(batch.commandBuffer), "uploadCommandBuffer[%d]", u.nbatches)
+	u.nbatches++
+	commandBufferBeginInfo := C.VkCommandBufferBeginInfo{
+		sType: C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_BEGIN_INFO,
+		flags: C.VK_COMMAND_BUFFER_USAGE_ONE_TIME_SUBMIT_BIT,
+	}
+	if result := app.deviceProcs.BeginCommandBuffer(batch.commandBuffer, &commandBufferBeginInfo); result != C.VK_SUCCESS {
+		retireUpload(app, batch)
+		return nil, errors.Wrap(Result(result), "unable to begin recording command buffer")
+	}
+	u.batch = batch
+	return batch, nil
+}
+
+// flushUploads submits the batch of copies being recorded, if any, to the
+// transfer queue, and retires completed batches.
+func flushUploads(app *App) error {
+	u := app.uploader
+	pollUploads(app)
+	batch := u.batch
+	if batch == nil {
+		return nil
+	}
+	u.batch = nil
+	if result := app.deviceProcs.EndCommandBuffer(batch.commandBuffer); result != C.VK_SUCCESS {
+		retireUpload(app, batch)
+		return errors.Wrap(Result(result), "unable to record upload command buffer")
+	}
+	scratch := newArena()
+	defer scratch.free()
+	var signals []submitSemaphore
+	var fence C.VkFence
+	if app.timelineSemaphores {
+		batch.value = app.transferTimeline.next()
+		signals = append(signals, app.transferTimeline.signal(batch.value))
+	} else {
+		fenceCreateInfo := C.VkFenceCreateInfo{
+			sType: C.VK_STRUCTURE_TYPE_FENCE_CREATE_INFO,
+		}
+		batch.fence = batch.arena.newVkFence(nil)
+		if result := app.deviceProcs.CreateFence(*app.device, &fenceCreateInfo, nil, batch.fence); result != C.VK_SUCCESS {
+			batch.fence = nil
+			retireUpload(app, batch)
+			return errors.Wrap(Result(result), "unable to create fence")
+		}
+		trackObject(app, C.VK_OBJECT_TYPE_FENCE, unsafe.Pointer(*batch.fence), "uploadFence")
+		fence = *batch.fence
+	}
+	submitInfo := newSubmitInfo(app, scratch, []C.VkCommandBuffer{batch.commandBuffer}, nil, signals)
+	submits := scratch.newVkSubmitInfoSlice(submitInfo)
+	if result := app.deviceProcs.QueueSubmit(*app.transferQueue, C.uint(len(submits)), &submits[0], fence); result != C.VK_SUCCESS {
+		retireUpload(app, batch)
+		return errors.Wrap(Result(result), "unable to submit command buffers to transfer queue")
+	}
+	u.pending = append(u.pending, batch)
+	return nil
+}
+
+// pollUploads retires the submitted batches of copies that have completed.
+func pollUploads(app *App) {
+	u := app.uploader
+	var completed uint64
+	if app.timelineSemaphores && len(u.pending) > 0 {
+		value, err := app.transferTimeline.completed(app)
+		if err != nil {
+			warn.Printf("%+v", err) // print warning and continue
+			return
+		}
+		completed = value
+	}
+	// Batches complete in submission order, as the fence or timeline value of
+	// a submission is signalled after all prior submissions to the queue have
+	// completed.
+	for len(u.pending) > 0 {
+		batch := u.pending[0]
+		if app.timelineSemaphores {
+			if batch.value > completed {
+				break
+			}
+		} else if Result(app.deviceProcs.GetFenceStatus(*app.device, *batch.fence)) != Success {
+			break
+		}
+		u.pending = u.pending[1:]
+		retireUpload(app, batch)
+	}
+}
+
+// wait waits for the copies of the batch to complete, submitting the batch
+// first if needed. A nil batch has no copies.
+func (batch *upload) wait(app *App) error {
+	if batch == nil || batch.done {
+		return nil
+	}
+	if batch.fence == nil && batch.value == 0 {
+		if err := flushUploads(app); err != nil {
+			return errors.WithStack(err)
+		}
+		if batch.done {
+			return errors.New("unable to submit upload")
+		}
+	}
+	if app.timelineSemaphores {
+		if err := app.transferTimeline.wait(app, batch.value); err != nil {
+			return errors.Wrap(err, "unable to wait for upload")
+		}
+	} else {
+		const (
+			nfences = 1
+			timeout = C.UINT64_MAX // disable timeout
+		)
+		if result := app.deviceProcs.WaitForFences(*app.device, nfences, batch.fence, C.VK_TRUE, timeout); result != C.VK_SUCCESS {
+			return errors.Wrap(Result(result), "unable to wait for upload")
+		}
+	}
+	pollUploads(app)
+	return nil
+}
+
+// waitOp returns the wait operation of the transfer timeline semaphore, at the
+// given pipeline stages, for a submission reading the destinations of the
+// copies of the batch; or nil if the batch has completed, or has no copies. The
+// batch is submitted first if needed.
+//
+// With binary semaphores, the host waits for the copies to complete instead,
+// and nil is returned.
+func (batch *upload) waitOp(app *App, stage C.VkPipelineStageFlags) (*submitSemaphore, error) {
+	if batch == nil || batch.done {
+		return nil, nil
+	}
+	if !app.timelineSemaphores {
+		if err := batch.wait(app); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return nil, nil
+	}
+	if batch.value == 0 {
+		if err := flushUploads(app); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if batch.done {
+			return nil, errors.New("unable to submit upload")
+		}
+	}
+	return &submitSemaphore{semaphore: *app.transferTimeline.semaphore, value: batch.value, stage: stage}, nil
+}
+
+// retireUpload releases the resources of the given batch of copies, once
+// completed or discarded.
+func retireUpload(app *App, batch *upload) {
+	u := app.uploader
+	if batch.fence != nil {
+		destroyFence(app, batch.fence)
+		batch.fence = nil
+	}
+	untrackObject(C.VK_OBJECT_TYPE_COMMAND_BUFFER, unsafe.Pointer(batch.commandBuffer))
+	commandBuffers := batch.arena.newVkCommandBufferSlice(batch.commandBuffer)
+	app.deviceProcs.FreeCommandBuffers(*app.device, *u.commandPool, C.uint(len(commandBuffers)), &commandBuffers[0])
+	for i := range batch.stagingBuffers {
+		destroyBuffer(app, batch.stagingBuffers[i], batch.stagingBufferMems[i])
+	}
+	batch.stagingBuffers = nil
+	batch.stagingBufferMems = nil
+	u.ring.release(batch.ringSize)
+	batch.ringSize = 0
+	batch.done = true
+	batch.arena.free()
+}
+
+// stagingRing allocates ranges of a ring buffer, released in allocation order.
+type stagingRing struct {
+	// Size of the ring buffer in bytes.
+	size uint64
+	// Offset of the next allocation.
+	head uint64
+	// Number of bytes allocated, ending at head (including padding).
+	used uint64
+}
+
+// alloc allocates n bytes of the ring buffer at the given alignment, and
+// returns the offset of the allocation and the number of bytes consumed by the
+// allocation, including padding; to be released once no longer in use.
+func (r *stagingRing) alloc(n, align uint64) (offset, consumed uint64, ok bool) {
+	if r.used == 0 {
+		r.head = 0 // ring is empty; restart at the beginning.
+	}
+	offset = (r.head + align - 1) &^ (align - 1)
+	if offset+n > r.size {
+		// Wrap around, skipping the end of the ring.
+		offset = 0
+		consumed = r.size - r.head + n
+	} else {
+		consumed = offset + n - r.head
+	}
+	if r.used+consumed > r.size {
+		return 0, 0, false
+	}
+	r.head = offset + n
+	r.used += consumed
+	return offset, consumed, true
+}
+
+// release releases the given number of bytes of the oldest allocations.
+func (r *stagingRing) release(consumed uint64) {
+	r.used -= consumed
+}
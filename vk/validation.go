@@ -0,0 +1,408 @@
+package vk
+
+// #include <vulkan/vulkan.h>
+import "C"
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/mewkiz/pkg/term"
+)
+
+var (
+	// dbgValidationLayer is a logger with the "vk (validation layer):" prefix
+	// which logs debug messages to standard error.
+	dbgValidationLayer = log.New(os.Stderr, term.CyanBold("vk (validation layer):")+" ", 0)
+	// warnValidationLayer is a logger with the "vk (validation layer):" prefix
+	// which logs warning messages to standard error.
+	warnValidationLayer = log.New(os.Stderr, term.RedBold("vk (validation layer):")+" ", log.Lshortfile)
+)
+
+// DebugMessageSeverity specifies the severity of a debug message reported by
+// the Vulkan validation layers.
+type DebugMessageSeverity uint32
+
+// Debug message severities.
+const (
+	SeverityVerbose DebugMessageSeverity = C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_VERBOSE_BIT_EXT
+	SeverityInfo    DebugMessageSeverity = C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_INFO_BIT_EXT
+	SeverityWarning DebugMessageSeverity = C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_WARNING_BIT_EXT
+	SeverityError   DebugMessageSeverity = C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_ERROR_BIT_EXT
+)
+
+// String returns the string representation of the debug message severity.
+func (severity DebugMessageSeverity) String() string {
+	switch severity {
+	case SeverityVerbose:
+		return "verbose"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("DebugMessageSeverity(0x%X)", uint32(severity))
+}
+
+// DebugMessageType is a bitmask specifying the types of a debug message
+// reported by the Vulkan validation layers.
+type DebugMessageType uint32
+
+// Debug message types.
+const (
+	// Unrelated to the specification or performance.
+	MessageTypeGeneral DebugMessageType = C.VK_DEBUG_UTILS_MESSAGE_TYPE_GENERAL_BIT_EXT
+	// Violation of the specification, or possible mistake.
+	MessageTypeValidation DebugMessageType = C.VK_DEBUG_UTILS_MESSAGE_TYPE_VALIDATION_BIT_EXT
+	// Potential non-optimal use of Vulkan.
+	MessageTypePerformance DebugMessageType = C.VK_DEBUG_UTILS_MESSAGE_TYPE_PERFORMANCE_BIT_EXT
+)
+
+// String returns the string representation of the debug message types (e.g.
+// "validation|performance").
+func (types DebugMessageType) String() string {
+	var names []string
+	if types&MessageTypeGeneral != 0 {
+		names = append(names, "general")
+	}
+	if types&MessageTypeValidation != 0 {
+		names = append(names, "validation")
+	}
+	if types&MessageTypePerformance != 0 {
+		names = append(names, "performance")
+	}
+	if len(names) == 0 {
+		return fmt.Sprintf("DebugMessageType(0x%X)", uint32(types))
+	}
+	return strings.Join(names, "|")
+}
+
+// DebugObject is a Vulkan object related to a debug message.
+type DebugObject struct {
+	// Vulkan object type (VkObjectType).
+	Type uint32
+	// Vulkan object handle.
+	Handle uint64
+	// Debug name of Vulkan object; or empty if unnamed.
+	Name string
+}
+
+// String returns the string representation of the Vulkan object.
+func (obj DebugObject) String() string {
+	if len(obj.Name) > 0 {
+		return fmt.Sprintf("%q (type=%d, handle=0x%X)", obj.Name, obj.Type, obj.Handle)
+	}
+	return fmt.Sprintf("(type=%d, handle=0x%X)", obj.Type, obj.Handle)
+}
+
+// DebugMessage is a debug message reported by the Vulkan validation layers.
+type DebugMessage struct {
+	// Severity of message.
+	Severity DebugMessageSeverity
+	// Types of message.
+	Types DebugMessageType
+	// Message ID name (e.g. "VUID-vkCmdDraw-None-02699"); or empty if not
+	// present.
+	MessageIDName string
+	// Message ID number.
+	MessageIDNumber int32
+	// Message text.
+	Message string
+	// Vulkan objects related to the message.
+	Objects []DebugObject
+	// Labels of active queue regions, innermost first.
+	QueueLabels []string
+	// Labels of active command buffer regions, innermost first.
+	CmdBufLabels []string
+}
+
+// String returns the string representation of the debug message.
+func (msg *DebugMessage) String() string {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "(%s) [%s] ", msg.Severity, msg.Types)
+	if len(msg.MessageIDName) > 0 {
+		fmt.Fprintf(buf, "%s (0x%08X): ", msg.MessageIDName, uint32(msg.MessageIDNumber))
+	}
+	buf.WriteString(msg.Message)
+	return buf.String()
+}
+
+// DebugSink handles debug messages reported by the Vulkan validation layers.
+//
+// HandleDebugMessage may be invoked concurrently from any thread which calls
+// into Vulkan, and must not call into Vulkan itself.
+type DebugSink interface {
+	// HandleDebugMessage handles the given debug message.
+	HandleDebugMessage(msg *DebugMessage)
+}
+
+// DebugSinkFunc is an adapter which allows the use of an ordinary function as
+// a debug message sink.
+type DebugSinkFunc func(msg *DebugMessage)
+
+// HandleDebugMessage handles the given debug message by invoking f(msg).
+func (f DebugSinkFunc) HandleDebugMessage(msg *DebugMessage) {
+	f(msg)
+}
+
+// ValidationMode specifies how validation errors reported by the Vulkan
+// validation layers are handled.
+type ValidationMode uint8
+
+// Validation modes.
+const (
+	// Log validation errors and continue.
+	ValidationLog ValidationMode = iota
+	// Return validation errors as a *ValidationError from InitVulkan and from
+	// each frame of EventLoop.
+	ValidationFail
+	// Panic with a *ValidationError once control returns from Vulkan.
+	ValidationPanic
+)
+
+// ValidationErrorMode specifies how validation errors reported by the Vulkan
+// validation layers are handled. The default is to log validation errors, or
+// to panic if laki was built with the "laki_strict" build tag.
+//
+// ValidationErrorMode must be set before InitVulkan (or NewDevice) is invoked,
+// and not modified while a device is in use; the debug callback, which may run
+// on any thread, reads the mode recorded at device initialization rather than
+// the variable itself.
+var ValidationErrorMode = ValidationLog
+
+// ValidationError is an error which records the validation errors reported by
+// the Vulkan validation layers.
+type ValidationError struct {
+	// Validation error messages, in order of occurrence.
+	Messages []*DebugMessage
+}
+
+// Error returns the error message of the validation error.
+func (e *ValidationError) Error() string {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "%d validation error(s)", len(e.Messages))
+	for _, msg := range e.Messages {
+		buf.WriteString("\n   ")
+		buf.WriteString(msg.String())
+	}
+	return buf.String()
+}
+
+// validationState tracks the sinks, suppressed message IDs and pending
+// validation errors of the debug messenger.
+//
+// NOTE: the debug callback may be invoked from any thread which calls into
+// Vulkan.
+type validationState struct {
+	mu sync.Mutex
+	// Handling of validation errors; ValidationErrorMode at the last device
+	// initialization.
+	mode ValidationMode
+	// Registered debug message sinks.
+	sinks []debugSinkEntry
+	// ID of the next registered debug message sink.
+	nextSinkID int
+	// Suppressed message ID names.
+	suppressedNames map[string]bool
+	// Suppressed message ID numbers.
+	suppressedNumbers map[int32]bool
+	// Validation errors not yet reported by checkValidationErrors.
+	pendingErrors []*DebugMessage
+}
+
+// debugSinkEntry is a registered debug message sink.
+type debugSinkEntry struct {
+	// Registration ID of sink; sinks may be funcs, which are not comparable.
+	id   int
+	sink DebugSink
+}
+
+// defaultSinkID is the registration ID of the default debug message sink.
+const defaultSinkID = 0
+
+// validation is the validation state of the debug messenger.
+var validation = &validationState{
+	sinks: []debugSinkEntry{
+		{id: defaultSinkID, sink: DebugSinkFunc(logDebugMessage)},
+	},
+	nextSinkID:        defaultSinkID + 1,
+	suppressedNames:   make(map[string]bool),
+	suppressedNumbers: make(map[int32]bool),
+}
+
+// AddDebugSink registers the given sink to receive debug messages reported by
+// the Vulkan validation layers. The returned function unregisters the sink.
+//
+// Debug messages are logged to standard error by a default sink, which may be
+// removed using RemoveDefaultDebugSink.
+func AddDebugSink(sink DebugSink) (remove func()) {
+	validation.mu.Lock()
+	defer validation.mu.Unlock()
+	id := validation.nextSinkID
+	validation.nextSinkID++
+	// Copy on write, as sinks are invoked without holding the lock.
+	sinks := make([]debugSinkEntry, len(validation.sinks), len(validation.sinks)+1)
+	copy(sinks, validation.sinks)
+	validation.sinks = append(sinks, debugSinkEntry{id: id, sink: sink})
+	return func() {
+		removeDebugSink(id)
+	}
+}
+
+// RemoveDefaultDebugSink unregisters the default debug message sink, which logs
+// debug messages to standard error.
+func RemoveDefaultDebugSink() {
+	removeDebugSink(defaultSinkID)
+}
+
+// removeDebugSink unregisters the debug message sink with the given
+// registration ID.
+func removeDebugSink(id int) {
+	validation.mu.Lock()
+	defer validation.mu.Unlock()
+	var sinks []debugSinkEntry
+	for _, entry := range validation.sinks {
+		if entry.id == id {
+			continue
+		}
+		sinks = append(sinks, entry)
+	}
+	validation.sinks = sinks
+}
+
+// SuppressDebugMessages suppresses debug messages with the given message ID
+// names (e.g. "UNASSIGNED-BestPractices-vkCreateInstance-specialuse-extension")
+// or message ID numbers (e.g. "0x822806fa"). Suppressed messages are neither
+// handed to debug sinks nor counted as validation errors.
+func SuppressDebugMessages(ids ...string) {
+	validation.mu.Lock()
+	defer validation.mu.Unlock()
+	for _, id := range ids {
+		var number uint32
+		if _, err := fmt.Sscanf(id, "0x%x", &number); err == nil {
+			validation.suppressedNumbers[int32(number)] = true
+			continue
+		}
+		validation.suppressedNames[id] = true
+	}
+}
+
+// initValidationMode records ValidationErrorMode as the handling of validation
+// errors of the device being initialized.
+func initValidationMode() {
+	validation.mu.Lock()
+	defer validation.mu.Unlock()
+	validation.mode = ValidationErrorMode
+}
+
+// validationMode returns the handling of validation errors of the device.
+func validationMode() ValidationMode {
+	validation.mu.Lock()
+	defer validation.mu.Unlock()
+	return validation.mode
+}
+
+// handleDebugMessage dispatches the given debug message to the registered
+// sinks, and records validation errors.
+func handleDebugMessage(msg *DebugMessage) {
+	validation.mu.Lock()
+	if validation.suppressedNames[msg.MessageIDName] || validation.suppressedNumbers[msg.MessageIDNumber] {
+		validation.mu.Unlock()
+		return
+	}
+	if msg.Severity == SeverityError && validation.mode != ValidationLog {
+		validation.pendingErrors = append(validation.pendingErrors, msg)
+	}
+	sinks := validation.sinks
+	validation.mu.Unlock()
+	for _, entry := range sinks {
+		entry.sink.HandleDebugMessage(msg)
+	}
+}
+
+// checkValidationErrors reports the validation errors recorded since the last
+// call, as specified by ValidationErrorMode.
+func checkValidationErrors() error {
+	validation.mu.Lock()
+	msgs := validation.pendingErrors
+	validation.pendingErrors = nil
+	mode := validation.mode
+	validation.mu.Unlock()
+	if len(msgs) == 0 {
+		return nil
+	}
+	err := &ValidationError{Messages: msgs}
+	switch mode {
+	case ValidationFail:
+		return err
+	case ValidationPanic:
+		panic(err)
+	}
+	return nil
+}
+
+// logDebugMessage logs the given debug message to standard error.
+func logDebugMessage(msg *DebugMessage) {
+	switch msg.Severity {
+	case SeverityError, SeverityWarning:
+		warnValidationLayer.Println(msg)
+	default:
+		dbgValidationLayer.Println(msg)
+	}
+	for _, obj := range msg.Objects {
+		dbgValidationLayer.Println("   object:", obj)
+	}
+	for _, label := range msg.CmdBufLabels {
+		dbgValidationLayer.Println("   command buffer label:", label)
+	}
+	for _, label := range msg.QueueLabels {
+		dbgValidationLayer.Println("   queue label:", label)
+	}
+}
+
+// newDebugMessage returns a Go copy of the given debug callback data.
+func newDebugMessage(messageSeverity C.VkDebugUtilsMessageSeverityFlagBitsEXT, messageTypes C.VkDebugUtilsMessageTypeFlagsEXT, callbackData *C.VkDebugUtilsMessengerCallbackDataEXT) *DebugMessage {
+	msg := &DebugMessage{
+		Severity:        DebugMessageSeverity(messageSeverity),
+		Types:           DebugMessageType(messageTypes),
+		MessageIDNumber: int32(callbackData.messageIdNumber),
+		Message:         C.GoString(callbackData.pMessage),
+	}
+	if callbackData.pMessageIdName != nil {
+		msg.MessageIDName = C.GoString(callbackData.pMessageIdName)
+	}
+	if callbackData.objectCount > 0 {
+		objects := unsafe.Slice(callbackData.pObjects, callbackData.objectCount)
+		for _, object := range objects {
+			obj := DebugObject{
+				Type:   uint32(object.objectType),
+				Handle: uint64(object.objectHandle),
+			}
+			if object.pObjectName != nil {
+				obj.Name = C.GoString(object.pObjectName)
+			}
+			msg.Objects = append(msg.Objects, obj)
+		}
+	}
+	msg.QueueLabels = getDebugLabels(callbackData.pQueueLabels, callbackData.queueLabelCount)
+	msg.CmdBufLabels = getDebugLabels(callbackData.pCmdBufLabels, callbackData.cmdBufLabelCount)
+	return msg
+}
+
+// getDebugLabels returns the names of the given debug labels.
+func getDebugLabels(labels *C.VkDebugUtilsLabelEXT, n C.uint32_t) []string {
+	if n == 0 {
+		return nil
+	}
+	var names []string
+	for _, label := range unsafe.Slice(labels, n) {
+		names = append(names, C.GoString(label.pLabelName))
+	}
+	return names
+}
@@ -0,0 +1,10 @@
+//go:build laki_strict
+// +build laki_strict
+
+package vk
+
+// Panic on validation errors when built with the "laki_strict" build tag (e.g.
+// `go test -tags laki_strict ./...` in CI).
+func init() {
+	ValidationErrorMode = ValidationPanic
+}
@@ -0,0 +1,170 @@
+package vk
+
+import (
+	"reflect"
+	"testing"
+)
+
+// useValidationState replaces the validation state of the debug messenger by
+// a fresh state for the duration of the test, with the given default sink in
+// place of the sink logging to standard error.
+func useValidationState(t *testing.T, mode ValidationMode, defaultSink DebugSinkFunc) {
+	t.Helper()
+	prev := validation
+	validation = &validationState{
+		mode: mode,
+		sinks: []debugSinkEntry{
+			{id: defaultSinkID, sink: defaultSink},
+		},
+		nextSinkID:        defaultSinkID + 1,
+		suppressedNames:   make(map[string]bool),
+		suppressedNumbers: make(map[int32]bool),
+	}
+	t.Cleanup(func() {
+		validation = prev
+	})
+}
+
+func TestSuppressDebugMessages(t *testing.T) {
+	golden := []struct {
+		name string
+		// Suppressed message IDs.
+		ids []string
+		// Message ID name and number of the debug message.
+		msgName   string
+		msgNumber int32
+		// Whether the message is suppressed.
+		want bool
+	}{
+		{name: "no suppressed IDs", msgName: "VUID-vkCmdDraw-None-02699", msgNumber: 1, want: false},
+		{name: "name", ids: []string{"VUID-vkCmdDraw-None-02699"}, msgName: "VUID-vkCmdDraw-None-02699", msgNumber: 1, want: true},
+		{name: "other name", ids: []string{"VUID-vkCmdDraw-None-02699"}, msgName: "VUID-vkCmdDraw-None-02700", msgNumber: 1, want: false},
+		{name: "hex number", ids: []string{"0x1234abcd"}, msgNumber: 0x1234abcd, want: true},
+		{name: "negative hex number", ids: []string{"0x822806fa"}, msgNumber: int32(-0x7dd7f906), want: true},
+		{name: "upper case hex number", ids: []string{"0x822806FA"}, msgNumber: int32(-0x7dd7f906), want: true},
+		{name: "other number", ids: []string{"0x1234abcd"}, msgNumber: 0x1234abce, want: false},
+		{name: "number not matching name", ids: []string{"0x1234abcd"}, msgName: "0x1234abcd", msgNumber: 1, want: false},
+		{name: "invalid hex as name", ids: []string{"0xZZ"}, msgName: "0xZZ", msgNumber: 1, want: true},
+		{name: "decimal as name", ids: []string{"1234"}, msgNumber: 1234, want: false},
+		{name: "multiple IDs", ids: []string{"foo", "0x10", "bar"}, msgNumber: 0x10, want: true},
+	}
+	for _, g := range golden {
+		var got []*DebugMessage
+		useValidationState(t, ValidationLog, func(msg *DebugMessage) {
+			got = append(got, msg)
+		})
+		SuppressDebugMessages(g.ids...)
+		handleDebugMessage(&DebugMessage{
+			Severity:        SeverityWarning,
+			MessageIDName:   g.msgName,
+			MessageIDNumber: g.msgNumber,
+		})
+		if suppressed := len(got) == 0; suppressed != g.want {
+			t.Errorf("%s: suppressed mismatch; expected %v, got %v", g.name, g.want, suppressed)
+		}
+	}
+}
+
+func TestDebugSinks(t *testing.T) {
+	var defaultMsgs, aMsgs, bMsgs []string
+	useValidationState(t, ValidationLog, func(msg *DebugMessage) {
+		defaultMsgs = append(defaultMsgs, msg.Message)
+	})
+	send := func(message string) {
+		handleDebugMessage(&DebugMessage{Severity: SeverityInfo, Message: message})
+	}
+	send("1")
+	removeA := AddDebugSink(DebugSinkFunc(func(msg *DebugMessage) {
+		aMsgs = append(aMsgs, msg.Message)
+	}))
+	send("2")
+	removeB := AddDebugSink(DebugSinkFunc(func(msg *DebugMessage) {
+		bMsgs = append(bMsgs, msg.Message)
+	}))
+	send("3")
+	removeA()
+	send("4")
+	RemoveDefaultDebugSink()
+	send("5")
+	removeB()
+	send("6")
+	// Removing a sink twice is a no-op.
+	removeA()
+	send("7")
+	golden := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{name: "default sink", got: defaultMsgs, want: []string{"1", "2", "3", "4"}},
+		{name: "sink a", got: aMsgs, want: []string{"2", "3"}},
+		{name: "sink b", got: bMsgs, want: []string{"3", "4", "5"}},
+	}
+	for _, g := range golden {
+		if !reflect.DeepEqual(g.got, g.want) {
+			t.Errorf("%s: messages mismatch; expected %v, got %v", g.name, g.want, g.got)
+		}
+	}
+}
+
+func TestCheckValidationErrors(t *testing.T) {
+	golden := []struct {
+		name string
+		mode ValidationMode
+		// Severities of reported debug messages.
+		severities []DebugMessageSeverity
+		// Number of validation errors expected to be returned or panicked
+		// with; or 0 if none.
+		wantErrors int
+		wantPanic  bool
+	}{
+		{name: "log", mode: ValidationLog, severities: []DebugMessageSeverity{SeverityError}},
+		{name: "fail without messages", mode: ValidationFail},
+		{name: "fail with warnings", mode: ValidationFail, severities: []DebugMessageSeverity{SeverityWarning, SeverityInfo, SeverityVerbose}},
+		{name: "fail with errors", mode: ValidationFail, severities: []DebugMessageSeverity{SeverityError, SeverityWarning, SeverityError}, wantErrors: 2},
+		{name: "panic without messages", mode: ValidationPanic},
+		{name: "panic with warnings", mode: ValidationPanic, severities: []DebugMessageSeverity{SeverityWarning}},
+		{name: "panic with errors", mode: ValidationPanic, severities: []DebugMessageSeverity{SeverityError}, wantErrors: 1, wantPanic: true},
+	}
+	for _, g := range golden {
+		useValidationState(t, g.mode, func(msg *DebugMessage) {})
+		for _, severity := range g.severities {
+			handleDebugMessage(&DebugMessage{Severity: severity})
+		}
+		var (
+			err       error
+			recovered interface{}
+		)
+		func() {
+			defer func() {
+				recovered = recover()
+			}()
+			err = checkValidationErrors()
+		}()
+		if panicked := recovered != nil; panicked != g.wantPanic {
+			t.Errorf("%s: panic mismatch; expected %v, got %v", g.name, g.wantPanic, panicked)
+			continue
+		}
+		if g.wantPanic {
+			err, _ = recovered.(error)
+		}
+		if g.wantErrors == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", g.name, err)
+			}
+			continue
+		}
+		verr, ok := err.(*ValidationError)
+		if !ok {
+			t.Errorf("%s: expected *ValidationError, got %T", g.name, err)
+			continue
+		}
+		if len(verr.Messages) != g.wantErrors {
+			t.Errorf("%s: number of validation errors mismatch; expected %d, got %d", g.name, g.wantErrors, len(verr.Messages))
+		}
+		// Validation errors are reported once.
+		if err := checkValidationErrors(); err != nil {
+			t.Errorf("%s: unexpected error on second check: %v", g.name, err)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package vk
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// VertexStruct specifies a vertex buffer binding of elements of a Go struct
+// type, for use with VertexLayoutOf.
+//
+// Each field of the struct is a vertex attribute, read at the offset of the
+// field within the struct. The shader input location and format of an
+// attribute are specified by the struct tag of the field:
+//
+//	type Particle struct {
+//		Pos   Vec3      // location 0, r32g32b32_sfloat
+//		UV    [2]uint16 `vk:"location=2,format=r16g16_uint"`
+//		Color [4]uint8  // location 3, r8g8b8a8_unorm
+//		pad   uint32    `vk:"-"` // not an attribute
+//	}
+//
+// The location defaults to the location following that of the previous
+// attribute (starting at 0 across all bindings of the layout). The format
+// defaults to the format inferred from the type of the field; a number or an
+// array of 1 to 4 numbers of type float32 (SFLOAT), int32 (SINT), uint32
+// (UINT), int16 or int8 (SNORM), and uint16 or uint8 (UNORM). Integer
+// attributes of other numeric formats (e.g. r16g16_uint) require the format to
+// be specified explicitly, the size of which must match the size of the field.
+type VertexStruct struct {
+	// Value of the struct type (e.g. Vertex{}).
+	Elem interface{}
+	// Advance to the next element per vertex or per instance.
+	InputRate VertexInputRate
+}
+
+// maxVertexBindings specifies the maximum number of vertex buffer bindings of
+// a vertex layout; the minimum limit of maxVertexInputBindings guaranteed by
+// Vulkan.
+const maxVertexBindings = 16
+
+// vertexLayoutKey is the key of a vertex layout in the vertex layout cache.
+type vertexLayoutKey struct {
+	// Number of bindings.
+	n int
+	// Struct types and input rates of bindings.
+	types      [maxVertexBindings]reflect.Type
+	inputRates [maxVertexBindings]VertexInputRate
+}
+
+// vertexLayouts is the cache of vertex layouts created by VertexLayoutOf.
+var vertexLayouts = struct {
+	sync.Mutex
+	m map[vertexLayoutKey]*VertexLayout
+}{
+	m: make(map[vertexLayoutKey]*VertexLayout),
+}
+
+// VertexLayoutOf returns the vertex input layout of vertex buffers of the given
+// Go struct types, read from consecutive vertex buffer bindings starting at
+// binding 0 (see VertexStruct).
+//
+// The binding strides and attribute offsets are the sizes and field offsets of
+// the struct types, which must therefore have no padding between or after
+// fields, and no fields of pointers or types of platform-dependent size.
+//
+// Vertex layouts of equal bindings are shared, so that pipeline descriptions
+// comparing vertex layouts by identity compare equal.
+func VertexLayoutOf(bindings ...VertexStruct) (*VertexLayout, error) {
+	if len(bindings) == 0 {
+		return nil, errors.New("invalid vertex layout; no bindings")
+	}
+	if len(bindings) > maxVertexBindings {
+		return nil, errors.Errorf("invalid vertex layout; number of bindings (%d) exceeds %d", len(bindings), maxVertexBindings)
+	}
+	key := vertexLayoutKey{n: len(bindings)}
+	for i, binding := range bindings {
+		if binding.Elem == nil {
+			return nil, errors.Errorf("invalid nil element of vertex buffer binding %d", i)
+		}
+		key.types[i] = reflect.TypeOf(binding.Elem)
+		key.inputRates[i] = binding.InputRate
+	}
+	vertexLayouts.Lock()
+	defer vertexLayouts.Unlock()
+	if layout, ok := vertexLayouts.m[key]; ok {
+		return layout, nil
+	}
+	layout := &VertexLayout{}
+	locations := make(map[int]string)
+	location := 0
+	for i := 0; i < key.n; i++ {
+		t := key.types[i]
+		if t.Kind() != reflect.Struct {
+			return nil, errors.Errorf("invalid element type %v of vertex buffer binding %d; expected struct", t, i)
+		}
+		layout.Bindings = append(layout.Bindings, VertexBinding{
+			Binding:   i,
+			Stride:    int(t.Size()),
+			InputRate: key.inputRates[i],
+		})
+		end := uintptr(0)
+		for j := 0; j < t.NumField(); j++ {
+			field := t.Field(j)
+			if !isVertexFieldType(field.Type) {
+				return nil, errors.Errorf("invalid type %v of field %v.%s; expected number or array of numbers", field.Type, t, field.Name)
+			}
+			if field.Offset != end {
+				return nil, errors.Errorf("invalid padding of %d bytes before field %v.%s", field.Offset-end, t, field.Name)
+			}
+			end = field.Offset + field.Type.Size()
+			tag := field.Tag.Get("vk")
+			if tag == "-" {
+				continue
+			}
+			format, ok := inferVertexFormat(field.Type)
+			if tag != "" {
+				for _, opt := range strings.Split(tag, ",") {
+					parts := strings.SplitN(opt, "=", 2)
+					if len(parts) != 2 {
+						return nil, errors.Errorf("invalid option %q in struct tag of field %v.%s; expected key=value", opt, t, field.Name)
+					}
+					switch option, val := parts[0], parts[1]; option {
+					case "location":
+						loc, err := strconv.Atoi(val)
+						if err != nil || loc < 0 {
+							return nil, errors.Errorf("invalid location %q in struct tag of field %v.%s", val, t, field.Name)
+						}
+						location = loc
+					case "format":
+						f, found := vertexFormats[val]
+						if !found {
+							return nil, errors.Errorf("invalid vertex format %q in struct tag of field %v.%s", val, t, field.Name)
+						}
+						if size := vertexFormatSize(f); size != int(field.Type.Size()) {
+							return nil, errors.Errorf("size mismatch of vertex format %q (%d bytes) and field %v.%s (%d bytes)", val, size, t, field.Name, field.Type.Size())
+						}
+						format, ok = f, true
+					default:
+						return nil, errors.Errorf("invalid option %q in struct tag of field %v.%s", option, t, field.Name)
+					}
+				}
+			}
+			if !ok {
+				return nil, errors.Errorf("unable to infer vertex format of field %v.%s of type %v; specify format in struct tag", t, field.Name, field.Type)
+			}
+			name := t.String() + "." + field.Name
+			if prev, ok := locations[location]; ok {
+				return nil, errors.Errorf("location %d of field %s already used by field %s", location, name, prev)
+			}
+			locations[location] = name
+			layout.Attributes = append(layout.Attributes, VertexAttribute{
+				Location: location,
+				Binding:  i,
+				Format:   format,
+				Offset:   int(field.Offset),
+			})
+			location++
+		}
+		if end != t.Size() {
+			return nil, errors.Errorf("invalid padding of %d bytes at end of struct %v", t.Size()-end, t)
+		}
+	}
+	vertexLayouts.m[key] = layout
+	return layout, nil
+}
+
+// mustVertexLayoutOf returns the vertex input layout of vertex buffers of the
+// given Go struct types, panicking on error.
+func mustVertexLayoutOf(bindings ...VertexStruct) *VertexLayout {
+	layout, err := VertexLayoutOf(bindings...)
+	if err != nil {
+		panic(err)
+	}
+	return layout
+}
+
+// isVertexFieldType reports whether the given type may be the type of a field
+// of a vertex struct; i.e. a fixed-size number, or an array thereof.
+func isVertexFieldType(t reflect.Type) bool {
+	if t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// inferVertexFormat returns the vertex format inferred from the given type of a
+// field of a vertex struct.
+func inferVertexFormat(t reflect.Type) (Format, bool) {
+	n := 1
+	if t.Kind() == reflect.Array {
+		n = t.Len()
+		t = t.Elem()
+	}
+	if n < 1 || n > 4 {
+		return 0, false
+	}
+	var numFormat string
+	switch t.Kind() {
+	case reflect.Float32:
+		numFormat = "sfloat"
+	case reflect.Int32:
+		numFormat = "sint"
+	case reflect.Uint32:
+		numFormat = "uint"
+	case reflect.Int8, reflect.Int16:
+		numFormat = "snorm"
+	case reflect.Uint8, reflect.Uint16:
+		numFormat = "unorm"
+	default:
+		return 0, false
+	}
+	bits := strconv.Itoa(int(t.Size()) * 8)
+	name := ""
+	for _, c := range "rgba"[:n] {
+		name += string(c) + bits
+	}
+	format, ok := vertexFormats[name+"_"+numFormat]
+	return format, ok
+}
+
+// vertexFormatName matches the names of vertex formats of 8-, 16- and 32-bit
+// components, and 32-bit packed formats.
+var vertexFormatName = regexp.MustCompile(`^VK_FORMAT_((?:[RGBA](?:8|16|32))+)_(?:UNORM|SNORM|USCALED|SSCALED|UINT|SINT|SFLOAT)$|^VK_FORMAT_[RGBA0-9]+_(?:UNORM|SNORM|USCALED|SSCALED|UINT|SINT)_PACK32$`)
+
+// componentBits matches the number of bits of each component of a format name.
+var componentBits = regexp.MustCompile(`[0-9]+`)
+
+// vertexFormats maps from lowercase format names without the VK_FORMAT_ prefix
+// (e.g. "r16g16_unorm") to vertex formats.
+var vertexFormats = getVertexFormats()
+
+// getVertexFormats returns the vertex formats by lowercase name.
+func getVertexFormats() map[string]Format {
+	formats := make(map[string]Format)
+	// Vertex formats are all core formats of Vulkan 1.0.
+	for f := FormatUndefined; f <= FormatD32SfloatS8Uint; f++ {
+		if vertexFormatSize(f) == 0 {
+			continue
+		}
+		name := strings.ToLower(strings.TrimPrefix(f.String(), "VK_FORMAT_"))
+		formats[name] = f
+	}
+	return formats
+}
+
+// vertexFormatSize returns the size in bytes of the given vertex format, or 0
+// if not a vertex format.
+func vertexFormatSize(f Format) int {
+	m := vertexFormatName.FindStringSubmatch(f.String())
+	if m == nil {
+		return 0
+	}
+	if m[1] == "" {
+		// Packed format.
+		return 4
+	}
+	bits := 0
+	for _, s := range componentBits.FindAllString(m[1], -1) {
+		n, _ := strconv.Atoi(s)
+		bits += n
+	}
+	return bits / 8
+}
@@ -0,0 +1,184 @@
+package vk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVertexLayoutOf(t *testing.T) {
+	type particle struct {
+		Pos   Vec3
+		UV    [2]uint16 `vk:"location=2,format=r16g16_uint"`
+		Color [4]uint8
+		pad   uint32 `vk:"-"`
+	}
+	type scalars struct {
+		F float32
+		I int32
+		U uint32
+		S [2]int16
+		B [4]int8
+	}
+	type paddingBefore struct {
+		A uint8
+		B float32
+	}
+	type paddingAfter struct {
+		A float32
+		B uint8
+	}
+	type pointerField struct {
+		P *float32
+	}
+	type intField struct {
+		A int
+	}
+	type float64Field struct {
+		A float64
+	}
+	type missingValue struct {
+		A float32 `vk:"location"`
+	}
+	type unknownOption struct {
+		A float32 `vk:"offset=4"`
+	}
+	type invalidLocation struct {
+		A float32 `vk:"location=-1"`
+	}
+	type unknownFormat struct {
+		A float32 `vk:"format=r7_unorm"`
+	}
+	type sizeMismatch struct {
+		A [2]uint16 `vk:"format=r32g32_uint"`
+	}
+	type duplicateLocation struct {
+		A float32
+		B float32 `vk:"location=0"`
+	}
+	tooManyBindings := make([]VertexStruct, maxVertexBindings+1)
+	for i := range tooManyBindings {
+		tooManyBindings[i] = VertexStruct{Elem: scalars{}, InputRate: VertexInputRateVertex}
+	}
+	golden := []struct {
+		name     string
+		bindings []VertexStruct
+		want     *VertexLayout
+		wantErr  bool
+	}{
+		{
+			name:     "tags and skipped field",
+			bindings: []VertexStruct{{Elem: particle{}, InputRate: VertexInputRateVertex}},
+			want: &VertexLayout{
+				Bindings: []VertexBinding{
+					{Binding: 0, Stride: 24, InputRate: VertexInputRateVertex},
+				},
+				Attributes: []VertexAttribute{
+					{Location: 0, Binding: 0, Format: FormatR32g32b32Sfloat, Offset: 0},
+					{Location: 2, Binding: 0, Format: FormatR16g16Uint, Offset: 12},
+					{Location: 3, Binding: 0, Format: FormatR8g8b8a8Unorm, Offset: 16},
+				},
+			},
+		},
+		{
+			name:     "inferred formats",
+			bindings: []VertexStruct{{Elem: scalars{}, InputRate: VertexInputRateVertex}},
+			want: &VertexLayout{
+				Bindings: []VertexBinding{
+					{Binding: 0, Stride: 20, InputRate: VertexInputRateVertex},
+				},
+				Attributes: []VertexAttribute{
+					{Location: 0, Binding: 0, Format: FormatR32Sfloat, Offset: 0},
+					{Location: 1, Binding: 0, Format: FormatR32Sint, Offset: 4},
+					{Location: 2, Binding: 0, Format: FormatR32Uint, Offset: 8},
+					{Location: 3, Binding: 0, Format: FormatR16g16Snorm, Offset: 12},
+					{Location: 4, Binding: 0, Format: FormatR8g8b8a8Snorm, Offset: 16},
+				},
+			},
+		},
+		{
+			name: "per-vertex and per-instance bindings",
+			bindings: []VertexStruct{
+				{Elem: Vertex{}, InputRate: VertexInputRateVertex},
+				{Elem: Instance{}, InputRate: VertexInputRateInstance},
+			},
+			want: &VertexLayout{
+				Bindings: []VertexBinding{
+					{Binding: 0, Stride: 20, InputRate: VertexInputRateVertex},
+					{Binding: 1, Stride: 28, InputRate: VertexInputRateInstance},
+				},
+				Attributes: []VertexAttribute{
+					{Location: 0, Binding: 0, Format: FormatR32g32Sfloat, Offset: 0},
+					{Location: 1, Binding: 0, Format: FormatR32g32b32Sfloat, Offset: 8},
+					{Location: 2, Binding: 1, Format: FormatR32g32Sfloat, Offset: 0},
+					{Location: 3, Binding: 1, Format: FormatR32Sfloat, Offset: 8},
+					{Location: 4, Binding: 1, Format: FormatR32Sfloat, Offset: 12},
+					{Location: 5, Binding: 1, Format: FormatR32g32b32Sfloat, Offset: 16},
+				},
+			},
+		},
+		{name: "no bindings", bindings: nil, wantErr: true},
+		{name: "too many bindings", bindings: tooManyBindings, wantErr: true},
+		{name: "nil element", bindings: []VertexStruct{{Elem: nil}}, wantErr: true},
+		{name: "non-struct element", bindings: []VertexStruct{{Elem: float32(0)}}, wantErr: true},
+		{name: "padding before field", bindings: []VertexStruct{{Elem: paddingBefore{}}}, wantErr: true},
+		{name: "padding at end", bindings: []VertexStruct{{Elem: paddingAfter{}}}, wantErr: true},
+		{name: "pointer field", bindings: []VertexStruct{{Elem: pointerField{}}}, wantErr: true},
+		{name: "int field", bindings: []VertexStruct{{Elem: intField{}}}, wantErr: true},
+		{name: "uninferable format", bindings: []VertexStruct{{Elem: float64Field{}}}, wantErr: true},
+		{name: "tag option without value", bindings: []VertexStruct{{Elem: missingValue{}}}, wantErr: true},
+		{name: "unknown tag option", bindings: []VertexStruct{{Elem: unknownOption{}}}, wantErr: true},
+		{name: "invalid location", bindings: []VertexStruct{{Elem: invalidLocation{}}}, wantErr: true},
+		{name: "unknown format", bindings: []VertexStruct{{Elem: unknownFormat{}}}, wantErr: true},
+		{name: "format and field size mismatch", bindings: []VertexStruct{{Elem: sizeMismatch{}}}, wantErr: true},
+		{name: "duplicate location", bindings: []VertexStruct{{Elem: duplicateLocation{}}}, wantErr: true},
+		{
+			name: "duplicate location across bindings",
+			bindings: []VertexStruct{
+				{Elem: Vertex{}, InputRate: VertexInputRateVertex},
+				{Elem: duplicateLocation{}, InputRate: VertexInputRateInstance},
+			},
+			wantErr: true,
+		},
+	}
+	for _, g := range golden {
+		got, err := VertexLayoutOf(g.bindings...)
+		if g.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %+v", g.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", g.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, g.want) {
+			t.Errorf("%s: vertex layout mismatch; expected %+v, got %+v", g.name, g.want, got)
+		}
+	}
+}
+
+func TestVertexLayoutOfCache(t *testing.T) {
+	type vertex struct {
+		Pos Vec2
+	}
+	vertexRate := VertexStruct{Elem: vertex{}, InputRate: VertexInputRateVertex}
+	instanceRate := VertexStruct{Elem: vertex{}, InputRate: VertexInputRateInstance}
+	a := mustVertexLayoutOf(vertexRate)
+	b := mustVertexLayoutOf(vertexRate)
+	if a != b {
+		t.Errorf("expected vertex layouts of equal bindings to be identical")
+	}
+	c := mustVertexLayoutOf(instanceRate)
+	if a == c {
+		t.Errorf("expected vertex layouts of different input rates to differ")
+	}
+	d := mustVertexLayoutOf(vertexRate, instanceRate)
+	if a == d {
+		t.Errorf("expected vertex layouts of different numbers of bindings to differ")
+	}
+	// Pipeline descriptions of equal vertex layouts compare equal.
+	if instancedVertexLayout != mustVertexLayoutOf(VertexStruct{Elem: Vertex{}, InputRate: VertexInputRateVertex}, VertexStruct{Elem: Instance{}, InputRate: VertexInputRateInstance}) {
+		t.Errorf("expected instanced vertex layout to be shared")
+	}
+}
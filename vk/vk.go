@@ -10,18 +10,18 @@ package vk
 //
 // #include "callback.h"
 // #include "invoke.h"
-// #include "malloc.h"
+//
+//#cgo CFLAGS: -DVK_NO_PROTOTYPES
 //
 //#cgo linux pkg-config: glfw3
-//#cgo linux pkg-config: vulkan
 //
 //#cgo windows LDFLAGS: -lglfw3dll
-//#cgo windows LDFLAGS: -lvulkan-1
 import "C"
 
 import (
 	"io/ioutil"
 	"log"
+	"math/rand"
 	"os"
 	"sort"
 	"unsafe"
@@ -67,133 +67,169 @@ var RequiredDeviceExtensions = []string{
 // Maximum number of frames processed concurrently by GPU.
 const MaxFramesInFlight = 2
 
-func Init() error {
+// Options specifies the options of the application.
+type Options struct {
+	// Recording of presented frames, starting with the first frame; or nil.
+	Recording *Recording
+	// Number of samples per pixel of multisample anti-aliasing (1, 2, 4 or 8);
+	// limited to the sample counts supported by the device. Zero is
+	// interpreted as 1 (no multisampling).
+	Samples int
+	// Invoke the fragment shader once per sample, rather than once per pixel,
+	// if multisampling; also smoothing aliasing within primitives (e.g. of
+	// textures), at a higher cost. Ignored if not supported by the device.
+	SampleShading bool
+	// Maximum anisotropy of texture filtering (e.g. 16), limited to the
+	// anisotropy supported by the device; improves the sharpness of textures
+	// viewed at oblique angles. Zero or 1 disables anisotropic filtering.
+	Anisotropy float32
+	// Present modes of the window swapchain in order of preference (e.g.
+	// PresentModeImmediate for latency tests, or PresentModeFifo to save
+	// power); the first present mode supported by the surface is used, and
+	// FIFO otherwise. Nil uses DefaultPresentModes.
+	PresentModes []PresentMode
+	// OnFrame is invoked by the event loop before each frame, with the
+	// application rendering to the window; e.g. to change the present mode
+	// (App.SetPresentMode, App.SetVSync), query the frame rate
+	// (App.FrameStats), request a screenshot (RequestScreenshot) or start a
+	// recording (StartRecording). The application must not be used after Init
+	// returns. Nil is ignored.
+	OnFrame func(app *App)
+}
+
+func Init(opts Options) error {
+	if !isVulkanLoaded() {
+		if err := LoadVulkan(""); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	switch opts.Samples {
+	case 0, 1, 2, 4, 8:
+		// valid number of samples.
+	default:
+		return errors.Errorf("invalid number of samples per pixel %d; expected 1, 2, 4 or 8", opts.Samples)
+	}
 	app := newApp()
+	if opts.Samples > 0 {
+		app.requestedSamples = opts.Samples
+	}
+	app.requestedSampleShading = opts.SampleShading
+	app.requestedAnisotropy = opts.Anisotropy
+	app.presentModes = opts.PresentModes
+	app.onFrame = opts.OnFrame
 	app.win = InitWindow(app)
 	defer CleanupWindow(app.win)
 	if err := InitVulkan(app); err != nil {
 		return errors.WithStack(err)
 	}
 	defer CleanupVulkan(app)
+	if opts.Recording != nil {
+		if err := StartRecording(app, opts.Recording); err != nil {
+			return errors.WithStack(err)
+		}
+	}
 
-	EventLoop(app)
+	if err := EventLoop(app); err != nil {
+		return errors.WithStack(err)
+	}
 	return nil
 }
 
 func InitVulkan(app *App) error {
-	// Create Vulkan instance.
-	instance, err := initInstance()
-	if err != nil {
+	if err := initVulkanDevice(app); err != nil {
 		return errors.WithStack(err)
 	}
-	app.instance = instance
-	// Create debug messanger.
-	debugMessanger, err := initDebugMessanger(app.instance)
-	if err != nil {
-		return errors.WithStack(err)
+	if app.headless {
+		// Create offscreen image, in place of swapchain images.
+		if err := initOffscreenImg(app); err != nil {
+			return errors.WithStack(err)
+		}
+	} else {
+		// Create swapchain.
+		swapchain, err := initSwapchain(app)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		app.swapchain = swapchain
+		// Create swapchain images.
+		app.swapchainImgs = getSwapchainImgs(app)
 	}
-	app.debugMessanger = debugMessanger
-	// Create Vulkan surface.
-	surface, err := initSurface(app)
+	// Create swapchain image views.
+	swapchainImgViews, err := initSwapchainImgViews(app)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	app.surface = surface
-	// Create Vulkan physical device.
-	physicalDevice, err := initPhysicalDevice(app)
-	if err != nil {
+	app.swapchainImgViews = swapchainImgViews
+	// Create multisampled color target.
+	if err := initMSAAColorTarget(app); err != nil {
 		return errors.WithStack(err)
 	}
-	app.physicalDevice = physicalDevice
-	// Create Vulkan logical device.
-	device, err := initDevice(app)
-	if err != nil {
-		return errors.WithStack(err)
+	// Create render pass, unless dynamic rendering.
+	if !app.dynamicRendering {
+		renderPass, err := initRenderPass(app)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		app.renderPass = renderPass
 	}
-	app.device = device
-	// Init queue indices.
-	initQueues(app)
-
-	// Create swapchain.
-	swapchain, err := initSwapchain(app)
+	// Create descriptor set layout of textures; not recreated with the
+	// swapchain, as it outlives the pipeline layout.
+	textureSetLayout, err := initTextureSetLayout(app)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	app.swapchain = swapchain
-	// Create swapchain images.
-	app.swapchainImgs = getSwapchainImgs(app)
-	// Create swapchain image views.
-	swapchainImgViews, err := initSwapchainImgViews(app)
+	app.textureSetLayout = textureSetLayout
+	// Create pipeline layout of graphics pipelines; the graphics pipelines are
+	// created on first use.
+	pipelineLayout, err := initPipelineLayout(app)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	app.swapchainImgViews = swapchainImgViews
-	// Create render pass.
-	renderPass, err := initRenderPass(app)
+	app.pipelineLayout = pipelineLayout
+	// Create framebuffers, unless dynamic rendering.
+	if !app.dynamicRendering {
+		framebuffers, err := initFramebuffers(app)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		app.swapchainFramebuffers = framebuffers
+	}
+	// Create command pool.
+	//
+	// NOTE: command pool does not need to be re-initialized during
+	// recreateSwapchain.
+	commandPool, err := initCommandPool(app)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	app.renderPass = renderPass
-	// Create graphics pipeline.
-	graphicsPipelines, err := initGraphicsPipeline(app)
+	app.commandPool = commandPool
+	// Create uploader of buffers and images.
+	u, err := initUploader(app)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	app.graphicsPipelines = graphicsPipelines
-	// Create framebuffers.
-	framebuffers, err := initFramebuffers(app)
+	app.uploader = u
+	// Create mesh of the scene in GPU memory.
+	vertices := app.scene.vertices(rand.New(rand.NewSource(app.seed)))
+	mesh, meshUpload, err := createMesh(app, app.scene.name, vertices, len(app.scene.computeShader) > 0)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	app.swapchainFramebuffers = framebuffers
-	// Create command pool.
-	//
-	// NOTE: command pool does not need to be re-initialized during
-	// recreateSwapchain.
-	commandPool, err := initCommandPool(app)
+	app.sceneMesh = mesh
+	app.sceneRoot = newSceneGraph(app.scene, mesh)
+	app.sceneUpload = meshUpload
+	// Create texture of the scene, if any.
+	tex, err := createSceneTexture(app, app.scene)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	app.commandPool = commandPool
-	// Create vertex buffer.
-	// top-left
-	topLeft := Vertex{
-		pos:   vec2(-0.5, -0.5),    // x, y
-		color: vec3(1.0, 0.0, 0.0), // red
-	}
-	// top-right
-	topRight := Vertex{
-		pos:   vec2(0.5, -0.5),     // x, y
-		color: vec3(0.0, 1.0, 0.0), // green
-	}
-	// bottom-right
-	bottomRight := Vertex{
-		pos:   vec2(0.5, 0.5),      // x, y
-		color: vec3(0.0, 0.0, 1.0), // blue
-	}
-	// bottom-left
-	bottomLeft := Vertex{
-		pos:   vec2(-0.5, 0.5),     // x, y
-		color: vec3(1.0, 1.0, 1.0), // white
-	}
-	vertices := []Vertex{
-		// first triangle.
-		topLeft,
-		topRight,
-		bottomRight,
-		// second triangle.
-		bottomRight,
-		bottomLeft,
-		topLeft,
-	}
-	indices, uniqueVertices := uniqueIndexList(vertices)
-	app.indices = indices
-	app.uniqueVertices = uniqueVertices
-	if err := createVertexBuffer(app, uniqueVertices); err != nil {
+	app.sceneTexture = tex
+	// Submit uploads of vertex and index buffers, waited for before rendering.
+	if err := flushUploads(app); err != nil {
 		return errors.WithStack(err)
 	}
-	// Create index buffer in GPU memory.
-	if err := createIndexBuffer(app, indices); err != nil {
+	// Animate vertices on compute queue.
+	if err := initAnimation(app); err != nil {
 		return errors.WithStack(err)
 	}
 	// Create command buffers.
@@ -206,80 +242,263 @@ func InitVulkan(app *App) error {
 		return errors.WithStack(err)
 	}
 	// Sync objects.
-	if err := initSyncObjects(app); err != nil {
+	if !app.headless {
+		if err := initSyncObjects(app); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	// Report validation errors of initialization.
+	if err := checkValidationErrors(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// initVulkanDevice initializes the Vulkan instance, the logical device and its
+// queues; and the surface of the window, unless headless.
+func initVulkanDevice(app *App) error {
+	initValidationMode()
+	// Create Vulkan instance.
+	instance, err := initInstance(app)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.instance = instance
+	trackObject(app, C.VK_OBJECT_TYPE_INSTANCE, unsafe.Pointer(*app.instance), "instance")
+	// Load Vulkan commands of instance.
+	app.instanceProcs = newInstanceProcs(*app.instance)
+	// Create debug messanger.
+	if contains(app.instanceExtensions, C.VK_EXT_DEBUG_UTILS_EXTENSION_NAME) {
+		debugMessanger, err := initDebugMessanger(app)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		app.debugMessanger = debugMessanger
+		trackObject(app, C.VK_OBJECT_TYPE_DEBUG_UTILS_MESSENGER_EXT, unsafe.Pointer(*app.debugMessanger), "debugMessanger")
+	}
+	// Create Vulkan surface.
+	if !app.headless {
+		surface, err := initSurface(app)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		app.surface = surface
+		trackObject(app, C.VK_OBJECT_TYPE_SURFACE_KHR, unsafe.Pointer(*app.surface), "surface")
+	}
+	// Create Vulkan physical device.
+	physicalDevice, err := initPhysicalDevice(app)
+	if err != nil {
 		return errors.WithStack(err)
 	}
+	app.physicalDevice = physicalDevice
+	// Create Vulkan logical device.
+	device, err := initDevice(app)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.device = device
+	trackObject(app, C.VK_OBJECT_TYPE_DEVICE, unsafe.Pointer(*app.device), "device")
+	// Load Vulkan commands of device.
+	app.deviceProcs = newDeviceProcs(app.instanceProcs, *app.device)
+	if app.dynamicRendering {
+		loadDynamicRenderingProcs(app)
+	}
+	if !app.dynamicRendering {
+		dbg.Println("rendering with render passes")
+	}
+	if app.timelineSemaphores {
+		loadTimelineSemaphoreProcs(app)
+	}
+	if !app.timelineSemaphores {
+		dbg.Println("synchronizing with binary semaphores and fences")
+	}
+	// Create timelines of queue submissions.
+	if err := initTimelines(app); err != nil {
+		return errors.WithStack(err)
+	}
+	// Init queue indices.
+	initQueues(app)
+	// Create compute command pool.
+	computeCommandPool, err := initComputeCommandPool(app)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.computeCommandPool = computeCommandPool
 	return nil
 }
 
 func CleanupVulkan(app *App) {
+	// Finish captures of the last frames, as the device is idle.
+	scratch := newArena()
+	for frame := 0; frame < MaxFramesInFlight; frame++ {
+		finishCaptures(app, scratch, frame)
+	}
+	scratch.free()
+	failCapture(app.captureRequests)
+	app.captureRequests = nil
+	stopRecording(app)
+	app.screenshotWrites.Wait()
 	for i := range app.imageAvailableSemaphores {
-		C.vkDestroyFence(*app.device, *app.imagesInFlightFences[i], nil)
-		C.vkDestroyFence(*app.device, *app.framesInFlightFences[i], nil)
-		C.vkDestroySemaphore(*app.device, *app.imageAvailableSemaphores[i], nil)
-		C.vkDestroySemaphore(*app.device, *app.renderFinishedSemaphores[i], nil)
-	}
-	C.vkFreeMemory(*app.device, *app.indexBufferMem, nil)
-	C.vkDestroyBuffer(*app.device, *app.indexBuffer, nil)
-	C.vkFreeMemory(*app.device, *app.vertexBufferMem, nil)
-	C.vkDestroyBuffer(*app.device, *app.vertexBuffer, nil)
+		destroyFence(app, app.framesInFlightFences[i])
+		destroySemaphore(app, app.imageAvailableSemaphores[i])
+		destroySemaphore(app, app.renderFinishedSemaphores[i])
+	}
+	app.imagesInFlight = nil
+	cleanupUploader(app)
+	flushDeletions(app)
+	if app.animation != nil {
+		destroyAnimation(app, app.animation)
+		app.animation = nil
+	}
+	destroyInstanceBuffers(app)
+	destroyMesh(app, app.sceneMesh)
+	app.sceneMesh = nil
+	if app.sceneTexture != nil {
+		destroyTexture(app, app.sceneTexture)
+		app.sceneTexture = nil
+	}
+	app.sceneRoot = nil
 	cleanupSwapchain(app)
-	C.vkDestroyCommandPool(*app.device, *app.commandPool, nil)
-	C.vkDestroyDevice(*app.device, nil) // free command pool after command buffers allocated in pool.
+	destroyTextureSetLayout(app)
+	untrackObject(C.VK_OBJECT_TYPE_COMMAND_POOL, unsafe.Pointer(*app.commandPool))
+	app.deviceProcs.DestroyCommandPool(*app.device, *app.commandPool, nil)
+	cleanupVulkanDevice(app)
+}
+
+// cleanupVulkanDevice destroys the logical device, the surface and the Vulkan
+// instance, and releases the C memory of the application.
+func cleanupVulkanDevice(app *App) {
+	destroyTimelines(app)
+	untrackObject(C.VK_OBJECT_TYPE_COMMAND_POOL, unsafe.Pointer(*app.computeCommandPool))
+	app.deviceProcs.DestroyCommandPool(*app.device, *app.computeCommandPool, nil)
+	untrackObject(C.VK_OBJECT_TYPE_DEVICE, unsafe.Pointer(*app.device))
+	app.deviceProcs.DestroyDevice(*app.device, nil) // free command pool after command buffers allocated in pool.
 	app.physicalDevice = nil
-	DestroyDebugUtilsMessengerEXT(*app.instance, *app.debugMessanger, nil)
-	C.vkDestroySurfaceKHR(*app.instance, *app.surface, nil)
-	C.vkDestroyInstance(*app.instance, nil)
+	app.graphicsQueue = nil
+	app.presentQueue = nil
+	app.computeQueue = nil
+	app.transferQueue = nil
+	app.device = nil
+	if app.debugMessanger != nil {
+		untrackObject(C.VK_OBJECT_TYPE_DEBUG_UTILS_MESSENGER_EXT, unsafe.Pointer(*app.debugMessanger))
+		app.instanceProcs.DestroyDebugUtilsMessengerEXT(*app.instance, *app.debugMessanger, nil)
+		app.debugMessanger = nil
+	}
+	if app.surface != nil {
+		untrackObject(C.VK_OBJECT_TYPE_SURFACE_KHR, unsafe.Pointer(*app.surface))
+		app.instanceProcs.DestroySurfaceKHR(*app.instance, *app.surface, nil)
+		app.surface = nil
+	}
+	untrackObject(C.VK_OBJECT_TYPE_INSTANCE, unsafe.Pointer(*app.instance))
+	app.instanceProcs.DestroyInstance(*app.instance, nil)
+	app.instance = nil
+	// Release C memory.
+	app.frameArena.free()
+	app.swapchainArena.free()
+	app.arena.free()
+	// Report Vulkan objects and C allocations not released.
+	tracker.reportLeaks()
 }
 
 func cleanupSwapchain(app *App) {
 	for i := range app.swapchainFramebuffers {
 		if app.swapchainFramebuffers[i] != nil {
-			C.vkDestroyFramebuffer(*app.device, app.swapchainFramebuffers[i], nil)
+			untrackObject(C.VK_OBJECT_TYPE_FRAMEBUFFER, unsafe.Pointer(app.swapchainFramebuffers[i]))
+			app.deviceProcs.DestroyFramebuffer(*app.device, app.swapchainFramebuffers[i], nil)
 			app.swapchainFramebuffers[i] = nil
 		}
 	}
+	app.swapchainFramebuffers = nil
 	if len(app.swapchainCommandBuffers) > 0 {
-		C.vkFreeCommandBuffers(*app.device, *app.commandPool, C.uint(len(app.swapchainCommandBuffers)), &app.swapchainCommandBuffers[0])
-		app.swapchainCommandBuffers = nil
-	}
-	if len(app.graphicsPipelines) > 0 {
-		for _, graphicsPipeline := range app.graphicsPipelines {
-			C.vkDestroyPipeline(*app.device, graphicsPipeline, nil)
+		for i := range app.swapchainCommandBuffers {
+			untrackObject(C.VK_OBJECT_TYPE_COMMAND_BUFFER, unsafe.Pointer(app.swapchainCommandBuffers[i]))
 		}
-		app.graphicsPipelines = nil
+		app.deviceProcs.FreeCommandBuffers(*app.device, *app.commandPool, C.uint(len(app.swapchainCommandBuffers)), &app.swapchainCommandBuffers[0])
+		app.swapchainCommandBuffers = nil
 	}
+	destroyPipelines(app)
 	if app.pipelineLayout != nil {
-		C.vkDestroyPipelineLayout(*app.device, *app.pipelineLayout, nil)
+		untrackObject(C.VK_OBJECT_TYPE_PIPELINE_LAYOUT, unsafe.Pointer(*app.pipelineLayout))
+		app.deviceProcs.DestroyPipelineLayout(*app.device, *app.pipelineLayout, nil)
 		app.pipelineLayout = nil
 	}
 	if app.renderPass != nil {
-		C.vkDestroyRenderPass(*app.device, *app.renderPass, nil)
+		untrackObject(C.VK_OBJECT_TYPE_RENDER_PASS, unsafe.Pointer(*app.renderPass))
+		app.deviceProcs.DestroyRenderPass(*app.device, *app.renderPass, nil)
 		app.renderPass = nil
 	}
 	if len(app.swapchainImgViews) > 0 {
 		for i := range app.swapchainImgViews {
-			C.vkDestroyImageView(*app.device, app.swapchainImgViews[i], nil)
+			untrackObject(C.VK_OBJECT_TYPE_IMAGE_VIEW, unsafe.Pointer(app.swapchainImgViews[i]))
+			app.deviceProcs.DestroyImageView(*app.device, app.swapchainImgViews[i], nil)
 		}
 		app.swapchainImgViews = nil
 	}
+	if app.msaaColorImgView != nil {
+		destroyImageView(app, app.msaaColorImgView)
+		app.msaaColorImgView = nil
+	}
+	if app.msaaColorImg != nil {
+		destroyImage(app, app.msaaColorImg, app.msaaColorImgMem)
+		app.msaaColorImg = nil
+		app.msaaColorImgMem = nil
+	}
+	if app.offscreenImg != nil {
+		destroyImage(app, app.offscreenImg, app.offscreenImgMem)
+		app.offscreenImg = nil
+		app.offscreenImgMem = nil
+	}
 	if app.swapchain != nil {
-		C.vkDestroySwapchainKHR(*app.device, *app.swapchain, nil)
+		untrackObject(C.VK_OBJECT_TYPE_SWAPCHAIN_KHR, unsafe.Pointer(*app.swapchain))
+		app.deviceProcs.DestroySwapchainKHR(*app.device, *app.swapchain, nil)
 		app.swapchain = nil
 	}
+	app.swapchainImgs = nil
+	// Release handles of swapchain objects.
+	app.swapchainArena.reset()
+}
+
+// destroySemaphore destroys the given semaphore.
+func destroySemaphore(app *App, semaphore *C.VkSemaphore) {
+	if semaphore == nil {
+		return
+	}
+	untrackObject(C.VK_OBJECT_TYPE_SEMAPHORE, unsafe.Pointer(*semaphore))
+	app.deviceProcs.DestroySemaphore(*app.device, *semaphore, nil)
+}
+
+// destroyFence destroys the given fence.
+func destroyFence(app *App, fence *C.VkFence) {
+	if fence == nil {
+		return
+	}
+	untrackObject(C.VK_OBJECT_TYPE_FENCE, unsafe.Pointer(*fence))
+	app.deviceProcs.DestroyFence(*app.device, *fence, nil)
 }
 
-func initInstance() (*C.VkInstance, error) {
-	appInfo := C.VkApplicationInfo{
+// destroyBuffer destroys the given buffer and frees its memory.
+func destroyBuffer(app *App, buffer *C.VkBuffer, bufferMem *C.VkDeviceMemory) {
+	untrackObject(C.VK_OBJECT_TYPE_BUFFER, unsafe.Pointer(*buffer))
+	app.deviceProcs.DestroyBuffer(*app.device, *buffer, nil)
+	untrackObject(C.VK_OBJECT_TYPE_DEVICE_MEMORY, unsafe.Pointer(*bufferMem))
+	app.deviceProcs.FreeMemory(*app.device, *bufferMem, nil)
+}
+
+func initInstance(app *App) (*C.VkInstance, error) {
+	scratch := newArena()
+	defer scratch.free()
+	apiVersion := getInstanceVersion()
+	dbg.Printf("instance API version: %d.%d", apiVersion>>22, apiVersion>>12&0x3FF)
+	appInfo := scratch.newVkApplicationInfo(C.VkApplicationInfo{
 		sType:              C.VK_STRUCTURE_TYPE_APPLICATION_INFO,
-		pApplicationName:   C.CString(AppTitle),
+		pApplicationName:   scratch.cString(AppTitle),
 		applicationVersion: VK_MAKE_API_VERSION(0, 1, 0, 0),
-		pEngineName:        C.CString("No Engine"),
+		pEngineName:        scratch.cString("No Engine"),
 		engineVersion:      VK_MAKE_API_VERSION(0, 1, 0, 0),
-		apiVersion:         C.VK_API_VERSION_1_0,
-	}
+		apiVersion:         C.uint32_t(apiVersion),
+	})
 
-	enabledInstanceExtensions := getInstanceExtensions()
+	enabledInstanceExtensions := getInstanceExtensions(app)
 	dbg.Println("nenabledInstanceExtensions:", len(enabledInstanceExtensions))
 	for _, enabledInstanceExtension := range enabledInstanceExtensions {
 		dbg.Println("   enabledInstanceExtension:", enabledInstanceExtension)
@@ -291,32 +510,53 @@ func initInstance() (*C.VkInstance, error) {
 		dbg.Println("   enabledLayer:", enabledLayer)
 	}
 
-	createInfo := C.new_VkInstanceCreateInfo()
+	createInfo := scratch.newVkInstanceCreateInfo(C.VkInstanceCreateInfo{})
 	createInfo.sType = C.VK_STRUCTURE_TYPE_INSTANCE_CREATE_INFO
-	createInfo.pApplicationInfo = &appInfo
+	createInfo.pApplicationInfo = appInfo
 	createInfo.enabledExtensionCount = C.uint32_t(len(enabledInstanceExtensions))
-	createInfo.ppEnabledExtensionNames = getCStringSlice(enabledInstanceExtensions)
+	createInfo.ppEnabledExtensionNames = scratch.cStrings(enabledInstanceExtensions)
 	createInfo.enabledLayerCount = C.uint32_t(len(enabledLayers))
-	createInfo.ppEnabledLayerNames = getCStringSlice(enabledLayers)
+	createInfo.ppEnabledLayerNames = scratch.cStrings(enabledLayers)
 
-	debugMessangerCreateInfo := C.new_VkDebugUtilsMessengerCreateInfoEXT()
-	populateDebugMessangerCreateInfo(debugMessangerCreateInfo)
-	createInfo.pNext = unsafe.Pointer(debugMessangerCreateInfo)
+	// Report messages of instance creation and destruction.
+	if contains(enabledInstanceExtensions, C.VK_EXT_DEBUG_UTILS_EXTENSION_NAME) {
+		debugMessangerCreateInfo := scratch.newVkDebugUtilsMessengerCreateInfoEXT(C.VkDebugUtilsMessengerCreateInfoEXT{})
+		populateDebugMessangerCreateInfo(debugMessangerCreateInfo)
+		createInfo.pNext = unsafe.Pointer(debugMessangerCreateInfo)
+	}
 
-	instance := C.new_VkInstance()
-	result := C.vkCreateInstance(createInfo, nil, instance)
+	instance := app.arena.newVkInstance(nil)
+	result := vkGlobal.CreateInstance(createInfo, nil, instance)
 	if result != C.VK_SUCCESS {
-		return nil, errors.Errorf("unable to create Vulkan instance")
+		return nil, errors.Wrap(Result(result), "unable to create Vulkan instance")
 	}
+	app.apiVersion = apiVersion
+	app.instanceExtensions = enabledInstanceExtensions
 	return instance, nil
 }
 
-func getInstanceExtensions() []string {
+// getInstanceVersion returns the Vulkan API version to request for the
+// instance; the latest version supported by the Vulkan loader, up to Vulkan
+// 1.3. Vulkan 1.0 loaders lack vkEnumerateInstanceVersion.
+func getInstanceVersion() uint32 {
+	var version C.uint32_t
+	if result := vkGlobal.EnumerateInstanceVersion(&version); result != C.VK_SUCCESS {
+		return apiVersion1_0
+	}
+	if version > apiVersion1_3 {
+		return apiVersion1_3
+	}
+	return uint32(version)
+}
+
+func getInstanceExtensions(app *App) []string {
 	// Get supported instance extensions.
 	var ninstanceExtensions C.uint32_t
-	C.vkEnumerateInstanceExtensionProperties(nil, &ninstanceExtensions, nil)
+	vkGlobal.EnumerateInstanceExtensionProperties(nil, &ninstanceExtensions, nil)
 	instanceExtensions := make([]C.VkExtensionProperties, int(ninstanceExtensions))
-	C.vkEnumerateInstanceExtensionProperties(nil, &ninstanceExtensions, &instanceExtensions[0])
+	if ninstanceExtensions > 0 {
+		vkGlobal.EnumerateInstanceExtensionProperties(nil, &ninstanceExtensions, &instanceExtensions[0])
+	}
 	dbg.Println("ninstanceExtensions:", len(instanceExtensions))
 	var instanceExtensionNames []string
 	for _, instanceExtension := range instanceExtensions {
@@ -325,10 +565,13 @@ func getInstanceExtensions() []string {
 		instanceExtensionNames = append(instanceExtensionNames, instanceExtensionName)
 	}
 
-	// Get required instance extensions for GLFW.
-	var nglfwRequiredInstanceExtensions C.uint32_t
-	_glfwRequiredInstanceExtensions := C.glfwGetRequiredInstanceExtensions(&nglfwRequiredInstanceExtensions)
-	glfwRequiredInstanceExtensions := getStringSlice(unsafe.Pointer(_glfwRequiredInstanceExtensions), int(nglfwRequiredInstanceExtensions))
+	// Get required instance extensions for GLFW; none if headless.
+	var glfwRequiredInstanceExtensions []string
+	if !app.headless {
+		var nglfwRequiredInstanceExtensions C.uint32_t
+		_glfwRequiredInstanceExtensions := C.glfwGetRequiredInstanceExtensions(&nglfwRequiredInstanceExtensions)
+		glfwRequiredInstanceExtensions = getStringSlice(unsafe.Pointer(_glfwRequiredInstanceExtensions), int(nglfwRequiredInstanceExtensions))
+	}
 	dbg.Println("nglfwRequiredInstanceExtensions:", len(glfwRequiredInstanceExtensions))
 	for _, glfwRequiredInstanceExtension := range glfwRequiredInstanceExtensions {
 		dbg.Println("   glfwRequiredInstanceExtension:", glfwRequiredInstanceExtension)
@@ -363,9 +606,11 @@ func getInstanceExtensions() []string {
 func getLayers() []string {
 	// Get supported layers.
 	var nlayers C.uint32_t
-	C.vkEnumerateInstanceLayerProperties(&nlayers, nil)
+	vkGlobal.EnumerateInstanceLayerProperties(&nlayers, nil)
 	layers := make([]C.VkLayerProperties, int(nlayers))
-	C.vkEnumerateInstanceLayerProperties(&nlayers, &layers[0])
+	if nlayers > 0 {
+		vkGlobal.EnumerateInstanceLayerProperties(&nlayers, &layers[0])
+	}
 	dbg.Println("nlayers:", len(layers))
 	var layerNames []string
 	for _, layer := range layers {
@@ -395,12 +640,14 @@ func getLayers() []string {
 	return enabledLayers
 }
 
-func getDeviceExtensions(physicalDevice *C.VkPhysicalDevice) []string {
+func getDeviceExtensions(app *App, physicalDevice *C.VkPhysicalDevice) []string {
 	// Get supported device extensions.
 	var ndeviceExtensions C.uint32_t
-	C.vkEnumerateDeviceExtensionProperties(*physicalDevice, nil, &ndeviceExtensions, nil)
+	app.instanceProcs.EnumerateDeviceExtensionProperties(*physicalDevice, nil, &ndeviceExtensions, nil)
 	deviceExtensions := make([]C.VkExtensionProperties, int(ndeviceExtensions))
-	C.vkEnumerateDeviceExtensionProperties(*physicalDevice, nil, &ndeviceExtensions, &deviceExtensions[0])
+	if ndeviceExtensions > 0 {
+		app.instanceProcs.EnumerateDeviceExtensionProperties(*physicalDevice, nil, &ndeviceExtensions, &deviceExtensions[0])
+	}
 	dbg.Println("ndeviceExtensions:", len(deviceExtensions))
 	var deviceExtensionNames []string
 	for _, deviceExtension := range deviceExtensions {
@@ -410,14 +657,15 @@ func getDeviceExtensions(physicalDevice *C.VkPhysicalDevice) []string {
 	}
 
 	// Get required device extensions by user.
-	dbg.Println("nrequiredDeviceExtensions:", len(RequiredDeviceExtensions))
-	for _, requiredDeviceExtension := range RequiredDeviceExtensions {
+	requiredDeviceExtensions := getRequiredDeviceExtensions(app)
+	dbg.Println("nrequiredDeviceExtensions:", len(requiredDeviceExtensions))
+	for _, requiredDeviceExtension := range requiredDeviceExtensions {
 		dbg.Println("   requiredDeviceExtension:", requiredDeviceExtension)
 	}
 
 	// Check required device extensions.
 	var enabledDeviceExtensions []string
-	for _, requiredDeviceExtension := range RequiredDeviceExtensions {
+	for _, requiredDeviceExtension := range requiredDeviceExtensions {
 		if !contains(deviceExtensionNames, requiredDeviceExtension) {
 			warn.Printf("unable to locate required extension %q", requiredDeviceExtension)
 			continue
@@ -428,146 +676,173 @@ func getDeviceExtensions(physicalDevice *C.VkPhysicalDevice) []string {
 	return enabledDeviceExtensions
 }
 
-func initPhysicalDevice(app *App) (*C.VkPhysicalDevice, error) {
-	// TODO: rank physical devices by score if more than one is present. E.g.
-	// prefer dedicated graphics card with capability for larger textures.
-	//
-	// ref: https://vulkan-tutorial.com/en/Drawing_a_triangle/Setup/Physical_devices_and_queue_families#page_Base-device-suitability-checks
+// getRequiredDeviceExtensions returns the device extensions required by the
+// app; the swapchain extension is not required when headless.
+func getRequiredDeviceExtensions(app *App) []string {
+	if !app.headless {
+		return RequiredDeviceExtensions
+	}
+	var requiredDeviceExtensions []string
+	for _, requiredDeviceExtension := range RequiredDeviceExtensions {
+		if requiredDeviceExtension == C.VK_KHR_SWAPCHAIN_EXTENSION_NAME {
+			continue
+		}
+		requiredDeviceExtensions = append(requiredDeviceExtensions, requiredDeviceExtension)
+	}
+	return requiredDeviceExtensions
+}
 
+func initPhysicalDevice(app *App) (*C.VkPhysicalDevice, error) {
 	// Get physical devices.
 	var nphysicalDevices C.uint32_t
-	C.vkEnumeratePhysicalDevices(*app.instance, &nphysicalDevices, nil)
+	app.instanceProcs.EnumeratePhysicalDevices(*app.instance, &nphysicalDevices, nil)
 	if nphysicalDevices == 0 {
 		return nil, errors.Errorf("unable to locate physical device (GPU)")
 	}
-	if nphysicalDevices > 1 {
-		warn.Printf("multiple (%d) physical device (GPU) located; support for ranking physical devices not yet implemented", nphysicalDevices)
-	}
 	physicalDevices := make([]C.VkPhysicalDevice, int(nphysicalDevices))
-	C.vkEnumeratePhysicalDevices(*app.instance, &nphysicalDevices, &physicalDevices[0])
+	app.instanceProcs.EnumeratePhysicalDevices(*app.instance, &nphysicalDevices, &physicalDevices[0])
 	dbg.Println("nphysicalDevices:", len(physicalDevices))
-	for _, physicalDevice := range physicalDevices {
-		if !isSuitablePhysicalDevice(app, &physicalDevice) {
-			continue
-		}
-		_physicalDevice := C.new_VkPhysicalDevice()
-		*_physicalDevice = physicalDevice // allocate pointer on C heap.
-		return _physicalDevice, nil
+	physicalDeviceInfos := make([]physicalDeviceInfo, len(physicalDevices))
+	for i := range physicalDevices {
+		physicalDeviceInfos[i] = queryPhysicalDevice(app, &physicalDevices[i])
 	}
-	return nil, errors.Errorf("unable to locate suitable physical device (GPU)")
+	i, err := selectPhysicalDevice(physicalDeviceInfos, getRequiredDeviceExtensions(app), app.headless)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// Limit multisampling to the capabilities of the physical device.
+	info := physicalDeviceInfos[i]
+	samples := chooseSampleCount(app.requestedSamples, info.framebufferSampleCounts)
+	if int(samples) != app.requestedSamples {
+		warn.Printf("%d samples per pixel not supported by physical device %q; using %d samples", app.requestedSamples, info.name, samples)
+	}
+	app.msaaSamples = C.VkSampleCountFlagBits(samples)
+	app.sampleShading = app.requestedSampleShading && samples != SampleCount1
+	if app.sampleShading && !info.sampleRateShading {
+		warn.Printf("sample shading not supported by physical device %q", info.name)
+		app.sampleShading = false
+	}
+	app.maxSamplerAnisotropy = chooseAnisotropy(app.requestedAnisotropy, info.samplerAnisotropy, info.maxSamplerAnisotropy)
+	if app.requestedAnisotropy > 1 && app.maxSamplerAnisotropy != app.requestedAnisotropy {
+		warn.Printf("anisotropy %v not supported by physical device %q; using %v", app.requestedAnisotropy, info.name, app.maxSamplerAnisotropy)
+	}
+	// Render with dynamic rendering where available, and fall back to render
+	// passes otherwise.
+	app.dynamicRendering, app.dynamicRenderingKHR = chooseDynamicRendering(app.apiVersion, info)
+	// Synchronize with timeline semaphores where available, and fall back to
+	// binary semaphores and fences otherwise.
+	if !BinarySemaphores {
+		app.timelineSemaphores, app.timelineSemaphoresKHR = chooseTimelineSemaphores(app.apiVersion, info)
+	}
+	return app.arena.newVkPhysicalDevice(physicalDevices[i]), nil // allocate pointer on C heap.
 }
 
-func isSuitablePhysicalDevice(app *App, physicalDevice *C.VkPhysicalDevice) bool {
+// queryPhysicalDevice returns the properties of the given physical device
+// relevant to device selection and swapchain creation.
+func queryPhysicalDevice(app *App, physicalDevice *C.VkPhysicalDevice) physicalDeviceInfo {
 	// Get device properties.
 	var deviceProperties C.VkPhysicalDeviceProperties
-	C.vkGetPhysicalDeviceProperties(*physicalDevice, &deviceProperties)
+	app.instanceProcs.GetPhysicalDeviceProperties(*physicalDevice, &deviceProperties)
 	deviceName := C.GoString(&deviceProperties.deviceName[0])
 	dbg.Println("   deviceName:", deviceName)
 	pretty.Println("   deviceProperties:", deviceProperties)
 
 	// Get device features.
 	var deviceFeatures C.VkPhysicalDeviceFeatures
-	C.vkGetPhysicalDeviceFeatures(*physicalDevice, &deviceFeatures)
+	app.instanceProcs.GetPhysicalDeviceFeatures(*physicalDevice, &deviceFeatures)
 	pretty.Println("   deviceFeatures:", deviceFeatures)
 
-	// Find queue which supports graphics operations.
-	queueFamilies := getQueueFamilies(physicalDevice)
-	dbg.Println("nqueueFamilies:", len(queueFamilies))
-	for _, queueFamily := range queueFamilies {
-		pretty.Println("   queueFamily:", queueFamily)
-	}
-	if _, ok := findQueueWithFlag(queueFamilies, C.VK_QUEUE_GRAPHICS_BIT); !ok {
-		return false
-	}
-	if _, ok := findQueueWithPresentSupport(physicalDevice, app.surface, queueFamilies); !ok {
-		return false
-	}
-
-	if !hasDeviceExtensionSupport(physicalDevice) {
-		return false
-	}
-
-	swapchainSupportInfo := getSwapchainSupportInfo(app, physicalDevice)
-	if len(swapchainSupportInfo.surfaceFormats) == 0 {
-		return false
-	}
-	if len(swapchainSupportInfo.presentModes) == 0 {
-		return false
-	}
-
-	return true
-}
-
-func hasDeviceExtensionSupport(physicalDevice *C.VkPhysicalDevice) bool {
+	// Get supported device extensions.
 	var ndeviceExtensions C.uint32_t
-	C.vkEnumerateDeviceExtensionProperties(*physicalDevice, nil, &ndeviceExtensions, nil)
+	app.instanceProcs.EnumerateDeviceExtensionProperties(*physicalDevice, nil, &ndeviceExtensions, nil)
 	deviceExtensions := make([]C.VkExtensionProperties, int(ndeviceExtensions))
-	C.vkEnumerateDeviceExtensionProperties(*physicalDevice, nil, &ndeviceExtensions, &deviceExtensions[0])
-	dbg.Println("ndeviceExtensions:", len(deviceExtensions))
-	// Check that all required device extensions are present.
-	m := make(map[string]bool)
-	for _, requiredDeviceExtension := range RequiredDeviceExtensions {
-		m[requiredDeviceExtension] = true
+	if ndeviceExtensions > 0 {
+		app.instanceProcs.EnumerateDeviceExtensionProperties(*physicalDevice, nil, &ndeviceExtensions, &deviceExtensions[0])
 	}
+	dbg.Println("ndeviceExtensions:", len(deviceExtensions))
+	var deviceExtensionNames []string
 	for _, deviceExtension := range deviceExtensions {
 		deviceExtensionName := C.GoString(&deviceExtension.extensionName[0])
 		dbg.Println("   deviceExtensionName:", deviceExtensionName)
-		pretty.Println("   deviceExtension:", deviceExtension)
-		delete(m, deviceExtensionName)
-	}
-	if len(m) > 1 {
-		warn.Printf("   missing required device extensions: %#v", m)
+		deviceExtensionNames = append(deviceExtensionNames, deviceExtensionName)
 	}
-	return len(m) == 0
-}
 
-func findQueueWithFlag(queueFamilies []C.VkQueueFamilyProperties, queueFlags C.VkQueueFlags) (int, bool) {
-	for queueFamilyIndex, queueFamily := range queueFamilies {
-		if queueFamily.queueFlags&queueFlags == queueFlags {
-			return queueFamilyIndex, true
-		}
+	info := physicalDeviceInfo{
+		name:          deviceName,
+		deviceType:    PhysicalDeviceType(deviceProperties.deviceType),
+		apiVersion:    uint32(deviceProperties.apiVersion),
+		queueFamilies: queryQueueFamilies(app, physicalDevice),
+		extensions:    deviceExtensionNames,
+		// Sample counts of color targets, and of depth targets once used.
+		framebufferSampleCounts: SampleCount(deviceProperties.limits.framebufferColorSampleCounts & deviceProperties.limits.framebufferDepthSampleCounts),
+		sampleRateShading:       deviceFeatures.sampleRateShading == C.VK_TRUE,
+		samplerAnisotropy:       deviceFeatures.samplerAnisotropy == C.VK_TRUE,
+		maxSamplerAnisotropy:    float32(deviceProperties.limits.maxSamplerAnisotropy),
+	}
+	if !app.headless {
+		info.swapchainSupport = querySwapchainSupport(app, physicalDevice)
 	}
-	return 0, false
+	queryExtendedFeatures(app, physicalDevice, &info)
+	return info
 }
 
-func findQueueWithPresentSupport(physicalDevice *C.VkPhysicalDevice, surface *C.VkSurfaceKHR, queueFamilies []C.VkQueueFamilyProperties) (int, bool) {
-	for queueFamilyIndex := range queueFamilies {
-		var presentSupport C.VkBool32
-		C.vkGetPhysicalDeviceSurfaceSupportKHR(*physicalDevice, C.uint(queueFamilyIndex), *surface, &presentSupport)
-		if presentSupport == C.VK_TRUE {
-			return queueFamilyIndex, true
-		}
+// queryExtendedFeatures queries the support of features of the given physical
+// device beyond Vulkan 1.0, through vkGetPhysicalDeviceFeatures2 of Vulkan 1.1;
+// stored in info. Features are only queried where provided by the API version
+// or the extensions of the device.
+func queryExtendedFeatures(app *App, physicalDevice *C.VkPhysicalDevice, info *physicalDeviceInfo) {
+	version := deviceAPIVersion(app.apiVersion, *info)
+	if version < apiVersion1_1 {
+		return
+	}
+	scratch := newArena()
+	defer scratch.free()
+	features := scratch.newVkPhysicalDeviceFeatures2(C.VkPhysicalDeviceFeatures2{
+		sType: C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2,
+	})
+	// Dynamic rendering is only used on Vulkan 1.2 and later (see
+	// chooseDynamicRendering).
+	var dynamicRenderingFeatures *C.VkPhysicalDeviceDynamicRenderingFeatures
+	if version >= apiVersion1_3 || (version >= apiVersion1_2 && contains(info.extensions, dynamicRenderingExtension)) {
+		dynamicRenderingFeatures = scratch.newVkPhysicalDeviceDynamicRenderingFeatures(C.VkPhysicalDeviceDynamicRenderingFeatures{
+			sType: C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_DYNAMIC_RENDERING_FEATURES,
+			pNext: features.pNext,
+		})
+		features.pNext = unsafe.Pointer(dynamicRenderingFeatures)
+	}
+	var timelineSemaphoreFeatures *C.VkPhysicalDeviceTimelineSemaphoreFeatures
+	if version >= apiVersion1_2 || contains(info.extensions, timelineSemaphoreExtension) {
+		timelineSemaphoreFeatures = scratch.newVkPhysicalDeviceTimelineSemaphoreFeatures(C.VkPhysicalDeviceTimelineSemaphoreFeatures{
+			sType: C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_TIMELINE_SEMAPHORE_FEATURES,
+			pNext: features.pNext,
+		})
+		features.pNext = unsafe.Pointer(timelineSemaphoreFeatures)
+	}
+	if features.pNext == nil {
+		return
+	}
+	app.instanceProcs.GetPhysicalDeviceFeatures2(*physicalDevice, features)
+	if dynamicRenderingFeatures != nil {
+		info.dynamicRendering = dynamicRenderingFeatures.dynamicRendering == C.VK_TRUE
+	}
+	if timelineSemaphoreFeatures != nil {
+		info.timelineSemaphore = timelineSemaphoreFeatures.timelineSemaphore == C.VK_TRUE
 	}
-	return 0, false
 }
 
-func initDebugMessanger(instance *C.VkInstance) (*C.VkDebugUtilsMessengerEXT, error) {
-	debugMessangerCreateInfo := C.new_VkDebugUtilsMessengerCreateInfoEXT()
+func initDebugMessanger(app *App) (*C.VkDebugUtilsMessengerEXT, error) {
+	scratch := newArena()
+	defer scratch.free()
+	debugMessangerCreateInfo := scratch.newVkDebugUtilsMessengerCreateInfoEXT(C.VkDebugUtilsMessengerCreateInfoEXT{})
 	populateDebugMessangerCreateInfo(debugMessangerCreateInfo)
-	debugMessenger := C.new_VkDebugUtilsMessengerEXT()
-	result := CreateDebugUtilsMessengerEXT(*instance, debugMessangerCreateInfo, nil, debugMessenger)
+	debugMessenger := app.arena.newVkDebugUtilsMessengerEXT(nil)
+	result := app.instanceProcs.CreateDebugUtilsMessengerEXT(*app.instance, debugMessangerCreateInfo, nil, debugMessenger)
 	if result != C.VK_SUCCESS {
-		return nil, errors.Errorf("unable to register debug messanger (result=%d)", result)
+		return nil, errors.Wrap(Result(result), "unable to register debug messanger")
 	}
 	return debugMessenger, nil
 }
 
-func CreateDebugUtilsMessengerEXT(instance C.VkInstance, pCreateInfo *C.VkDebugUtilsMessengerCreateInfoEXT, pAllocator *C.VkAllocationCallbacks, pMessenger *C.VkDebugUtilsMessengerEXT) C.VkResult {
-	fn := C.vkGetInstanceProcAddr(instance, C.CString("vkCreateDebugUtilsMessengerEXT"))
-	if fn == nil {
-		return C.VK_ERROR_EXTENSION_NOT_PRESENT
-	}
-	return C.invoke_CreateDebugUtilsMessengerEXT(fn, instance, pCreateInfo, pAllocator, pMessenger)
-}
-
-func DestroyDebugUtilsMessengerEXT(instance C.VkInstance, messenger C.VkDebugUtilsMessengerEXT, pAllocator *C.VkAllocationCallbacks) {
-	fn := C.vkGetInstanceProcAddr(instance, C.CString("vkDestroyDebugUtilsMessengerEXT"))
-	if fn == nil {
-		return
-	}
-	C.invoke_DestroyDebugUtilsMessengerEXT(fn, instance, messenger, pAllocator)
-}
-
 func populateDebugMessangerCreateInfo(createInfo *C.VkDebugUtilsMessengerCreateInfoEXT) {
 	createInfo.sType = C.VK_STRUCTURE_TYPE_DEBUG_UTILS_MESSENGER_CREATE_INFO_EXT
 	createInfo.messageSeverity = C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_VERBOSE_BIT_EXT | C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_INFO_BIT_EXT | C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_WARNING_BIT_EXT | C.VK_DEBUG_UTILS_MESSAGE_SEVERITY_ERROR_BIT_EXT
@@ -577,145 +852,203 @@ func populateDebugMessangerCreateInfo(createInfo *C.VkDebugUtilsMessengerCreateI
 	createInfo.pUserData = nil // optional.
 }
 
-func getQueueFamilies(device *C.VkPhysicalDevice) []C.VkQueueFamilyProperties {
+// queryQueueFamilies returns the queue families of the given physical device,
+// and their support for presentation to the window surface.
+func queryQueueFamilies(app *App, physicalDevice *C.VkPhysicalDevice) []queueFamilyInfo {
 	var nqueueFamilies C.uint32_t
-	C.vkGetPhysicalDeviceQueueFamilyProperties(*device, &nqueueFamilies, nil)
+	app.instanceProcs.GetPhysicalDeviceQueueFamilyProperties(*physicalDevice, &nqueueFamilies, nil)
 	queueFamilies := make([]C.VkQueueFamilyProperties, int(nqueueFamilies))
-	C.vkGetPhysicalDeviceQueueFamilyProperties(*device, &nqueueFamilies, &queueFamilies[0])
-	return queueFamilies
+	if nqueueFamilies > 0 {
+		app.instanceProcs.GetPhysicalDeviceQueueFamilyProperties(*physicalDevice, &nqueueFamilies, &queueFamilies[0])
+	}
+	dbg.Println("nqueueFamilies:", len(queueFamilies))
+	queueFamilyInfos := make([]queueFamilyInfo, len(queueFamilies))
+	for queueFamilyIndex, queueFamily := range queueFamilies {
+		pretty.Println("   queueFamily:", queueFamily)
+		var presentSupport C.VkBool32
+		if app.surface != nil {
+			app.instanceProcs.GetPhysicalDeviceSurfaceSupportKHR(*physicalDevice, C.uint(queueFamilyIndex), *app.surface, &presentSupport)
+		}
+		queueFamilyInfos[queueFamilyIndex] = queueFamilyInfo{
+			flags:          QueueFlag(queueFamily.queueFlags),
+			presentSupport: presentSupport == C.VK_TRUE,
+		}
+	}
+	return queueFamilyInfos
 }
 
 func initDevice(app *App) (*C.VkDevice, error) {
-	queueFamilies := getQueueFamilies(app.physicalDevice)
-
-	// Graphics queue.
-	graphicsQueueFamilyIndex, ok := findQueueWithFlag(queueFamilies, C.VK_QUEUE_GRAPHICS_BIT)
-	if !ok {
-		return nil, errors.Errorf("unable to locate queue family with support for graphics operations")
+	queueFamilies := queryQueueFamilies(app, app.physicalDevice)
+	graphicsQueueFamilyIndex, presentQueueFamilyIndex, err := selectQueueFamilies(queueFamilies, app.headless)
+	if err != nil {
+		return nil, errors.WithStack(err)
 	}
 	app.graphicsQueueFamilyIndex = graphicsQueueFamilyIndex
-
-	// Present queue.
-	presentQueueFamilyIndex, ok := findQueueWithPresentSupport(app.physicalDevice, app.surface, queueFamilies)
-	if !ok {
-		return nil, errors.Errorf("unable to locate queue family with support for present operations")
-	}
 	app.presentQueueFamilyIndex = presentQueueFamilyIndex
+	app.computeQueueFamilyIndex = selectComputeQueueFamily(queueFamilies, graphicsQueueFamilyIndex)
+	app.transferQueueFamilyIndex = selectTransferQueueFamily(queueFamilies, graphicsQueueFamilyIndex)
+	dbg.Printf("queue families: graphics=%d, present=%d, compute=%d, transfer=%d", app.graphicsQueueFamilyIndex, app.presentQueueFamilyIndex, app.computeQueueFamilyIndex, app.transferQueueFamilyIndex)
+
+	scratch := newArena()
+	defer scratch.free()
 
 	// Create queues.
-	var queueCreateInfos []C.VkDeviceQueueCreateInfo
 	// Find unique indices.
 	queueFamilyIndices := unique(app.QueueFamilyIndices.Indices()...)
-	for _, queueFamilyIndex := range queueFamilyIndices {
+	queueCreateInfos := scratch.makeVkDeviceQueueCreateInfoSlice(len(queueFamilyIndices))
+	for i, queueFamilyIndex := range queueFamilyIndices {
 		const queueCount = 1
-		queuePriorities := [queueCount]C.float{1.0}
-		queueCreateInfo := C.new_VkDeviceQueueCreateInfo()
+		queuePriorities := scratch.newCFloatSlice(1.0)
+		queueCreateInfo := &queueCreateInfos[i]
 		queueCreateInfo.sType = C.VK_STRUCTURE_TYPE_DEVICE_QUEUE_CREATE_INFO
 		queueCreateInfo.queueFamilyIndex = C.uint(queueFamilyIndex)
 		queueCreateInfo.queueCount = queueCount
 		queueCreateInfo.pQueuePriorities = &queuePriorities[0]
-		queueCreateInfos = append(queueCreateInfos, *queueCreateInfo)
 	}
 
-	enabledFeatures := C.new_VkPhysicalDeviceFeatures()
-	// TODO: enable device features here when needed.
+	enabledFeatures := scratch.newVkPhysicalDeviceFeatures(C.VkPhysicalDeviceFeatures{})
+	if app.sampleShading {
+		enabledFeatures.sampleRateShading = C.VK_TRUE
+	}
+	if app.maxSamplerAnisotropy > 1 {
+		enabledFeatures.samplerAnisotropy = C.VK_TRUE
+	}
 
-	enabledDeviceExtensions := getDeviceExtensions(app.physicalDevice)
+	enabledDeviceExtensions := getDeviceExtensions(app, app.physicalDevice)
+	if app.dynamicRenderingKHR {
+		enabledDeviceExtensions = append(enabledDeviceExtensions, dynamicRenderingExtension)
+	}
+	if app.timelineSemaphoresKHR {
+		enabledDeviceExtensions = append(enabledDeviceExtensions, timelineSemaphoreExtension)
+	}
 	dbg.Println("nenabledDeviceExtensions:", len(enabledDeviceExtensions))
 	for _, enabledDeviceExtension := range enabledDeviceExtensions {
 		dbg.Println("   enabledDeviceExtension:", enabledDeviceExtension)
 	}
 
-	createInfo := C.new_VkDeviceCreateInfo()
+	createInfo := scratch.newVkDeviceCreateInfo(C.VkDeviceCreateInfo{})
 	createInfo.sType = C.VK_STRUCTURE_TYPE_DEVICE_CREATE_INFO
 	createInfo.queueCreateInfoCount = C.uint(len(queueCreateInfos))
 	createInfo.pQueueCreateInfos = &queueCreateInfos[0]
 	createInfo.enabledLayerCount = 0 // ignored by recent version of Vulkan.
 	createInfo.enabledExtensionCount = C.uint32_t(len(enabledDeviceExtensions))
-	createInfo.ppEnabledExtensionNames = getCStringSlice(enabledDeviceExtensions)
+	createInfo.ppEnabledExtensionNames = scratch.cStrings(enabledDeviceExtensions)
 	createInfo.pEnabledFeatures = enabledFeatures
-
-	device := C.new_VkDevice()
-	if result := C.vkCreateDevice(*app.physicalDevice, createInfo, nil, device); result != C.VK_SUCCESS {
-		return nil, errors.Errorf("unable to create device (result=%d)", result)
+	if app.dynamicRendering {
+		dynamicRenderingFeatures := scratch.newVkPhysicalDeviceDynamicRenderingFeatures(C.VkPhysicalDeviceDynamicRenderingFeatures{
+			sType:            C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_DYNAMIC_RENDERING_FEATURES,
+			pNext:            createInfo.pNext,
+			dynamicRendering: C.VK_TRUE,
+		})
+		createInfo.pNext = unsafe.Pointer(dynamicRenderingFeatures)
+	}
+	if app.timelineSemaphores {
+		timelineSemaphoreFeatures := scratch.newVkPhysicalDeviceTimelineSemaphoreFeatures(C.VkPhysicalDeviceTimelineSemaphoreFeatures{
+			sType:             C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_TIMELINE_SEMAPHORE_FEATURES,
+			pNext:             createInfo.pNext,
+			timelineSemaphore: C.VK_TRUE,
+		})
+		createInfo.pNext = unsafe.Pointer(timelineSemaphoreFeatures)
+	}
+
+	device := app.arena.newVkDevice(nil)
+	if result := app.instanceProcs.CreateDevice(*app.physicalDevice, createInfo, nil, device); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to create device")
 	}
 	return device, nil
 }
 
 func initQueues(app *App) {
 	// Graphics queue.
-	graphicsQueue := C.new_VkQueue()
-	C.vkGetDeviceQueue(*app.device, C.uint(app.graphicsQueueFamilyIndex), 0, graphicsQueue)
+	graphicsQueue := app.arena.newVkQueue(nil)
+	app.deviceProcs.GetDeviceQueue(*app.device, C.uint(app.graphicsQueueFamilyIndex), 0, graphicsQueue)
 	app.graphicsQueue = graphicsQueue
+	setObjectName(app, C.VK_OBJECT_TYPE_QUEUE, unsafe.Pointer(*graphicsQueue), "graphics queue")
 	// Present queue.
-	presentQueue := C.new_VkQueue()
-	C.vkGetDeviceQueue(*app.device, C.uint(app.presentQueueFamilyIndex), 0, presentQueue)
+	presentQueue := app.arena.newVkQueue(nil)
+	app.deviceProcs.GetDeviceQueue(*app.device, C.uint(app.presentQueueFamilyIndex), 0, presentQueue)
 	app.presentQueue = presentQueue
+	if *presentQueue != *graphicsQueue {
+		setObjectName(app, C.VK_OBJECT_TYPE_QUEUE, unsafe.Pointer(*presentQueue), "present queue")
+	}
+	// Compute queue.
+	computeQueue := app.arena.newVkQueue(nil)
+	app.deviceProcs.GetDeviceQueue(*app.device, C.uint(app.computeQueueFamilyIndex), 0, computeQueue)
+	app.computeQueue = computeQueue
+	if *computeQueue != *graphicsQueue {
+		setObjectName(app, C.VK_OBJECT_TYPE_QUEUE, unsafe.Pointer(*computeQueue), "compute queue")
+	}
+	// Transfer queue.
+	transferQueue := app.arena.newVkQueue(nil)
+	app.deviceProcs.GetDeviceQueue(*app.device, C.uint(app.transferQueueFamilyIndex), 0, transferQueue)
+	app.transferQueue = transferQueue
+	if *transferQueue != *graphicsQueue && *transferQueue != *computeQueue {
+		setObjectName(app, C.VK_OBJECT_TYPE_QUEUE, unsafe.Pointer(*transferQueue), "transfer queue")
+	}
 }
 
 func initSurface(app *App) (*C.VkSurfaceKHR, error) {
-	surface := C.new_VkSurfaceKHR()
+	surface := app.arena.newVkSurfaceKHR(nil)
 	if result := C.glfwCreateWindowSurface(*app.instance, app.win, nil, surface); result != C.VK_SUCCESS {
-		return nil, errors.Errorf("unable to create window surface (result=%d)", result)
+		return nil, errors.Wrap(Result(result), "unable to create window surface")
 	}
 	return surface, nil
 }
 
-func getSwapchainSupportInfo(app *App, physicalDevice *C.VkPhysicalDevice) *SwapchainSupportInfo {
+// querySwapchainSupport returns the swapchain support of the window surface
+// for the given physical device.
+func querySwapchainSupport(app *App, physicalDevice *C.VkPhysicalDevice) swapchainSupport {
 	// Get surface capabilities.
-	swapchainSupportInfo := &SwapchainSupportInfo{}
-	var surfaceCapabilities C.VkSurfaceCapabilitiesKHR
-	C.vkGetPhysicalDeviceSurfaceCapabilitiesKHR(*physicalDevice, *app.surface, &surfaceCapabilities)
-	swapchainSupportInfo.surfaceCapabilities = &surfaceCapabilities
+	var capabilities C.VkSurfaceCapabilitiesKHR
+	app.instanceProcs.GetPhysicalDeviceSurfaceCapabilitiesKHR(*physicalDevice, *app.surface, &capabilities)
+	support := swapchainSupport{
+		capabilities: surfaceCapabilities{
+			minImageCount:    uint32(capabilities.minImageCount),
+			maxImageCount:    uint32(capabilities.maxImageCount),
+			currentExtent:    newExtent2D(capabilities.currentExtent),
+			minImageExtent:   newExtent2D(capabilities.minImageExtent),
+			maxImageExtent:   newExtent2D(capabilities.maxImageExtent),
+			currentTransform: uint32(capabilities.currentTransform),
+			supportedUsage:   ImageUsage(capabilities.supportedUsageFlags),
+		},
+	}
 
 	// Get surface formats.
 	var nsurfaceFormats C.uint32_t
-	C.vkGetPhysicalDeviceSurfaceFormatsKHR(*physicalDevice, *app.surface, &nsurfaceFormats, nil)
+	app.instanceProcs.GetPhysicalDeviceSurfaceFormatsKHR(*physicalDevice, *app.surface, &nsurfaceFormats, nil)
 	surfaceFormats := make([]C.VkSurfaceFormatKHR, int(nsurfaceFormats))
-	C.vkGetPhysicalDeviceSurfaceFormatsKHR(*physicalDevice, *app.surface, &nsurfaceFormats, &surfaceFormats[0])
-	swapchainSupportInfo.surfaceFormats = surfaceFormats
+	if nsurfaceFormats > 0 {
+		app.instanceProcs.GetPhysicalDeviceSurfaceFormatsKHR(*physicalDevice, *app.surface, &nsurfaceFormats, &surfaceFormats[0])
+	}
+	for _, format := range surfaceFormats {
+		support.formats = append(support.formats, surfaceFormat{
+			format:     uint32(format.format),
+			colorSpace: uint32(format.colorSpace),
+		})
+	}
 
 	// Get present modes.
 	var npresentModes C.uint32_t
-	C.vkGetPhysicalDeviceSurfacePresentModesKHR(*physicalDevice, *app.surface, &npresentModes, nil)
+	app.instanceProcs.GetPhysicalDeviceSurfacePresentModesKHR(*physicalDevice, *app.surface, &npresentModes, nil)
 	presentModes := make([]C.VkPresentModeKHR, int(npresentModes))
-	C.vkGetPhysicalDeviceSurfacePresentModesKHR(*physicalDevice, *app.surface, &npresentModes, &presentModes[0])
-	swapchainSupportInfo.presentModes = presentModes
-
-	return swapchainSupportInfo
-}
-
-func chooseSwapExtent(app *App, surfaceCapabilities *C.VkSurfaceCapabilitiesKHR) C.VkExtent2D {
-	dbg.Println("vk.chooseSwapExtent")
-	if surfaceCapabilities.currentExtent.width == C.UINT32_MAX || surfaceCapabilities.currentExtent.height == C.UINT32_MAX {
-		var width, height C.int
-		C.glfwGetFramebufferSize(app.win, &width, &height)
-		dbg.Printf("   framebuffer size (%dx%d)", width, height)
-		actualExtent := C.VkExtent2D{
-			width:  C.uint(clamp(int(width), int(surfaceCapabilities.minImageExtent.width), int(surfaceCapabilities.maxImageExtent.width))),
-			height: C.uint(clamp(int(height), int(surfaceCapabilities.minImageExtent.height), int(surfaceCapabilities.maxImageExtent.height))),
-		}
-		return actualExtent
+	if npresentModes > 0 {
+		app.instanceProcs.GetPhysicalDeviceSurfacePresentModesKHR(*physicalDevice, *app.surface, &npresentModes, &presentModes[0])
+	}
+	for _, presentMode := range presentModes {
+		support.presentModes = append(support.presentModes, PresentMode(presentMode))
 	}
-	return surfaceCapabilities.currentExtent
+
+	return support
 }
 
-func chooseSwapSurfaceFormat(surfaceFormats []C.VkSurfaceFormatKHR) C.VkSurfaceFormatKHR {
-	for _, surfaceFormat := range surfaceFormats {
-		if surfaceFormat.format == C.VK_FORMAT_B8G8R8A8_SRGB && surfaceFormat.colorSpace == C.VK_COLOR_SPACE_SRGB_NONLINEAR_KHR {
-			return surfaceFormat
-		}
-	}
-	return surfaceFormats[0]
+// newExtent2D returns the extent corresponding to the given Vulkan extent.
+func newExtent2D(extent C.VkExtent2D) extent2D {
+	return extent2D{width: uint32(extent.width), height: uint32(extent.height)}
 }
 
-func chooseSwapPresentMode(presentModes []C.VkPresentModeKHR) C.VkPresentModeKHR {
-	for _, presentMode := range presentModes {
-		if presentMode == C.VK_PRESENT_MODE_MAILBOX_KHR {
-			return presentMode
-		}
-	}
-	return C.VK_PRESENT_MODE_FIFO_KHR
+// vkExtent2D returns the Vulkan extent corresponding to the given extent.
+func vkExtent2D(extent extent2D) C.VkExtent2D {
+	return C.VkExtent2D{width: C.uint32_t(extent.width), height: C.uint32_t(extent.height)}
 }
 
 func recreateSwapchain(app *App) error {
@@ -729,9 +1062,12 @@ func recreateSwapchain(app *App) error {
 		C.glfwWaitEvents() // wait until window is not minimized.
 	}
 
-	if result := C.vkDeviceWaitIdle(*app.device); result != C.VK_SUCCESS {
-		return errors.Errorf("unable to wait for device to become idle (result=%d)", result)
+	if result := app.deviceProcs.DeviceWaitIdle(*app.device); result != C.VK_SUCCESS {
+		return errors.Wrap(Result(result), "unable to wait for device to become idle")
 	}
+	// Device idle; release deferred resources early, as the pipelines of the
+	// swapchain may be among them.
+	flushDeletions(app)
 
 	cleanupSwapchain(app)
 
@@ -743,30 +1079,40 @@ func recreateSwapchain(app *App) error {
 	app.swapchain = swapchain
 	// Create swapchain images.
 	app.swapchainImgs = getSwapchainImgs(app)
+	app.imagesInFlight = make([]uint64, len(app.swapchainImgs))
 	// Create swapchain image views.
 	swapchainImgViews, err := initSwapchainImgViews(app)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 	app.swapchainImgViews = swapchainImgViews
-	// Create render pass.
-	renderPass, err := initRenderPass(app)
-	if err != nil {
+	// Create multisampled color target.
+	if err := initMSAAColorTarget(app); err != nil {
 		return errors.WithStack(err)
 	}
-	app.renderPass = renderPass
-	// Create graphics pipeline.
-	graphicsPipelines, err := initGraphicsPipeline(app)
-	if err != nil {
-		return errors.WithStack(err)
+	// Create render pass, unless dynamic rendering.
+	if !app.dynamicRendering {
+		renderPass, err := initRenderPass(app)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		app.renderPass = renderPass
 	}
-	app.graphicsPipelines = graphicsPipelines
-	// Create framebuffers.
-	framebuffers, err := initFramebuffers(app)
+	// Create pipeline layout of graphics pipelines; the graphics pipelines are
+	// created on first use.
+	pipelineLayout, err := initPipelineLayout(app)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	app.swapchainFramebuffers = framebuffers
+	app.pipelineLayout = pipelineLayout
+	// Create framebuffers, unless dynamic rendering.
+	if !app.dynamicRendering {
+		framebuffers, err := initFramebuffers(app)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		app.swapchainFramebuffers = framebuffers
+	}
 	// Create command buffers.
 	commandBuffers, err := initCommandBuffers(app)
 	if err != nil {
@@ -781,36 +1127,38 @@ func recreateSwapchain(app *App) error {
 
 func initSwapchain(app *App) (*C.VkSwapchainKHR, error) {
 	dbg.Println("vk.initSwapchain")
-	swapchainSupportInfo := getSwapchainSupportInfo(app, app.physicalDevice)
-	app.swapchainSupportInfo = swapchainSupportInfo
-	pretty.Println("   swapchainSupportInfo:", swapchainSupportInfo)
-	extent := chooseSwapExtent(app, app.swapchainSupportInfo.surfaceCapabilities)
-	dbg.Println("   extent:", extent)
-	surfaceFormat := chooseSwapSurfaceFormat(app.swapchainSupportInfo.surfaceFormats)
-	presentMode := chooseSwapPresentMode(app.swapchainSupportInfo.presentModes)
-	imageCount := app.swapchainSupportInfo.surfaceCapabilities.minImageCount
-	// Max image count of zero means unlimited max image count.
-	maxImageCount := app.swapchainSupportInfo.surfaceCapabilities.maxImageCount
-	if maxImageCount == 0 || imageCount+1 <= maxImageCount {
-		imageCount++ // use 1 image more than minimum to avoid having to wait for swap chain.
+	support := querySwapchainSupport(app, app.physicalDevice)
+	pretty.Println("   swapchainSupport:", support)
+	var width, height C.int
+	C.glfwGetFramebufferSize(app.win, &width, &height)
+	dbg.Printf("   framebuffer size (%dx%d)", width, height)
+	config, err := configureSwapchain(support, app.presentModes, app.graphicsQueueFamilyIndex, app.presentQueueFamilyIndex, int(width), int(height))
+	if err != nil {
+		return nil, errors.WithStack(err)
 	}
+	dbg.Println("   extent:", config.extent)
+	dbg.Println("   image usage:", config.imageUsage)
+	dbg.Println("   present mode:", config.presentMode)
+	extent := vkExtent2D(config.extent)
 	// Create swap chain.
+	scratch := newArena()
+	defer scratch.free()
 	createInfo := C.VkSwapchainCreateInfoKHR{
 		sType:            C.VK_STRUCTURE_TYPE_SWAPCHAIN_CREATE_INFO_KHR,
 		surface:          *app.surface,
-		minImageCount:    imageCount,
-		imageFormat:      surfaceFormat.format,
-		imageColorSpace:  surfaceFormat.colorSpace,
+		minImageCount:    C.uint32_t(config.imageCount),
+		imageFormat:      C.VkFormat(config.format.format),
+		imageColorSpace:  C.VkColorSpaceKHR(config.format.colorSpace),
 		imageExtent:      extent,
 		imageArrayLayers: 1,
-		imageUsage:       C.VK_IMAGE_USAGE_COLOR_ATTACHMENT_BIT, // NOTE: use VK_IMAGE_USAGE_TRANSFER_DST_BIT if rendering into separate image for post-processing, before copying result to swap chain.
-		preTransform:     app.swapchainSupportInfo.surfaceCapabilities.currentTransform,
+		imageUsage:       C.VkImageUsageFlags(config.imageUsage), // NOTE: use VK_IMAGE_USAGE_TRANSFER_DST_BIT if rendering into separate image for post-processing, before copying result to swap chain.
+		preTransform:     C.VkSurfaceTransformFlagBitsKHR(support.capabilities.currentTransform),
 		compositeAlpha:   C.VK_COMPOSITE_ALPHA_OPAQUE_BIT_KHR,
-		presentMode:      presentMode,
+		presentMode:      C.VkPresentModeKHR(config.presentMode),
 		clipped:          C.VK_TRUE, // NOTE: set to false if we need to be able to read pixels of areas obscured by other windows.
 		oldSwapchain:     nil,
 	}
-	queueFamilyIndices := unique(app.QueueFamilyIndices.Indices()...)
+	queueFamilyIndices := config.queueFamilyIndices
 	switch len(queueFamilyIndices) {
 	case 1:
 		// exclusive mode.
@@ -821,31 +1169,37 @@ func initSwapchain(app *App) (*C.VkSwapchainKHR, error) {
 		// concurrent mode.
 		createInfo.imageSharingMode = C.VK_SHARING_MODE_CONCURRENT
 		createInfo.queueFamilyIndexCount = C.uint(len(queueFamilyIndices))
-		createInfo.pQueueFamilyIndices = getCUintSlice(queueFamilyIndices)
+		createInfo.pQueueFamilyIndices = scratch.cUint32s(queueFamilyIndices)
 	}
 
-	swapchain := C.new_VkSwapchainKHR()
-	if result := C.vkCreateSwapchainKHR(*app.device, &createInfo, nil, swapchain); result != C.VK_SUCCESS {
-		return nil, errors.Errorf("unable to create swap chain (result=%d)", result)
+	swapchain := app.swapchainArena.newVkSwapchainKHR(nil)
+	if result := app.deviceProcs.CreateSwapchainKHR(*app.device, &createInfo, nil, swapchain); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to create swap chain")
 	}
+	trackObject(app, C.VK_OBJECT_TYPE_SWAPCHAIN_KHR, unsafe.Pointer(*swapchain), "swapchain")
 
 	// Store swap chain image format and extent.
-	app.swapchainImageFormat = surfaceFormat.format
+	app.swapchainImageFormat = C.VkFormat(config.format.format)
 	app.swapchainExtent = extent
+	app.swapchainImageUsage = config.imageUsage
+	app.swapchainPresentMode = config.presentMode
 
 	return swapchain, nil
 }
 
 func getSwapchainImgs(app *App) []C.VkImage {
 	var nswapchainImgs C.uint32_t
-	C.vkGetSwapchainImagesKHR(*app.device, *app.swapchain, &nswapchainImgs, nil)
-	swapchainImgs := make([]C.VkImage, int(nswapchainImgs))
-	C.vkGetSwapchainImagesKHR(*app.device, *app.swapchain, &nswapchainImgs, &swapchainImgs[0])
+	app.deviceProcs.GetSwapchainImagesKHR(*app.device, *app.swapchain, &nswapchainImgs, nil)
+	swapchainImgs := app.swapchainArena.makeVkImageSlice(int(nswapchainImgs))
+	app.deviceProcs.GetSwapchainImagesKHR(*app.device, *app.swapchain, &nswapchainImgs, &swapchainImgs[0])
+	for i := range swapchainImgs {
+		setObjectNamef(app, C.VK_OBJECT_TYPE_IMAGE, unsafe.Pointer(swapchainImgs[i]), "swapchainImg[%d]", i)
+	}
 	return swapchainImgs
 }
 
 func initSwapchainImgViews(app *App) ([]C.VkImageView, error) {
-	swapchainImgViews := make([]C.VkImageView, len(app.swapchainImgs))
+	swapchainImgViews := app.swapchainArena.makeVkImageViewSlice(len(app.swapchainImgs))
 	for i := range swapchainImgViews {
 		createInfo := C.VkImageViewCreateInfo{
 			sType:    C.VK_STRUCTURE_TYPE_IMAGE_VIEW_CREATE_INFO,
@@ -866,31 +1220,46 @@ func initSwapchainImgViews(app *App) ([]C.VkImageView, error) {
 				layerCount:     1,
 			},
 		}
-		if result := C.vkCreateImageView(*app.device, &createInfo, nil, &swapchainImgViews[i]); result != C.VK_SUCCESS {
-			return nil, errors.Errorf("unable to create image view of swap chain image (result=%d)", result)
+		if result := app.deviceProcs.CreateImageView(*app.device, &createInfo, nil, &swapchainImgViews[i]); result != C.VK_SUCCESS {
+			return nil, errors.Wrap(Result(result), "unable to create image view of swap chain image")
 		}
+		trackObjectf(app, C.VK_OBJECT_TYPE_IMAGE_VIEW, unsafe.Pointer(swapchainImgViews[i]), "swapchainImgView[%d]", i)
 	}
 	return swapchainImgViews, nil
 }
 
 func initRenderPass(app *App) (*C.VkRenderPass, error) {
+	scratch := newArena()
+	defer scratch.free()
+	// Swapchain images are presented, and the offscreen image is copied to host
+	// memory.
+	finalLayout := C.VkImageLayout(C.VK_IMAGE_LAYOUT_PRESENT_SRC_KHR)
+	if app.headless {
+		finalLayout = C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL
+	}
 	colorAttachment := C.VkAttachmentDescription{
 		format:         app.swapchainImageFormat,
-		samples:        C.VK_SAMPLE_COUNT_1_BIT,
+		samples:        app.msaaSamples,
 		loadOp:         C.VK_ATTACHMENT_LOAD_OP_CLEAR,
 		storeOp:        C.VK_ATTACHMENT_STORE_OP_STORE,
 		stencilLoadOp:  C.VK_ATTACHMENT_LOAD_OP_DONT_CARE,  // NOTE: change if using stencils
 		stencilStoreOp: C.VK_ATTACHMENT_STORE_OP_DONT_CARE, // NOTE: change if using stencils
 		initialLayout:  C.VK_IMAGE_LAYOUT_UNDEFINED,
-		finalLayout:    C.VK_IMAGE_LAYOUT_PRESENT_SRC_KHR,
+		finalLayout:    finalLayout,
+	}
+	if app.msaaSamples != C.VK_SAMPLE_COUNT_1_BIT {
+		// The multisampled color target is resolved into the swapchain image,
+		// and its samples discarded.
+		colorAttachment.storeOp = C.VK_ATTACHMENT_STORE_OP_DONT_CARE
+		colorAttachment.finalLayout = C.VK_IMAGE_LAYOUT_COLOR_ATTACHMENT_OPTIMAL
 	}
-	colorAttachments := newVkAttachmentDescriptionSlice(colorAttachment)
+	colorAttachments := scratch.newVkAttachmentDescriptionSlice(colorAttachment)
 
 	colorAttachmentRef := C.VkAttachmentReference{
-		attachment: 0, // index of color attachment descriptor (we only have one).
+		attachment: 0, // index of color attachment descriptor.
 		layout:     C.VK_IMAGE_LAYOUT_COLOR_ATTACHMENT_OPTIMAL,
 	}
-	colorAttachmentRefs := newVkAttachmentReferenceSlice(colorAttachmentRef)
+	colorAttachmentRefs := scratch.newVkAttachmentReferenceSlice(colorAttachmentRef)
 
 	subpass := C.VkSubpassDescription{
 		pipelineBindPoint:       C.VK_PIPELINE_BIND_POINT_GRAPHICS,
@@ -903,17 +1272,42 @@ func initRenderPass(app *App) (*C.VkRenderPass, error) {
 		preserveAttachmentCount: 0,   // optional
 		pPreserveAttachments:    nil, // optional
 	}
-	subpasses := newVkSubpassDescriptionSlice(subpass)
+	if app.msaaSamples != C.VK_SAMPLE_COUNT_1_BIT {
+		resolveAttachment := C.VkAttachmentDescription{
+			format:         app.swapchainImageFormat,
+			samples:        C.VK_SAMPLE_COUNT_1_BIT,
+			loadOp:         C.VK_ATTACHMENT_LOAD_OP_DONT_CARE, // overwritten by resolve.
+			storeOp:        C.VK_ATTACHMENT_STORE_OP_STORE,
+			stencilLoadOp:  C.VK_ATTACHMENT_LOAD_OP_DONT_CARE,
+			stencilStoreOp: C.VK_ATTACHMENT_STORE_OP_DONT_CARE,
+			initialLayout:  C.VK_IMAGE_LAYOUT_UNDEFINED,
+			finalLayout:    finalLayout,
+		}
+		colorAttachments = scratch.newVkAttachmentDescriptionSlice(colorAttachment, resolveAttachment)
+		resolveAttachmentRef := C.VkAttachmentReference{
+			attachment: 1, // index of resolve attachment descriptor.
+			layout:     C.VK_IMAGE_LAYOUT_COLOR_ATTACHMENT_OPTIMAL,
+		}
+		resolveAttachmentRefs := scratch.newVkAttachmentReferenceSlice(resolveAttachmentRef)
+		subpass.pResolveAttachments = &resolveAttachmentRefs[0]
+	}
+	subpasses := scratch.newVkSubpassDescriptionSlice(subpass)
+	// Order the writes of the subpass after the acquire of the swapchain image
+	// (waited for at the color attachment output stage), and after the color
+	// attachment writes of earlier frames. The multisampled color target is
+	// shared by the frames in flight, so the clear and draws of this frame must
+	// not overlap the writes and resolve of the previous frame (write-after-
+	// write hazard).
 	dependency := C.VkSubpassDependency{
 		srcSubpass:      C.VK_SUBPASS_EXTERNAL,
 		dstSubpass:      0, // index of first and only subpass.
 		srcStageMask:    C.VK_PIPELINE_STAGE_COLOR_ATTACHMENT_OUTPUT_BIT,
 		dstStageMask:    C.VK_PIPELINE_STAGE_COLOR_ATTACHMENT_OUTPUT_BIT,
-		srcAccessMask:   0,
+		srcAccessMask:   C.VK_ACCESS_COLOR_ATTACHMENT_WRITE_BIT,
 		dstAccessMask:   C.VK_ACCESS_COLOR_ATTACHMENT_WRITE_BIT,
 		dependencyFlags: 0, // optional
 	}
-	dependencies := newVkSubpassDependencySlice(dependency)
+	dependencies := scratch.newVkSubpassDependencySlice(dependency)
 	renderPassCreateInfo := C.VkRenderPassCreateInfo{
 		sType:           C.VK_STRUCTURE_TYPE_RENDER_PASS_CREATE_INFO,
 		attachmentCount: C.uint(len(colorAttachments)),
@@ -923,179 +1317,24 @@ func initRenderPass(app *App) (*C.VkRenderPass, error) {
 		dependencyCount: C.uint(len(dependencies)),
 		pDependencies:   &dependencies[0],
 	}
-	renderPass := C.new_VkRenderPass()
-	if result := C.vkCreateRenderPass(*app.device, &renderPassCreateInfo, nil, renderPass); result != C.VK_SUCCESS {
-		return nil, errors.Errorf("unable to create render pass (result=%d)", result)
+	renderPass := app.swapchainArena.newVkRenderPass(nil)
+	if result := app.deviceProcs.CreateRenderPass(*app.device, &renderPassCreateInfo, nil, renderPass); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to create render pass")
 	}
+	trackObject(app, C.VK_OBJECT_TYPE_RENDER_PASS, unsafe.Pointer(*renderPass), "renderPass")
 	return renderPass, nil
 }
 
-func initGraphicsPipeline(app *App) ([]C.VkPipeline, error) {
-	shaderStages, cleanupShaderModules, err := initShaderModules(app)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	}
-	defer cleanupShaderModules()
-
-	// Vertex input.
-	bindingDescs, attrDescs := getBindingDescs()
-	vertexInputState := C.VkPipelineVertexInputStateCreateInfo{
-		sType:                           C.VK_STRUCTURE_TYPE_PIPELINE_VERTEX_INPUT_STATE_CREATE_INFO,
-		vertexBindingDescriptionCount:   C.uint(len(bindingDescs)),
-		pVertexBindingDescriptions:      &bindingDescs[0],
-		vertexAttributeDescriptionCount: C.uint(len(attrDescs)),
-		pVertexAttributeDescriptions:    &attrDescs[0],
-	}
-
-	// Input assembler    (fixed-function stage)
-	inputAssemblyState := C.VkPipelineInputAssemblyStateCreateInfo{
-		sType:                  C.VK_STRUCTURE_TYPE_PIPELINE_INPUT_ASSEMBLY_STATE_CREATE_INFO,
-		topology:               C.VK_PRIMITIVE_TOPOLOGY_TRIANGLE_LIST,
-		primitiveRestartEnable: C.VK_FALSE,
-	}
-
-	// Vertex shader      (programmable)         // DONE
-	//shaderStages[0]
-
-	// Tessalation        (programmable)
-	// Geometry shader    (programmable)
-
-	// Viewports and scissors.
-	viewport := C.VkViewport{
-		x:        0.0,
-		y:        0.0,
-		width:    C.float(app.swapchainExtent.width),
-		height:   C.float(app.swapchainExtent.height),
-		minDepth: 0.0,
-		maxDepth: 1.0,
-	}
-	viewports := newVkViewportSlice(viewport)
-	scissor := C.VkRect2D{
-		offset: C.VkOffset2D{x: 0, y: 0},
-		extent: app.swapchainExtent,
-	}
-	scissors := newVkRect2DSlice(scissor)
-	viewportState := C.VkPipelineViewportStateCreateInfo{
-		sType:         C.VK_STRUCTURE_TYPE_PIPELINE_VIEWPORT_STATE_CREATE_INFO,
-		viewportCount: C.uint(len(viewports)),
-		pViewports:    &viewports[0],
-		scissorCount:  C.uint(len(scissors)),
-		pScissors:     &scissors[0],
-	}
-
-	// Rasterization      (fixed-function stage)
-	rasterizationState := C.VkPipelineRasterizationStateCreateInfo{
-		sType:                   C.VK_STRUCTURE_TYPE_PIPELINE_RASTERIZATION_STATE_CREATE_INFO,
-		depthClampEnable:        C.VK_FALSE,
-		rasterizerDiscardEnable: C.VK_FALSE,
-		polygonMode:             C.VK_POLYGON_MODE_FILL,
-		cullMode:                C.VK_CULL_MODE_BACK_BIT,
-		frontFace:               C.VK_FRONT_FACE_CLOCKWISE,
-		depthBiasEnable:         C.VK_FALSE,
-		depthBiasConstantFactor: 0.0, // optional
-		depthBiasClamp:          0.0, // optional
-		depthBiasSlopeFactor:    0.0, // optional
-		lineWidth:               1.0,
-	}
-
-	// Multisampling.
-	multisampleState := C.VkPipelineMultisampleStateCreateInfo{
-		sType:                 C.VK_STRUCTURE_TYPE_PIPELINE_MULTISAMPLE_STATE_CREATE_INFO,
-		rasterizationSamples:  C.VK_SAMPLE_COUNT_1_BIT,
-		sampleShadingEnable:   C.VK_FALSE,
-		minSampleShading:      1.0,        // optional
-		pSampleMask:           nil,        // optional
-		alphaToCoverageEnable: C.VK_FALSE, // optional
-		alphaToOneEnable:      C.VK_FALSE, // optional
-	}
-
-	// Depth and stencil testing.
-	//depthStencilCreateInfo := C.VkPipelineDepthStencilStateCreateInfo
-
-	// Fragment shader    (programmable)         // DONE
-	//shaderStages[1]
-
-	// Color blending     (fixed-function stage)
-	colorBlendAttachment := C.VkPipelineColorBlendAttachmentState{
-		blendEnable:         C.VK_FALSE,
-		srcColorBlendFactor: C.VK_BLEND_FACTOR_ONE,  // optional
-		dstColorBlendFactor: C.VK_BLEND_FACTOR_ZERO, // optional
-		colorBlendOp:        C.VK_BLEND_OP_ADD,      // optional
-		srcAlphaBlendFactor: C.VK_BLEND_FACTOR_ONE,  // optional
-		dstAlphaBlendFactor: C.VK_BLEND_FACTOR_ZERO, // optional
-		alphaBlendOp:        C.VK_BLEND_OP_ADD,      // optional
-		colorWriteMask:      C.VK_COLOR_COMPONENT_R_BIT | C.VK_COLOR_COMPONENT_G_BIT | C.VK_COLOR_COMPONENT_B_BIT | C.VK_COLOR_COMPONENT_A_BIT,
-	}
-	colorBlendAttachments := newVkPipelineColorBlendAttachmentStateSlice(colorBlendAttachment)
-	colorBlendState := C.VkPipelineColorBlendStateCreateInfo{
-		sType:           C.VK_STRUCTURE_TYPE_PIPELINE_COLOR_BLEND_STATE_CREATE_INFO,
-		logicOpEnable:   C.VK_FALSE,
-		logicOp:         C.VK_LOGIC_OP_COPY, // optional
-		attachmentCount: C.uint(len(colorBlendAttachments)),
-		pAttachments:    &colorBlendAttachments[0],
-		blendConstants:  [4]C.float{0.0, 0.0, 0.0, 0.0}, // optional
-	}
-
-	// Dynamic state.
-	//dynamicStates := []C.VkDynamicState{
-	//	C.VK_DYNAMIC_STATE_VIEWPORT,
-	//}
-	//dynamicState := C.VkPipelineDynamicStateCreateInfo{
-	//	sType:             C.VK_STRUCTURE_TYPE_PIPELINE_DYNAMIC_STATE_CREATE_INFO,
-	//	dynamicStateCount: C.uint(len(dynamicStates)),
-	//	pDynamicStates:    &dynamicStates[0],
-	//}
-
-	// Uniform values.
-	pipelineLayoutCreateInfo := C.VkPipelineLayoutCreateInfo{
-		sType:                  C.VK_STRUCTURE_TYPE_PIPELINE_LAYOUT_CREATE_INFO,
-		setLayoutCount:         0,   // optional
-		pSetLayouts:            nil, // optional
-		pushConstantRangeCount: 0,   // optional
-		pPushConstantRanges:    nil, // optional
-	}
-	pipelineLayout := C.new_VkPipelineLayout()
-	if result := C.vkCreatePipelineLayout(*app.device, &pipelineLayoutCreateInfo, nil, pipelineLayout); result != C.VK_SUCCESS {
-		return nil, errors.Errorf("unable to create pipeline layout (result=%d)", result)
-	}
-	app.pipelineLayout = pipelineLayout
-
-	graphicsPipelineCreateInfo := C.VkGraphicsPipelineCreateInfo{
-		sType:               C.VK_STRUCTURE_TYPE_GRAPHICS_PIPELINE_CREATE_INFO,
-		stageCount:          C.uint(len(shaderStages)),
-		pStages:             &shaderStages[0],
-		pVertexInputState:   &vertexInputState,
-		pInputAssemblyState: &inputAssemblyState,
-		pTessellationState:  nil, // optional
-		pViewportState:      &viewportState,
-		pRasterizationState: &rasterizationState,
-		pMultisampleState:   &multisampleState,
-		pDepthStencilState:  nil, // optional
-		pColorBlendState:    &colorBlendState,
-		//pDynamicState:       &dynamicState,
-		layout:             *pipelineLayout,
-		renderPass:         *app.renderPass,
-		subpass:            0,   // index of subpass in the render pass
-		basePipelineHandle: nil, // optional
-		basePipelineIndex:  -1,  // optional
-	}
-	graphicsPipelineCreateInfos := newVkGraphicsPipelineCreateInfoSlice(graphicsPipelineCreateInfo)
-	graphicsPipelines := newVkPipelineSlice(make([]C.VkPipeline, len(graphicsPipelineCreateInfos))...)
-	if result := C.vkCreateGraphicsPipelines(*app.device, nil, C.uint(len(graphicsPipelineCreateInfos)), &graphicsPipelineCreateInfos[0], nil, &graphicsPipelines[0]); result != C.VK_SUCCESS {
-		return nil, errors.Errorf("unable to create graphics pipeline (result=%d)", result)
-	}
-	return graphicsPipelines, nil
-}
-
-func initShaderModules(app *App) (shaderStageCreateInfos []C.VkPipelineShaderStageCreateInfo, cleanup func(), err error) {
+func initShaderModules(app *App, scratch *arena, vertexShaderPath, fragmentShaderPath string) (shaderStageCreateInfos []C.VkPipelineShaderStageCreateInfo, cleanup func(), err error) {
 	// Create vertex shader.
-	vertexShaderModule, err := createShaderModule(app, "shaders/shader_vert.spv")
+	vertexShaderModule, err := createShaderModule(app, scratch, vertexShaderPath)
 	if err != nil {
 		return nil, nil, errors.WithStack(err)
 	}
 	// Create fragment shader.
-	fragmentShaderModule, err := createShaderModule(app, "shaders/shader_frag.spv")
+	fragmentShaderModule, err := createShaderModule(app, scratch, fragmentShaderPath)
 	if err != nil {
+		destroyShaderModule(app, vertexShaderModule)
 		return nil, nil, errors.WithStack(err)
 	}
 	// Create graphics pipeline.
@@ -1103,47 +1342,82 @@ func initShaderModules(app *App) (shaderStageCreateInfos []C.VkPipelineShaderSta
 		sType:  C.VK_STRUCTURE_TYPE_PIPELINE_SHADER_STAGE_CREATE_INFO,
 		stage:  C.VK_SHADER_STAGE_VERTEX_BIT,
 		module: *vertexShaderModule,
-		pName:  C.CString("main"),
+		pName:  scratch.cString("main"),
 	}
 	fragmentShaderStageInfo := C.VkPipelineShaderStageCreateInfo{
 		sType:  C.VK_STRUCTURE_TYPE_PIPELINE_SHADER_STAGE_CREATE_INFO,
 		stage:  C.VK_SHADER_STAGE_FRAGMENT_BIT,
 		module: *fragmentShaderModule,
-		pName:  C.CString("main"),
+		pName:  scratch.cString("main"),
 	}
-	shaderStageCreateInfos = []C.VkPipelineShaderStageCreateInfo{
+	shaderStageCreateInfos = scratch.newVkPipelineShaderStageCreateInfoSlice(
 		vertexShaderStageInfo,
 		fragmentShaderStageInfo,
-	}
+	)
 	cleanup = func() {
-		C.vkDestroyShaderModule(*app.device, *fragmentShaderModule, nil)
-		C.vkDestroyShaderModule(*app.device, *vertexShaderModule, nil)
+		destroyShaderModule(app, fragmentShaderModule)
+		destroyShaderModule(app, vertexShaderModule)
 	}
 	return shaderStageCreateInfos, cleanup, nil
 }
 
-func createShaderModule(app *App, shaderPath string) (*C.VkShaderModule, error) {
+// destroyShaderModule destroys the given shader module.
+func destroyShaderModule(app *App, shaderModule *C.VkShaderModule) {
+	untrackObject(C.VK_OBJECT_TYPE_SHADER_MODULE, unsafe.Pointer(*shaderModule))
+	app.deviceProcs.DestroyShaderModule(*app.device, *shaderModule, nil)
+}
+
+func createShaderModule(app *App, scratch *arena, shaderPath string) (*C.VkShaderModule, error) {
+	shaderCode, err := readShader(shaderPath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	shaderModule, err := createShaderModuleFromCode(app, scratch, shaderPath, shaderCode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return shaderModule, nil
+}
+
+// readShader reads the SPIR-V code of the given shader file.
+func readShader(shaderPath string) ([]byte, error) {
 	dbg.Printf("loading shader %q", shaderPath)
-	shaderData, err := ioutil.ReadFile(shaderPath)
+	shaderCode, err := ioutil.ReadFile(shaderPath)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	return shaderCode, nil
+}
+
+// createShaderModuleFromCode creates a shader module with the given name from
+// the given SPIR-V code.
+func createShaderModuleFromCode(app *App, scratch *arena, name string, shaderCode []byte) (*C.VkShaderModule, error) {
+	if len(shaderCode) == 0 || len(shaderCode)%4 != 0 {
+		return nil, errors.Errorf("invalid SPIR-V code of shader module %q; expected non-empty multiple of 4 bytes, got %d bytes", name, len(shaderCode))
+	}
 	createInfo := C.VkShaderModuleCreateInfo{
 		sType:    C.VK_STRUCTURE_TYPE_SHADER_MODULE_CREATE_INFO,
-		codeSize: C.size_t(len(shaderData)),
-		pCode:    getCUintSliceFromBytes(shaderData),
+		codeSize: C.size_t(len(shaderCode)),
+		pCode:    scratch.code(shaderCode),
 	}
-	shaderModule := C.new_VkShaderModule()
-	if result := C.vkCreateShaderModule(*app.device, &createInfo, nil, shaderModule); result != C.VK_SUCCESS {
-		return nil, errors.Errorf("unable to create shader module %q (result=%d)", shaderPath, result)
+	shaderModule := scratch.newVkShaderModule(nil)
+	if result := app.deviceProcs.CreateShaderModule(*app.device, &createInfo, nil, shaderModule); result != C.VK_SUCCESS {
+		return nil, errors.Wrapf(Result(result), "unable to create shader module %q", name)
 	}
+	trackObject(app, C.VK_OBJECT_TYPE_SHADER_MODULE, unsafe.Pointer(*shaderModule), name)
 	return shaderModule, nil
 }
 
 func initFramebuffers(app *App) ([]C.VkFramebuffer, error) {
-	framebuffers := newVkFramebufferSlice(make([]C.VkFramebuffer, len(app.swapchainImgViews))...)
+	scratch := newArena()
+	defer scratch.free()
+	framebuffers := app.swapchainArena.makeVkFramebufferSlice(len(app.swapchainImgViews))
 	for i := range app.swapchainImgViews {
-		attachments := newVkImageViewSlice(app.swapchainImgViews[i])
+		attachments := scratch.newVkImageViewSlice(app.swapchainImgViews[i])
+		if app.msaaColorImgView != nil {
+			// Render to multisampled color target, resolved into swapchain image.
+			attachments = scratch.newVkImageViewSlice(*app.msaaColorImgView, app.swapchainImgViews[i])
+		}
 		framebufferCreateInfo := C.VkFramebufferCreateInfo{
 			sType:           C.VK_STRUCTURE_TYPE_FRAMEBUFFER_CREATE_INFO,
 			renderPass:      *app.renderPass,
@@ -1153,9 +1427,10 @@ func initFramebuffers(app *App) ([]C.VkFramebuffer, error) {
 			height:          app.swapchainExtent.height,
 			layers:          1,
 		}
-		if result := C.vkCreateFramebuffer(*app.device, &framebufferCreateInfo, nil, &framebuffers[i]); result != C.VK_SUCCESS {
-			return nil, errors.Errorf("unable to create framebuffer (result=%d)", result)
+		if result := app.deviceProcs.CreateFramebuffer(*app.device, &framebufferCreateInfo, nil, &framebuffers[i]); result != C.VK_SUCCESS {
+			return nil, errors.Wrap(Result(result), "unable to create framebuffer")
 		}
+		trackObjectf(app, C.VK_OBJECT_TYPE_FRAMEBUFFER, unsafe.Pointer(framebuffers[i]), "swapchainFramebuffer[%d]", i)
 	}
 	return framebuffers, nil
 }
@@ -1163,45 +1438,75 @@ func initFramebuffers(app *App) ([]C.VkFramebuffer, error) {
 func initCommandPool(app *App) (*C.VkCommandPool, error) {
 	commandPoolCreateInfo := C.VkCommandPoolCreateInfo{
 		sType:            C.VK_STRUCTURE_TYPE_COMMAND_POOL_CREATE_INFO,
+		flags:            C.VK_COMMAND_POOL_CREATE_RESET_COMMAND_BUFFER_BIT, // command buffers are re-recorded every frame.
 		queueFamilyIndex: C.uint(app.graphicsQueueFamilyIndex),
 	}
-	commandPool := C.new_VkCommandPool()
-	if result := C.vkCreateCommandPool(*app.device, &commandPoolCreateInfo, nil, commandPool); result != C.VK_SUCCESS {
-		return nil, errors.Errorf("unable to create command pool (result=%d)", result)
+	commandPool := app.arena.newVkCommandPool(nil)
+	if result := app.deviceProcs.CreateCommandPool(*app.device, &commandPoolCreateInfo, nil, commandPool); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to create command pool")
 	}
+	trackObject(app, C.VK_OBJECT_TYPE_COMMAND_POOL, unsafe.Pointer(*commandPool), "commandPool")
 	return commandPool, nil
 }
 
 func initCommandBuffers(app *App) ([]C.VkCommandBuffer, error) {
-	commandBuffers := newVkCommandBufferSlice(make([]C.VkCommandBuffer, len(app.swapchainFramebuffers))...)
+	commandBuffers := app.swapchainArena.makeVkCommandBufferSlice(len(app.swapchainImgViews))
 	commandBufferAllocateInfo := C.VkCommandBufferAllocateInfo{
 		sType:              C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_ALLOCATE_INFO,
 		commandPool:        *app.commandPool,
 		level:              C.VK_COMMAND_BUFFER_LEVEL_PRIMARY,
 		commandBufferCount: C.uint(len(commandBuffers)),
 	}
-	if result := C.vkAllocateCommandBuffers(*app.device, &commandBufferAllocateInfo, &commandBuffers[0]); result != C.VK_SUCCESS {
-		return nil, errors.Errorf("unable to create command buffers (result=%d)", result)
+	if result := app.deviceProcs.AllocateCommandBuffers(*app.device, &commandBufferAllocateInfo, &commandBuffers[0]); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to create command buffers")
+	}
+	for i := range commandBuffers {
+		trackObjectf(app, C.VK_OBJECT_TYPE_COMMAND_BUFFER, unsafe.Pointer(commandBuffers[i]), "swapchainCommandBuffer[%d]", i)
 	}
 	return commandBuffers, nil
 }
 
 func recordRenderCommands(app *App) error {
+	scratch := newArena()
+	defer scratch.free()
 	for i := range app.swapchainCommandBuffers {
-		commandBufferBeginInfo := C.VkCommandBufferBeginInfo{
-			sType:            C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_BEGIN_INFO,
-			flags:            0,   // optional
-			pInheritanceInfo: nil, // optional
-		}
-		if result := C.vkBeginCommandBuffer(app.swapchainCommandBuffers[i], &commandBufferBeginInfo); result != C.VK_SUCCESS {
-			return errors.Errorf("unable to begin recording command buffer (result=%d)", result)
+		if err := recordRenderCommandBuffer(app, scratch, i); err != nil {
+			return errors.WithStack(err)
 		}
+	}
+	return nil
+}
 
-		clearColor := C.VkClearValue{
-			0.0, 0.0, 0.0, 1.0, // r, g, b, a
-		}
-		clearColors := newVkClearValueSlice(clearColor)
+// recordRenderCommandBuffer records the render commands of the command buffer
+// of the given swapchain image, for the current frame of the clock.
+func recordRenderCommandBuffer(app *App, scratch *arena, i int) error {
+	commandBufferBeginInfo := C.VkCommandBufferBeginInfo{
+		sType:            C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_BEGIN_INFO,
+		flags:            0,   // optional
+		pInheritanceInfo: nil, // optional
+	}
+	if result := app.deviceProcs.BeginCommandBuffer(app.swapchainCommandBuffers[i], &commandBufferBeginInfo); result != C.VK_SUCCESS {
+		return errors.Wrap(Result(result), "unable to begin recording command buffer")
+	}
+
+	clearColor := C.VkClearValue{
+		0.0, 0.0, 0.0, 1.0, // r, g, b, a
+	}
+	clearColors := scratch.newVkClearValueSlice(clearColor)
+
+	// Build the draw list of the frame, sorted to reduce rebinds.
+	if err := buildDrawList(app); err != nil {
+		return errors.WithStack(err)
+	}
+	app.drawList.sort()
+
+	// Acquire animated vertices from compute queue family.
+	cmdAcquireAnimatedVertices(app, scratch, app.swapchainCommandBuffers[i])
 
+	beginLabel(app, scratch, app.swapchainCommandBuffers[i], "render pass", labelColorPass)
+	if app.dynamicRendering {
+		cmdBeginDynamicRendering(app, scratch, app.swapchainCommandBuffers[i], i, clearColor)
+	} else {
 		renderPassBeginInfo := C.VkRenderPassBeginInfo{
 			sType:       C.VK_STRUCTURE_TYPE_RENDER_PASS_BEGIN_INFO,
 			renderPass:  *app.renderPass,
@@ -1213,35 +1518,22 @@ func recordRenderCommands(app *App) error {
 			clearValueCount: C.uint(len(clearColors)),
 			pClearValues:    &clearColors[0],
 		}
+		app.deviceProcs.CmdBeginRenderPass(app.swapchainCommandBuffers[i], &renderPassBeginInfo, C.VK_SUBPASS_CONTENTS_INLINE)
+	}
 
-		C.vkCmdBeginRenderPass(app.swapchainCommandBuffers[i], &renderPassBeginInfo, C.VK_SUBPASS_CONTENTS_INLINE)
+	if err := cmdDrawList(app, scratch, app.swapchainCommandBuffers[i], &app.drawList); err != nil {
+		return errors.WithStack(err)
+	}
 
-		C.vkCmdBindPipeline(app.swapchainCommandBuffers[i], C.VK_PIPELINE_BIND_POINT_GRAPHICS, app.graphicsPipelines[0]) // NOTE: we only use one graphics pipeline.
+	if app.dynamicRendering {
+		cmdEndDynamicRendering(app, scratch, app.swapchainCommandBuffers[i], i)
+	} else {
+		app.deviceProcs.CmdEndRenderPass(app.swapchainCommandBuffers[i])
+	}
+	endLabel(app, app.swapchainCommandBuffers[i])
 
-		vertexBuffers := []C.VkBuffer{
-			*app.vertexBuffer,
-		}
-		offsets := []C.VkDeviceSize{
-			0,
-		}
-		const firstVertexBufferBinding = 0
-		C.vkCmdBindVertexBuffers(app.swapchainCommandBuffers[i], firstVertexBufferBinding, C.uint(len(vertexBuffers)), &vertexBuffers[0], &offsets[0])
-		const indexBufferOffset = 0
-		C.vkCmdBindIndexBuffer(app.swapchainCommandBuffers[i], *app.indexBuffer, indexBufferOffset, C.VK_INDEX_TYPE_UINT32)
-
-		const (
-			instanceCount = 1
-			firstIndex    = 0
-			vertexOffset  = 0
-			firstInstance = 0
-		)
-		C.vkCmdDrawIndexed(app.swapchainCommandBuffers[i], C.uint(len(app.indices)), instanceCount, firstIndex, vertexOffset, firstInstance)
-
-		C.vkCmdEndRenderPass(app.swapchainCommandBuffers[i])
-
-		if result := C.vkEndCommandBuffer(app.swapchainCommandBuffers[i]); result != C.VK_SUCCESS {
-			return errors.Errorf("unable to record command buffer (result=%d)", result)
-		}
+	if result := app.deviceProcs.EndCommandBuffer(app.swapchainCommandBuffers[i]); result != C.VK_SUCCESS {
+		return errors.Wrap(Result(result), "unable to record command buffer")
 	}
 	return nil
 }
@@ -1254,32 +1546,33 @@ func initSyncObjects(app *App) error {
 		sType: C.VK_STRUCTURE_TYPE_FENCE_CREATE_INFO,
 		flags: C.VK_FENCE_CREATE_SIGNALED_BIT, // start fence in signalled state.
 	}
-	app.imagesInFlightFences = make([]*C.VkFence, len(app.swapchainImgs))
+	app.imagesInFlight = make([]uint64, len(app.swapchainImgs))
 	for i := range app.imageAvailableSemaphores {
 		// Image available semaphore.
-		imageAvailableSemaphore := C.new_VkSemaphore()
-		if result := C.vkCreateSemaphore(*app.device, &semaphoreCreateInfo, nil, imageAvailableSemaphore); result != C.VK_SUCCESS {
-			return errors.Errorf("unable to create semaphore (result=%d)", result)
+		imageAvailableSemaphore := app.arena.newVkSemaphore(nil)
+		if result := app.deviceProcs.CreateSemaphore(*app.device, &semaphoreCreateInfo, nil, imageAvailableSemaphore); result != C.VK_SUCCESS {
+			return errors.Wrap(Result(result), "unable to create semaphore")
 		}
 		app.imageAvailableSemaphores[i] = imageAvailableSemaphore
+		trackObjectf(app, C.VK_OBJECT_TYPE_SEMAPHORE, unsafe.Pointer(*imageAvailableSemaphore), "imageAvailableSemaphore[%d]", i)
 		// Rendering finished semaphore.
-		renderFinishedSemaphore := C.new_VkSemaphore()
-		if result := C.vkCreateSemaphore(*app.device, &semaphoreCreateInfo, nil, renderFinishedSemaphore); result != C.VK_SUCCESS {
-			return errors.Errorf("unable to create semaphore (result=%d)", result)
+		renderFinishedSemaphore := app.arena.newVkSemaphore(nil)
+		if result := app.deviceProcs.CreateSemaphore(*app.device, &semaphoreCreateInfo, nil, renderFinishedSemaphore); result != C.VK_SUCCESS {
+			return errors.Wrap(Result(result), "unable to create semaphore")
 		}
 		app.renderFinishedSemaphores[i] = renderFinishedSemaphore
-		// In-flight fence.
-		framesInFlightFence := C.new_VkFence()
-		if result := C.vkCreateFence(*app.device, &fenceCreateInfo, nil, framesInFlightFence); result != C.VK_SUCCESS {
-			return errors.Errorf("unable to create fence (result=%d)", result)
+		trackObjectf(app, C.VK_OBJECT_TYPE_SEMAPHORE, unsafe.Pointer(*renderFinishedSemaphore), "renderFinishedSemaphore[%d]", i)
+		// In-flight fence; frames in flight are instead waited for by the value
+		// of the graphics timeline if timeline semaphores.
+		if app.timelineSemaphores {
+			continue
 		}
-		app.framesInFlightFences[i] = framesInFlightFence
-		// Images in-flight fence.
-		imagesInFlightFence := C.new_VkFence()
-		if result := C.vkCreateFence(*app.device, &fenceCreateInfo, nil, imagesInFlightFence); result != C.VK_SUCCESS {
-			return errors.Errorf("unable to create fence (result=%d)", result)
+		framesInFlightFence := app.arena.newVkFence(nil)
+		if result := app.deviceProcs.CreateFence(*app.device, &fenceCreateInfo, nil, framesInFlightFence); result != C.VK_SUCCESS {
+			return errors.Wrap(Result(result), "unable to create fence")
 		}
-		app.imagesInFlightFences[i] = imagesInFlightFence
+		app.framesInFlightFences[i] = framesInFlightFence
+		trackObjectf(app, C.VK_OBJECT_TYPE_FENCE, unsafe.Pointer(*framesInFlightFence), "framesInFlightFence[%d]", i)
 	}
 	return nil
 }
@@ -1289,72 +1582,134 @@ func drawFrame(app *App) error {
 		nfences = 1
 		timeout = C.UINT64_MAX // disable timeout
 	)
-	C.vkWaitForFences(*app.device, nfences, app.framesInFlightFences[app.curFrame], C.VK_TRUE, timeout)
+	// Wait for the previous use of the frame in flight to complete.
+	if err := waitFrame(app, app.frameValues[app.curFrame]); err != nil {
+		return errors.WithStack(err)
+	}
+	// Reuse the memory of the previous frame.
+	app.frameArena.reset()
+	// Release resources no longer in use since the previous use of the frame.
+	runDeletions(app, app.curFrame)
+	// Finish captures copied by the previous use of the frame.
+	finishCaptures(app, app.frameArena, app.curFrame)
+	// Reload shaders and switch scenes, as requested.
+	if err := handleReloadRequests(app); err != nil {
+		warn.Printf("%+v", err) // print warning and continue
+	}
+	// Submit uploads queued since the previous frame.
+	if err := flushUploads(app); err != nil {
+		return errors.WithStack(err)
+	}
 
 	//dbg.Println("vk.drawFrame")
 	var imageIndex C.uint32_t // swapchainImgs array index
-	if result := C.vkAcquireNextImageKHR(*app.device, *app.swapchain, timeout, *app.imageAvailableSemaphores[app.curFrame], nil, &imageIndex); result != C.VK_SUCCESS {
-		switch result {
-		case C.VK_ERROR_OUT_OF_DATE_KHR:
+	if result := app.deviceProcs.AcquireNextImageKHR(*app.device, *app.swapchain, timeout, *app.imageAvailableSemaphores[app.curFrame], nil, &imageIndex); result != C.VK_SUCCESS {
+		switch Result(result) {
+		case ErrOutOfDate:
 			// Recreate swapchain; window resolution has most likely been changed.
 			if err := recreateSwapchain(app); err != nil {
 				return errors.WithStack(err)
 			}
 			return nil // early return, try again on next call to drawFrame.
-		case C.VK_SUBOPTIMAL_KHR:
+		case Suboptimal:
 			// nothing to do; present aquired image even if suboptimal.
 		default:
-			return errors.Errorf("unable to aquire next image (result=%d)", result)
+			return errors.Wrap(Result(result), "unable to aquire next image")
 		}
 	}
-	// check if frame is used by previous frame.
-	if app.imagesInFlightFences[imageIndex] != nil {
-		C.vkWaitForFences(*app.device, nfences, app.imagesInFlightFences[imageIndex], C.VK_TRUE, timeout)
+	// Wait for the previous frame rendering to the swapchain image, if still
+	// in flight.
+	if err := waitFrame(app, app.imagesInFlight[imageIndex]); err != nil {
+		return errors.WithStack(err)
+	}
+	// Record render commands of the frame.
+	t := app.clock.tick()
+	// The vertex and index buffers of the scene are read once uploaded; waited
+	// for by the submission of the frame with timeline semaphores, and by the
+	// host otherwise.
+	uploaded, err := app.sceneUpload.waitOp(app, C.VK_PIPELINE_STAGE_VERTEX_INPUT_BIT)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	animatedVertices, err := submitAnimation(app, app.frameArena)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := recordRenderCommandBuffer(app, app.frameArena, int(imageIndex)); err != nil {
+		return errors.WithStack(err)
 	}
+	updateRecording(app, t)
 
-	waitSemaphores := newVkSemaphoreSlice(*app.imageAvailableSemaphores[app.curFrame])
-	waitStages := []C.VkPipelineStageFlags{
-		C.VK_PIPELINE_STAGE_COLOR_ATTACHMENT_OUTPUT_BIT,
+	waits := []submitSemaphore{
+		{semaphore: *app.imageAvailableSemaphores[app.curFrame], stage: C.VK_PIPELINE_STAGE_COLOR_ATTACHMENT_OUTPUT_BIT},
 	}
-	signalSemaphores := newVkSemaphoreSlice(*app.renderFinishedSemaphores[app.curFrame])
-	submitInfo := C.VkSubmitInfo{
-		sType:                C.VK_STRUCTURE_TYPE_SUBMIT_INFO,
-		waitSemaphoreCount:   C.uint(len(waitSemaphores)),
-		pWaitSemaphores:      &waitSemaphores[0],
-		pWaitDstStageMask:    &waitStages[0],
-		commandBufferCount:   1,
-		pCommandBuffers:      &app.swapchainCommandBuffers[imageIndex],
-		signalSemaphoreCount: C.uint(len(signalSemaphores)),
-		pSignalSemaphores:    &signalSemaphores[0],
+	if animatedVertices != nil {
+		// Wait for animated vertices.
+		waits = append(waits, *animatedVertices)
 	}
-	submits := newVkSubmitInfoSlice(submitInfo)
-	C.vkResetFences(*app.device, nfences, app.framesInFlightFences[app.curFrame])
-	if result := C.vkQueueSubmit(*app.graphicsQueue, C.uint(len(submits)), &submits[0], *app.framesInFlightFences[app.curFrame]); result != C.VK_SUCCESS {
-		return errors.Errorf("unable to submit command buffers to graphics queue (result=%d)", result)
+	if uploaded != nil {
+		// Wait for upload of vertex and index buffers.
+		waits = append(waits, *uploaded)
 	}
+	signals := []submitSemaphore{
+		{semaphore: *app.renderFinishedSemaphores[app.curFrame]},
+	}
+	frameValue := app.graphicsTimeline.next()
+	var fence C.VkFence
+	if app.timelineSemaphores {
+		signals = append(signals, app.graphicsTimeline.signal(frameValue))
+	} else {
+		fence = *app.framesInFlightFences[app.curFrame]
+	}
+	commandBuffers := []C.VkCommandBuffer{app.swapchainCommandBuffers[imageIndex]}
+	// Copy swapchain image after rendering, if capture requested.
+	captureCommandBuffer, err := initCapture(app, int(imageIndex))
+	if err != nil {
+		warn.Printf("%+v", err) // print warning and continue
+	}
+	if captureCommandBuffer != nil {
+		commandBuffers = append(commandBuffers, captureCommandBuffer)
+	}
+	submitInfo := newSubmitInfo(app, app.frameArena, commandBuffers, waits, signals)
+	submits := app.frameArena.newVkSubmitInfoSlice(submitInfo)
+	if fence != nil {
+		app.deviceProcs.ResetFences(*app.device, nfences, app.framesInFlightFences[app.curFrame])
+	}
+	if result := app.deviceProcs.QueueSubmit(*app.graphicsQueue, C.uint(len(submits)), &submits[0], fence); result != C.VK_SUCCESS {
+		if captureCommandBuffer != nil {
+			cancelCapture(app, app.frameArena, captureCommandBuffer)
+		}
+		return errors.Wrap(Result(result), "unable to submit command buffers to graphics queue")
+	}
+	app.frameValues[app.curFrame] = frameValue
+	app.imagesInFlight[imageIndex] = frameValue
+	submitDeletions(app, app.curFrame)
 	// Present frame.
-	swapchains := newVkSwapchainKHRSlice(*app.swapchain)
-	imageIndices := newCUint32Slice(imageIndex)
+	swapchains := app.frameArena.newVkSwapchainKHRSlice(*app.swapchain)
+	imageIndices := app.frameArena.newCUint32Slice(imageIndex)
+	presentWaitSemaphores := app.frameArena.newVkSemaphoreSlice(*app.renderFinishedSemaphores[app.curFrame])
 	presentInfo := C.VkPresentInfoKHR{
 		sType:              C.VK_STRUCTURE_TYPE_PRESENT_INFO_KHR,
-		waitSemaphoreCount: C.uint(len(signalSemaphores)),
-		pWaitSemaphores:    &signalSemaphores[0],
+		waitSemaphoreCount: C.uint(len(presentWaitSemaphores)),
+		pWaitSemaphores:    &presentWaitSemaphores[0],
 		swapchainCount:     C.uint(len(swapchains)),
 		pSwapchains:        &swapchains[0],
 		pImageIndices:      &imageIndices[0],
 		pResults:           nil, // optional
 	}
-	result := C.vkQueuePresentKHR(*app.presentQueue, &presentInfo)
+	result := Result(app.deviceProcs.QueuePresentKHR(*app.presentQueue, &presentInfo))
 	switch {
-	case result == C.VK_ERROR_OUT_OF_DATE_KHR, result == C.VK_SUBOPTIMAL_KHR, app.framebufferResized:
-		// Recreate swapchain; window resolution has most likely been changed.
+	case result == ErrOutOfDate, result == Suboptimal, app.framebufferResized, app.presentModeChanged:
+		// Recreate swapchain; window resolution has most likely been changed,
+		// or a different present mode requested.
 		if err := recreateSwapchain(app); err != nil {
 			return errors.WithStack(err)
 		}
 		app.framebufferResized = false
+		app.presentModeChanged = false
 	default:
-		if result != C.VK_SUCCESS {
-			return errors.Errorf("unable to queue image for presentation (result=%d)", result)
+		if result != Success {
+			return errors.Wrap(result, "unable to queue image for presentation")
 		}
 	}
 
@@ -1371,39 +1726,9 @@ func getIndicesSize(indices []uint32) C.VkDeviceSize {
 	return C.VkDeviceSize(int(unsafe.Sizeof(indices[0])) * len(indices))
 }
 
-func fillVertexBuffer(app *App, vertices []Vertex, bufferMem *C.VkDeviceMemory) error {
-	// Fill vertex buffer with data.
-	const offset = 0
-	var data unsafe.Pointer
-	size := getVerticesSize(vertices)
-	if result := C.vkMapMemory(*app.device, *bufferMem, offset, size, 0, &data); result != C.VK_SUCCESS {
-		return errors.Errorf("unable to map memory of vertex buffer with size=%d (result=%d)", size, result)
-	}
-	dst := unsafe.Slice((*byte)(data), size)
-	src := unsafe.Slice((*byte)(unsafe.Pointer(&vertices[0])), size)
-	copy(dst, src)
-	C.vkUnmapMemory(*app.device, *bufferMem)
-	return nil
-}
-
-func fillIndexBuffer(app *App, indices []uint32, bufferMem *C.VkDeviceMemory) error {
-	// Fill index buffer with data.
-	const offset = 0
-	var data unsafe.Pointer
-	size := getIndicesSize(indices)
-	if result := C.vkMapMemory(*app.device, *bufferMem, offset, size, 0, &data); result != C.VK_SUCCESS {
-		return errors.Errorf("unable to map memory of index buffer with size=%d (result=%d)", size, result)
-	}
-	dst := unsafe.Slice((*byte)(data), size)
-	src := unsafe.Slice((*byte)(unsafe.Pointer(&indices[0])), size)
-	copy(dst, src)
-	C.vkUnmapMemory(*app.device, *bufferMem)
-	return nil
-}
-
 func findMemoryType(app *App, typeFilter C.uint, properties C.VkMemoryPropertyFlags) (uint32, error) {
 	var memProperties C.VkPhysicalDeviceMemoryProperties
-	C.vkGetPhysicalDeviceMemoryProperties(*app.physicalDevice, &memProperties)
+	app.instanceProcs.GetPhysicalDeviceMemoryProperties(*app.physicalDevice, &memProperties)
 	for i := 0; i < int(memProperties.memoryTypeCount); i++ {
 		if typeFilter&C.uint(1<<i) != 0 && memProperties.memoryTypes[i].propertyFlags&properties == properties {
 			return uint32(i), nil
@@ -1412,7 +1737,16 @@ func findMemoryType(app *App, typeFilter C.uint, properties C.VkMemoryPropertyFl
 	return 0, errors.Errorf("unable to find suitable memory type for filter 0x%08X", uint32(typeFilter))
 }
 
-func createBuffer(app *App, size C.VkDeviceSize, usage C.VkBufferUsageFlags, properties C.VkMemoryPropertyFlags) (*C.VkBuffer, *C.VkDeviceMemory, error) {
+func createBuffer(app *App, name string, size C.VkDeviceSize, usage C.VkBufferUsageFlags, properties C.VkMemoryPropertyFlags) (*C.VkBuffer, *C.VkDeviceMemory, error) {
+	return createSharedBuffer(app, name, size, usage, properties, nil)
+}
+
+// createSharedBuffer creates a buffer accessed concurrently by the given queue
+// families, without transfer of ownership. The buffer is exclusively owned by
+// one queue family at a time if less than two unique queue families are given.
+func createSharedBuffer(app *App, name string, size C.VkDeviceSize, usage C.VkBufferUsageFlags, properties C.VkMemoryPropertyFlags, queueFamilyIndices []int) (*C.VkBuffer, *C.VkDeviceMemory, error) {
+	scratch := newArena()
+	defer scratch.free()
 	bufferCreateInfo := C.VkBufferCreateInfo{
 		sType:                 C.VK_STRUCTURE_TYPE_BUFFER_CREATE_INFO,
 		size:                  size,
@@ -1421,13 +1755,23 @@ func createBuffer(app *App, size C.VkDeviceSize, usage C.VkBufferUsageFlags, pro
 		queueFamilyIndexCount: 0,   // optional
 		pQueueFamilyIndices:   nil, // optional
 	}
-	buffer := C.new_VkBuffer()
-	if result := C.vkCreateBuffer(*app.device, &bufferCreateInfo, nil, buffer); result != C.VK_SUCCESS {
-		return nil, nil, errors.Errorf("unable to create vertex buffer (result=%d)", result)
+	if indices := unique(queueFamilyIndices...); len(indices) > 1 {
+		cIndices := scratch.makeCUint32Slice(len(indices))
+		for i, index := range indices {
+			cIndices[i] = C.uint32_t(index)
+		}
+		bufferCreateInfo.sharingMode = C.VK_SHARING_MODE_CONCURRENT
+		bufferCreateInfo.queueFamilyIndexCount = C.uint(len(cIndices))
+		bufferCreateInfo.pQueueFamilyIndices = &cIndices[0]
+	}
+	buffer := app.arena.newVkBuffer(nil)
+	if result := app.deviceProcs.CreateBuffer(*app.device, &bufferCreateInfo, nil, buffer); result != C.VK_SUCCESS {
+		return nil, nil, errors.Wrapf(Result(result), "unable to create buffer %q", name)
 	}
+	trackObject(app, C.VK_OBJECT_TYPE_BUFFER, unsafe.Pointer(*buffer), name)
 	// Get memory requirements.
 	var memRequirements C.VkMemoryRequirements
-	C.vkGetBufferMemoryRequirements(*app.device, *buffer, &memRequirements)
+	app.deviceProcs.GetBufferMemoryRequirements(*app.device, *buffer, &memRequirements)
 	// Allocate memory.
 	memoryTypeIndex, err := findMemoryType(app, memRequirements.memoryTypeBits, properties)
 	if err != nil {
@@ -1438,121 +1782,74 @@ func createBuffer(app *App, size C.VkDeviceSize, usage C.VkBufferUsageFlags, pro
 		allocationSize:  memRequirements.size,
 		memoryTypeIndex: C.uint(memoryTypeIndex),
 	}
-	bufferMem := C.new_VkDeviceMemory()
-	if result := C.vkAllocateMemory(*app.device, &memAllocInfo, nil, bufferMem); result != C.VK_SUCCESS {
-		return nil, nil, errors.Errorf("unable to allocate memory of size=%d (result=%d)", memRequirements.size, result)
+	bufferMem := app.arena.newVkDeviceMemory(nil)
+	if result := app.deviceProcs.AllocateMemory(*app.device, &memAllocInfo, nil, bufferMem); result != C.VK_SUCCESS {
+		return nil, nil, errors.Wrapf(Result(result), "unable to allocate memory of size=%d", memRequirements.size)
 	}
+	trackObject(app, C.VK_OBJECT_TYPE_DEVICE_MEMORY, unsafe.Pointer(*bufferMem), name+"Mem")
 	const memoryOffset = 0
-	if result := C.vkBindBufferMemory(*app.device, *buffer, *bufferMem, memoryOffset); result != C.VK_SUCCESS {
-		return nil, nil, errors.Errorf("unable to bind memory of vertex buffer (result=%d)", result)
+	if result := app.deviceProcs.BindBufferMemory(*app.device, *buffer, *bufferMem, memoryOffset); result != C.VK_SUCCESS {
+		return nil, nil, errors.Wrapf(Result(result), "unable to bind memory of buffer %q", name)
 	}
 	return buffer, bufferMem, nil
 }
 
-func copyBuffer(app *App, dstBuffer, srcBuffer *C.VkBuffer, size C.VkDeviceSize) error {
-	tmpCommandBuffers := newVkCommandBufferSlice(make([]C.VkCommandBuffer, 1)...)
+// beginSingleTimeCommands allocates a temporary command buffer with the given
+// name, and begins recording commands to be submitted once by
+// endSingleTimeCommands.
+func beginSingleTimeCommands(app *App, scratch *arena, name string) (C.VkCommandBuffer, error) {
+	tmpCommandBuffers := scratch.makeVkCommandBufferSlice(1)
 	commandBufferAllocateInfo := C.VkCommandBufferAllocateInfo{
 		sType:              C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_ALLOCATE_INFO,
 		commandPool:        *app.commandPool,
 		level:              C.VK_COMMAND_BUFFER_LEVEL_PRIMARY,
 		commandBufferCount: C.uint(len(tmpCommandBuffers)),
 	}
-	if result := C.vkAllocateCommandBuffers(*app.device, &commandBufferAllocateInfo, &tmpCommandBuffers[0]); result != C.VK_SUCCESS {
-		return errors.Errorf("unable to create command buffers (result=%d)", result)
+	if result := app.deviceProcs.AllocateCommandBuffers(*app.device, &commandBufferAllocateInfo, &tmpCommandBuffers[0]); result != C.VK_SUCCESS {
+		return nil, errors.Wrap(Result(result), "unable to create command buffers")
 	}
-	defer C.vkFreeCommandBuffers(*app.device, *app.commandPool, C.uint(len(tmpCommandBuffers)), &tmpCommandBuffers[0])
+	trackObject(app, C.VK_OBJECT_TYPE_COMMAND_BUFFER, unsafe.Pointer(tmpCommandBuffers[0]), name)
 	commandBufferBeginInfo := C.VkCommandBufferBeginInfo{
 		sType:            C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_BEGIN_INFO,
 		flags:            C.VK_COMMAND_BUFFER_USAGE_ONE_TIME_SUBMIT_BIT,
 		pInheritanceInfo: nil, // optional
 	}
-	if result := C.vkBeginCommandBuffer(tmpCommandBuffers[0], &commandBufferBeginInfo); result != C.VK_SUCCESS {
-		return errors.Errorf("unable to begin recording command buffer (result=%d)", result)
-	}
-	copyRegions := []C.VkBufferCopy{
-		{
-			srcOffset: 0,
-			dstOffset: 0,
-			size:      size,
-		},
-	}
-	C.vkCmdCopyBuffer(tmpCommandBuffers[0], *srcBuffer, *dstBuffer, C.uint(len(copyRegions)), &copyRegions[0])
-	if result := C.vkEndCommandBuffer(tmpCommandBuffers[0]); result != C.VK_SUCCESS {
-		return errors.Errorf("unable to record command buffer (result=%d)", result)
-	}
-	submitInfo := C.VkSubmitInfo{
-		sType:              C.VK_STRUCTURE_TYPE_SUBMIT_INFO,
-		commandBufferCount: C.uint(len(tmpCommandBuffers)),
-		pCommandBuffers:    &tmpCommandBuffers[0],
+	if result := app.deviceProcs.BeginCommandBuffer(tmpCommandBuffers[0], &commandBufferBeginInfo); result != C.VK_SUCCESS {
+		freeCommandBuffer(app, scratch, tmpCommandBuffers[0])
+		return nil, errors.Wrap(Result(result), "unable to begin recording command buffer")
 	}
-	submits := newVkSubmitInfoSlice(submitInfo)
-	if result := C.vkQueueSubmit(*app.graphicsQueue, C.uint(len(submits)), &submits[0], nil); result != C.VK_SUCCESS {
-		return errors.Errorf("unable to submit command buffers to graphics queue (result=%d)", result)
-	}
-	if result := C.vkQueueWaitIdle(*app.graphicsQueue); result != C.VK_SUCCESS {
-		return errors.Errorf("unable to wait for graphics queue to become idle (result=%d)", result)
-	}
-	return nil
+	return tmpCommandBuffers[0], nil
 }
 
-func createVertexBuffer(app *App, uniqueVertices []Vertex) error {
-	// Create vertex staging buffer in CPU memory.
-	vertexBufferSize := getVerticesSize(uniqueVertices)
-	stagingBufferUsage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_TRANSFER_SRC_BIT)
-	stagingBufferProperties := C.VkMemoryPropertyFlags(C.VK_MEMORY_PROPERTY_HOST_VISIBLE_BIT | C.VK_MEMORY_PROPERTY_HOST_COHERENT_BIT)
-	stagingBuffer, stagingBufferMem, err := createBuffer(app, vertexBufferSize, stagingBufferUsage, stagingBufferProperties)
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	defer C.vkDestroyBuffer(*app.device, *stagingBuffer, nil)
-	defer C.vkFreeMemory(*app.device, *stagingBufferMem, nil)
-	if err := fillVertexBuffer(app, uniqueVertices, stagingBufferMem); err != nil {
-		return errors.WithStack(err)
-	}
-	// Create vertex buffer in GPU memory.
-	vertexBufferUsage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_TRANSFER_DST_BIT | C.VK_BUFFER_USAGE_VERTEX_BUFFER_BIT)
-	vertexBufferProperties := C.VkMemoryPropertyFlags(C.VK_MEMORY_PROPERTY_DEVICE_LOCAL_BIT)
-	vertexBuffer, vertexBufferMem, err := createBuffer(app, vertexBufferSize, vertexBufferUsage, vertexBufferProperties)
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	app.vertexBuffer = vertexBuffer
-	app.vertexBufferMem = vertexBufferMem
-	// Copy staging buffer to vertex buffer.
-	if err := copyBuffer(app, vertexBuffer, stagingBuffer, vertexBufferSize); err != nil {
+// endSingleTimeCommands ends recording the given temporary command buffer,
+// submits it to the graphics queue and waits for its completion, before
+// freeing the command buffer.
+func endSingleTimeCommands(app *App, scratch *arena, commandBuffer C.VkCommandBuffer) error {
+	defer freeCommandBuffer(app, scratch, commandBuffer)
+	if result := app.deviceProcs.EndCommandBuffer(commandBuffer); result != C.VK_SUCCESS {
+		return errors.Wrap(Result(result), "unable to record command buffer")
+	}
+	var signals []submitSemaphore
+	value := app.graphicsTimeline.next()
+	if app.timelineSemaphores {
+		signals = append(signals, app.graphicsTimeline.signal(value))
+	}
+	submitInfo := newSubmitInfo(app, scratch, []C.VkCommandBuffer{commandBuffer}, nil, signals)
+	submits := scratch.newVkSubmitInfoSlice(submitInfo)
+	if result := app.deviceProcs.QueueSubmit(*app.graphicsQueue, C.uint(len(submits)), &submits[0], nil); result != C.VK_SUCCESS {
+		return errors.Wrap(Result(result), "unable to submit command buffers to graphics queue")
+	}
+	if err := app.graphicsTimeline.waitSubmitted(app, *app.graphicsQueue, value); err != nil {
 		return errors.WithStack(err)
 	}
 	return nil
 }
 
-func createIndexBuffer(app *App, indices []uint32) error {
-	// Create index staging buffer in CPU memory.
-	indexBufferSize := getIndicesSize(indices)
-	stagingBufferUsage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_TRANSFER_SRC_BIT)
-	stagingBufferProperties := C.VkMemoryPropertyFlags(C.VK_MEMORY_PROPERTY_HOST_VISIBLE_BIT | C.VK_MEMORY_PROPERTY_HOST_COHERENT_BIT)
-	stagingBuffer, stagingBufferMem, err := createBuffer(app, indexBufferSize, stagingBufferUsage, stagingBufferProperties)
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	defer C.vkDestroyBuffer(*app.device, *stagingBuffer, nil)
-	defer C.vkFreeMemory(*app.device, *stagingBufferMem, nil)
-	if err := fillIndexBuffer(app, indices, stagingBufferMem); err != nil {
-		return errors.WithStack(err)
-	}
-	// Create index buffer in GPU memory.
-	indexBufferUsage := C.VkBufferUsageFlags(C.VK_BUFFER_USAGE_TRANSFER_DST_BIT | C.VK_BUFFER_USAGE_INDEX_BUFFER_BIT)
-	indexBufferProperties := C.VkMemoryPropertyFlags(C.VK_MEMORY_PROPERTY_DEVICE_LOCAL_BIT)
-	indexBuffer, indexBufferMem, err := createBuffer(app, indexBufferSize, indexBufferUsage, indexBufferProperties)
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	app.indexBuffer = indexBuffer
-	app.indexBufferMem = indexBufferMem
-	// Copy staging buffer to index buffer.
-	if err := copyBuffer(app, indexBuffer, stagingBuffer, indexBufferSize); err != nil {
-		return errors.WithStack(err)
-	}
-	return nil
+// freeCommandBuffer frees the given command buffer of the command pool.
+func freeCommandBuffer(app *App, scratch *arena, commandBuffer C.VkCommandBuffer) {
+	untrackObject(C.VK_OBJECT_TYPE_COMMAND_BUFFER, unsafe.Pointer(commandBuffer))
+	tmpCommandBuffers := scratch.newVkCommandBufferSlice(commandBuffer)
+	app.deviceProcs.FreeCommandBuffers(*app.device, *app.commandPool, C.uint(len(tmpCommandBuffers)), &tmpCommandBuffers[0])
 }
 
 // ### [ Helper functions ] ####################################################
@@ -3,17 +3,47 @@ package vk
 // #define GLFW_INCLUDE_VULKAN
 // #include <GLFW/glfw3.h>
 //
+// #include <stdlib.h>
+//
 // #include "callback.h"
+//
+// // initVulkanLoader sets the vkGetInstanceProcAddr function used by GLFW to
+// // create window surfaces. GLFW loads its own Vulkan loader prior to version
+// // 3.4.
+// static void initVulkanLoader(PFN_vkGetInstanceProcAddr fn) {
+// #if GLFW_VERSION_MAJOR > 3 || (GLFW_VERSION_MAJOR == 3 && GLFW_VERSION_MINOR >= 4)
+// 	glfwInitVulkanLoader(fn);
+// #endif
+// }
 import "C"
 
+import (
+	"unsafe"
+)
+
+// Key which captures a screenshot of the window.
+const ScreenshotKey = C.GLFW_KEY_F12
+
+// Key which reloads the shaders of the graphics pipeline.
+const ReloadShadersKey = C.GLFW_KEY_F5
+
+// Key which switches to the next scene.
+const NextSceneKey = C.GLFW_KEY_TAB
+
+// Key which toggles vertical synchronization (VSync).
+const VSyncKey = C.GLFW_KEY_V
+
 func InitWindow(app *App) *C.GLFWwindow {
 	dbg.Println("vk.InitWindow")
-	// Initialize GLFW.
+	// Initialize GLFW, using the loaded Vulkan commands.
+	C.initVulkanLoader(vkGetInstanceProcAddr)
 	C.glfwInit()
 	C.glfwWindowHint(C.GLFW_CLIENT_API, C.GLFW_NO_API) // skip OpenGL context.
 	//C.glfwWindowHint(C.GLFW_RESIZABLE, C.GLFW_FALSE)
 	// Create window.
-	win := C.glfwCreateWindow(WindowWidth, WindowHeight, C.CString(AppTitle), nil, nil)
+	title := C.CString(AppTitle)
+	defer C.free(unsafe.Pointer(title))
+	win := C.glfwCreateWindow(WindowWidth, WindowHeight, title, nil, nil)
 	_framebufferResizeCallback = func(win *C.GLFWwindow, width, height int) {
 		dbg.Println("framebufferResizeCallback")
 		dbg.Println("   width:", width)
@@ -21,6 +51,24 @@ func InitWindow(app *App) *C.GLFWwindow {
 		app.framebufferResized = true
 	}
 	C.glfwSetFramebufferSizeCallback(win, (*[0]byte)(C.framebufferResizeCallback))
+	_keyCallback = func(win *C.GLFWwindow, key, scancode, action, mods int) {
+		if key == ScreenshotKey && action == C.GLFW_PRESS {
+			dbg.Println("screenshot requested")
+			RequestScreenshot(app, "")
+		}
+		if key == ReloadShadersKey && action == C.GLFW_PRESS {
+			app.reloadShadersRequested = true
+		}
+		if key == NextSceneKey && action == C.GLFW_PRESS {
+			app.nextSceneRequested = true
+		}
+		if key == VSyncKey && action == C.GLFW_PRESS {
+			vsync := !app.VSync()
+			dbg.Printf("VSync %v requested", vsync)
+			app.SetVSync(vsync)
+		}
+	}
+	C.glfwSetKeyCallback(win, (*[0]byte)(C.keyCallback))
 	return win
 }
 